@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -75,6 +76,10 @@ type Client interface {
 	GetLocalTS(ctx context.Context, dcLocation string) (int64, int64, error)
 	// GetLocalTSAsync gets a local timestamp from PD, without block the caller.
 	GetLocalTSAsync(ctx context.Context, dcLocation string) TSFuture
+	// GetTSOClockUncertainty returns the clock uncertainty bound of the TSO physical time reported
+	// by the latest TSO response of the given dc-location. It returns false if the server doesn't
+	// check the clock uncertainty or no TSO response has been received yet.
+	GetTSOClockUncertainty(dcLocation string) (time.Duration, bool)
 	// GetRegion gets a region and its leader Peer from PD by key.
 	// The region may expire after split. Caller is responsible for caching and
 	// taking care of region change.
@@ -136,6 +141,14 @@ type Client interface {
 	GetExternalTimestamp(ctx context.Context) (uint64, error)
 	// SetExternalTimestamp sets external timestamp
 	SetExternalTimestamp(ctx context.Context, timestamp uint64) error
+	// GetSafeReadTS returns the newest timestamp at which a stale read on the key range [startKey, endKey)
+	// is guaranteed to be consistent. It's computed from the min resolved ts of all the stores holding a peer
+	// of the range, so it's usually newer than the cluster-level min resolved ts. The keys are in the same
+	// format as the region boundaries, i.e., memcomparable-encoded for the TxnKV keys.
+	GetSafeReadTS(ctx context.Context, startKey, endKey []byte) (uint64, error)
+	// GetKeyspaceSafeReadTS returns the newest timestamp at which a stale read on the given keyspace
+	// is guaranteed to be consistent.
+	GetKeyspaceSafeReadTS(ctx context.Context, keyspaceID uint32) (uint64, error)
 
 	// MetaStorageClient returns the meta storage client.
 	MetaStorageClient
@@ -254,6 +267,14 @@ type client struct {
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 	option *option
+
+	tlsCfg *tlsutil.TLSConfig
+	// httpClient is used to call the HTTP API of PD server which has no gRPC interface, e.g., GetSafeReadTS.
+	httpClient struct {
+		sync.Once
+		cli *http.Client
+		err error
+	}
 }
 
 // SecurityOption records options about tls
@@ -291,6 +312,7 @@ func NewClientWithContext(ctx context.Context, svrAddrs []string, security Secur
 		c.cancel()
 		return nil, err
 	}
+	c.startTSMonotonicityMonitor()
 	return c, nil
 }
 
@@ -318,6 +340,7 @@ func NewTSOClientWithContext(ctx context.Context, keyspaceID uint32, svrAddrs []
 		c.cancel()
 		return nil, err
 	}
+	c.startTSMonotonicityMonitor()
 	return c, nil
 }
 
@@ -339,6 +362,7 @@ func createClient(ctx context.Context, keyspaceID uint32, security *SecurityOpti
 		cancel:                  clientCancel,
 		keyspaceID:              keyspaceID,
 		option:                  newOption(),
+		tlsCfg:                  tlsCfg,
 	}
 
 	return c, clientCtx, clientCancel, tlsCfg
@@ -362,6 +386,14 @@ func (c *client) setup() error {
 	return nil
 }
 
+func (c *client) startTSMonotonicityMonitor() {
+	if c.option.tsMonitorInterval <= 0 {
+		return
+	}
+	c.wg.Add(1)
+	go c.tsMonotonicityMonitorLoop()
+}
+
 func (c *client) Close() {
 	c.cancel()
 	c.wg.Wait()
@@ -415,6 +447,12 @@ func (c *client) UpdateOption(option DynamicOption, value interface{}) error {
 			return errors.New("[pd] invalid value type for EnableTSOFollowerProxy option, it should be bool")
 		}
 		c.option.setEnableTSOFollowerProxy(enable)
+	case EnableTSOAdaptiveBatching:
+		enable, ok := value.(bool)
+		if !ok {
+			return errors.New("[pd] invalid value type for EnableTSOAdaptiveBatching option, it should be bool")
+		}
+		c.option.setEnableTSOAdaptiveBatching(enable)
 	default:
 		return errors.New("[pd] unsupported client option")
 	}
@@ -557,6 +595,10 @@ func (c *client) GetLocalTS(ctx context.Context, dcLocation string) (physical in
 	return resp.Wait()
 }
 
+func (c *client) GetTSOClockUncertainty(dcLocation string) (time.Duration, bool) {
+	return c.tsoClient.getClockUncertainty(dcLocation)
+}
+
 func handleRegionResponse(res *pdpb.GetRegionResponse) *Region {
 	if res.Region == nil {
 		return nil
@@ -38,6 +38,8 @@ var (
 	ErrClientUpdateMember     = errors.Normalize("update member failed, %v", errors.RFCCodeText("PD:client:ErrUpdateMember"))
 	ErrClientProtoUnmarshal   = errors.Normalize("failed to unmarshal proto", errors.RFCCodeText("PD:proto:ErrClientProtoUnmarshal"))
 	ErrClientGetMultiResponse = errors.Normalize("get invalid value response %v, must only one", errors.RFCCodeText("PD:client:ErrClientGetMultiResponse"))
+	ErrClientJSONUnmarshal    = errors.Normalize("failed to unmarshal json", errors.RFCCodeText("PD:json:ErrClientJSONUnmarshal"))
+	ErrClientGetSafeReadTS    = errors.Normalize("get safe read ts failed, %v", errors.RFCCodeText("PD:client:ErrClientGetSafeReadTS"))
 )
 
 // grpcutil errors
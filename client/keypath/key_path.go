@@ -0,0 +1,43 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keypath defines the etcd key paths shared by the PD server and the PD client,
+// so that the client doesn't need to keep its own copy of the path layout.
+package keypath
+
+import (
+	"path"
+	"strconv"
+)
+
+const (
+	legacyRootPrefix = "/pd"
+	// keyspace group membership has prefix `tso/keyspace_groups`
+	tsoKeyspaceGroupPrefix     = "tso/keyspace_groups"
+	keyspaceGroupMembershipKey = "membership"
+)
+
+// KeyspaceGroupMembershipPrefix returns the prefix of the keyspace group membership,
+// relative to the root path of the cluster.
+// Path: tso/keyspace_groups/membership/
+func KeyspaceGroupMembershipPrefix() string {
+	return path.Join(tsoKeyspaceGroupPrefix, keyspaceGroupMembershipKey) + "/"
+}
+
+// LegacyKeyspaceGroupMembershipPrefix returns the full prefix of the keyspace group membership
+// stored under the legacy root path of the given cluster.
+// Path: /pd/{cluster_id}/tso/keyspace_groups/membership/
+func LegacyKeyspaceGroupMembershipPrefix(clusterID uint64) string {
+	return path.Join(legacyRootPrefix, strconv.FormatUint(clusterID, 10), KeyspaceGroupMembershipPrefix()) + "/"
+}
@@ -71,6 +71,48 @@ var (
 			Help:      "tso batch send latency",
 		})
 
+	tsoAdaptiveBatchWaitDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "pd_client",
+			Subsystem: "request",
+			Name:      "tso_adaptive_batch_wait_duration_seconds",
+			Help:      "Bucketed histogram of the batch wait interval (s) chosen by the TSO adaptive batching.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2, 10), // 50us ~ 25.6ms
+		})
+
+	tsoAdaptiveBestBatchSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "pd_client",
+			Subsystem: "request",
+			Name:      "tso_adaptive_best_batch_size",
+			Help:      "Bucketed histogram of the best batch size chosen by the TSO adaptive batching.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 13),
+		})
+
+	tsoEstimatedRTT = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd_client",
+			Subsystem: "request",
+			Name:      "tso_estimated_rtt_seconds",
+			Help:      "The estimated TSO stream RTT (s) used by the TSO adaptive batching.",
+		}, []string{"dc"})
+
+	tsoEstimatedArrivalRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd_client",
+			Subsystem: "request",
+			Name:      "tso_estimated_arrival_rate",
+			Help:      "The estimated TSO request arrival rate per second used by the TSO adaptive batching.",
+		}, []string{"dc"})
+
+	tsoViolationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd_client",
+			Subsystem: "request",
+			Name:      "tso_monotonicity_violation_total",
+			Help:      "Counter of the detected timestamp monotonicity violations.",
+		}, []string{"type"})
+
 	requestForwarded = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "pd_client",
@@ -103,6 +145,7 @@ var (
 	cmdDurationUpdateKeyspaceState      = cmdDuration.WithLabelValues("update_keyspace_state")
 	cmdDurationGet                      = cmdDuration.WithLabelValues("get")
 	cmdDurationPut                      = cmdDuration.WithLabelValues("put")
+	cmdDurationGetSafeReadTS            = cmdDuration.WithLabelValues("get_safe_read_ts")
 
 	cmdFailDurationGetRegion                  = cmdFailedDuration.WithLabelValues("get_region")
 	cmdFailDurationTSO                        = cmdFailedDuration.WithLabelValues("tso")
@@ -119,6 +162,7 @@ var (
 	requestDurationTSO                        = requestDuration.WithLabelValues("tso")
 	cmdFailedDurationGet                      = cmdFailedDuration.WithLabelValues("get")
 	cmdFailedDurationPut                      = cmdFailedDuration.WithLabelValues("put")
+	cmdFailedDurationGetSafeReadTS            = cmdFailedDuration.WithLabelValues("get_safe_read_ts")
 )
 
 func init() {
@@ -129,4 +173,9 @@ func init() {
 	prometheus.MustRegister(tsoBatchSize)
 	prometheus.MustRegister(tsoBatchSendLatency)
 	prometheus.MustRegister(requestForwarded)
+	prometheus.MustRegister(tsoViolationCounter)
+	prometheus.MustRegister(tsoAdaptiveBatchWaitDuration)
+	prometheus.MustRegister(tsoAdaptiveBestBatchSize)
+	prometheus.MustRegister(tsoEstimatedRTT)
+	prometheus.MustRegister(tsoEstimatedArrivalRate)
 }
@@ -23,10 +23,11 @@ import (
 )
 
 const (
-	defaultPDTimeout                             = 3 * time.Second
-	maxInitClusterRetries                        = 100
-	defaultMaxTSOBatchWaitInterval time.Duration = 0
-	defaultEnableTSOFollowerProxy                = false
+	defaultPDTimeout                               = 3 * time.Second
+	maxInitClusterRetries                          = 100
+	defaultMaxTSOBatchWaitInterval   time.Duration = 0
+	defaultEnableTSOFollowerProxy                  = false
+	defaultEnableTSOAdaptiveBatching               = false
 )
 
 // DynamicOption is used to distinguish the dynamic option type.
@@ -39,6 +40,10 @@ const (
 	// EnableTSOFollowerProxy is the TSO Follower Proxy option.
 	// It is stored as bool.
 	EnableTSOFollowerProxy
+	// EnableTSOAdaptiveBatching is the TSO adaptive batching option.
+	// It is stored as bool. Once enabled, the batch size and the batch wait interval will be chosen
+	// according to the TSO stream RTT and the request arrival rate, and MaxTSOBatchWaitInterval is ignored.
+	EnableTSOAdaptiveBatching
 
 	dynamicOptionCount
 )
@@ -51,6 +56,11 @@ type option struct {
 	timeout          time.Duration
 	maxRetryTimes    int
 	enableForwarding bool
+	// tsViolationPolicy, tsViolationCallback and tsMonitorInterval are
+	// used to detect and handle the timestamp monotonicity violation.
+	tsViolationPolicy   TSViolationPolicy
+	tsViolationCallback func(*TSViolationError)
+	tsMonitorInterval   time.Duration
 
 	// Dynamic options.
 	dynamicOptions [dynamicOptionCount]atomic.Value
@@ -68,6 +78,7 @@ func newOption() *option {
 
 	co.dynamicOptions[MaxTSOBatchWaitInterval].Store(defaultMaxTSOBatchWaitInterval)
 	co.dynamicOptions[EnableTSOFollowerProxy].Store(defaultEnableTSOFollowerProxy)
+	co.dynamicOptions[EnableTSOAdaptiveBatching].Store(defaultEnableTSOAdaptiveBatching)
 	return co
 }
 
@@ -105,3 +116,16 @@ func (o *option) setEnableTSOFollowerProxy(enable bool) {
 func (o *option) getEnableTSOFollowerProxy() bool {
 	return o.dynamicOptions[EnableTSOFollowerProxy].Load().(bool)
 }
+
+// setEnableTSOAdaptiveBatching sets the TSO adaptive batching option.
+func (o *option) setEnableTSOAdaptiveBatching(enable bool) {
+	old := o.getEnableTSOAdaptiveBatching()
+	if enable != old {
+		o.dynamicOptions[EnableTSOAdaptiveBatching].Store(enable)
+	}
+}
+
+// getEnableTSOAdaptiveBatching gets the TSO adaptive batching option.
+func (o *option) getEnableTSOAdaptiveBatching() bool {
+	return o.dynamicOptions[EnableTSOAdaptiveBatching].Load().(bool)
+}
@@ -28,6 +28,7 @@ func TestDynamicOptionChange(t *testing.T) {
 	// Check the default value setting.
 	re.Equal(defaultMaxTSOBatchWaitInterval, o.getMaxTSOBatchWaitInterval())
 	re.Equal(defaultEnableTSOFollowerProxy, o.getEnableTSOFollowerProxy())
+	re.Equal(defaultEnableTSOAdaptiveBatching, o.getEnableTSOAdaptiveBatching())
 
 	// Check the invalid value setting.
 	re.NotNil(o.setMaxTSOBatchWaitInterval(time.Second))
@@ -55,4 +56,7 @@ func TestDynamicOptionChange(t *testing.T) {
 	close(o.enableTSOFollowerProxyCh)
 	// Setting the same value should not notify the channel.
 	o.setEnableTSOFollowerProxy(expectBool)
+
+	o.setEnableTSOAdaptiveBatching(expectBool)
+	re.Equal(expectBool, o.getEnableTSOAdaptiveBatching())
 }
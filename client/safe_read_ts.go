@@ -0,0 +1,117 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/tikv/pd/client/errs"
+)
+
+const safeReadTSPrefix = "/pd/api/v1/min-resolved-ts/safe-read-ts"
+
+// safeReadTSResponse is the response of the safe read ts HTTP API.
+type safeReadTSResponse struct {
+	SafeReadTS uint64 `json:"safe_read_ts"`
+}
+
+// GetSafeReadTS implements the Client interface.
+func (c *client) GetSafeReadTS(ctx context.Context, startKey, endKey []byte) (uint64, error) {
+	query := url.Values{}
+	query.Set("start_key", string(startKey))
+	query.Set("end_key", string(endKey))
+	return c.getSafeReadTS(ctx, query)
+}
+
+// GetKeyspaceSafeReadTS implements the Client interface.
+func (c *client) GetKeyspaceSafeReadTS(ctx context.Context, keyspaceID uint32) (uint64, error) {
+	query := url.Values{}
+	query.Set("keyspace_id", strconv.FormatUint(uint64(keyspaceID), 10))
+	return c.getSafeReadTS(ctx, query)
+}
+
+// getSafeReadTS gets the safe read ts from the PD leader. Since there is no gRPC interface
+// to get the min resolved ts of each store, it's served by the HTTP API of the PD leader.
+func (c *client) getSafeReadTS(ctx context.Context, query url.Values) (uint64, error) {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span = opentracing.StartSpan("pdclient.GetSafeReadTS", opentracing.ChildOf(span.Context()))
+		defer span.Finish()
+	}
+	start := time.Now()
+	defer func() { cmdDurationGetSafeReadTS.Observe(time.Since(start).Seconds()) }()
+
+	ts, err := c.requestSafeReadTS(ctx, query)
+	if err != nil {
+		cmdFailedDurationGetSafeReadTS.Observe(time.Since(start).Seconds())
+		c.svcDiscovery.ScheduleCheckMemberChanged()
+		return 0, err
+	}
+	return ts, nil
+}
+
+func (c *client) requestSafeReadTS(ctx context.Context, query url.Values) (uint64, error) {
+	httpClient, err := c.getHTTPClient()
+	if err != nil {
+		return 0, errs.ErrClientGetSafeReadTS.Wrap(err).GenWithStackByCause()
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.option.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.svcDiscovery.GetServingAddr()+safeReadTSPrefix+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, errs.ErrClientGetSafeReadTS.Wrap(err).GenWithStackByCause()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, errs.ErrClientGetSafeReadTS.Wrap(err).GenWithStackByCause()
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, errs.ErrClientGetSafeReadTS.Wrap(err).GenWithStackByCause()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, errs.ErrClientGetSafeReadTS.GenWithStackByArgs(string(body))
+	}
+	var safeReadTS safeReadTSResponse
+	if err := json.Unmarshal(body, &safeReadTS); err != nil {
+		return 0, errs.ErrClientJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return safeReadTS.SafeReadTS, nil
+}
+
+// getHTTPClient returns the HTTP client, which shares the TLS config with the gRPC connections.
+func (c *client) getHTTPClient() (*http.Client, error) {
+	c.httpClient.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if c.tlsCfg != nil {
+			tlsConfig, err := c.tlsCfg.ToTLSConfig()
+			if err != nil {
+				c.httpClient.err = err
+				return
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		c.httpClient.cli = &http.Client{Transport: transport}
+	})
+	return c.httpClient.cli, c.httpClient.err
+}
@@ -16,9 +16,23 @@ package pd
 
 import (
 	"context"
+	"math"
 	"time"
 )
 
+const (
+	// maxAdaptiveBatchWaitInterval is the upper bound of the batch wait interval chosen by the adaptive batching.
+	maxAdaptiveBatchWaitInterval = 10 * time.Millisecond
+	// adaptiveBatchingSmoothFactor is the weight of the newest sample in the EWMA estimations.
+	adaptiveBatchingSmoothFactor = 0.2
+	// adaptiveBatchingMinRTTDriftFactor makes the minimum RTT drift towards the recent samples slowly,
+	// so that a permanent change of the network will not be treated as the server load.
+	adaptiveBatchingMinRTTDriftFactor = 0.01
+	// adaptiveBatchingLoadedRatio is the ratio of the estimated RTT to the minimum RTT,
+	// above which the server is regarded as loaded and waiting for a larger batch is worthwhile.
+	adaptiveBatchingLoadedRatio = 1.5
+)
+
 type tsoBatchController struct {
 	maxBatchSize int
 	// bestBatchSize is a dynamic size that changed based on the current batch effect.
@@ -29,6 +43,16 @@ type tsoBatchController struct {
 	collectedRequestCount int
 
 	batchStartTime time.Time
+
+	// The following fields are used by the adaptive batching.
+	// lastBatchStartTime is the start time of the previous batch, which is used to estimate the arrival rate.
+	lastBatchStartTime time.Time
+	// estimatedRTT is the EWMA of the TSO stream round-trip time.
+	estimatedRTT time.Duration
+	// minRTT is the approximate minimum of the TSO stream round-trip time, i.e., the RTT without the server load.
+	minRTT time.Duration
+	// estimatedArrivalRate is the EWMA of the TSO request arrival rate per second.
+	estimatedArrivalRate float64
 }
 
 func newTSOBatchController(tsoRequestCh chan *tsoRequest, maxBatchSize int) *tsoBatchController {
@@ -51,6 +75,7 @@ func (tbc *tsoBatchController) fetchPendingRequests(ctx context.Context, maxBatc
 	case firstTSORequest = <-tbc.tsoRequestCh:
 	}
 	// Start to batch when the first TSO request arrives.
+	tbc.lastBatchStartTime = tbc.batchStartTime
 	tbc.batchStartTime = time.Now()
 	tbc.collectedRequestCount = 0
 	tbc.pushRequest(firstTSORequest)
@@ -130,6 +155,72 @@ func (tbc *tsoBatchController) adjustBestBatchSize() {
 	}
 }
 
+// observeArrivalRate updates the estimated arrival rate with the requests collected in the current batch,
+// which are regarded as arriving between the start of the previous batch and the current one.
+func (tbc *tsoBatchController) observeArrivalRate() {
+	if tbc.lastBatchStartTime.IsZero() {
+		return
+	}
+	interval := tbc.batchStartTime.Sub(tbc.lastBatchStartTime).Seconds()
+	if interval <= 0 {
+		return
+	}
+	rate := float64(tbc.collectedRequestCount) / interval
+	if tbc.estimatedArrivalRate <= 0 {
+		tbc.estimatedArrivalRate = rate
+		return
+	}
+	tbc.estimatedArrivalRate += adaptiveBatchingSmoothFactor * (rate - tbc.estimatedArrivalRate)
+}
+
+// observeRTT updates the estimated RTT and the minimum RTT with the round-trip time of a TSO stream request.
+func (tbc *tsoBatchController) observeRTT(rtt time.Duration) {
+	if rtt <= 0 {
+		return
+	}
+	if tbc.estimatedRTT <= 0 {
+		tbc.estimatedRTT, tbc.minRTT = rtt, rtt
+		return
+	}
+	tbc.estimatedRTT += time.Duration(adaptiveBatchingSmoothFactor * float64(rtt-tbc.estimatedRTT))
+	if rtt < tbc.minRTT {
+		tbc.minRTT = rtt
+	} else {
+		tbc.minRTT += time.Duration(adaptiveBatchingMinRTTDriftFactor * float64(rtt-tbc.minRTT))
+	}
+}
+
+// adaptBatchWait chooses the batch size and the batch wait interval for the next batch according to
+// the estimated RTT and arrival rate, and returns the chosen wait interval.
+//   - The best batch size is the number of requests expected to arrive during one round trip.
+//   - If the server is not loaded, i.e., the estimated RTT is close to the minimum RTT, sending the
+//     batch immediately is the best choice for the latency, so no wait is needed.
+//   - Otherwise, waiting a little for a larger batch reduces the RPC count and thus the server load.
+//     The wait is bounded by the queuing delay (estimated RTT minus minimum RTT) we expect to save.
+func (tbc *tsoBatchController) adaptBatchWait() time.Duration {
+	if tbc.estimatedRTT <= 0 || tbc.estimatedArrivalRate <= 0 {
+		return 0
+	}
+	bestBatchSize := int(math.Ceil(tbc.estimatedArrivalRate * tbc.estimatedRTT.Seconds()))
+	if bestBatchSize < 1 {
+		bestBatchSize = 1
+	} else if bestBatchSize > tbc.maxBatchSize {
+		bestBatchSize = tbc.maxBatchSize
+	}
+	tbc.bestBatchSize = bestBatchSize
+	if bestBatchSize <= 1 || float64(tbc.estimatedRTT) < float64(tbc.minRTT)*adaptiveBatchingLoadedRatio {
+		return 0
+	}
+	wait := time.Duration(float64(bestBatchSize) / tbc.estimatedArrivalRate * float64(time.Second))
+	if queuingDelay := tbc.estimatedRTT - tbc.minRTT; wait > queuingDelay {
+		wait = queuingDelay
+	}
+	if wait > maxAdaptiveBatchWaitInterval {
+		wait = maxAdaptiveBatchWaitInterval
+	}
+	return wait
+}
+
 func (tbc *tsoBatchController) revokePendingTSORequest(err error) {
 	for i := 0; i < len(tbc.tsoRequestCh); i++ {
 		req := <-tbc.tsoRequestCh
@@ -0,0 +1,61 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptBatchWait(t *testing.T) {
+	re := require.New(t)
+	tbc := newTSOBatchController(make(chan *tsoRequest, 1), 100)
+	// No estimation yet, no wait.
+	re.Zero(tbc.adaptBatchWait())
+
+	// The arrival rate is estimated by the interval between two batches.
+	tbc.batchStartTime = time.Now()
+	tbc.observeArrivalRate()
+	re.Zero(tbc.estimatedArrivalRate)
+	tbc.lastBatchStartTime = tbc.batchStartTime
+	tbc.batchStartTime = tbc.lastBatchStartTime.Add(10 * time.Millisecond)
+	tbc.collectedRequestCount = 100
+	tbc.observeArrivalRate()
+	re.InDelta(10000, tbc.estimatedArrivalRate, 1e-6)
+
+	// The server is not loaded, send the batch immediately.
+	tbc.observeRTT(time.Millisecond)
+	re.Zero(tbc.adaptBatchWait())
+	re.Equal(10, tbc.bestBatchSize)
+
+	// The server becomes loaded, wait for a larger batch.
+	for i := 0; i < 20; i++ {
+		tbc.observeRTT(4 * time.Millisecond)
+	}
+	re.Greater(tbc.estimatedRTT, 3*time.Millisecond)
+	re.Less(tbc.minRTT, 2*time.Millisecond)
+	wait := tbc.adaptBatchWait()
+	re.Greater(wait, time.Duration(0))
+	re.LessOrEqual(wait, tbc.estimatedRTT-tbc.minRTT)
+	re.Greater(tbc.bestBatchSize, 30)
+	re.LessOrEqual(tbc.bestBatchSize, tbc.maxBatchSize)
+
+	// The best batch size should not exceed the max batch size.
+	tbc.estimatedArrivalRate = 1e9
+	tbc.adaptBatchWait()
+	re.Equal(tbc.maxBatchSize, tbc.bestBatchSize)
+}
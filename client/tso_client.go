@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -79,6 +80,11 @@ type tsoClient struct {
 	tsDeadline sync.Map // Same as map[string]chan deadline
 	// dc-location -> *lastTSO
 	lastTSMap sync.Map // Same as map[string]*lastTSO
+	// dc-location -> clockUncertainty reported by the latest TSO response
+	clockUncertainties sync.Map // Same as map[string]clockUncertainty
+	// latchedTSViolation is the violation detected by the monotonicity monitor,
+	// which will fail all the subsequent TSO requests under the TSViolationPolicyFail policy.
+	latchedTSViolation atomic.Pointer[TSViolationError]
 
 	checkTSDeadlineCh         chan struct{}
 	checkTSODispatcherCh      chan struct{}
@@ -140,6 +146,16 @@ func (c *tsoClient) GetTSOAllocators() *sync.Map {
 	return &c.tsoAllocators
 }
 
+// getClockUncertainty returns the clock uncertainty bound of the TSO physical time reported
+// by the latest TSO response of the given dcLocation.
+func (c *tsoClient) getClockUncertainty(dcLocation string) (time.Duration, bool) {
+	uncertainty, ok := c.clockUncertainties.Load(dcLocation)
+	if !ok {
+		return 0, false
+	}
+	return uncertainty.(clockUncertainty).bound, uncertainty.(clockUncertainty).reported
+}
+
 // GetTSOAllocatorServingAddrByDCLocation returns the tso allocator of the given dcLocation
 func (c *tsoClient) GetTSOAllocatorServingAddrByDCLocation(dcLocation string) (string, bool) {
 	url, exist := c.tsoAllocators.Load(dcLocation)
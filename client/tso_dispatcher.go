@@ -371,6 +371,10 @@ tsoBatchLoop:
 		}
 		// Start to collect the TSO requests.
 		maxBatchWaitInterval := c.option.getMaxTSOBatchWaitInterval()
+		enableAdaptiveBatching := c.option.getEnableTSOAdaptiveBatching()
+		if enableAdaptiveBatching {
+			maxBatchWaitInterval = tbc.adaptBatchWait()
+		}
 		if err = tbc.fetchPendingRequests(dispatcherCtx, maxBatchWaitInterval); err != nil {
 			if err == context.Canceled {
 				log.Info("[tso] stop fetching the pending tso requests due to context canceled",
@@ -381,7 +385,13 @@ tsoBatchLoop:
 			}
 			return
 		}
-		if maxBatchWaitInterval >= 0 {
+		tbc.observeArrivalRate()
+		if enableAdaptiveBatching {
+			tsoAdaptiveBatchWaitDuration.Observe(maxBatchWaitInterval.Seconds())
+			tsoAdaptiveBestBatchSize.Observe(float64(tbc.bestBatchSize))
+			tsoEstimatedRTT.WithLabelValues(dc).Set(tbc.estimatedRTT.Seconds())
+			tsoEstimatedArrivalRate.WithLabelValues(dc).Set(tbc.estimatedArrivalRate)
+		} else if maxBatchWaitInterval >= 0 {
 			tbc.adjustBestBatchSize()
 		}
 		streamLoopTimer.Reset(c.option.timeout)
@@ -699,15 +709,30 @@ func (c *tsoClient) processTSORequests(stream tsoStream, dcLocation string, tbc
 	}
 
 	requests := tbc.getCollectedRequests()
+	if err := c.getLatchedTSViolation(); err != nil {
+		c.finishTSORequest(requests, 0, 0, 0, err)
+		return nil
+	}
 	count := int64(len(requests))
-	physical, logical, suffixBits, err := stream.processRequests(c.svcDiscovery.GetClusterID(), dcLocation, requests, tbc.batchStartTime)
+	keyspaceGroupID := defaultKeyspaceGroupID
+	if tsoSvcDiscovery, ok := c.svcDiscovery.(*tsoServiceDiscovery); ok {
+		keyspaceGroupID = tsoSvcDiscovery.GetKeyspaceGroupID()
+	}
+	start := time.Now()
+	physical, logical, suffixBits, uncertainty, err := stream.processRequests(
+		c.svcDiscovery.GetClusterID(), c.keyspaceID, keyspaceGroupID, dcLocation, requests, tbc.batchStartTime)
 	if err != nil {
 		c.finishTSORequest(requests, 0, 0, 0, err)
 		return err
 	}
+	tbc.observeRTT(time.Since(start))
+	c.clockUncertainties.Store(dcLocation, uncertainty)
 	// `logical` is the largest ts's logical part here, we need to do the subtracting before we finish each TSO request.
 	firstLogical := addLogical(logical, -count+1, suffixBits)
-	c.compareAndSwapTS(dcLocation, physical, firstLogical, suffixBits, count)
+	if err := c.compareAndSwapTS(dcLocation, physical, firstLogical, suffixBits, count); err != nil {
+		c.finishTSORequest(requests, 0, 0, 0, err)
+		return err
+	}
 	c.finishTSORequest(requests, physical, firstLogical, suffixBits, nil)
 	return nil
 }
@@ -717,7 +742,7 @@ func addLogical(logical, count int64, suffixBits uint32) int64 {
 	return logical + count<<suffixBits
 }
 
-func (c *tsoClient) compareAndSwapTS(dcLocation string, physical, firstLogical int64, suffixBits uint32, count int64) {
+func (c *tsoClient) compareAndSwapTS(dcLocation string, physical, firstLogical int64, suffixBits uint32, count int64) error {
 	largestLogical := addLogical(firstLogical, count-1, suffixBits)
 	lastTSOInterface, loaded := c.lastTSMap.LoadOrStore(dcLocation, &lastTSO{
 		physical: physical,
@@ -725,7 +750,7 @@ func (c *tsoClient) compareAndSwapTS(dcLocation string, physical, firstLogical i
 		logical: largestLogical,
 	})
 	if !loaded {
-		return
+		return nil
 	}
 	lastTSOPointer := lastTSOInterface.(*lastTSO)
 	lastPhysical := lastTSOPointer.physical
@@ -733,12 +758,26 @@ func (c *tsoClient) compareAndSwapTS(dcLocation string, physical, firstLogical i
 	// The TSO we get is a range like [largestLogical-count+1, largestLogical], so we save the last TSO's largest logical to compare with the new TSO's first logical.
 	// For example, if we have a TSO resp with logical 10, count 5, then all TSOs we get will be [6, 7, 8, 9, 10].
 	if tsLessEqual(physical, firstLogical, lastPhysical, lastLogical) {
-		panic(errors.Errorf("%s timestamp fallback, newly acquired ts (%d, %d) is less or equal to last one (%d, %d)",
-			dcLocation, physical, firstLogical, lastPhysical, lastLogical))
+		if err := c.handleTSViolation(&TSViolationError{
+			Type:         TSViolationLocalFallback,
+			DCLocation:   dcLocation,
+			Physical:     physical,
+			Logical:      firstLogical,
+			PrevPhysical: lastPhysical,
+			PrevLogical:  lastLogical,
+		}); err != nil {
+			return err
+		}
+		// The log policy lets the fallback through, but the last TSO must keep
+		// the largest one so that the later responses are still checked against it.
+		if tsLessEqual(physical, largestLogical, lastPhysical, lastLogical) {
+			return nil
+		}
 	}
 	lastTSOPointer.physical = physical
 	// Same as above, we save the largest logical part here.
 	lastTSOPointer.logical = largestLogical
+	return nil
 }
 
 func tsLessEqual(physical, logical, thatPhysical, thatLogical int64) bool {
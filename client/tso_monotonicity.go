@@ -0,0 +1,209 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+const physicalShiftBits = 18
+
+// TSViolationType is the type of the timestamp monotonicity violation.
+type TSViolationType string
+
+const (
+	// TSViolationLocalFallback means the newly acquired TSO is less than or equal to
+	// the last one acquired by this client in the same dc-location.
+	TSViolationLocalFallback TSViolationType = "local-fallback"
+	// TSViolationMonitorFallback means the TSO probed by the monotonicity monitor is
+	// less than or equal to the last probed one.
+	TSViolationMonitorFallback TSViolationType = "monitor-fallback"
+	// TSViolationBehindExternalTimestamp means the TSO probed by the monotonicity monitor
+	// is less than the external timestamp of the cluster, which is always less than the
+	// TSO allocated after it is set.
+	TSViolationBehindExternalTimestamp TSViolationType = "behind-external-timestamp"
+)
+
+// TSViolationError is the error describing a timestamp monotonicity violation.
+type TSViolationError struct {
+	Type       TSViolationType
+	DCLocation string
+	// Physical and Logical are the newly acquired timestamp.
+	Physical int64
+	Logical  int64
+	// PrevPhysical and PrevLogical are the timestamp that the newly acquired one is compared with,
+	// i.e., the last acquired TSO, or the external timestamp.
+	PrevPhysical int64
+	PrevLogical  int64
+}
+
+// Error implements the error interface.
+func (e *TSViolationError) Error() string {
+	switch e.Type {
+	case TSViolationBehindExternalTimestamp:
+		return fmt.Sprintf("%s timestamp violation, newly acquired ts (%d, %d) is less than the external timestamp (%d, %d)",
+			e.DCLocation, e.Physical, e.Logical, e.PrevPhysical, e.PrevLogical)
+	default:
+		return fmt.Sprintf("%s timestamp fallback, newly acquired ts (%d, %d) is less or equal to last one (%d, %d)",
+			e.DCLocation, e.Physical, e.Logical, e.PrevPhysical, e.PrevLogical)
+	}
+}
+
+// TSViolationPolicy decides how the client reacts to a timestamp monotonicity violation.
+type TSViolationPolicy int
+
+const (
+	// TSViolationPolicyPanic panics once a violation is detected. It's the default policy.
+	TSViolationPolicyPanic TSViolationPolicy = iota
+	// TSViolationPolicyFail fails the TSO requests with a *TSViolationError instead of panicking.
+	// The violation detected by the monotonicity monitor will fail all the subsequent TSO requests,
+	// the client should be recreated after the cause is fixed.
+	TSViolationPolicyFail
+	// TSViolationPolicyLog only logs the violation and records the metrics.
+	TSViolationPolicyLog
+)
+
+// WithTSViolationPolicy configures the client with the policy to handle the timestamp monotonicity violation.
+func WithTSViolationPolicy(policy TSViolationPolicy) ClientOption {
+	return func(c *client) {
+		c.option.tsViolationPolicy = policy
+	}
+}
+
+// WithTSViolationCallback configures the client with a callback which will be called
+// with the detail of every detected timestamp monotonicity violation before the policy is applied.
+func WithTSViolationCallback(callback func(*TSViolationError)) ClientOption {
+	return func(c *client) {
+		c.option.tsViolationCallback = callback
+	}
+}
+
+// WithTSMonotonicityMonitor configures the client to check the timestamp monotonicity periodically
+// by comparing the acquired TSO with the last probed one and the external timestamp of the cluster.
+// The monitor is disabled if the interval is not positive.
+func WithTSMonotonicityMonitor(interval time.Duration) ClientOption {
+	return func(c *client) {
+		c.option.tsMonitorInterval = interval
+	}
+}
+
+func composeTS(physical, logical int64) uint64 {
+	return uint64(physical<<physicalShiftBits + logical)
+}
+
+func extractTS(ts uint64) (physical, logical int64) {
+	return int64(ts >> physicalShiftBits), int64(ts & (1<<physicalShiftBits - 1))
+}
+
+// handleTSViolation reports the violation and applies the violation policy.
+// It returns a non-nil error if the TSO requests should be failed.
+func (c *tsoClient) handleTSViolation(violation *TSViolationError) error {
+	tsoViolationCounter.WithLabelValues(string(violation.Type)).Inc()
+	log.Error("[tso] timestamp monotonicity violation detected",
+		zap.String("type", string(violation.Type)), zap.String("dc-location", violation.DCLocation), zap.Error(violation))
+	if c.option.tsViolationCallback != nil {
+		c.option.tsViolationCallback(violation)
+	}
+	switch c.option.tsViolationPolicy {
+	case TSViolationPolicyFail:
+		return violation
+	case TSViolationPolicyLog:
+		return nil
+	default:
+		panic(violation)
+	}
+}
+
+// getLatchedTSViolation returns the violation detected by the monotonicity monitor
+// under the TSViolationPolicyFail policy, which fails all the subsequent TSO requests.
+func (c *tsoClient) getLatchedTSViolation() error {
+	if violation := c.latchedTSViolation.Load(); violation != nil {
+		return violation
+	}
+	return nil
+}
+
+// tsMonotonicityMonitorLoop probes the TSO and the external timestamp periodically
+// to detect the timestamp regression, e.g., caused by a misconfigured restore or clock issues.
+func (c *client) tsMonotonicityMonitorLoop() {
+	defer c.wg.Done()
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(c.option.tsMonitorInterval)
+	defer ticker.Stop()
+
+	var lastTS uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if c.tsoClient.getLatchedTSViolation() != nil {
+			return
+		}
+		// Get the external timestamp first, so the TSO acquired later must be greater than it.
+		externalTS, err := c.GetExternalTimestamp(ctx)
+		if err != nil {
+			log.Warn("[tso] failed to get external timestamp for the monotonicity monitor", zap.Error(err))
+			continue
+		}
+		physical, logical, err := c.GetTS(ctx)
+		if err != nil {
+			log.Warn("[tso] failed to get tso for the monotonicity monitor", zap.Error(err))
+			continue
+		}
+		ts := composeTS(physical, logical)
+		var violation *TSViolationError
+		if lastTS != 0 && ts <= lastTS {
+			lastPhysical, lastLogical := extractTS(lastTS)
+			violation = &TSViolationError{
+				Type:         TSViolationMonitorFallback,
+				DCLocation:   globalDCLocation,
+				Physical:     physical,
+				Logical:      logical,
+				PrevPhysical: lastPhysical,
+				PrevLogical:  lastLogical,
+			}
+		} else if externalTS != 0 && ts < externalTS {
+			externalPhysical, externalLogical := extractTS(externalTS)
+			violation = &TSViolationError{
+				Type:         TSViolationBehindExternalTimestamp,
+				DCLocation:   globalDCLocation,
+				Physical:     physical,
+				Logical:      logical,
+				PrevPhysical: externalPhysical,
+				PrevLogical:  externalLogical,
+			}
+		}
+		if ts > lastTS {
+			lastTS = ts
+		}
+		if violation == nil {
+			continue
+		}
+		if err := c.tsoClient.handleTSViolation(violation); err != nil {
+			c.tsoClient.latchedTSViolation.CompareAndSwap(nil, violation)
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTSViolationPolicy(t *testing.T) {
+	re := require.New(t)
+	var violations []*TSViolationError
+	c := &tsoClient{option: newOption()}
+	c.option.tsViolationCallback = func(v *TSViolationError) {
+		violations = append(violations, v)
+	}
+
+	// The default policy panics on the fallback.
+	re.NoError(c.compareAndSwapTS(globalDCLocation, 100, 10, 0, 1))
+	re.NoError(c.compareAndSwapTS(globalDCLocation, 100, 11, 0, 5))
+	re.Panics(func() { c.compareAndSwapTS(globalDCLocation, 100, 15, 0, 1) })
+	re.Len(violations, 1)
+	re.Equal(TSViolationLocalFallback, violations[0].Type)
+	re.Equal(int64(15), violations[0].Logical)
+	re.Equal(int64(15), violations[0].PrevLogical)
+
+	// The fail policy returns the typed error and keeps the last TSO unchanged.
+	c.option.tsViolationPolicy = TSViolationPolicyFail
+	err := c.compareAndSwapTS(globalDCLocation, 99, 100, 0, 1)
+	violation, ok := err.(*TSViolationError)
+	re.True(ok)
+	re.Equal(int64(99), violation.Physical)
+	re.Equal(int64(100), violation.PrevPhysical)
+	re.Len(violations, 2)
+	re.NoError(c.compareAndSwapTS(globalDCLocation, 100, 16, 0, 1))
+
+	// The log policy only reports the violation.
+	c.option.tsViolationPolicy = TSViolationPolicyLog
+	re.NoError(c.compareAndSwapTS(globalDCLocation, 98, 0, 0, 1))
+	re.Len(violations, 3)
+	// The last TSO still holds the largest one after the logged fallback.
+	last, ok := c.lastTSMap.Load(globalDCLocation)
+	re.True(ok)
+	re.Equal(int64(100), last.(*lastTSO).physical)
+	re.Equal(int64(16), last.(*lastTSO).logical)
+	re.NoError(c.compareAndSwapTS(globalDCLocation, 99, 0, 0, 1))
+	re.Len(violations, 4)
+
+	// The latched violation fails the subsequent requests.
+	re.NoError(c.getLatchedTSViolation())
+	c.latchedTSViolation.Store(violation)
+	re.Equal(violation, c.getLatchedTSViolation())
+}
+
+func TestComposeAndExtractTS(t *testing.T) {
+	re := require.New(t)
+	physical, logical := extractTS(composeTS(1680000000000, 12345))
+	re.Equal(int64(1680000000000), physical)
+	re.Equal(int64(12345), logical)
+}
@@ -16,6 +16,7 @@ package pd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"sync"
@@ -28,6 +29,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/client/errs"
 	"github.com/tikv/pd/client/grpcutil"
+	"github.com/tikv/pd/client/keypath"
 	"github.com/tikv/pd/client/tlsutil"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -38,8 +40,20 @@ const (
 	// The entire key is in the format of "/ms/<cluster-id>/tso/<group-id>/primary" in which
 	// <group-id> is 5 digits integer with leading zeros. For now we use 0 as the default cluster id.
 	tsoPrimaryPrefix = "/ms/0/tso"
+	// defaultKeyspaceID is the ID of the default keyspace, which always belongs to the default keyspace group.
+	defaultKeyspaceID = uint32(0)
+	// defaultKeyspaceGroupID is the ID of the default keyspace group, which serves all the keyspaces
+	// that don't belong to any other keyspace group.
+	defaultKeyspaceGroupID = uint32(0)
 )
 
+// tsoKeyspaceGroup is the keyspace group, which serves the TSO of a set of keyspaces
+// with an independent allocator and primary election.
+type tsoKeyspaceGroup struct {
+	ID        uint32   `json:"id"`
+	Keyspaces []uint32 `json:"keyspaces"`
+}
+
 var _ ServiceDiscovery = (*tsoServiceDiscovery)(nil)
 var _ tsoAllocatorEventSource = (*tsoServiceDiscovery)(nil)
 
@@ -47,9 +61,9 @@ var _ tsoAllocatorEventSource = (*tsoServiceDiscovery)(nil)
 type tsoServiceDiscovery struct {
 	clusterID  uint64
 	keyspaceID uint32
-	urls       atomic.Value // Store as []string
-	// primary key is the etcd path used for discoverying the serving endpoint of this keyspace
-	primaryKey string
+	// keyspaceGroupID is the ID of the keyspace group to which the keyspace belongs.
+	keyspaceGroupID uint32       // accessed atomically
+	urls            atomic.Value // Store as []string
 	// TSO Primary URL
 	primary atomic.Value // Store as string
 	// TSO Secondary URLs
@@ -92,7 +106,7 @@ func newTSOServiceDiscovery(ctx context.Context, cancel context.CancelFunc, wg *
 		metacli:           metacli,
 		keyspaceID:        keyspaceID,
 		clusterID:         clusterID,
-		primaryKey:        path.Join(tsoPrimaryPrefix, fmt.Sprintf("%05d", 0), "primary"),
+		keyspaceGroupID:   defaultKeyspaceGroupID,
 		tlsCfg:            tlsCfg,
 		option:            option,
 		checkMembershipCh: make(chan struct{}, 1),
@@ -164,6 +178,11 @@ func (c *tsoServiceDiscovery) GetClusterID() uint64 {
 	return c.clusterID
 }
 
+// GetKeyspaceGroupID returns the ID of the keyspace group which serves the keyspace.
+func (c *tsoServiceDiscovery) GetKeyspaceGroupID() uint32 {
+	return atomic.LoadUint32(&c.keyspaceGroupID)
+}
+
 // GetURLs returns the URLs of the servers.
 // For testing use. It should only be called when the client is closed.
 func (c *tsoServiceDiscovery) GetURLs() []string {
@@ -285,15 +304,60 @@ func (c *tsoServiceDiscovery) switchPrimary(addrs []string) error {
 	return nil
 }
 
+// updateKeyspaceGroupID finds out the keyspace group to which the keyspace belongs.
+// The keyspace is served by the default keyspace group if it doesn't belong to any
+// other keyspace group.
+func (c *tsoServiceDiscovery) updateKeyspaceGroupID() error {
+	keyspaceGroupID := defaultKeyspaceGroupID
+	if c.keyspaceID != defaultKeyspaceID {
+		prefix := keypath.LegacyKeyspaceGroupMembershipPrefix(c.clusterID)
+		resp, err := c.metacli.Get(c.ctx, []byte(prefix), WithPrefix())
+		if err != nil {
+			log.Error("[tso] failed to get the keyspace group membership", errs.ZapError(err))
+			return err
+		}
+		for _, kv := range resp.GetKvs() {
+			kg := &tsoKeyspaceGroup{}
+			if err := json.Unmarshal(kv.Value, kg); err != nil {
+				return errs.ErrClientJSONUnmarshal.Wrap(err).GenWithStackByCause()
+			}
+			if kg.ID == defaultKeyspaceGroupID {
+				continue
+			}
+			for _, keyspaceID := range kg.Keyspaces {
+				if keyspaceID == c.keyspaceID {
+					keyspaceGroupID = kg.ID
+				}
+			}
+		}
+	}
+	if oldKeyspaceGroupID := atomic.SwapUint32(&c.keyspaceGroupID, keyspaceGroupID); oldKeyspaceGroupID != keyspaceGroupID {
+		log.Info("[tso] switch keyspace group",
+			zap.Uint32("keyspace-id", c.keyspaceID),
+			zap.Uint32("new-keyspace-group-id", keyspaceGroupID),
+			zap.Uint32("old-keyspace-group-id", oldKeyspaceGroupID))
+	}
+	return nil
+}
+
+// getPrimaryKey returns the etcd path used for discovering the serving endpoint of the keyspace group.
+func (c *tsoServiceDiscovery) getPrimaryKey() string {
+	return path.Join(tsoPrimaryPrefix, fmt.Sprintf("%05d", c.GetKeyspaceGroupID()), "primary")
+}
+
 func (c *tsoServiceDiscovery) updateMember() error {
-	resp, err := c.metacli.Get(c.ctx, []byte(c.primaryKey))
+	if err := c.updateKeyspaceGroupID(); err != nil {
+		return err
+	}
+	resp, err := c.metacli.Get(c.ctx, []byte(c.getPrimaryKey()))
 	if err != nil {
 		log.Error("[tso] failed to get the keyspace serving endpoint", errs.ZapError(err))
 		return err
 	}
 
 	if resp == nil || len(resp.Kvs) == 0 {
-		log.Error("[tso] didn't find the keyspace serving endpoint")
+		log.Error("[tso] didn't find the keyspace serving endpoint",
+			zap.Uint32("keyspace-group-id", c.GetKeyspaceGroupID()))
 		return errs.ErrClientGetLeader
 	} else if resp.Count > 1 {
 		return errs.ErrClientGetMultiResponse.FastGenByArgs(resp.Kvs)
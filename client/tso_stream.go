@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/kvproto/pkg/tsopb"
+	"github.com/tikv/pd/client/tsoheader"
 	"google.golang.org/grpc"
 )
 
@@ -95,16 +96,28 @@ func checkStreamTimeout(ctx context.Context, cancel context.CancelFunc, done cha
 
 type tsoStream interface {
 	// processRequests processes TSO requests in streaming mode to get timestamps
-	processRequests(clusterID uint64, dcLocation string, requests []*tsoRequest,
-		batchStartTime time.Time) (physical, logical int64, suffixBits uint32, err error)
+	processRequests(clusterID uint64, keyspaceID, keyspaceGroupID uint32, dcLocation string,
+		requests []*tsoRequest, batchStartTime time.Time) (physical, logical int64, suffixBits uint32, uncertainty clockUncertainty, err error)
+}
+
+// clockUncertainty is the clock uncertainty bound of the TSO physical time reported in
+// the header of the TSO response.
+type clockUncertainty struct {
+	bound    time.Duration
+	reported bool
+}
+
+func parseClockUncertainty(unrecognized []byte) clockUncertainty {
+	bound, reported := tsoheader.ParseClockUncertainty(unrecognized)
+	return clockUncertainty{bound: bound, reported: reported}
 }
 
 type pdTSOStream struct {
 	stream pdpb.PD_TsoClient
 }
 
-func (s *pdTSOStream) processRequests(clusterID uint64, dcLocation string, requests []*tsoRequest,
-	batchStartTime time.Time) (physical, logical int64, suffixBits uint32, err error) {
+func (s *pdTSOStream) processRequests(clusterID uint64, _, _ uint32, dcLocation string,
+	requests []*tsoRequest, batchStartTime time.Time) (physical, logical int64, suffixBits uint32, uncertainty clockUncertainty, err error) {
 	start := time.Now()
 	count := int64(len(requests))
 	req := &pdpb.TsoRequest{
@@ -134,6 +147,7 @@ func (s *pdTSOStream) processRequests(clusterID uint64, dcLocation string, reque
 	}
 
 	physical, logical, suffixBits = resp.GetTimestamp().GetPhysical(), resp.GetTimestamp().GetLogical(), resp.GetTimestamp().GetSuffixBits()
+	uncertainty = parseClockUncertainty(resp.GetHeader().XXX_unrecognized)
 	return
 }
 
@@ -141,13 +155,15 @@ type tsoTSOStream struct {
 	stream tsopb.TSO_TsoClient
 }
 
-func (s *tsoTSOStream) processRequests(clusterID uint64, dcLocation string, requests []*tsoRequest,
-	batchStartTime time.Time) (physical, logical int64, suffixBits uint32, err error) {
+func (s *tsoTSOStream) processRequests(clusterID uint64, keyspaceID, keyspaceGroupID uint32, dcLocation string,
+	requests []*tsoRequest, batchStartTime time.Time) (physical, logical int64, suffixBits uint32, uncertainty clockUncertainty, err error) {
 	start := time.Now()
 	count := int64(len(requests))
 	req := &tsopb.TsoRequest{
 		Header: &tsopb.RequestHeader{
-			ClusterId: clusterID,
+			ClusterId:       clusterID,
+			KeyspaceId:      keyspaceID,
+			KeyspaceGroupId: keyspaceGroupID,
 		},
 		Count:      uint32(count),
 		DcLocation: dcLocation,
@@ -172,5 +188,6 @@ func (s *tsoTSOStream) processRequests(clusterID uint64, dcLocation string, requ
 	}
 
 	physical, logical, suffixBits = resp.GetTimestamp().GetPhysical(), resp.GetTimestamp().GetLogical(), resp.GetTimestamp().GetSuffixBits()
+	uncertainty = parseClockUncertainty(resp.GetHeader().XXX_unrecognized)
 	return
 }
@@ -0,0 +1,62 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tsoheader encodes the extra fields carried by the header of the TSO response,
+// which are shared by the PD server, the TSO service and the PD client.
+package tsoheader
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// clockUncertaintyFieldNumber is the field number of the clock uncertainty bound in the
+// header of the TSO response. It isn't defined by kvproto, so it's carried as an unknown
+// field of the header, which is kept by the generated code on both sides and ignored by
+// the clients which don't know about it.
+const clockUncertaintyFieldNumber protowire.Number = 1000
+
+// AppendClockUncertainty appends the clock uncertainty bound of the TSO physical time to
+// the unknown fields of the TSO response header.
+func AppendClockUncertainty(unrecognized []byte, uncertainty time.Duration) []byte {
+	unrecognized = protowire.AppendTag(unrecognized, clockUncertaintyFieldNumber, protowire.VarintType)
+	return protowire.AppendVarint(unrecognized, uint64(uncertainty))
+}
+
+// ParseClockUncertainty parses the clock uncertainty bound of the TSO physical time from
+// the unknown fields of the TSO response header. It returns false if the server doesn't
+// report it.
+func ParseClockUncertainty(unrecognized []byte) (time.Duration, bool) {
+	for len(unrecognized) > 0 {
+		num, typ, n := protowire.ConsumeTag(unrecognized)
+		if n < 0 {
+			return 0, false
+		}
+		unrecognized = unrecognized[n:]
+		if num == clockUncertaintyFieldNumber && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(unrecognized)
+			if n < 0 {
+				return 0, false
+			}
+			return time.Duration(v), true
+		}
+		n = protowire.ConsumeFieldValue(num, typ, unrecognized)
+		if n < 0 {
+			return 0, false
+		}
+		unrecognized = unrecognized[n:]
+	}
+	return 0, false
+}
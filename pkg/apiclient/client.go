@@ -0,0 +1,225 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apiclient is the client of the PD HTTP API. The methods of the operations are
+// generated from the OpenAPI document in docs/openapi by `make openapi-spec`.
+package apiclient
+
+//go:generate sh -c "cd ../.. && go run ./tools/openapi-gen"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// Client is a client of the PD HTTP API. The requests are sent to the endpoints in order
+// until one of them succeeds.
+type Client struct {
+	endpoints   []string
+	cli         *http.Client
+	bearerToken string
+	header      http.Header
+}
+
+// ClientOption configures the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used to send the requests.
+func WithHTTPClient(cli *http.Client) ClientOption {
+	return func(c *Client) {
+		c.cli = cli
+	}
+}
+
+// WithBearerToken sets the token which is sent in the Authorization header.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) {
+		c.bearerToken = token
+	}
+}
+
+// WithHeader sets the header which is sent with every request.
+func WithHeader(header http.Header) ClientOption {
+	return func(c *Client) {
+		c.header = header
+	}
+}
+
+// NewClient creates a client of the endpoints, like "http://127.0.0.1:2379".
+func NewClient(endpoints []string, opts ...ClientOption) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoint is specified")
+	}
+	c := &Client{cli: http.DefaultClient}
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, errors.Errorf("invalid endpoint %s", endpoint)
+		}
+		c.endpoints = append(c.endpoints, strings.TrimSuffix(endpoint, "/"))
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// StatusError is returned if the server responds with a non-2xx status.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("[%d] %s", e.StatusCode, e.Message)
+}
+
+// Do sends the request of the URI, which is the path with the query, to the endpoints in
+// order and returns the first successful response. The caller must close the body of the
+// response.
+func (c *Client) Do(ctx context.Context, method, uri string, body io.Reader, header http.Header) (*http.Response, error) {
+	// The body is resent to the next endpoint if the request fails.
+	if _, ok := body.(io.Seeker); body != nil && !ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	var err error
+	for _, endpoint := range c.endpoints {
+		var resp *http.Response
+		resp, err = c.doEndpoint(ctx, endpoint, method, uri, body, header)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	if len(c.endpoints) > 1 {
+		return nil, errors.Errorf("after trying all endpoints, no endpoint is available, the last error we met: %s", err)
+	}
+	return nil, err
+}
+
+func (c *Client) doEndpoint(ctx context.Context, endpoint, method, uri string, body io.Reader, header http.Header) (*http.Response, error) {
+	if seeker, ok := body.(io.Seeker); ok {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+uri, body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, h := range []http.Header{c.header, header} {
+		for k, values := range h {
+			for _, v := range values {
+				req.Header.Add(k, v)
+			}
+		}
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return nil, &StatusError{StatusCode: resp.StatusCode, Message: string(msg)}
+	}
+	return resp, nil
+}
+
+func (c *Client) send(ctx context.Context, method, uri string, query url.Values, header http.Header, in interface{}) (*http.Response, error) {
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	var body io.Reader
+	if in != nil {
+		switch v := in.(type) {
+		case io.Reader:
+			body = v
+		default:
+			data, err := json.Marshal(in)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			body = bytes.NewReader(data)
+			header = header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			header.Set("Content-Type", "application/json")
+		}
+	}
+	return c.Do(ctx, method, uri, body, header)
+}
+
+// doJSON sends the request with the JSON body of in and decodes the JSON response into out.
+// The response is discarded if out is nil.
+func (c *Client) doJSON(ctx context.Context, method, uri string, query url.Values, header http.Header, in, out interface{}) error {
+	resp, err := c.send(ctx, method, uri, query, header, in)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(json.NewDecoder(resp.Body).Decode(out))
+}
+
+// doText sends the request and returns the response as a string.
+func (c *Client) doText(ctx context.Context, method, uri string, query url.Values, header http.Header, in interface{}) (string, error) {
+	resp, err := c.send(ctx, method, uri, query, header, in)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(data), nil
+}
+
+// doStream sends the request and returns the body of the response, which must be closed
+// by the caller.
+func (c *Client) doStream(ctx context.Context, method, uri string, query url.Values, header http.Header, in interface{}) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, method, uri, query, header, in)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
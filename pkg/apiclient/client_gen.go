@@ -0,0 +1,3268 @@
+// Code generated by openapi-gen. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/audit"
+	"github.com/tikv/pd/pkg/event"
+	"github.com/tikv/pd/pkg/mcs/resource_manager/server"
+	"github.com/tikv/pd/pkg/rbac"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/maintenance"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/configutil"
+	"github.com/tikv/pd/pkg/utils/metricutil"
+)
+
+// basePath is the path prefix of the API.
+const basePath = "/pd/api/v1"
+
+// AccelerateRegionsScheduleInRangeOptions is the optional parameters of AccelerateRegionsScheduleInRange.
+type AccelerateRegionsScheduleInRangeOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// AccelerateRegionsScheduleInRange calls POST /regions/accelerate-schedule: Accelerate regions scheduling a in given range, only receive hex format for keys.
+func (c *Client) AccelerateRegionsScheduleInRange(ctx context.Context, body map[string]interface{}, opts *AccelerateRegionsScheduleInRangeOptions) (string, error) {
+	uri := basePath + "/regions/accelerate-schedule"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// ApproveFailedStoresRemovalPlan calls POST /admin/unsafe/remove-failed-stores/approve: Approve the recovery plan generated by the last dry run to remove the failed stores.
+func (c *Client) ApproveFailedStoresRemovalPlan(ctx context.Context, body map[string]interface{}) error {
+	uri := basePath + "/admin/unsafe/remove-failed-stores/approve"
+	return c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, nil)
+}
+
+// BatchRules calls POST /config/rules/batch: Batch operations for the cluster. Operations should be independent(different ID). If there is an error, modifications are promised to be rollback in memory, but may fail to rollback disk. You probably want to request again to make rules in memory/disk consistent.
+func (c *Client) BatchRules(ctx context.Context, body []*placement.RuleOp) (string, error) {
+	uri := basePath + "/config/rules/batch"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// CancelStoreDrain calls DELETE /store/{id}/drain: Cancel draining the store, and the store becomes up again if it's not tombstone.
+func (c *Client) CancelStoreDrain(ctx context.Context, id uint64) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/drain"
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// CheckRegionPlacementRule calls GET /config/rules/region/{region}/detail: List rules and matched peers related to the given region.
+func (c *Client) CheckRegionPlacementRule(ctx context.Context, region uint64) (*placement.RegionFit, error) {
+	uri := basePath + "/config/rules/region/" + url.PathEscape(strconv.FormatUint(region, 10)) + "/detail"
+	var out *placement.RegionFit
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// CheckRegionsReplicated calls GET /regions/replicated: Check if regions in the given key ranges are replicated. Returns 'REPLICATED', 'INPROGRESS', or 'PENDING'. 'PENDING' means that there is at least one region pending for scheduling. Similarly, 'INPROGRESS' means there is at least one region in scheduling.
+func (c *Client) CheckRegionsReplicated(ctx context.Context, startKey string, endKey string) (string, error) {
+	uri := basePath + "/regions/replicated"
+	query := url.Values{}
+	query.Set("startKey", startKey)
+	query.Set("endKey", endKey)
+	return c.doText(ctx, http.MethodGet, uri, query, nil, nil)
+}
+
+// CreateOperator calls POST /operators: Create an operator.
+func (c *Client) CreateOperator(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/operators"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// CreateScheduler calls POST /schedulers: Create a scheduler.
+func (c *Client) CreateScheduler(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/schedulers"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// DeleteAllRegionCache calls DELETE /admin/cache/regions: Drop all regions from cache.
+func (c *Client) DeleteAllRegionCache(ctx context.Context) (string, error) {
+	uri := basePath + "/admin/cache/regions"
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteGCSafePoint calls DELETE /gc/safepoint/{service_id}: Delete a service GC safepoint.
+func (c *Client) DeleteGCSafePoint(ctx context.Context, serviceID string) (string, error) {
+	uri := basePath + "/gc/safepoint/" + url.PathEscape(serviceID)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteGroupConfig calls DELETE /config/rule_group/{id}: Delete rule group config.
+func (c *Client) DeleteGroupConfig(ctx context.Context, id string) (string, error) {
+	uri := basePath + "/config/rule_group/" + url.PathEscape(id)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteMemberByID calls DELETE /members/id/{id}: Remove a PD server from the cluster.
+func (c *Client) DeleteMemberByID(ctx context.Context, id uint64) (string, error) {
+	uri := basePath + "/members/id/" + url.PathEscape(strconv.FormatUint(id, 10))
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteMemberByName calls DELETE /members/name/{name}: Remove a PD server from the cluster.
+func (c *Client) DeleteMemberByName(ctx context.Context, name string) (string, error) {
+	uri := basePath + "/members/name/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteOperatorByRegion calls DELETE /operators/{region_id}: Cancel a Region's pending operator.
+func (c *Client) DeleteOperatorByRegion(ctx context.Context, regionID uint64) (string, error) {
+	uri := basePath + "/operators/" + url.PathEscape(strconv.FormatUint(regionID, 10))
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeletePlacementRuleByGroupOptions is the optional parameters of DeletePlacementRuleByGroup.
+type DeletePlacementRuleByGroupOptions struct {
+	// Use regular expression
+	Regexp bool
+}
+
+// DeletePlacementRuleByGroup calls DELETE /config/placement-rule/{group}: Delete group config and all rules belong to the group.
+func (c *Client) DeletePlacementRuleByGroup(ctx context.Context, group string, opts *DeletePlacementRuleByGroupOptions) (string, error) {
+	uri := basePath + "/config/placement-rule/" + url.PathEscape(group)
+	query := url.Values{}
+	if opts != nil {
+		if opts.Regexp {
+			query.Set("regexp", strconv.FormatBool(opts.Regexp))
+		}
+	}
+	return c.doText(ctx, http.MethodDelete, uri, query, nil, nil)
+}
+
+// DeleteRBACBinding calls DELETE /rbac/bindings: Delete the role binding of a subject.
+func (c *Client) DeleteRBACBinding(ctx context.Context, kind string, value string) (string, error) {
+	uri := basePath + "/rbac/bindings"
+	query := url.Values{}
+	query.Set("kind", kind)
+	query.Set("value", value)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// DeleteRBACRole calls DELETE /rbac/roles/{name}: Delete a custom role which isn't bound to any subject.
+func (c *Client) DeleteRBACRole(ctx context.Context, name string) (string, error) {
+	uri := basePath + "/rbac/roles/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteRegionCache calls DELETE /admin/cache/region/{id}: Drop a specific region from cache.
+func (c *Client) DeleteRegionCache(ctx context.Context, id uint64) (string, error) {
+	uri := basePath + "/admin/cache/region/" + url.PathEscape(strconv.FormatUint(id, 10))
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteRegionLabelRule calls DELETE /config/region-label/rule/{id}: Delete label rule of cluster by id.
+func (c *Client) DeleteRegionLabelRule(ctx context.Context, id string) (string, error) {
+	uri := basePath + "/config/region-label/rule/" + url.PathEscape(id)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteRuleByGroup calls DELETE /config/rule/{group}/{id}: Delete rule of cluster.
+func (c *Client) DeleteRuleByGroup(ctx context.Context, group string, id string) (string, error) {
+	uri := basePath + "/config/rule/" + url.PathEscape(group) + "/" + url.PathEscape(id)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteScatterGroup calls DELETE /regions/scatter-groups/{name}: Delete a scatter group, the regions of the group are no longer kept evenly distributed.
+func (c *Client) DeleteScatterGroup(ctx context.Context, name string) (string, error) {
+	uri := basePath + "/regions/scatter-groups/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteScheduler calls DELETE /schedulers/{name}: Delete a scheduler.
+func (c *Client) DeleteScheduler(ctx context.Context, name string) (string, error) {
+	uri := basePath + "/schedulers/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteSchedulerConfigStore calls DELETE /scheduler-config/{name}/delete/{store_id}: Delete a store from the config of a scheduler, such as evict-leader-scheduler and grant-leader-scheduler.
+func (c *Client) DeleteSchedulerConfigStore(ctx context.Context, name string, storeID uint64) (string, error) {
+	uri := basePath + "/scheduler-config/" + url.PathEscape(name) + "/delete/" + url.PathEscape(strconv.FormatUint(storeID, 10))
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// DeleteStoreOptions is the optional parameters of DeleteStore.
+type DeleteStoreOptions struct {
+	// Set the store as Offline even if the regions can't be moved out
+	Force string
+}
+
+// DeleteStore calls DELETE /store/{id}: Take down a store from the cluster.
+func (c *Client) DeleteStore(ctx context.Context, id uint64, opts *DeleteStoreOptions) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10))
+	query := url.Values{}
+	if opts != nil {
+		if opts.Force != "" {
+			query.Set("force", opts.Force)
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// DeleteStoreLabel calls DELETE /store/{id}/label: delete the store's label.
+func (c *Client) DeleteStoreLabel(ctx context.Context, id uint64, body string) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/label"
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// DrainStore calls POST /store/{id}/drain: Drain a store gracefully. The leaders are transferred out first, then the peers are moved out, and the store becomes tombstone at last.
+func (c *Client) DrainStore(ctx context.Context, id uint64, body *StoreDrainOptions) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/drain"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// GetAllGroupConfigs calls GET /config/rule_groups: List all rule group configs.
+func (c *Client) GetAllGroupConfigs(ctx context.Context) ([]*placement.RuleGroup, error) {
+	uri := basePath + "/config/rule_groups"
+	var out []*placement.RuleGroup
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetAllRegionLabelRules calls GET /config/region-label/rules: List all label rules of cluster.
+func (c *Client) GetAllRegionLabelRules(ctx context.Context) ([]*labeler.LabelRule, error) {
+	uri := basePath + "/config/region-label/rules"
+	var out []*labeler.LabelRule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetAllRules calls GET /config/rules: List all rules of cluster.
+func (c *Client) GetAllRules(ctx context.Context) ([]*placement.Rule, error) {
+	uri := basePath + "/config/rules"
+	var out []*placement.Rule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetAllStoresLimitOptions is the optional parameters of GetAllStoresLimit.
+type GetAllStoresLimitOptions struct {
+	// include Tombstone
+	IncludeTombstone bool
+}
+
+// GetAllStoresLimit calls GET /stores/limit: Get limit of all stores in the cluster.
+func (c *Client) GetAllStoresLimit(ctx context.Context, opts *GetAllStoresLimitOptions) (string, error) {
+	uri := basePath + "/stores/limit"
+	query := url.Values{}
+	if opts != nil {
+		if opts.IncludeTombstone {
+			query.Set("include_tombstone", strconv.FormatBool(opts.IncludeTombstone))
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetAuditRecordsOptions is the optional parameters of GetAuditRecords.
+type GetAuditRecordsOptions struct {
+	// Start time in unix seconds
+	StartTime uint64
+	// End time in unix seconds
+	EndTime uint64
+	// IP of the caller
+	IP string
+	// Component of the caller
+	Component string
+	// Service label of the route
+	Service string
+	// Max number of the records, default 1000
+	Limit uint64
+}
+
+// GetAuditRecords calls GET /admin/audit: Query the audit records of the configuration changes and admin operations. The records are kept by each server, so the leader collects the records from all the members, and only the records of the server itself are returned if the header PD-Allow-follower-handle is set.
+func (c *Client) GetAuditRecords(ctx context.Context, opts *GetAuditRecordsOptions) ([]*audit.Record, error) {
+	uri := basePath + "/admin/audit"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartTime != 0 {
+			query.Set("start_time", strconv.FormatUint(opts.StartTime, 10))
+		}
+		if opts.EndTime != 0 {
+			query.Set("end_time", strconv.FormatUint(opts.EndTime, 10))
+		}
+		if opts.IP != "" {
+			query.Set("ip", opts.IP)
+		}
+		if opts.Component != "" {
+			query.Set("component", opts.Component)
+		}
+		if opts.Service != "" {
+			query.Set("service", opts.Service)
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out []*audit.Record
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetCheckerStatus calls GET /checker/{name}: Get if checker is paused.
+func (c *Client) GetCheckerStatus(ctx context.Context, name string) (string, error) {
+	uri := basePath + "/checker/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetCluster calls GET /cluster: Get cluster info.
+func (c *Client) GetCluster(ctx context.Context) (*metapb.Cluster, error) {
+	uri := basePath + "/cluster"
+	var out *metapb.Cluster
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetClusterStatus calls GET /cluster/status: Get cluster status.
+func (c *Client) GetClusterStatus(ctx context.Context) (*ClusterStatus, error) {
+	uri := basePath + "/cluster/status"
+	var out *ClusterStatus
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetClusterVersion calls GET /config/cluster-version: Get cluster version.
+func (c *Client) GetClusterVersion(ctx context.Context) (string, error) {
+	uri := basePath + "/config/cluster-version"
+	var out string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetConfig calls GET /config: Get full config.
+func (c *Client) GetConfig(ctx context.Context) (*Config, error) {
+	uri := basePath + "/config"
+	var out *Config
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetDefaultConfig calls GET /config/default: Get default config.
+func (c *Client) GetDefaultConfig(ctx context.Context) (*Config, error) {
+	uri := basePath + "/config/default"
+	var out *Config
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetDiagnosticResult calls GET /schedulers/diagnostic/{name}: Get the diagnostic result of a scheduler.
+func (c *Client) GetDiagnosticResult(ctx context.Context, name string) (*DiagnosticResult, error) {
+	uri := basePath + "/schedulers/diagnostic/" + url.PathEscape(name)
+	var out *DiagnosticResult
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetDownPeerRegionsOptions is the optional parameters of GetDownPeerRegions.
+type GetDownPeerRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetDownPeerRegions calls GET /regions/check/down-peer: List all regions that has down peer.
+func (c *Client) GetDownPeerRegions(ctx context.Context, opts *GetDownPeerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/down-peer"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetEmptyRegionsOptions is the optional parameters of GetEmptyRegions.
+type GetEmptyRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetEmptyRegions calls GET /regions/check/empty-region: List all empty regions.
+func (c *Client) GetEmptyRegions(ctx context.Context, opts *GetEmptyRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/empty-region"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetExtraPeerRegionsOptions is the optional parameters of GetExtraPeerRegions.
+type GetExtraPeerRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetExtraPeerRegions calls GET /regions/check/extra-peer: List all regions that has extra peer.
+func (c *Client) GetExtraPeerRegions(ctx context.Context, opts *GetExtraPeerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/extra-peer"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetFailedStoresRemovalPlan calls GET /admin/unsafe/remove-failed-stores/plan: Get the recovery plan generated by the last dry run of failed stores removal.
+func (c *Client) GetFailedStoresRemovalPlan(ctx context.Context) (*UnsafeRecoveryPlan, error) {
+	uri := basePath + "/admin/unsafe/remove-failed-stores/plan"
+	var out *UnsafeRecoveryPlan
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetFailedStoresRemovalStatus calls GET /admin/unsafe/remove-failed-stores/show: Show the current status of failed stores removal.
+func (c *Client) GetFailedStoresRemovalStatus(ctx context.Context) error {
+	uri := basePath + "/admin/unsafe/remove-failed-stores/show"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// GetGCSafePoint calls GET /gc/safepoint: Get all service GC safepoint.
+func (c *Client) GetGCSafePoint(ctx context.Context) ([]*ListServiceGCSafepoint, error) {
+	uri := basePath + "/gc/safepoint"
+	var out []*ListServiceGCSafepoint
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetGroupConfig calls GET /config/rule_group/{id}: Get rule group config by group id.
+func (c *Client) GetGroupConfig(ctx context.Context, id string) (*placement.RuleGroup, error) {
+	uri := basePath + "/config/rule_group/" + url.PathEscape(id)
+	var out *placement.RuleGroup
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHealthStatus calls GET /health: Health status of PD servers.
+func (c *Client) GetHealthStatus(ctx context.Context) ([]*Health, error) {
+	uri := basePath + "/health"
+	var out []*Health
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHistoryHotRegions calls GET /hotspot/regions/history: List the history hot regions.
+func (c *Client) GetHistoryHotRegions(ctx context.Context) (*storage.HistoryHotRegions, error) {
+	uri := basePath + "/hotspot/regions/history"
+	var out *storage.HistoryHotRegions
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHotReadRegions calls GET /hotspot/regions/read: List the hot read regions.
+func (c *Client) GetHotReadRegions(ctx context.Context) (*statistics.StoreHotPeersInfos, error) {
+	uri := basePath + "/hotspot/regions/read"
+	var out *statistics.StoreHotPeersInfos
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHotStores calls GET /hotspot/stores: List the hot stores.
+func (c *Client) GetHotStores(ctx context.Context) (*HotStoreStats, error) {
+	uri := basePath + "/hotspot/stores"
+	var out *HotStoreStats
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetHotWriteRegions calls GET /hotspot/regions/write: List the hot write regions.
+func (c *Client) GetHotWriteRegions(ctx context.Context) (*statistics.StoreHotPeersInfos, error) {
+	uri := basePath + "/hotspot/regions/write"
+	var out *statistics.StoreHotPeersInfos
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetKeysHistogramOptions is the optional parameters of GetKeysHistogram.
+type GetKeysHistogramOptions struct {
+	// Key bound of region histogram
+	Bound uint64
+}
+
+// GetKeysHistogram calls GET /regions/check/hist-keys: Get keys of histogram.
+func (c *Client) GetKeysHistogram(ctx context.Context, opts *GetKeysHistogramOptions) ([]*HistItem, error) {
+	uri := basePath + "/regions/check/hist-keys"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Bound != 0 {
+			query.Set("bound", strconv.FormatUint(opts.Bound, 10))
+		}
+	}
+	var out []*HistItem
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetLabelPropertyConfig calls GET /config/label-property: Get label property config.
+func (c *Client) GetLabelPropertyConfig(ctx context.Context) (map[string][]*StoreLabel, error) {
+	uri := basePath + "/config/label-property"
+	var out map[string][]*StoreLabel
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetLabels calls GET /labels: List all label values.
+func (c *Client) GetLabels(ctx context.Context) ([]*metapb.StoreLabel, error) {
+	uri := basePath + "/labels"
+	var out []*metapb.StoreLabel
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetLeader calls GET /leader: Get the leader PD server of the cluster.
+func (c *Client) GetLeader(ctx context.Context) (*pdpb.Member, error) {
+	uri := basePath + "/leader"
+	var out *pdpb.Member
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetLearnerPeerRegionsOptions is the optional parameters of GetLearnerPeerRegions.
+type GetLearnerPeerRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetLearnerPeerRegions calls GET /regions/check/learner-peer: List all regions that has learner peer.
+func (c *Client) GetLearnerPeerRegions(ctx context.Context, opts *GetLearnerPeerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/learner-peer"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetMaintenanceWindowStatus calls GET /config/maintenance-windows: Get the maintenance status of the schedulers and checkers restricted by the maintenance windows or blackout periods.
+func (c *Client) GetMaintenanceWindowStatus(ctx context.Context) ([]*maintenance.Status, error) {
+	uri := basePath + "/config/maintenance-windows"
+	var out []*maintenance.Status
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetMembers calls GET /members: List all PD servers in the cluster.
+func (c *Client) GetMembers(ctx context.Context) (*pdpb.GetMembersResponse, error) {
+	uri := basePath + "/members"
+	var out *pdpb.GetMembersResponse
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetMinResolvedTS calls GET /min-resolved-ts: Get cluster-level min resolved ts.
+func (c *Client) GetMinResolvedTS(ctx context.Context) ([]*MinResolvedTS, error) {
+	uri := basePath + "/min-resolved-ts"
+	var out []*MinResolvedTS
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetMissPeerRegionsOptions is the optional parameters of GetMissPeerRegions.
+type GetMissPeerRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetMissPeerRegions calls GET /regions/check/miss-peer: List all regions that miss peer.
+func (c *Client) GetMissPeerRegions(ctx context.Context, opts *GetMissPeerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/miss-peer"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetOfflinePeerRegionsOptions is the optional parameters of GetOfflinePeerRegions.
+type GetOfflinePeerRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetOfflinePeerRegions calls GET /regions/check/offline-peer: List all regions that has offline peer.
+func (c *Client) GetOfflinePeerRegions(ctx context.Context, opts *GetOfflinePeerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/offline-peer"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetOperatorRecordsOptions is the optional parameters of GetOperatorRecords.
+type GetOperatorRecordsOptions struct {
+	// From Unix timestamp
+	From uint64
+}
+
+// GetOperatorRecords calls GET /operators/records: lists the finished operators since the given timestamp in second.
+func (c *Client) GetOperatorRecords(ctx context.Context, opts *GetOperatorRecordsOptions) ([]json.RawMessage, error) {
+	uri := basePath + "/operators/records"
+	query := url.Values{}
+	if opts != nil {
+		if opts.From != 0 {
+			query.Set("from", strconv.FormatUint(opts.From, 10))
+		}
+	}
+	var out []json.RawMessage
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetOperatorsOptions is the optional parameters of GetOperators.
+type GetOperatorsOptions struct {
+	// Specify the operator kind.
+	Kind string
+}
+
+// GetOperators calls GET /operators: List pending operators.
+func (c *Client) GetOperators(ctx context.Context, opts *GetOperatorsOptions) ([]json.RawMessage, error) {
+	uri := basePath + "/operators"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Kind != "" {
+			query.Set("kind", opts.Kind)
+		}
+	}
+	var out []json.RawMessage
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetOperatorsByRegion calls GET /operators/{region_id}: Get a Region's pending operator.
+func (c *Client) GetOperatorsByRegion(ctx context.Context, regionID uint64) (json.RawMessage, error) {
+	uri := basePath + "/operators/" + url.PathEscape(strconv.FormatUint(regionID, 10))
+	var out json.RawMessage
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetOverSizedRegionsOptions is the optional parameters of GetOverSizedRegions.
+type GetOverSizedRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetOverSizedRegions calls GET /regions/check/oversized-region: List all regions that are oversized.
+func (c *Client) GetOverSizedRegions(ctx context.Context, opts *GetOverSizedRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/oversized-region"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetPDServerConfig calls GET /config/pd-server: Get PD server config.
+func (c *Client) GetPDServerConfig(ctx context.Context) (*PDServerConfig, error) {
+	uri := basePath + "/config/pd-server"
+	var out *PDServerConfig
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetPDStatus calls GET /status: Get the build info of PD server.
+func (c *Client) GetPDStatus(ctx context.Context) (*APIStatus, error) {
+	uri := basePath + "/status"
+	var out *APIStatus
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetPendingPeerRegionsOptions is the optional parameters of GetPendingPeerRegions.
+type GetPendingPeerRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetPendingPeerRegions calls GET /regions/check/pending-peer: List all regions that has pending peer.
+func (c *Client) GetPendingPeerRegions(ctx context.Context, opts *GetPendingPeerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/pending-peer"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetPlacementRuleByGroup calls GET /config/placement-rule/{group}: Get group config and all rules belong to the group.
+func (c *Client) GetPlacementRuleByGroup(ctx context.Context, group string) (*placement.GroupBundle, error) {
+	uri := basePath + "/config/placement-rule/" + url.PathEscape(group)
+	var out *placement.GroupBundle
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetPlacementRules calls GET /config/placement-rule: List all rules and groups configuration.
+func (c *Client) GetPlacementRules(ctx context.Context) ([]*placement.GroupBundle, error) {
+	uri := basePath + "/config/placement-rule"
+	var out []*placement.GroupBundle
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRBACBindings calls GET /rbac/bindings: List all the role bindings. The values of the token bindings are the hashes of the tokens.
+func (c *Client) GetRBACBindings(ctx context.Context) ([]*rbac.Binding, error) {
+	uri := basePath + "/rbac/bindings"
+	var out []*rbac.Binding
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRBACRole calls GET /rbac/roles/{name}: Get the role by name.
+func (c *Client) GetRBACRole(ctx context.Context, name string) (*rbac.Role, error) {
+	uri := basePath + "/rbac/roles/" + url.PathEscape(name)
+	var out *rbac.Role
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRBACRoles calls GET /rbac/roles: List all the built-in and custom roles.
+func (c *Client) GetRBACRoles(ctx context.Context) ([]*rbac.Role, error) {
+	uri := basePath + "/rbac/roles"
+	var out []*rbac.Role
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRangeHoles calls GET /regions/range-holes: List all range holes whitout any region info.
+func (c *Client) GetRangeHoles(ctx context.Context) ([][]string, error) {
+	uri := basePath + "/regions/range-holes"
+	var out [][]string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegion calls GET /region/key/{key}: Search for a region by a key. GetRegion is named to be consistent with gRPC.
+func (c *Client) GetRegion(ctx context.Context, key string) (*RegionInfo, error) {
+	uri := basePath + "/region/key/" + url.PathEscape(key)
+	var out *RegionInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionByID calls GET /region/id/{id}: Search for a region by region ID.
+func (c *Client) GetRegionByID(ctx context.Context, id uint64) (*RegionInfo, error) {
+	uri := basePath + "/region/id/" + url.PathEscape(strconv.FormatUint(id, 10))
+	var out *RegionInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionCount calls GET /regions/count: Get count of regions.
+func (c *Client) GetRegionCount(ctx context.Context) (*RegionsInfo, error) {
+	uri := basePath + "/regions/count"
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionLabelByKey calls GET /region/id/{id}/label/{key}: Get label of a region.
+func (c *Client) GetRegionLabelByKey(ctx context.Context, id uint64, key string) (string, error) {
+	uri := basePath + "/region/id/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/label/" + url.PathEscape(key)
+	var out string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionLabelRuleByID calls GET /config/region-label/rule/{id}: Get label rule of cluster by id.
+func (c *Client) GetRegionLabelRuleByID(ctx context.Context, id string) (*labeler.LabelRule, error) {
+	uri := basePath + "/config/region-label/rule/" + url.PathEscape(id)
+	var out *labeler.LabelRule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionLabelRulesByIDs calls GET /config/region-label/rules/ids: Get label rules of cluster by ids.
+func (c *Client) GetRegionLabelRulesByIDs(ctx context.Context, body []string) ([]*labeler.LabelRule, error) {
+	uri := basePath + "/config/region-label/rules/ids"
+	var out []*labeler.LabelRule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// GetRegionLabels calls GET /region/id/{id}/labels: Get labels of a region.
+func (c *Client) GetRegionLabels(ctx context.Context, id uint64) (string, error) {
+	uri := basePath + "/region/id/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/labels"
+	var out string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionSiblings calls GET /regions/sibling/{id}: List sibling regions of a specific region.
+func (c *Client) GetRegionSiblings(ctx context.Context, id uint64) (*RegionsInfo, error) {
+	uri := basePath + "/regions/sibling/" + url.PathEscape(strconv.FormatUint(id, 10))
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionStatus calls GET /stats/region: Get region statistics of a specified range.
+func (c *Client) GetRegionStatus(ctx context.Context, startKey string, endKey string) (*statistics.RegionStats, error) {
+	uri := basePath + "/stats/region"
+	query := url.Values{}
+	query.Set("start_key", startKey)
+	query.Set("end_key", endKey)
+	var out *statistics.RegionStats
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetRegionsOptions is the optional parameters of GetRegions.
+type GetRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetRegions calls GET /regions: List all regions in the cluster.
+func (c *Client) GetRegions(ctx context.Context, opts *GetRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetReplicationConfig calls GET /config/replicate: Get replication config.
+func (c *Client) GetReplicationConfig(ctx context.Context) (*ReplicationConfig, error) {
+	uri := basePath + "/config/replicate"
+	var out *ReplicationConfig
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetReplicationModeConfig calls GET /config/replication-mode: Get replication mode config.
+func (c *Client) GetReplicationModeConfig(ctx context.Context) (*ReplicationModeConfig, error) {
+	uri := basePath + "/config/replication-mode"
+	var out *ReplicationModeConfig
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetReplicationModeStatus calls GET /replication_mode/status: Get status of replication mode.
+func (c *Client) GetReplicationModeStatus(ctx context.Context) (*HTTPReplicationStatus, error) {
+	uri := basePath + "/replication_mode/status"
+	var out *HTTPReplicationStatus
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRuleByGroup calls GET /config/rules/group/{group}: List all rules of cluster by group.
+func (c *Client) GetRuleByGroup(ctx context.Context, group string) ([]*placement.Rule, error) {
+	uri := basePath + "/config/rules/group/" + url.PathEscape(group)
+	var out []*placement.Rule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRuleByGroupAndID calls GET /config/rule/{group}/{id}: Get rule of cluster by group and id.
+func (c *Client) GetRuleByGroupAndID(ctx context.Context, group string, id string) (*placement.Rule, error) {
+	uri := basePath + "/config/rule/" + url.PathEscape(group) + "/" + url.PathEscape(id)
+	var out *placement.Rule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRulesByKey calls GET /config/rules/key/{key}: List all rules of cluster by key.
+func (c *Client) GetRulesByKey(ctx context.Context, key string) ([]*placement.Rule, error) {
+	uri := basePath + "/config/rules/key/" + url.PathEscape(key)
+	var out []*placement.Rule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetRulesByRegion calls GET /config/rules/region/{region}: List all rules of cluster by region.
+func (c *Client) GetRulesByRegion(ctx context.Context, region uint64) ([]*placement.Rule, error) {
+	uri := basePath + "/config/rules/region/" + url.PathEscape(strconv.FormatUint(region, 10))
+	var out []*placement.Rule
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetSafeReadTSOptions is the optional parameters of GetSafeReadTS.
+type GetSafeReadTSOptions struct {
+	// Start key of the range, in the same format as the region boundaries, i.e., memcomparable-encoded for the TxnKV keys
+	StartKey string
+	// End key of the range, in the same format as the region boundaries, i.e., memcomparable-encoded for the TxnKV keys
+	EndKey string
+	// ID of the keyspace, the key range will be ignored if it's set
+	KeyspaceID uint64
+}
+
+// GetSafeReadTS calls GET /min-resolved-ts/safe-read-ts: Get the safe read ts of a key range or a keyspace, which is the newest ts that a stale read on it is guaranteed to be consistent.
+func (c *Client) GetSafeReadTS(ctx context.Context, opts *GetSafeReadTSOptions) (*SafeReadTS, error) {
+	uri := basePath + "/min-resolved-ts/safe-read-ts"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.EndKey != "" {
+			query.Set("end_key", opts.EndKey)
+		}
+		if opts.KeyspaceID != 0 {
+			query.Set("keyspace_id", strconv.FormatUint(opts.KeyspaceID, 10))
+		}
+	}
+	var out *SafeReadTS
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetScatterGroup calls GET /regions/scatter-groups/{name}: Get the distribution of a scatter group over the stores.
+func (c *Client) GetScatterGroup(ctx context.Context, name string) (*scattergroup.Distribution, error) {
+	uri := basePath + "/regions/scatter-groups/" + url.PathEscape(name)
+	var out *scattergroup.Distribution
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetScatterGroups calls GET /regions/scatter-groups: List all scatter groups and their distribution over the stores.
+func (c *Client) GetScatterGroups(ctx context.Context) ([]*scattergroup.Distribution, error) {
+	uri := basePath + "/regions/scatter-groups"
+	var out []*scattergroup.Distribution
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetScheduleConfig calls GET /config/schedule: Get schedule config.
+func (c *Client) GetScheduleConfig(ctx context.Context) (*ScheduleConfig, error) {
+	uri := basePath + "/config/schedule"
+	var out *ScheduleConfig
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetSchedulerRoles calls GET /scheduler-config/{name}/roles: Get the roles of the peers which the shuffle region scheduler shuffles.
+func (c *Client) GetSchedulerRoles(ctx context.Context, name string) ([]string, error) {
+	uri := basePath + "/scheduler-config/" + url.PathEscape(name) + "/roles"
+	var out []string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetSchedulers calls GET /schedulers: List all created schedulers by status.
+func (c *Client) GetSchedulers(ctx context.Context) ([]string, error) {
+	uri := basePath + "/schedulers"
+	var out []string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetServiceMiddlewareConfig calls GET /service-middleware/config: Get Service Middleware config.
+func (c *Client) GetServiceMiddlewareConfig(ctx context.Context) (*Config, error) {
+	uri := basePath + "/service-middleware/config"
+	var out *Config
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetSizeHistogramOptions is the optional parameters of GetSizeHistogram.
+type GetSizeHistogramOptions struct {
+	// Size bound of region histogram
+	Bound uint64
+}
+
+// GetSizeHistogram calls GET /regions/check/hist-size: Get size of histogram.
+func (c *Client) GetSizeHistogram(ctx context.Context, opts *GetSizeHistogramOptions) ([]*HistItem, error) {
+	uri := basePath + "/regions/check/hist-size"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Bound != 0 {
+			query.Set("bound", strconv.FormatUint(opts.Bound, 10))
+		}
+	}
+	var out []*HistItem
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetStore calls GET /store/{id}: Get a store's information.
+func (c *Client) GetStore(ctx context.Context, id uint64) (*StoreInfo, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10))
+	var out *StoreInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStoreDrain calls GET /store/{id}/drain: Get the progress of draining the store.
+func (c *Client) GetStoreDrain(ctx context.Context, id uint64) (*StoreDrainProgress, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/drain"
+	var out *StoreDrainProgress
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStoreDrains calls GET /stores/drain: Get the progress of all the store drains.
+func (c *Client) GetStoreDrains(ctx context.Context) ([]*StoreDrainProgress, error) {
+	uri := basePath + "/stores/drain"
+	var out []*StoreDrainProgress
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStoreLimitScene calls GET /stores/limit/scene: Get limit scene in the cluster.
+func (c *Client) GetStoreLimitScene(ctx context.Context) (string, error) {
+	uri := basePath + "/stores/limit/scene"
+	var out string
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetStoreRegionsOptions is the optional parameters of GetStoreRegions.
+type GetStoreRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetStoreRegions calls GET /regions/store/{id}: List all regions of a specific store.
+func (c *Client) GetStoreRegions(ctx context.Context, id uint64, opts *GetStoreRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/store/" + url.PathEscape(strconv.FormatUint(id, 10))
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetStores calls GET /stores: Get stores in the cluster.
+func (c *Client) GetStores(ctx context.Context, state []string) (*StoresInfo, error) {
+	uri := basePath + "/stores"
+	query := url.Values{}
+	for _, v := range state {
+		query.Add("state", v)
+	}
+	var out *StoresInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetStoresByLabel calls GET /labels/stores: List stores that have specific label values.
+func (c *Client) GetStoresByLabel(ctx context.Context, name string, value string) (*StoresInfo, error) {
+	uri := basePath + "/labels/stores"
+	query := url.Values{}
+	query.Set("name", name)
+	query.Set("value", value)
+	var out *StoresInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetStoresProgress calls GET /stores/progress: Get store progress in the cluster.
+func (c *Client) GetStoresProgress(ctx context.Context) (*Progress, error) {
+	uri := basePath + "/stores/progress"
+	var out *Progress
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// GetTopCPURegionsOptions is the optional parameters of GetTopCPURegions.
+type GetTopCPURegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopCPURegions calls GET /regions/cpu: List regions with the highest CPU usage.
+func (c *Client) GetTopCPURegions(ctx context.Context, opts *GetTopCPURegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/cpu"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTopConfVerRegionsOptions is the optional parameters of GetTopConfVerRegions.
+type GetTopConfVerRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopConfVerRegions calls GET /regions/confver: List regions with the largest conf version.
+func (c *Client) GetTopConfVerRegions(ctx context.Context, opts *GetTopConfVerRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/confver"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTopKeysRegionsOptions is the optional parameters of GetTopKeysRegions.
+type GetTopKeysRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopKeysRegions calls GET /regions/keys: List regions with the largest keys.
+func (c *Client) GetTopKeysRegions(ctx context.Context, opts *GetTopKeysRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/keys"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTopReadFlowRegionsOptions is the optional parameters of GetTopReadFlowRegions.
+type GetTopReadFlowRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopReadFlowRegions calls GET /regions/readflow: List regions with the highest read flow.
+func (c *Client) GetTopReadFlowRegions(ctx context.Context, opts *GetTopReadFlowRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/readflow"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTopSizeRegionsOptions is the optional parameters of GetTopSizeRegions.
+type GetTopSizeRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopSizeRegions calls GET /regions/size: List regions with the largest size.
+func (c *Client) GetTopSizeRegions(ctx context.Context, opts *GetTopSizeRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/size"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTopVersionRegionsOptions is the optional parameters of GetTopVersionRegions.
+type GetTopVersionRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopVersionRegions calls GET /regions/version: List regions with the largest version.
+func (c *Client) GetTopVersionRegions(ctx context.Context, opts *GetTopVersionRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/version"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTopWriteFlowRegionsOptions is the optional parameters of GetTopWriteFlowRegions.
+type GetTopWriteFlowRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// GetTopWriteFlowRegions calls GET /regions/writeflow: List regions with the highest write flow.
+func (c *Client) GetTopWriteFlowRegions(ctx context.Context, opts *GetTopWriteFlowRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/writeflow"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetTrendOptions is the optional parameters of GetTrend.
+type GetTrendOptions struct {
+	// From Unix timestamp
+	From uint64
+}
+
+// GetTrend calls GET /trend: Get the growth and changes of data in the most recent period of time.
+func (c *Client) GetTrend(ctx context.Context, opts *GetTrendOptions) (*Trend, error) {
+	uri := basePath + "/trend"
+	query := url.Values{}
+	if opts != nil {
+		if opts.From != 0 {
+			query.Set("from", strconv.FormatUint(opts.From, 10))
+		}
+	}
+	var out *Trend
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetUndersizedRegionsOptions is the optional parameters of GetUndersizedRegions.
+type GetUndersizedRegionsOptions struct {
+	// Hex encoded key to list the regions from in the order of the start key
+	StartKey string
+	// Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster
+	StartID uint64
+	// Max count of the regions in a page
+	Limit uint64
+	// Fields of the regions to return
+	Fields []string
+	// List the regions which have a peer on any of the stores
+	StoreID []string
+	// List the regions which have all the labels in the form of key=value
+	Label []string
+	// List the regions which have a peer in any of the states: pending, down, learner, witness
+	PeerState []string
+	// Min approximate size in MiB
+	MinSize uint64
+	// Max approximate size in MiB
+	MaxSize uint64
+	// Min approximate keys
+	MinKeys uint64
+	// Max approximate keys
+	MaxKeys uint64
+	// Min written bytes
+	MinWriteBytes uint64
+	// Max written bytes
+	MaxWriteBytes uint64
+	// Min read bytes
+	MinReadBytes uint64
+	// Max read bytes
+	MaxReadBytes uint64
+}
+
+// GetUndersizedRegions calls GET /regions/check/undersized-region: List all regions that are undersized.
+func (c *Client) GetUndersizedRegions(ctx context.Context, opts *GetUndersizedRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/check/undersized-region"
+	query := url.Values{}
+	if opts != nil {
+		if opts.StartKey != "" {
+			query.Set("start_key", opts.StartKey)
+		}
+		if opts.StartID != 0 {
+			query.Set("start_id", strconv.FormatUint(opts.StartID, 10))
+		}
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+		for _, v := range opts.StoreID {
+			query.Add("store_id", v)
+		}
+		for _, v := range opts.Label {
+			query.Add("label", v)
+		}
+		for _, v := range opts.PeerState {
+			query.Add("peer_state", v)
+		}
+		if opts.MinSize != 0 {
+			query.Set("min_size", strconv.FormatUint(opts.MinSize, 10))
+		}
+		if opts.MaxSize != 0 {
+			query.Set("max_size", strconv.FormatUint(opts.MaxSize, 10))
+		}
+		if opts.MinKeys != 0 {
+			query.Set("min_keys", strconv.FormatUint(opts.MinKeys, 10))
+		}
+		if opts.MaxKeys != 0 {
+			query.Set("max_keys", strconv.FormatUint(opts.MaxKeys, 10))
+		}
+		if opts.MinWriteBytes != 0 {
+			query.Set("min_write_bytes", strconv.FormatUint(opts.MinWriteBytes, 10))
+		}
+		if opts.MaxWriteBytes != 0 {
+			query.Set("max_write_bytes", strconv.FormatUint(opts.MaxWriteBytes, 10))
+		}
+		if opts.MinReadBytes != 0 {
+			query.Set("min_read_bytes", strconv.FormatUint(opts.MinReadBytes, 10))
+		}
+		if opts.MaxReadBytes != 0 {
+			query.Set("max_read_bytes", strconv.FormatUint(opts.MaxReadBytes, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// GetVersion calls GET /version: Get the version of PD server.
+func (c *Client) GetVersion(ctx context.Context) (*Version, error) {
+	uri := basePath + "/version"
+	var out *Version
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// IsSnapshotRecovering calls GET /admin/cluster/markers/snapshot-recovering: Get whether the cluster is recovering from a snapshot.
+func (c *Client) IsSnapshotRecovering(ctx context.Context) (*SnapshotRecoveringStatus, error) {
+	uri := basePath + "/admin/cluster/markers/snapshot-recovering"
+	var out *SnapshotRecoveringStatus
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// ListSchedulerConfig calls GET /scheduler-config/{name}/list: List the config of a scheduler.
+func (c *Client) ListSchedulerConfig(ctx context.Context, name string) (map[string]interface{}, error) {
+	uri := basePath + "/scheduler-config/" + url.PathEscape(name) + "/list"
+	var out map[string]interface{}
+	err := c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// LoadPlugin calls POST /plugin: Load plugin.
+func (c *Client) LoadPlugin(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/plugin"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// MarkSnapshotRecovering calls POST /admin/cluster/markers/snapshot-recovering: Mark the cluster as recovering from a snapshot.
+func (c *Client) MarkSnapshotRecovering(ctx context.Context) (string, error) {
+	uri := basePath + "/admin/cluster/markers/snapshot-recovering"
+	return c.doText(ctx, http.MethodPost, uri, nil, nil, nil)
+}
+
+// PProfAllocs calls GET /debug/pprof/allocs: debug allocs of PD servers.
+func (c *Client) PProfAllocs(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/allocs"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfBlock calls GET /debug/pprof/block: debug block of PD servers.
+func (c *Client) PProfBlock(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/block"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfGoroutine calls GET /debug/pprof/goroutine: debug goroutine of PD servers.
+func (c *Client) PProfGoroutine(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/goroutine"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfHeap calls GET /debug/pprof/heap: debug heap of PD servers.
+func (c *Client) PProfHeap(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/heap"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfMutex calls GET /debug/pprof/mutex: debug mutex of PD servers.
+func (c *Client) PProfMutex(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/mutex"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfProfile calls GET /debug/pprof/profile: debug profile of PD servers.
+func (c *Client) PProfProfile(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/profile"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfSymbol calls GET /debug/pprof/symbol: debug symbol of PD servers.
+func (c *Client) PProfSymbol(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/symbol"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfThreadcreate calls GET /debug/pprof/threadcreate: debug threadcreate of PD servers.
+func (c *Client) PProfThreadcreate(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/threadcreate"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfTrace calls GET /debug/pprof/trace: debug trace of PD servers.
+func (c *Client) PProfTrace(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/trace"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PProfZip calls GET /debug/pprof/zip: debug zip of PD servers.
+func (c *Client) PProfZip(ctx context.Context) error {
+	uri := basePath + "/debug/pprof/zip"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// PatchRegionLabelRules calls PATCH /config/region-label/rules: Update region label rules in batch.
+func (c *Client) PatchRegionLabelRules(ctx context.Context, body *labeler.LabelRulePatch) (string, error) {
+	uri := basePath + "/config/region-label/rules"
+	var out string
+	err := c.doJSON(ctx, http.MethodPatch, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// PauseOrResumeChecker calls POST /checker/{name}: Pause or resume region merge.
+func (c *Client) PauseOrResumeChecker(ctx context.Context, name string, body map[string]interface{}) (string, error) {
+	uri := basePath + "/checker/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// PauseOrResumeScheduler calls POST /schedulers/{name}: Pause or resume a scheduler.
+func (c *Client) PauseOrResumeScheduler(ctx context.Context, name string, body map[string]interface{}) (string, error) {
+	uri := basePath + "/schedulers/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// Ping calls GET /ping: Ping PD servers.
+func (c *Client) Ping(ctx context.Context) error {
+	uri := basePath + "/ping"
+	return c.doJSON(ctx, http.MethodGet, uri, nil, nil, nil, nil)
+}
+
+// QueryMetricOptions is the optional parameters of QueryMetric.
+type QueryMetricOptions struct {
+	// The evaluation timestamp
+	Time string
+}
+
+// QueryMetric calls GET /metric/query: Query the metrics at an instant from the metric storage, which is compatible with the Prometheus API.
+func (c *Client) QueryMetric(ctx context.Context, queryParam string, opts *QueryMetricOptions) (map[string]interface{}, error) {
+	uri := basePath + "/metric/query"
+	query := url.Values{}
+	query.Set("query", queryParam)
+	if opts != nil {
+		if opts.Time != "" {
+			query.Set("time", opts.Time)
+		}
+	}
+	var out map[string]interface{}
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// QueryMetricByPostOptions is the optional parameters of QueryMetricByPost.
+type QueryMetricByPostOptions struct {
+	// The evaluation timestamp
+	Time string
+}
+
+// QueryMetricByPost calls POST /metric/query: Query the metrics at an instant from the metric storage, which is compatible with the Prometheus API.
+func (c *Client) QueryMetricByPost(ctx context.Context, queryParam string, opts *QueryMetricByPostOptions) (map[string]interface{}, error) {
+	uri := basePath + "/metric/query"
+	query := url.Values{}
+	query.Set("query", queryParam)
+	if opts != nil {
+		if opts.Time != "" {
+			query.Set("time", opts.Time)
+		}
+	}
+	var out map[string]interface{}
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// QueryMetricRange calls GET /metric/query_range: Query the metrics in a range of time from the metric storage, which is compatible with the Prometheus API.
+func (c *Client) QueryMetricRange(ctx context.Context, queryParam string, start string, end string, step string) (map[string]interface{}, error) {
+	uri := basePath + "/metric/query_range"
+	query := url.Values{}
+	query.Set("query", queryParam)
+	query.Set("start", start)
+	query.Set("end", end)
+	query.Set("step", step)
+	var out map[string]interface{}
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// QueryMetricRangeByPost calls POST /metric/query_range: Query the metrics in a range of time from the metric storage, which is compatible with the Prometheus API.
+func (c *Client) QueryMetricRangeByPost(ctx context.Context, queryParam string, start string, end string, step string) (map[string]interface{}, error) {
+	uri := basePath + "/metric/query_range"
+	query := url.Values{}
+	query.Set("query", queryParam)
+	query.Set("start", start)
+	query.Set("end", end)
+	query.Set("step", step)
+	var out map[string]interface{}
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// QueryRegionsOptions is the optional parameters of QueryRegions.
+type QueryRegionsOptions struct {
+	// The query of the regions, all the regions are listed if it's empty
+	Q string
+	// Fields of the regions to return
+	Fields []string
+}
+
+// QueryRegions calls GET /regions/query: List the regions which match the query, like `size > 96MiB and store in (1, 4) order by size desc limit 10`.
+func (c *Client) QueryRegions(ctx context.Context, opts *QueryRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/query"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Q != "" {
+			query.Set("q", opts.Q)
+		}
+		for _, v := range opts.Fields {
+			query.Add("fields", v)
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// RecoverAllocID calls POST /admin/base-alloc-id: Recover the base of the allocated IDs when the cluster is recovering from a snapshot.
+func (c *Client) RecoverAllocID(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/admin/base-alloc-id"
+	return c.doText(ctx, http.MethodPost, uri, nil, nil, body)
+}
+
+// RemoveFailedStores calls POST /admin/unsafe/remove-failed-stores: Remove failed stores unsafely.
+func (c *Client) RemoveFailedStores(ctx context.Context, body map[string]interface{}) error {
+	uri := basePath + "/admin/unsafe/remove-failed-stores"
+	return c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, nil)
+}
+
+// RemoveTombStone calls DELETE /stores/remove-tombstone: Remove tombstone records in the cluster.
+func (c *Client) RemoveTombStone(ctx context.Context) (string, error) {
+	uri := basePath + "/stores/remove-tombstone"
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// ResetTS calls POST /admin/reset-ts: Reset the ts.
+func (c *Client) ResetTS(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/admin/reset-ts"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// ResignLeader calls POST /leader/resign: Transfer etcd leadership to another PD server.
+func (c *Client) ResignLeader(ctx context.Context) (string, error) {
+	uri := basePath + "/leader/resign"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// SavePersistFile calls POST /admin/persist-file/{file_name}: Save a file in the data directory of the PD server.
+func (c *Client) SavePersistFile(ctx context.Context, fileName string, body map[string]interface{}) (string, error) {
+	uri := basePath + "/admin/persist-file/" + url.PathEscape(fileName)
+	return c.doText(ctx, http.MethodPost, uri, nil, nil, body)
+}
+
+// ScanRegionsOptions is the optional parameters of ScanRegions.
+type ScanRegionsOptions struct {
+	// Limit count
+	Limit uint64
+}
+
+// ScanRegions calls GET /regions/key: List regions in a given range [startKey, endKey).
+func (c *Client) ScanRegions(ctx context.Context, key string, endkey string, opts *ScanRegionsOptions) (*RegionsInfo, error) {
+	uri := basePath + "/regions/key"
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("endkey", endkey)
+	if opts != nil {
+		if opts.Limit != 0 {
+			query.Set("limit", strconv.FormatUint(opts.Limit, 10))
+		}
+	}
+	var out *RegionsInfo
+	err := c.doJSON(ctx, http.MethodGet, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// ScatterRegions calls POST /regions/scatter: Scatter regions by given key ranges or regions id distributed by given group with given retry limit.
+func (c *Client) ScatterRegions(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/regions/scatter"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetAllRules calls POST /config/rules: Set all rules for the cluster. If there is an error, modifications are promised to be rollback in memory, but may fail to rollback disk. You probably want to request again to make rules in memory/disk consistent.
+func (c *Client) SetAllRules(ctx context.Context, body []*placement.Rule) (string, error) {
+	uri := basePath + "/config/rules"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetAllStoresLimitOptions is the optional parameters of SetAllStoresLimit.
+type SetAllStoresLimitOptions struct {
+	// ttl param is only for BR and lightning now. Don't use it.
+	TTLSecond uint64
+}
+
+// SetAllStoresLimit calls POST /stores/limit: Set limit of all stores in the cluster.
+func (c *Client) SetAllStoresLimit(ctx context.Context, body map[string]interface{}, opts *SetAllStoresLimitOptions) (string, error) {
+	uri := basePath + "/stores/limit"
+	query := url.Values{}
+	if opts != nil {
+		if opts.TTLSecond != 0 {
+			query.Set("ttlSecond", strconv.FormatUint(opts.TTLSecond, 10))
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// SetClusterVersion calls POST /config/cluster-version: Update cluster version.
+func (c *Client) SetClusterVersion(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/config/cluster-version"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetConfigOptions is the optional parameters of SetConfig.
+type SetConfigOptions struct {
+	// ttl param is only for BR and lightning now. Don't use it.
+	TTLSecond uint64
+}
+
+// SetConfig calls POST /config: Update a config item.
+func (c *Client) SetConfig(ctx context.Context, body map[string]interface{}, opts *SetConfigOptions) (string, error) {
+	uri := basePath + "/config"
+	query := url.Values{}
+	if opts != nil {
+		if opts.TTLSecond != 0 {
+			query.Set("ttlSecond", strconv.FormatUint(opts.TTLSecond, 10))
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// SetGRPCRateLimitConfig calls POST /service-middleware/config/grpc-rate-limit: update the rate limit config of a gRPC method.
+func (c *Client) SetGRPCRateLimitConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/service-middleware/config/grpc-rate-limit"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetGroupConfig calls POST /config/rule_group: Update rule group config.
+func (c *Client) SetGroupConfig(ctx context.Context, body *placement.RuleGroup) (string, error) {
+	uri := basePath + "/config/rule_group"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetLabelPropertyConfig calls POST /config/label-property: Update label property config item.
+func (c *Client) SetLabelPropertyConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/config/label-property"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetLogLevel calls POST /admin/log: Set log level.
+func (c *Client) SetLogLevel(ctx context.Context, body string) (string, error) {
+	uri := basePath + "/admin/log"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetMemberPropertyByName calls POST /members/name/{name}: Set leader priority of a PD member.
+func (c *Client) SetMemberPropertyByName(ctx context.Context, name string, body map[string]interface{}) (string, error) {
+	uri := basePath + "/members/name/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetPlacementRuleByGroup calls POST /config/placement-rule/{group}: Update group and all rules belong to it.
+func (c *Client) SetPlacementRuleByGroup(ctx context.Context, group string, body *placement.GroupBundle) (string, error) {
+	uri := basePath + "/config/placement-rule/" + url.PathEscape(group)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetPlacementRulesOptions is the optional parameters of SetPlacementRules.
+type SetPlacementRulesOptions struct {
+	// if partially update rules
+	Partial bool
+}
+
+// SetPlacementRules calls POST /config/placement-rule: Update all rules and groups configuration.
+func (c *Client) SetPlacementRules(ctx context.Context, body []*placement.GroupBundle, opts *SetPlacementRulesOptions) (string, error) {
+	uri := basePath + "/config/placement-rule"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Partial {
+			query.Set("partial", strconv.FormatBool(opts.Partial))
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// SetRBACBinding calls POST /rbac/bindings: Bind the roles to a certificate CN, a certificate SAN or a bearer token. The existing binding of the subject is replaced.
+func (c *Client) SetRBACBinding(ctx context.Context, body *rbac.Binding) (string, error) {
+	uri := basePath + "/rbac/bindings"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetRBACRole calls POST /rbac/roles: Create or update a custom role.
+func (c *Client) SetRBACRole(ctx context.Context, body *rbac.Role) (string, error) {
+	uri := basePath + "/rbac/roles"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetRatelimitConfig calls POST /service-middleware/config/rate-limit: update ratelimit config.
+func (c *Client) SetRatelimitConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/service-middleware/config/rate-limit"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetRegionLabelRule calls POST /config/region-label/rule: Update region label rule of cluster.
+func (c *Client) SetRegionLabelRule(ctx context.Context, body *labeler.LabelRule) (string, error) {
+	uri := basePath + "/config/region-label/rule"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetReplicationConfig calls POST /config/replicate: Update a replication config item.
+func (c *Client) SetReplicationConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/config/replicate"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetReplicationModeConfig calls POST /config/replication-mode: Set replication mode config.
+func (c *Client) SetReplicationModeConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/config/replication-mode"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetRule calls POST /config/rule: Update rule of cluster.
+func (c *Client) SetRule(ctx context.Context, body *placement.Rule) (string, error) {
+	uri := basePath + "/config/rule"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetScheduleConfig calls POST /config/schedule: Update a schedule config item.
+func (c *Client) SetScheduleConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/config/schedule"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetSchedulerConfig calls POST /scheduler-config/{name}/config: Update the config of a scheduler.
+func (c *Client) SetSchedulerConfig(ctx context.Context, name string, body map[string]interface{}) (string, error) {
+	uri := basePath + "/scheduler-config/" + url.PathEscape(name) + "/config"
+	return c.doText(ctx, http.MethodPost, uri, nil, nil, body)
+}
+
+// SetSchedulerRoles calls POST /scheduler-config/{name}/roles: Set the roles of the peers which the shuffle region scheduler shuffles.
+func (c *Client) SetSchedulerRoles(ctx context.Context, name string, body []string) (string, error) {
+	uri := basePath + "/scheduler-config/" + url.PathEscape(name) + "/roles"
+	return c.doText(ctx, http.MethodPost, uri, nil, nil, body)
+}
+
+// SetServiceMiddlewareConfig calls POST /service-middleware/config: Update some service-middleware's config items.
+func (c *Client) SetServiceMiddlewareConfig(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/service-middleware/config"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SetStoreLabelOptions is the optional parameters of SetStoreLabel.
+type SetStoreLabelOptions struct {
+	// Overwrite all the labels of the store instead of updating them
+	Force string
+}
+
+// SetStoreLabel calls POST /store/{id}/label: Set the store's label.
+func (c *Client) SetStoreLabel(ctx context.Context, id uint64, body map[string]interface{}, opts *SetStoreLabelOptions) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/label"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Force != "" {
+			query.Set("force", opts.Force)
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// SetStoreLimitOptions is the optional parameters of SetStoreLimit.
+type SetStoreLimitOptions struct {
+	// ttl param is only for BR and lightning now. Don't use it.
+	TTLSecond uint64
+}
+
+// SetStoreLimit calls POST /store/{id}/limit: Set the store's limit.
+func (c *Client) SetStoreLimit(ctx context.Context, id uint64, body map[string]interface{}, opts *SetStoreLimitOptions) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/limit"
+	query := url.Values{}
+	if opts != nil {
+		if opts.TTLSecond != 0 {
+			query.Set("ttlSecond", strconv.FormatUint(opts.TTLSecond, 10))
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// SetStoreLimitSceneOptions is the optional parameters of SetStoreLimitScene.
+type SetStoreLimitSceneOptions struct {
+	// The type of the store limit
+	Type string
+}
+
+// SetStoreLimitScene calls POST /stores/limit/scene: Set limit scene in the cluster.
+func (c *Client) SetStoreLimitScene(ctx context.Context, body map[string]interface{}, opts *SetStoreLimitSceneOptions) (string, error) {
+	uri := basePath + "/stores/limit/scene"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Type != "" {
+			query.Set("type", opts.Type)
+		}
+	}
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, body, &out)
+	return out, err
+}
+
+// SetStoreState calls POST /store/{id}/state: Set the store's state.
+func (c *Client) SetStoreState(ctx context.Context, id uint64, state string) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/state"
+	query := url.Values{}
+	query.Set("state", state)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, query, nil, nil, &out)
+	return out, err
+}
+
+// SetStoreWeight calls POST /store/{id}/weight: Set the store's leader/region weight.
+func (c *Client) SetStoreWeight(ctx context.Context, id uint64, body map[string]interface{}) (string, error) {
+	uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/weight"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SplitRegions calls POST /regions/split: Split regions with given split keys.
+func (c *Client) SplitRegions(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/regions/split"
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// SubscribeEventsOptions is the optional parameters of SubscribeEvents.
+type SubscribeEventsOptions struct {
+	// Comma separated event types: store, operator, scheduler, leader, placement-rule, label-rule, keyspace, alert
+	Types string
+	// Comma separated event actions, such as offline, create, timeout
+	Actions string
+	// Subject of the events, such as the store ID or the scheduler name
+	Subject string
+	// Resume after the event with the ID, the Last-Event-ID header is preferred
+	ResumeToken string
+}
+
+// SubscribeEvents calls GET /events: Subscribe the state changes of the cluster as the server-sent events.
+func (c *Client) SubscribeEvents(ctx context.Context, opts *SubscribeEventsOptions) (io.ReadCloser, error) {
+	uri := basePath + "/events"
+	query := url.Values{}
+	if opts != nil {
+		if opts.Types != "" {
+			query.Set("types", opts.Types)
+		}
+		if opts.Actions != "" {
+			query.Set("actions", opts.Actions)
+		}
+		if opts.Subject != "" {
+			query.Set("subject", opts.Subject)
+		}
+		if opts.ResumeToken != "" {
+			query.Set("resume_token", opts.ResumeToken)
+		}
+	}
+	return c.doStream(ctx, http.MethodGet, uri, query, nil, nil)
+}
+
+// TransferLeader calls POST /leader/transfer/{next_leader}: Transfer etcd leadership to the specific PD server.
+func (c *Client) TransferLeader(ctx context.Context, nextLeader string) (string, error) {
+	uri := basePath + "/leader/transfer/" + url.PathEscape(nextLeader)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, nil, &out)
+	return out, err
+}
+
+// TransferLocalTSOAllocator calls POST /tso/allocator/transfer/{name}: Transfer Local TSO Allocator.
+func (c *Client) TransferLocalTSOAllocator(ctx context.Context, name string, body map[string]interface{}) (string, error) {
+	uri := basePath + "/tso/allocator/transfer/" + url.PathEscape(name)
+	var out string
+	err := c.doJSON(ctx, http.MethodPost, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// UnloadPlugin calls DELETE /plugin: Unload plugin.
+func (c *Client) UnloadPlugin(ctx context.Context, body map[string]interface{}) (string, error) {
+	uri := basePath + "/plugin"
+	var out string
+	err := c.doJSON(ctx, http.MethodDelete, uri, nil, nil, body, &out)
+	return out, err
+}
+
+// UnmarkSnapshotRecovering calls DELETE /admin/cluster/markers/snapshot-recovering: Unmark the cluster as recovering from a snapshot.
+func (c *Client) UnmarkSnapshotRecovering(ctx context.Context) (string, error) {
+	uri := basePath + "/admin/cluster/markers/snapshot-recovering"
+	return c.doText(ctx, http.MethodDelete, uri, nil, nil, nil)
+}
+
+// APIStatus is the model of api.status.
+type APIStatus struct {
+	BuildTS        string `json:"build_ts,omitempty"`
+	GitHash        string `json:"git_hash,omitempty"`
+	StartTimestamp int64  `json:"start_timestamp,omitempty"`
+	Version        string `json:"version,omitempty"`
+}
+
+// ClusterStatus is the model of cluster.Status.
+type ClusterStatus struct {
+	IsInitialized     bool      `json:"is_initialized,omitempty"`
+	RaftBootstrapTime time.Time `json:"raft_bootstrap_time,omitempty"`
+	ReplicationStatus string    `json:"replication_status,omitempty"`
+}
+
+// Config is the model of config.Config.
+type Config struct {
+	DisableStrictReconfigCheck  bool                       `json:"DisableStrictReconfigCheck,omitempty"`
+	ElectionInterval            string                     `json:"ElectionInterval,omitempty"`
+	HeartbeatStreamBindInterval string                     `json:"HeartbeatStreamBindInterval,omitempty"`
+	LeaderPriorityCheckInterval string                     `json:"LeaderPriorityCheckInterval,omitempty"`
+	PreVote                     bool                       `json:"PreVote,omitempty"`
+	TickInterval                string                     `json:"TickInterval,omitempty"`
+	WarningMsgs                 []string                   `json:"WarningMsgs,omitempty"`
+	AdvertiseClientUrls         string                     `json:"advertise-client-urls,omitempty"`
+	AdvertisePeerUrls           string                     `json:"advertise-peer-urls,omitempty"`
+	AutoCompactionMode          string                     `json:"auto-compaction-mode,omitempty"`
+	AutoCompactionRetentionV2   string                     `json:"auto-compaction-retention-v2,omitempty"`
+	ClientUrls                  string                     `json:"client-urls,omitempty"`
+	ClusterVersion              string                     `json:"cluster-version,omitempty"`
+	Dashboard                   *DashboardConfig           `json:"dashboard,omitempty"`
+	DataDir                     string                     `json:"data-dir,omitempty"`
+	EnableGrpcGateway           bool                       `json:"enable-grpc-gateway,omitempty"`
+	EnableLocalTSO              bool                       `json:"enable-local-tso,omitempty"`
+	ForceNewCluster             bool                       `json:"force-new-cluster,omitempty"`
+	InitialCluster              string                     `json:"initial-cluster,omitempty"`
+	InitialClusterState         string                     `json:"initial-cluster-state,omitempty"`
+	InitialClusterToken         string                     `json:"initial-cluster-token,omitempty"`
+	Join                        string                     `json:"join,omitempty"`
+	Keyspace                    *KeyspaceConfig            `json:"keyspace,omitempty"`
+	LabelProperty               map[string][]*StoreLabel   `json:"label-property,omitempty"`
+	Labels                      map[string]string          `json:"labels,omitempty"`
+	Lease                       int64                      `json:"lease,omitempty"`
+	Log                         *log.Config                `json:"log,omitempty"`
+	LogFile                     string                     `json:"log-file,omitempty"`
+	LogLevel                    string                     `json:"log-level,omitempty"`
+	MaxRequestBytes             uint64                     `json:"max-request-bytes,omitempty"`
+	Metric                      *metricutil.MetricConfig   `json:"metric,omitempty"`
+	Name                        string                     `json:"name,omitempty"`
+	Notification                *NotificationConfig        `json:"notification,omitempty"`
+	PDServer                    *PDServerConfig            `json:"pd-server,omitempty"`
+	PeerUrls                    string                     `json:"peer-urls,omitempty"`
+	QuotaBackendBytes           string                     `json:"quota-backend-bytes,omitempty"`
+	Replication                 *ReplicationConfig         `json:"replication,omitempty"`
+	ReplicationMode             *ReplicationModeConfig     `json:"replication-mode,omitempty"`
+	RequestUnit                 *server.RequestUnitConfig  `json:"request-unit,omitempty"`
+	Schedule                    *ScheduleConfig            `json:"schedule,omitempty"`
+	Security                    *configutil.SecurityConfig `json:"security,omitempty"`
+	TSOMaxClockUncertainty      string                     `json:"tso-max-clock-uncertainty,omitempty"`
+	TSOSaveInterval             string                     `json:"tso-save-interval,omitempty"`
+	TSOTimeSource               string                     `json:"tso-time-source,omitempty"`
+	TSOUpdatePhysicalInterval   string                     `json:"tso-update-physical-interval,omitempty"`
+}
+
+// DRAutoSyncReplicationConfig is the model of config.DRAutoSyncReplicationConfig.
+type DRAutoSyncReplicationConfig struct {
+	DR               string `json:"dr,omitempty"`
+	DRReplicas       int64  `json:"dr-replicas,omitempty"`
+	LabelKey         string `json:"label-key,omitempty"`
+	PauseRegionSplit string `json:"pause-region-split,omitempty"`
+	Primary          string `json:"primary,omitempty"`
+	PrimaryReplicas  int64  `json:"primary-replicas,omitempty"`
+	WaitStoreTimeout string `json:"wait-store-timeout,omitempty"`
+}
+
+// DashboardConfig is the model of config.DashboardConfig.
+type DashboardConfig struct {
+	EnableExperimental bool   `json:"enable-experimental,omitempty"`
+	EnableTelemetry    bool   `json:"enable-telemetry,omitempty"`
+	InternalProxy      bool   `json:"internal-proxy,omitempty"`
+	PublicPathPrefix   string `json:"public-path-prefix,omitempty"`
+	TidbCacertPath     string `json:"tidb-cacert-path,omitempty"`
+	TidbCertPath       string `json:"tidb-cert-path,omitempty"`
+	TidbKeyPath        string `json:"tidb-key-path,omitempty"`
+}
+
+// DiagnosticResult is the model of cluster.DiagnosticResult.
+type DiagnosticResult struct {
+	Name      string `json:"name,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	Timestamp uint64 `json:"timestamp,omitempty"`
+}
+
+// DrainBlockedRegion is the model of cluster.DrainBlockedRegion.
+type DrainBlockedRegion struct {
+	Reason   string `json:"reason,omitempty"`
+	RegionID uint64 `json:"region-id,omitempty"`
+}
+
+// HTTPReplicationStatus is the model of replication.HTTPReplicationStatus.
+type HTTPReplicationStatus struct {
+	DRAutoSync struct {
+		AcidConsistent  bool    `json:"acid_consistent,omitempty"`
+		LabelKey        string  `json:"label_key,omitempty"`
+		RecoverProgress float32 `json:"recover_progress,omitempty"`
+		State           string  `json:"state,omitempty"`
+		StateID         uint64  `json:"state_id,omitempty"`
+		SyncedRegions   int64   `json:"synced_regions,omitempty"`
+		TotalRegions    int64   `json:"total_regions,omitempty"`
+	} `json:"dr-auto-sync,omitempty"`
+	Mode string `json:"mode,omitempty"`
+}
+
+// Health is the model of api.Health.
+type Health struct {
+	ClientUrls []string `json:"client_urls,omitempty"`
+	Health     bool     `json:"health,omitempty"`
+	MemberID   uint64   `json:"member_id,omitempty"`
+	Name       string   `json:"name,omitempty"`
+}
+
+// HistItem is the model of api.histItem.
+type HistItem struct {
+	Count int64 `json:"count,omitempty"`
+	End   int64 `json:"end,omitempty"`
+	Start int64 `json:"start,omitempty"`
+}
+
+// HotStoreStats is the model of api.HotStoreStats.
+type HotStoreStats struct {
+	BytesReadRate  map[string]float64 `json:"bytes-read-rate,omitempty"`
+	BytesWriteRate map[string]float64 `json:"bytes-write-rate,omitempty"`
+	KeysReadRate   map[string]float64 `json:"keys-read-rate,omitempty"`
+	KeysWriteRate  map[string]float64 `json:"keys-write-rate,omitempty"`
+	QueryReadRate  map[string]float64 `json:"query-read-rate,omitempty"`
+	QueryWriteRate map[string]float64 `json:"query-write-rate,omitempty"`
+}
+
+// KeyspaceConfig is the model of config.KeyspaceConfig.
+type KeyspaceConfig struct {
+	PreAlloc []string `json:"pre-alloc,omitempty"`
+}
+
+// ListServiceGCSafepoint is the model of api.listServiceGCSafepoint.
+type ListServiceGCSafepoint struct {
+	GCSafePoint         uint64                       `json:"gc_safe_point,omitempty"`
+	ServiceGCSafePoints []*endpoint.ServiceSafePoint `json:"service_gc_safe_points,omitempty"`
+}
+
+// MetaPeer is the model of api.MetaPeer.
+type MetaPeer struct {
+	ID        uint64 `json:"id,omitempty"`
+	IsLearner bool   `json:"is_learner,omitempty"`
+	IsWitness bool   `json:"is_witness,omitempty"`
+	Role      int32  `json:"role,omitempty"`
+	RoleName  string `json:"role_name,omitempty"`
+	StoreID   uint64 `json:"store_id,omitempty"`
+}
+
+// MetaStore is the model of api.MetaStore.
+type MetaStore struct {
+	Address             string               `json:"address,omitempty"`
+	DeployPath          string               `json:"deploy_path,omitempty"`
+	GitHash             string               `json:"git_hash,omitempty"`
+	ID                  uint64               `json:"id,omitempty"`
+	Labels              []*metapb.StoreLabel `json:"labels,omitempty"`
+	LastHeartbeat       int64                `json:"last_heartbeat,omitempty"`
+	NodeState           int32                `json:"node_state,omitempty"`
+	PeerAddress         string               `json:"peer_address,omitempty"`
+	PhysicallyDestroyed bool                 `json:"physically_destroyed,omitempty"`
+	StartTimestamp      int64                `json:"start_timestamp,omitempty"`
+	State               int32                `json:"state,omitempty"`
+	StateName           string               `json:"state_name,omitempty"`
+	StatusAddress       string               `json:"status_address,omitempty"`
+	Version             string               `json:"version,omitempty"`
+}
+
+// MinResolvedTS is the model of api.minResolvedTS.
+type MinResolvedTS struct {
+	IsRealTime      bool   `json:"is_real_time,omitempty"`
+	MinResolvedTS   uint64 `json:"min_resolved_ts,omitempty"`
+	PersistInterval string `json:"persist_interval,omitempty"`
+}
+
+// NotificationConfig is the model of config.NotificationConfig.
+type NotificationConfig struct {
+	GCSafepointStallThreshold string                 `json:"gc-safepoint-stall-threshold,omitempty"`
+	Webhooks                  []*event.WebhookConfig `json:"webhooks,omitempty"`
+}
+
+// PDPeerStats is the model of api.PDPeerStats.
+type PDPeerStats struct {
+	DownSeconds uint64       `json:"down_seconds,omitempty"`
+	Peer        *metapb.Peer `json:"peer,omitempty"`
+}
+
+// PDServerConfig is the model of config.PDServerConfig.
+type PDServerConfig struct {
+	DashboardAddress                 string  `json:"dashboard-address,omitempty"`
+	EnableGogcTuner                  string  `json:"enable-gogc-tuner,omitempty"`
+	FlowRoundByDigit                 int64   `json:"flow-round-by-digit,omitempty"`
+	GCTunerThreshold                 float64 `json:"gc-tuner-threshold,omitempty"`
+	KeyType                          string  `json:"key-type,omitempty"`
+	MaxGapResetTS                    string  `json:"max-gap-reset-ts,omitempty"`
+	MetricStorage                    string  `json:"metric-storage,omitempty"`
+	MinResolvedTSPersistenceInterval string  `json:"min-resolved-ts-persistence-interval,omitempty"`
+	// Comma separated strings
+	RuntimeServices            string  `json:"runtime-services,omitempty"`
+	ServerMemoryLimit          float64 `json:"server-memory-limit,omitempty"`
+	ServerMemoryLimitGCTrigger float64 `json:"server-memory-limit-gc-trigger,omitempty"`
+	TraceRegionFlow            string  `json:"trace-region-flow,omitempty"`
+	UseRegionStorage           string  `json:"use-region-storage,omitempty"`
+}
+
+// Progress is the model of api.Progress.
+type Progress struct {
+	Action       string  `json:"action,omitempty"`
+	CurrentSpeed float64 `json:"current_speed,omitempty"`
+	LeftSeconds  float64 `json:"left_seconds,omitempty"`
+	Progress     float64 `json:"progress,omitempty"`
+	StoreID      uint64  `json:"store_id,omitempty"`
+}
+
+// RegionInfo is the model of api.RegionInfo.
+type RegionInfo struct {
+	ApproximateKeys   int64               `json:"approximate_keys,omitempty"`
+	ApproximateSize   int64               `json:"approximate_size,omitempty"`
+	Buckets           []string            `json:"buckets,omitempty"`
+	CPUUsage          uint64              `json:"cpu_usage,omitempty"`
+	DownPeers         []*PDPeerStats      `json:"down_peers,omitempty"`
+	EndKey            string              `json:"end_key,omitempty"`
+	Epoch             *metapb.RegionEpoch `json:"epoch,omitempty"`
+	ID                uint64              `json:"id,omitempty"`
+	Leader            *MetaPeer           `json:"leader,omitempty"`
+	Peers             []*MetaPeer         `json:"peers,omitempty"`
+	PendingPeers      []*MetaPeer         `json:"pending_peers,omitempty"`
+	ReadBytes         uint64              `json:"read_bytes,omitempty"`
+	ReadKeys          uint64              `json:"read_keys,omitempty"`
+	ReplicationStatus *ReplicationStatus  `json:"replication_status,omitempty"`
+	StartKey          string              `json:"start_key,omitempty"`
+	WrittenBytes      uint64              `json:"written_bytes,omitempty"`
+	WrittenKeys       uint64              `json:"written_keys,omitempty"`
+}
+
+// RegionsInfo is the model of api.RegionsInfo.
+type RegionsInfo struct {
+	Count   int64         `json:"count,omitempty"`
+	NextID  uint64        `json:"next_id,omitempty"`
+	NextKey string        `json:"next_key,omitempty"`
+	Regions []*RegionInfo `json:"regions,omitempty"`
+}
+
+// ReplicationConfig is the model of config.ReplicationConfig.
+type ReplicationConfig struct {
+	EnablePlacementRules      string `json:"enable-placement-rules,omitempty"`
+	EnablePlacementRulesCache string `json:"enable-placement-rules-cache,omitempty"`
+	IsolationLevel            string `json:"isolation-level,omitempty"`
+	// Comma separated strings
+	LocationLabels     string `json:"location-labels,omitempty"`
+	MaxReplicas        uint64 `json:"max-replicas,omitempty"`
+	StrictlyMatchLabel string `json:"strictly-match-label,omitempty"`
+}
+
+// ReplicationModeConfig is the model of config.ReplicationModeConfig.
+type ReplicationModeConfig struct {
+	DRAutoSync      *DRAutoSyncReplicationConfig `json:"dr-auto-sync,omitempty"`
+	ReplicationMode string                       `json:"replication-mode,omitempty"`
+}
+
+// ReplicationStatus is the model of api.ReplicationStatus.
+type ReplicationStatus struct {
+	State   string `json:"state,omitempty"`
+	StateID uint64 `json:"state_id,omitempty"`
+}
+
+// SafeReadTS is the model of api.safeReadTS.
+type SafeReadTS struct {
+	SafeReadTS uint64 `json:"safe_read_ts,omitempty"`
+}
+
+// ScheduleConfig is the model of config.ScheduleConfig.
+type ScheduleConfig struct {
+	BlackoutPeriods                              []*maintenance.Blackout      `json:"blackout-periods,omitempty"`
+	ClusterMovementLimit                         string                       `json:"cluster-movement-limit,omitempty"`
+	ColdRegionMergeDuration                      string                       `json:"cold-region-merge-duration,omitempty"`
+	ColdRegionMergeRateLimit                     uint64                       `json:"cold-region-merge-rate-limit,omitempty"`
+	DisableLocationReplacement                   string                       `json:"disable-location-replacement,omitempty"`
+	DisableMakeUpReplica                         string                       `json:"disable-make-up-replica,omitempty"`
+	DisableRaftLearner                           string                       `json:"disable-raft-learner,omitempty"`
+	DisableRemoveDownReplica                     string                       `json:"disable-remove-down-replica,omitempty"`
+	DisableRemoveExtraReplica                    string                       `json:"disable-remove-extra-replica,omitempty"`
+	DisableReplaceOfflineReplica                 string                       `json:"disable-replace-offline-replica,omitempty"`
+	EnableCrossTableMerge                        string                       `json:"enable-cross-table-merge,omitempty"`
+	EnableDebugMetrics                           string                       `json:"enable-debug-metrics,omitempty"`
+	EnableDiagnostic                             string                       `json:"enable-diagnostic,omitempty"`
+	EnableJointConsensus                         string                       `json:"enable-joint-consensus,omitempty"`
+	EnableLocationReplacement                    string                       `json:"enable-location-replacement,omitempty"`
+	EnableMakeUpReplica                          string                       `json:"enable-make-up-replica,omitempty"`
+	EnableOneWayMerge                            string                       `json:"enable-one-way-merge,omitempty"`
+	EnableOperatorPreemption                     string                       `json:"enable-operator-preemption,omitempty"`
+	EnableRemoveDownReplica                      string                       `json:"enable-remove-down-replica,omitempty"`
+	EnableRemoveExtraReplica                     string                       `json:"enable-remove-extra-replica,omitempty"`
+	EnableReplaceOfflineReplica                  string                       `json:"enable-replace-offline-replica,omitempty"`
+	EnableTikvSplitRegion                        string                       `json:"enable-tikv-split-region,omitempty"`
+	EnableWitness                                string                       `json:"enable-witness,omitempty"`
+	HighSpaceRatio                               float64                      `json:"high-space-ratio,omitempty"`
+	HotRegionCacheHitsThreshold                  uint64                       `json:"hot-region-cache-hits-threshold,omitempty"`
+	HotRegionScheduleLimit                       uint64                       `json:"hot-region-schedule-limit,omitempty"`
+	HotRegionsReservedDays                       uint64                       `json:"hot-regions-reserved-days,omitempty"`
+	HotRegionsWriteInterval                      string                       `json:"hot-regions-write-interval,omitempty"`
+	LeaderScheduleLimit                          uint64                       `json:"leader-schedule-limit,omitempty"`
+	LeaderSchedulePolicy                         string                       `json:"leader-schedule-policy,omitempty"`
+	LowSpaceRatio                                float64                      `json:"low-space-ratio,omitempty"`
+	MaintenanceWindows                           []*maintenance.Window        `json:"maintenance-windows,omitempty"`
+	MaxMergeRegionKeys                           uint64                       `json:"max-merge-region-keys,omitempty"`
+	MaxMergeRegionSize                           uint64                       `json:"max-merge-region-size,omitempty"`
+	MaxMovableHotPeerSize                        int64                        `json:"max-movable-hot-peer-size,omitempty"`
+	MaxPendingPeerCount                          uint64                       `json:"max-pending-peer-count,omitempty"`
+	MaxSnapshotCount                             uint64                       `json:"max-snapshot-count,omitempty"`
+	MaxStoreDownTime                             string                       `json:"max-store-down-time,omitempty"`
+	MaxStorePreparingTime                        string                       `json:"max-store-preparing-time,omitempty"`
+	MergeHotRegionCoolDown                       string                       `json:"merge-hot-region-cool-down,omitempty"`
+	MergeScheduleLimit                           uint64                       `json:"merge-schedule-limit,omitempty"`
+	OperatorClassQuota                           map[string]uint64            `json:"operator-class-quota,omitempty"`
+	PatrolRegionInterval                         string                       `json:"patrol-region-interval,omitempty"`
+	RegionScheduleLimit                          uint64                       `json:"region-schedule-limit,omitempty"`
+	RegionScoreFormulaVersion                    string                       `json:"region-score-formula-version,omitempty"`
+	ReplicaScheduleLimit                         uint64                       `json:"replica-schedule-limit,omitempty"`
+	SchedulerMaxWaitingOperator                  uint64                       `json:"scheduler-max-waiting-operator,omitempty"`
+	SchedulersPayload                            map[string]json.RawMessage   `json:"schedulers-payload,omitempty"`
+	SchedulersV2                                 []*SchedulerConfig           `json:"schedulers-v2,omitempty"`
+	SlowStoreEvictingAffectedStoreRatioThreshold float64                      `json:"slow-store-evicting-affected-store-ratio-threshold,omitempty"`
+	SplitMergeInterval                           string                       `json:"split-merge-interval,omitempty"`
+	StoreBalanceRate                             float64                      `json:"store-balance-rate,omitempty"`
+	StoreLimit                                   map[string]*StoreLimitConfig `json:"store-limit,omitempty"`
+	StoreLimitMode                               string                       `json:"store-limit-mode,omitempty"`
+	SwtichWitnessInterval                        string                       `json:"swtich-witness-interval,omitempty"`
+	TolerantSizeRatio                            float64                      `json:"tolerant-size-ratio,omitempty"`
+	WitnessScheduleLimit                         uint64                       `json:"witness-schedule-limit,omitempty"`
+	ZoneMovementLimit                            string                       `json:"zone-movement-limit,omitempty"`
+}
+
+// SchedulerConfig is the model of config.SchedulerConfig.
+type SchedulerConfig struct {
+	Args        []string `json:"args,omitempty"`
+	ArgsPayload string   `json:"args-payload,omitempty"`
+	Disable     bool     `json:"disable,omitempty"`
+	Type        string   `json:"type,omitempty"`
+}
+
+// SlowTrend is the model of api.SlowTrend.
+type SlowTrend struct {
+	CauseRate   float64 `json:"cause_rate,omitempty"`
+	CauseValue  float64 `json:"cause_value,omitempty"`
+	ResultRate  float64 `json:"result_rate,omitempty"`
+	ResultValue float64 `json:"result_value,omitempty"`
+}
+
+// SnapshotRecoveringStatus is the model of api.snapshotRecoveringStatus.
+type SnapshotRecoveringStatus struct {
+	Marked bool `json:"marked,omitempty"`
+}
+
+// StageOutput is the model of cluster.StageOutput.
+type StageOutput struct {
+	Actions map[string][]string `json:"actions,omitempty"`
+	Details []string            `json:"details,omitempty"`
+	Info    string              `json:"info,omitempty"`
+	Time    string              `json:"time,omitempty"`
+}
+
+// StoreDrainOptions is the model of cluster.StoreDrainOptions.
+type StoreDrainOptions struct {
+	RateLimit    float64           `json:"rate-limit,omitempty"`
+	TargetLabels map[string]string `json:"target-labels,omitempty"`
+	TargetStores []uint64          `json:"target-stores,omitempty"`
+}
+
+// StoreDrainProgress is the model of cluster.StoreDrainProgress.
+type StoreDrainProgress struct {
+	BlockedRegions   []*DrainBlockedRegion `json:"blocked-regions,omitempty"`
+	BytesRemaining   uint64                `json:"bytes-remaining,omitempty"`
+	Eta              time.Time             `json:"eta,omitempty"`
+	FinishTime       time.Time             `json:"finish-time,omitempty"`
+	LeadersRemaining int64                 `json:"leaders-remaining,omitempty"`
+	Options          *StoreDrainOptions    `json:"options,omitempty"`
+	Phase            string                `json:"phase,omitempty"`
+	Progress         float64               `json:"progress,omitempty"`
+	RegionsRemaining int64                 `json:"regions-remaining,omitempty"`
+	StartTime        time.Time             `json:"start-time,omitempty"`
+	StoreID          uint64                `json:"store-id,omitempty"`
+}
+
+// StoreInfo is the model of api.StoreInfo.
+type StoreInfo struct {
+	Status *StoreStatus `json:"status,omitempty"`
+	Store  *MetaStore   `json:"store,omitempty"`
+}
+
+// StoreLabel is the model of config.StoreLabel.
+type StoreLabel struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// StoreLimitConfig is the model of config.StoreLimitConfig.
+type StoreLimitConfig struct {
+	AddPeer    float64 `json:"add-peer,omitempty"`
+	RemovePeer float64 `json:"remove-peer,omitempty"`
+}
+
+// StoreStatus is the model of api.StoreStatus.
+type StoreStatus struct {
+	Available          string     `json:"available,omitempty"`
+	Capacity           string     `json:"capacity,omitempty"`
+	HealthSignals      []string   `json:"health_signals,omitempty"`
+	HealthState        string     `json:"health_state,omitempty"`
+	IsBusy             bool       `json:"is_busy,omitempty"`
+	LastHeartbeatTS    time.Time  `json:"last_heartbeat_ts,omitempty"`
+	LeaderCount        int64      `json:"leader_count,omitempty"`
+	LeaderScore        float64    `json:"leader_score,omitempty"`
+	LeaderSize         int64      `json:"leader_size,omitempty"`
+	LeaderWeight       float64    `json:"leader_weight,omitempty"`
+	ReceivingSnapCount uint32     `json:"receiving_snap_count,omitempty"`
+	RegionCount        int64      `json:"region_count,omitempty"`
+	RegionScore        float64    `json:"region_score,omitempty"`
+	RegionSize         int64      `json:"region_size,omitempty"`
+	RegionWeight       float64    `json:"region_weight,omitempty"`
+	SendingSnapCount   uint32     `json:"sending_snap_count,omitempty"`
+	SlowScore          uint64     `json:"slow_score,omitempty"`
+	SlowTrend          *SlowTrend `json:"slow_trend,omitempty"`
+	StartTS            time.Time  `json:"start_ts,omitempty"`
+	Uptime             string     `json:"uptime,omitempty"`
+	UsedSize           string     `json:"used_size,omitempty"`
+	WitnessCount       int64      `json:"witness_count,omitempty"`
+}
+
+// StoresInfo is the model of api.StoresInfo.
+type StoresInfo struct {
+	Count  int64        `json:"count,omitempty"`
+	Stores []*StoreInfo `json:"stores,omitempty"`
+}
+
+// Trend is the model of api.Trend.
+type Trend struct {
+	History *TrendHistory `json:"history,omitempty"`
+	Stores  []*TrendStore `json:"stores,omitempty"`
+}
+
+// TrendHistory is the model of api.trendHistory.
+type TrendHistory struct {
+	End     int64                `json:"end,omitempty"`
+	Entries []*TrendHistoryEntry `json:"entries,omitempty"`
+	Start   int64                `json:"start,omitempty"`
+}
+
+// TrendHistoryEntry is the model of api.trendHistoryEntry.
+type TrendHistoryEntry struct {
+	Count int64  `json:"count,omitempty"`
+	From  uint64 `json:"from,omitempty"`
+	Kind  string `json:"kind,omitempty"`
+	To    uint64 `json:"to,omitempty"`
+}
+
+// TrendStore is the model of api.trendStore.
+type TrendStore struct {
+	Address             string    `json:"address,omitempty"`
+	Available           uint64    `json:"available,omitempty"`
+	Capacity            uint64    `json:"capacity,omitempty"`
+	HotReadFlow         float64   `json:"hot_read_flow,omitempty"`
+	HotReadRegionFlows  []float64 `json:"hot_read_region_flows,omitempty"`
+	HotWriteFlow        float64   `json:"hot_write_flow,omitempty"`
+	HotWriteRegionFlows []float64 `json:"hot_write_region_flows,omitempty"`
+	ID                  uint64    `json:"id,omitempty"`
+	LastHeartbeatTS     time.Time `json:"last_heartbeat_ts,omitempty"`
+	LeaderCount         int64     `json:"leader_count,omitempty"`
+	RegionCount         int64     `json:"region_count,omitempty"`
+	StartTS             time.Time `json:"start_ts,omitempty"`
+	StateName           string    `json:"state_name,omitempty"`
+	Uptime              string    `json:"uptime,omitempty"`
+}
+
+// UnsafeRecoveryPlan is the model of cluster.UnsafeRecoveryPlan.
+type UnsafeRecoveryPlan struct {
+	AffectedMetaRegions []uint64       `json:"affected_meta_regions,omitempty"`
+	AffectedRegions     []uint64       `json:"affected_regions,omitempty"`
+	AffectedTableIDs    []int64        `json:"affected_table_ids,omitempty"`
+	AutoDetect          bool           `json:"auto_detect,omitempty"`
+	FailedStores        []uint64       `json:"failed_stores,omitempty"`
+	ID                  string         `json:"id,omitempty"`
+	Stages              []*StageOutput `json:"stages,omitempty"`
+	Time                string         `json:"time,omitempty"`
+	Timeout             uint64         `json:"timeout,omitempty"`
+}
+
+// Version is the model of api.version.
+type Version struct {
+	Branch    string `json:"branch,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
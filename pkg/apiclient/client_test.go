@@ -0,0 +1,101 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	re := require.New(t)
+	var requests []*http.Request
+	var bodies []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		switch r.URL.Path {
+		case basePath + "/store/1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"store": map[string]interface{}{"id": 1, "address": "127.0.0.1:20160"},
+			})
+		case basePath + "/members/name/pd 1":
+			json.NewEncoder(w).Encode("The member's property is updated.")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+		}
+	}))
+	defer svr.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	// The unavailable endpoint is skipped.
+	cli, err := NewClient([]string{down.URL, svr.URL + "/"}, WithBearerToken("token"))
+	re.NoError(err)
+	ctx := context.Background()
+	store, err := cli.GetStore(ctx, 1)
+	re.NoError(err)
+	re.Equal(uint64(1), store.Store.ID)
+	re.Equal("127.0.0.1:20160", store.Store.Address)
+	re.Equal("Bearer token", requests[0].Header.Get("Authorization"))
+
+	// The path parameters are escaped and the body is encoded as JSON.
+	msg, err := cli.SetMemberPropertyByName(ctx, "pd 1", map[string]interface{}{"leader-priority": 1})
+	re.NoError(err)
+	re.Equal("The member's property is updated.", msg)
+	re.Equal("/pd/api/v1/members/name/pd%201", requests[1].URL.EscapedPath())
+	re.Equal("application/json", requests[1].Header.Get("Content-Type"))
+	re.JSONEq(`{"leader-priority":1}`, bodies[1])
+
+	// The optional parameters are omitted if they are zero.
+	_, err = cli.GetTopSizeRegions(ctx, &GetTopSizeRegionsOptions{Limit: 10})
+	re.Error(err)
+	re.Equal("10", requests[2].URL.Query().Get("limit"))
+	_, err = cli.GetTopSizeRegions(ctx, nil)
+	re.Error(err)
+	re.Empty(requests[3].URL.RawQuery)
+
+	// The error of the last endpoint is returned.
+	re.Contains(err.Error(), "after trying all endpoints")
+	re.Contains(err.Error(), "[404] not found")
+
+	cli, err = NewClient([]string{svr.URL})
+	re.NoError(err)
+	err = cli.Ping(ctx)
+	var statusErr *StatusError
+	re.True(errors.As(err, &statusErr))
+	re.Equal(http.StatusNotFound, statusErr.StatusCode)
+	re.Equal("not found", statusErr.Message)
+	re.Empty(requests[4].Header.Get("Authorization"))
+
+	// The header of the client is sent with every request.
+	cli, err = NewClient([]string{svr.URL}, WithHeader(http.Header{"PD-Allow-follower-handle": {"true"}}))
+	re.NoError(err)
+	_, err = cli.GetStore(ctx, 1)
+	re.NoError(err)
+	re.Equal("true", requests[5].Header.Get("PD-Allow-follower-handle"))
+
+	_, err = NewClient([]string{"127.0.0.1:2379"})
+	re.Error(err)
+}
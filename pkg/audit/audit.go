@@ -15,6 +15,7 @@
 package audit
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/pingcap/log"
@@ -64,6 +65,8 @@ func (s *Sequence) ProcessBeforeHandler() bool {
 type Backend interface {
 	// ProcessHTTPRequest is used to perform HTTP audit process
 	ProcessHTTPRequest(req *http.Request) bool
+	// ProcessGRPCRequest is used to perform gRPC audit process, the request info is in the context
+	ProcessGRPCRequest(ctx context.Context) bool
 	// Match is used to determine if the backend matches
 	Match(*BackendLabels) bool
 	ProcessBeforeHandler() bool
@@ -102,6 +105,20 @@ func (b *PrometheusHistogramBackend) ProcessHTTPRequest(req *http.Request) bool
 	return true
 }
 
+// ProcessGRPCRequest is used to implement audit.Backend
+func (b *PrometheusHistogramBackend) ProcessGRPCRequest(ctx context.Context) bool {
+	requestInfo, ok := requestutil.RequestInfoFrom(ctx)
+	if !ok {
+		return false
+	}
+	endTime, ok := requestutil.EndTimeFrom(ctx)
+	if !ok {
+		return false
+	}
+	b.histogramVec.WithLabelValues(requestInfo.ServiceLabel, "gRPC", requestInfo.Component, requestInfo.IP).Observe(float64(endTime - requestInfo.StartTimeStamp))
+	return true
+}
+
 // LocalLogBackend is an implementation of audit.Backend
 // and it uses `github.com/pingcap/log` to implement audit
 type LocalLogBackend struct {
@@ -126,3 +143,17 @@ func (l *LocalLogBackend) ProcessHTTPRequest(r *http.Request) bool {
 	log.Info("audit log", zap.String("service-info", requestInfo.String()))
 	return true
 }
+
+// ProcessGRPCRequest is used to implement audit.Backend. The gRPC request may carry the sensitive
+// payload like the global config, so only the digest of the request body is logged.
+func (l *LocalLogBackend) ProcessGRPCRequest(ctx context.Context) bool {
+	requestInfo, ok := requestutil.RequestInfoFrom(ctx)
+	if !ok {
+		return false
+	}
+	if requestInfo.BodyParam != "" {
+		requestInfo.BodyParam = "sha256:" + bodyDigest(requestInfo.BodyParam)
+	}
+	log.Info("audit log", zap.String("service-info", requestInfo.String()))
+	return true
+}
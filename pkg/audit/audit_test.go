@@ -15,6 +15,7 @@
 package audit
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,6 +30,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/utils/requestutil"
+	"google.golang.org/grpc/codes"
 )
 
 func TestLabelMatcher(t *testing.T) {
@@ -108,6 +110,103 @@ func TestLocalLogBackendUsingFile(t *testing.T) {
 			time.Unix(info.StartTimeStamp, 0).String()),
 		output[3],
 	)
+
+	// the body of the gRPC request is redacted.
+	grpcCtx := requestutil.WithRequestInfo(context.Background(), requestutil.RequestInfo{
+		ServiceLabel:   "pdpb.PD/StoreGlobalConfig",
+		Method:         "gRPC:pdpb.PD/StoreGlobalConfig",
+		BodyParam:      "secret-payload",
+		StartTimeStamp: time.Now().Unix(),
+	})
+	re.True(backend.ProcessGRPCRequest(grpcCtx))
+	b, _ = os.ReadFile(fname)
+	re.NotContains(string(b), "secret-payload")
+	re.Contains(string(b), "BodyParam:sha256:"+bodyDigest("secret-payload"))
+}
+
+func TestDurableStorageBackend(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	backend := NewDurableStorageBackend(nil)
+	re.False(backend.ProcessBeforeHandler())
+	re.True(backend.Match(&BackendLabels{Labels: []string{DurableStorageLabel}}))
+
+	newRequest := func(service, component string, status int) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:2379/test?test=test", strings.NewReader("testBody"))
+		info := requestutil.GetRequestInfo(req)
+		info.ServiceLabel = service
+		info.Component = component
+		info.IP = "localhost"
+		ctx := requestutil.WithRequestInfo(req.Context(), info)
+		ctx = requestutil.WithResponseInfo(ctx, requestutil.ResponseInfo{StatusCode: status, Latency: time.Millisecond})
+		return req.WithContext(ctx)
+	}
+	// nothing is recorded before it's opened.
+	re.False(backend.ProcessHTTPRequest(newRequest("SetConfig", "pdctl", http.StatusOK)))
+	_, err := backend.Query(&RecordFilter{})
+	re.Error(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	re.NoError(backend.Open(ctx, t.TempDir(), func() uint64 { return 0 }))
+	defer backend.Close()
+	start := time.Now()
+	for i := 0; i < 300; i++ {
+		re.True(backend.ProcessHTTPRequest(newRequest("SetConfig", "pdctl", http.StatusOK)))
+	}
+	re.True(backend.ProcessHTTPRequest(newRequest("SetStoreState", "tidb", http.StatusBadRequest)))
+
+	records, err := backend.Query(&RecordFilter{})
+	re.NoError(err)
+	re.Len(records, 301)
+	re.Equal("SetConfig", records[0].Service)
+	re.Equal(http.MethodPost, records[0].Method)
+	re.Equal("/test", records[0].Path)
+	re.Equal("localhost", records[0].IP)
+	re.Equal(http.StatusOK, records[0].StatusCode)
+	re.NotEmpty(records[0].BodyDigest)
+	re.NotContains(records[0].BodyDigest, "testBody")
+
+	records, err = backend.Query(&RecordFilter{Component: "tidb"})
+	re.NoError(err)
+	re.Len(records, 1)
+	re.Equal("SetStoreState", records[0].Service)
+	re.Equal(http.StatusBadRequest, records[0].StatusCode)
+	records, err = backend.Query(&RecordFilter{Service: "SetConfig", Limit: 10})
+	re.NoError(err)
+	re.Len(records, 10)
+	records, err = backend.Query(&RecordFilter{StartTime: start.Add(time.Hour)})
+	re.NoError(err)
+	re.Empty(records)
+	records, err = backend.Query(&RecordFilter{EndTime: start.Add(-time.Hour)})
+	re.NoError(err)
+	re.Empty(records)
+
+	// the gRPC request is recorded with the gRPC code.
+	grpcCtx := requestutil.WithRequestInfo(context.Background(), requestutil.RequestInfo{
+		ServiceLabel:   "pdpb.PD/PutStore",
+		Method:         "gRPC:pdpb.PD/PutStore",
+		Component:      "tikv",
+		IP:             "localhost",
+		BodyParam:      "store",
+		StartTimeStamp: time.Now().Unix(),
+	})
+	grpcCtx = requestutil.WithResponseInfo(grpcCtx, requestutil.ResponseInfo{StatusCode: int(codes.PermissionDenied)})
+	re.True(backend.ProcessGRPCRequest(grpcCtx))
+	records, err = backend.Query(&RecordFilter{Component: "tikv"})
+	re.NoError(err)
+	re.Len(records, 1)
+	re.Equal("pdpb.PD/PutStore", records[0].Service)
+	re.Equal("gRPC", records[0].Method)
+	re.Equal("/pdpb.PD/PutStore", records[0].Path)
+	re.Equal(int(codes.PermissionDenied), records[0].StatusCode)
+	re.NotEmpty(records[0].BodyDigest)
+	re.False(backend.ProcessGRPCRequest(context.Background()))
+
+	re.NoError(backend.deleteBefore(time.Now().Add(time.Hour)))
+	records, err = backend.Query(&RecordFilter{})
+	re.NoError(err)
+	re.Empty(records)
 }
 
 func BenchmarkLocalLogAuditUsingTerminal(b *testing.B) {
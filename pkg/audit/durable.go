@@ -0,0 +1,321 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/rbac"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/requestutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// DurableStorageLabel is label name of DurableStorageBackend
+	DurableStorageLabel = "durable-storage"
+
+	recordKeyPrefix = "audit/"
+	// cleanupInterval is the interval to delete the expired records.
+	cleanupInterval = time.Hour
+	// DefaultQueryLimit is the max number of records returned by a query if the limit isn't set.
+	DefaultQueryLimit = 1000
+	queryBatchSize    = 256
+)
+
+// Record is an audit record of a HTTP or gRPC request. The status code is the HTTP status
+// code or the gRPC code.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type Record struct {
+	Time       time.Time `json:"time"`
+	Service    string    `json:"service"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Component  string    `json:"component"`
+	IP         string    `json:"ip"`
+	Subjects   []string  `json:"subjects,omitempty"`
+	URLParam   string    `json:"url-param,omitempty"`
+	BodyDigest string    `json:"body-digest,omitempty"`
+	StatusCode int       `json:"status-code"`
+	LatencyMs  float64   `json:"latency-ms"`
+}
+
+// RecordFilter is used to query the audit records. The zero value of a field matches all.
+type RecordFilter struct {
+	StartTime time.Time
+	EndTime   time.Time
+	IP        string
+	Component string
+	Service   string
+	Limit     int
+}
+
+func (f *RecordFilter) match(r *Record) bool {
+	return (f.IP == "" || f.IP == r.IP) &&
+		(f.Component == "" || f.Component == r.Component) &&
+		(f.Service == "" || f.Service == r.Service)
+}
+
+// DurableStorageBackend is an implementation of audit.Backend
+// and it records the requests into LevelDB, which can be queried later.
+// It should be processed after the handler to record the response status.
+type DurableStorageBackend struct {
+	*LabelMatcher
+	*Sequence
+
+	mu  sync.RWMutex
+	db  *kv.LevelDBKV
+	seq uint64
+
+	// httpSubjects returns the subjects of the client of the HTTP request.
+	httpSubjects func(r *http.Request) []string
+
+	retentionDays func() uint64
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+// NewDurableStorageBackend returns a DurableStorageBackend, which doesn't record anything until it's opened.
+// The subjects of the HTTP requests are resolved by httpSubjects, which should be the same as the RBAC check,
+// so that the client instead of the PD follower is recorded for the redirected requests. The subjects of the
+// certificate and the token are recorded if it's nil.
+func NewDurableStorageBackend(httpSubjects func(r *http.Request) []string) *DurableStorageBackend {
+	if httpSubjects == nil {
+		httpSubjects = rbac.SubjectsFromHTTP
+	}
+	return &DurableStorageBackend{
+		LabelMatcher: &LabelMatcher{backendLabel: DurableStorageLabel},
+		Sequence:     &Sequence{before: false},
+		httpSubjects: httpSubjects,
+	}
+}
+
+// Open opens the LevelDB in the path and starts to delete the records older than the retention
+// days in background. The records are kept forever if the retention days is 0.
+func (b *DurableStorageBackend) Open(ctx context.Context, path string, retentionDays func() uint64) error {
+	db, err := kv.NewLevelDBKV(path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.db != nil {
+		db.Close()
+		return errors.New("audit storage is already opened")
+	}
+	b.db = db
+	b.retentionDays = retentionDays
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.wg.Add(1)
+	go b.backgroundCleanup(ctx)
+	return nil
+}
+
+// Close stops the background cleanup and closes the LevelDB.
+func (b *DurableStorageBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.db == nil {
+		return nil
+	}
+	b.cancel()
+	b.wg.Wait()
+	err := b.db.Close()
+	b.db = nil
+	return err
+}
+
+// ProcessHTTPRequest is used to implement audit.Backend
+func (b *DurableStorageBackend) ProcessHTTPRequest(r *http.Request) bool {
+	requestInfo, ok := requestutil.RequestInfoFrom(r.Context())
+	if !ok {
+		return false
+	}
+	record := &Record{
+		Time:      time.Unix(requestInfo.StartTimeStamp, 0),
+		Service:   requestInfo.ServiceLabel,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Component: requestInfo.Component,
+		IP:        requestInfo.IP,
+		Subjects:  b.httpSubjects(r),
+		URLParam:  requestInfo.URLParam,
+	}
+	return b.process(r.Context(), record, requestInfo.BodyParam)
+}
+
+// ProcessGRPCRequest is used to implement audit.Backend. The status code of the record is the gRPC code.
+func (b *DurableStorageBackend) ProcessGRPCRequest(ctx context.Context) bool {
+	requestInfo, ok := requestutil.RequestInfoFrom(ctx)
+	if !ok {
+		return false
+	}
+	record := &Record{
+		Time:      time.Unix(requestInfo.StartTimeStamp, 0),
+		Service:   requestInfo.ServiceLabel,
+		Method:    "gRPC",
+		Path:      "/" + requestInfo.ServiceLabel,
+		Component: requestInfo.Component,
+		IP:        requestInfo.IP,
+		Subjects:  rbac.SubjectsFromGRPC(ctx),
+	}
+	return b.process(ctx, record, requestInfo.BodyParam)
+}
+
+func bodyDigest(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func (b *DurableStorageBackend) process(ctx context.Context, record *Record, body string) bool {
+	if body != "" {
+		record.BodyDigest = bodyDigest(body)
+	}
+	if responseInfo, ok := requestutil.ResponseInfoFrom(ctx); ok {
+		record.StatusCode = responseInfo.StatusCode
+		record.LatencyMs = float64(responseInfo.Latency) / float64(time.Millisecond)
+		record.Time = time.Now().Add(-responseInfo.Latency)
+	}
+	if err := b.save(record); err != nil {
+		log.Error("failed to save audit record", zap.Reflect("record", record), errs.ZapError(err))
+		return false
+	}
+	return true
+}
+
+func (b *DurableStorageBackend) save(record *Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.db == nil {
+		return errors.New("audit storage is not opened")
+	}
+	// The sequence makes the keys of the records at the same time unique.
+	key := fmt.Sprintf("%s%s-%020d", recordKeyPrefix, timeKey(record.Time), atomic.AddUint64(&b.seq, 1))
+	return b.db.Save(key, string(value))
+}
+
+// Query returns the records matching the filter in time order.
+func (b *DurableStorageBackend) Query(filter *RecordFilter) ([]*Record, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.db == nil {
+		return nil, errors.New("audit storage is not opened")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	startKey := recordKeyPrefix + timeKey(filter.StartTime)
+	endKey := recordKeyPrefix + timeKey(filter.EndTime)
+	if filter.EndTime.IsZero() {
+		endKey = prefixEnd(recordKeyPrefix)
+	}
+	records := make([]*Record, 0)
+	for {
+		keys, values, err := b.db.LoadRange(startKey, endKey, queryBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			record := &Record{}
+			if err := json.Unmarshal([]byte(value), record); err != nil {
+				return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+			}
+			if filter.match(record) {
+				records = append(records, record)
+				if len(records) >= limit {
+					return records, nil
+				}
+			}
+		}
+		if len(keys) < queryBatchSize {
+			return records, nil
+		}
+		// Continue from the key next to the last one.
+		startKey = keys[len(keys)-1] + "\x00"
+	}
+}
+
+func (b *DurableStorageBackend) backgroundCleanup(ctx context.Context) {
+	defer logutil.LogPanic()
+	defer b.wg.Done()
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+	for {
+		if days := b.retentionDays(); days > 0 {
+			if err := b.deleteBefore(time.Now().AddDate(0, 0, -int(days))); err != nil {
+				log.Error("failed to delete expired audit records", errs.ZapError(err))
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deleteBefore deletes the records before the time. It's only called by the background
+// cleanup, which is stopped before the LevelDB is closed.
+func (b *DurableStorageBackend) deleteBefore(t time.Time) error {
+	iter := b.db.NewIterator(&util.Range{Start: []byte(recordKeyPrefix), Limit: []byte(recordKeyPrefix + timeKey(t))}, nil)
+	defer iter.Release()
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Delete(append([]byte(nil), iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		return errs.ErrLevelDBWrite.Wrap(err).GenWithStackByCause()
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	if err := b.db.Write(batch, nil); err != nil {
+		return errs.ErrLevelDBWrite.Wrap(err).GenWithStackByCause()
+	}
+	return nil
+}
+
+// timeKey encodes the time to be ordered by the key.
+func timeKey(t time.Time) string {
+	if t.IsZero() {
+		return fmt.Sprintf("%020d", 0)
+	}
+	return fmt.Sprintf("%020d", t.UnixNano())
+}
+
+// prefixEnd returns the end key of the prefix.
+func prefixEnd(prefix string) string {
+	return prefix[:len(prefix)-1] + string(prefix[len(prefix)-1]+1)
+}
@@ -50,3 +50,12 @@ type Server interface {
 	// AddServiceReadyCallback adds callbacks when the server becomes the leader, if there is embedded etcd, or the primary otherwise.
 	AddServiceReadyCallback(callbacks ...func(context.Context))
 }
+
+// AccessController defines the access control of a server. The services which are installed in the
+// server check the permissions of the clients if the server implements it.
+type AccessController interface {
+	// CheckHTTPPermission returns an error if the client of the HTTP request isn't allowed to access the service.
+	CheckHTTPPermission(r *http.Request, service string) error
+	// CheckGRPCPermission returns an error if the client of the gRPC request isn't allowed to call the method.
+	CheckGRPCPermission(ctx context.Context) error
+}
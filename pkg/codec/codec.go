@@ -17,6 +17,7 @@ package codec
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
 
 	"github.com/pingcap/errors"
 )
@@ -27,6 +28,13 @@ var (
 	recordPrefix = []byte("_r")
 )
 
+const (
+	keyspaceRawModePrefix = 'r'
+	keyspaceTxnModePrefix = 'x'
+	keyspacePrefixLen     = 4
+	maxKeyspaceID         = 0xFFFFFF
+)
+
 const (
 	signMask uint64 = 0x8000000000000000
 
@@ -54,6 +62,67 @@ func (k Key) TableID() int64 {
 	return tableID
 }
 
+// KeyspaceID returns the keyspace ID of the key encoded in the API V2 format,
+// i.e., the raw mode or txn mode prefix followed by the 3 bytes keyspace ID.
+// It returns false if the key is not a keyspace key.
+func (k Key) KeyspaceID() (uint32, bool) {
+	_, key, err := DecodeBytes(k)
+	if err != nil || len(key) < keyspacePrefixLen {
+		return 0, false
+	}
+	if key[0] != keyspaceRawModePrefix && key[0] != keyspaceTxnModePrefix {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(key[:keyspacePrefixLen]) & maxKeyspaceID, true
+}
+
+// TableRange returns the encoded key range of the table which the key belongs to.
+// The keyspace prefix of the key encoded in the API V2 format is kept in the range.
+// It returns false if the key is not a table key.
+func (k Key) TableRange() (startKey, endKey []byte, ok bool) {
+	_, key, err := DecodeBytes(k)
+	if err != nil {
+		return nil, nil, false
+	}
+	var prefix []byte
+	if _, isKeyspace := k.KeyspaceID(); isKeyspace {
+		prefix, key = key[:keyspacePrefixLen], key[keyspacePrefixLen:]
+	}
+	if !bytes.HasPrefix(key, tablePrefix) {
+		return nil, nil, false
+	}
+	_, tableID, err := DecodeInt(key[len(tablePrefix):])
+	if err != nil || tableID == math.MaxInt64 {
+		return nil, nil, false
+	}
+	startKey = append(append([]byte{}, prefix...), GenerateTableKey(tableID)...)
+	endKey = append(append([]byte{}, prefix...), GenerateTableKey(tableID+1)...)
+	return EncodeBytes(startKey), EncodeBytes(endKey), true
+}
+
+// KeyspaceRange returns the encoded key range of the keyspace which the key belongs to.
+// The raw mode and the txn mode of the same keyspace are in different ranges.
+// It returns false if the key is not a keyspace key.
+func (k Key) KeyspaceRange() (startKey, endKey []byte, ok bool) {
+	_, key, err := DecodeBytes(k)
+	if err != nil {
+		return nil, nil, false
+	}
+	id, isKeyspace := k.KeyspaceID()
+	if !isKeyspace {
+		return nil, nil, false
+	}
+	startKey = append([]byte{}, key[:keyspacePrefixLen]...)
+	if id == maxKeyspaceID {
+		endKey = []byte{key[0] + 1}
+	} else {
+		endKey = make([]byte, keyspacePrefixLen)
+		binary.BigEndian.PutUint32(endKey, id+1)
+		endKey[0] = key[0]
+	}
+	return EncodeBytes(startKey), EncodeBytes(endKey), true
+}
+
 // MetaOrTable checks if the key is a meta key or table key.
 // If the key is a meta key, it returns true and 0.
 // If the key is a table key, it returns false and table ID.
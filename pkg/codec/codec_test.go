@@ -49,3 +49,60 @@ func TestTableID(t *testing.T) {
 	key = EncodeBytes([]byte("t\x80\x00\x00\x00\x00\x00\xff"))
 	re.Equal(int64(0), key.TableID())
 }
+
+func TestKeyspaceID(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	id, ok := EncodeBytes([]byte("x\x00\x01\x02t\x80")).KeyspaceID()
+	re.True(ok)
+	re.Equal(uint32(0x102), id)
+
+	id, ok = EncodeBytes([]byte("r\xff\xff\xff")).KeyspaceID()
+	re.True(ok)
+	re.Equal(uint32(0xffffff), id)
+
+	_, ok = EncodeBytes([]byte("x\x00\x01")).KeyspaceID()
+	re.False(ok)
+	_, ok = EncodeBytes([]byte("t\x80\x00\x00\x00\x00\x00\x00\xff")).KeyspaceID()
+	re.False(ok)
+	_, ok = Key("x\x00\x00\x01").KeyspaceID()
+	re.False(ok)
+}
+
+func TestTableRange(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	startKey, endKey, ok := EncodeBytes(GenerateRowKey(10, 5)).TableRange()
+	re.True(ok)
+	re.Equal([]byte(EncodeBytes(GenerateTableKey(10))), startKey)
+	re.Equal([]byte(EncodeBytes(GenerateTableKey(11))), endKey)
+	re.Equal(int64(10), Key(startKey).TableID())
+
+	prefix := []byte("x\x00\x00\x01")
+	startKey, endKey, ok = EncodeBytes(append(prefix, GenerateRowKey(10, 5)...)).TableRange()
+	re.True(ok)
+	re.Equal([]byte(EncodeBytes(append(prefix, GenerateTableKey(10)...))), startKey)
+	re.Equal([]byte(EncodeBytes(append(prefix, GenerateTableKey(11)...))), endKey)
+
+	_, _, ok = EncodeBytes([]byte("m\x80")).TableRange()
+	re.False(ok)
+	_, _, ok = Key("").TableRange()
+	re.False(ok)
+}
+
+func TestKeyspaceRange(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	startKey, endKey, ok := EncodeBytes(append([]byte("x\x00\x01\xff"), GenerateRowKey(10, 5)...)).KeyspaceRange()
+	re.True(ok)
+	re.Equal([]byte(EncodeBytes([]byte("x\x00\x01\xff"))), startKey)
+	re.Equal([]byte(EncodeBytes([]byte("x\x00\x02\x00"))), endKey)
+
+	startKey, endKey, ok = EncodeBytes([]byte("r\xff\xff\xff")).KeyspaceRange()
+	re.True(ok)
+	re.Equal([]byte(EncodeBytes([]byte("r\xff\xff\xff"))), startKey)
+	re.Equal([]byte(EncodeBytes([]byte("s"))), endKey)
+
+	_, _, ok = EncodeBytes(GenerateRowKey(10, 5)).KeyspaceRange()
+	re.False(ok)
+}
@@ -159,6 +159,13 @@ func (bc *BasicCluster) SlowTrendRecovered(storeID uint64) {
 	bc.Stores.SlowTrendRecovered(storeID)
 }
 
+// SetStoreHealthState sets the health state, the unhealthy signals and the leader ratio of a store.
+func (bc *BasicCluster) SetStoreHealthState(storeID uint64, state StoreHealthState, signals []string, leaderRatio float64) {
+	bc.Stores.mu.Lock()
+	defer bc.Stores.mu.Unlock()
+	bc.Stores.SetStoreHealthState(storeID, state, signals, leaderRatio)
+}
+
 // SlowStoreRecovered cleans the evicted state of a store.
 func (bc *BasicCluster) SlowStoreRecovered(storeID uint64) {
 	bc.Stores.mu.Lock()
@@ -282,6 +289,8 @@ type StoreSetController interface {
 	SlowStoreRecovered(id uint64)
 	SlowTrendEvicted(id uint64) error
 	SlowTrendRecovered(id uint64)
+
+	SetStoreHealthState(id uint64, state StoreHealthState, signals []string, leaderRatio float64)
 }
 
 // KeyRange is a key range.
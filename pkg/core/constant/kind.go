@@ -27,6 +27,48 @@ const (
 	PriorityLevelLen
 )
 
+// PriorityClass is the class of the operator. When the store limit is exceeded, an operator
+// can preempt the running operators of the lower classes, larger class means higher priority.
+type PriorityClass int
+
+// Built-in priority classes
+const (
+	MergeClass PriorityClass = iota
+	BalanceClass
+	HotClass
+	RuleFixClass
+	RepairClass
+
+	PriorityClassLen
+)
+
+func (c PriorityClass) String() string {
+	switch c {
+	case MergeClass:
+		return "merge"
+	case BalanceClass:
+		return "balance"
+	case HotClass:
+		return "hot"
+	case RuleFixClass:
+		return "rule-fix"
+	case RepairClass:
+		return "repair"
+	default:
+		return "unknown"
+	}
+}
+
+// StringToPriorityClass creates a priority class with string, it returns false if the string is invalid.
+func StringToPriorityClass(input string) (PriorityClass, bool) {
+	for c := MergeClass; c < PriorityClassLen; c++ {
+		if c.String() == input {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
 // ScheduleKind distinguishes resources and schedule policy.
 type ScheduleKind struct {
 	Resource ResourceKind
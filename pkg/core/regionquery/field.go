@@ -0,0 +1,203 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regionquery
+
+import (
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/tikv/pd/pkg/core"
+)
+
+type valueKind int
+
+const (
+	kindNumber valueKind = iota
+	kindString
+	// kindKey is the raw key, which is written as the hex encoded string in the queries.
+	kindKey
+)
+
+type value struct {
+	num float64
+	str string
+}
+
+func compareValues(kind valueKind, a, b value) int {
+	if kind != kindNumber {
+		return strings.Compare(a.str, b.str)
+	}
+	switch {
+	case a.num < b.num:
+		return -1
+	case a.num > b.num:
+		return 1
+	}
+	return 0
+}
+
+type evalContext struct {
+	cluster Cluster
+	labeler Labeler
+}
+
+// field is a property of the regions which can be used in the queries.
+type field struct {
+	name string
+	kind valueKind
+	// multi is true if the region may have several values of the field, like the stores of
+	// the peers. The region matches a comparison if any of the values matches, except that
+	// it matches != and not in if none of the values equals.
+	multi bool
+	get   func(ctx *evalContext, region *core.RegionInfo) []value
+}
+
+func numberField(name string, get func(region *core.RegionInfo) float64) *field {
+	return &field{
+		name: name,
+		kind: kindNumber,
+		get: func(_ *evalContext, region *core.RegionInfo) []value {
+			return []value{{num: get(region)}}
+		},
+	}
+}
+
+func countField(name string, get func(region *core.RegionInfo) int) *field {
+	return numberField(name, func(region *core.RegionInfo) float64 { return float64(get(region)) })
+}
+
+func keyField(name string, get func(region *core.RegionInfo) []byte) *field {
+	return &field{
+		name: name,
+		kind: kindKey,
+		get: func(_ *evalContext, region *core.RegionInfo) []value {
+			return []value{{str: string(get(region))}}
+		},
+	}
+}
+
+var fields = map[string]*field{
+	"id": numberField("id", func(region *core.RegionInfo) float64 { return float64(region.GetID()) }),
+	// The approximate size is in MiB, it's converted to bytes to be compared with the sizes
+	// like 96MiB.
+	"size":          numberField("size", func(region *core.RegionInfo) float64 { return float64(region.GetApproximateSize() * units.MiB) }),
+	"keys":          numberField("keys", func(region *core.RegionInfo) float64 { return float64(region.GetApproximateKeys()) }),
+	"written_bytes": numberField("written_bytes", func(region *core.RegionInfo) float64 { return float64(region.GetBytesWritten()) }),
+	"written_keys":  numberField("written_keys", func(region *core.RegionInfo) float64 { return float64(region.GetKeysWritten()) }),
+	"read_bytes":    numberField("read_bytes", func(region *core.RegionInfo) float64 { return float64(region.GetBytesRead()) }),
+	"read_keys":     numberField("read_keys", func(region *core.RegionInfo) float64 { return float64(region.GetKeysRead()) }),
+	"cpu_usage":     numberField("cpu_usage", func(region *core.RegionInfo) float64 { return float64(region.GetCPUUsage()) }),
+	"conf_ver":      numberField("conf_ver", func(region *core.RegionInfo) float64 { return float64(region.GetRegionEpoch().GetConfVer()) }),
+	"version":       numberField("version", func(region *core.RegionInfo) float64 { return float64(region.GetRegionEpoch().GetVersion()) }),
+	"peers":         countField("peers", func(region *core.RegionInfo) int { return len(region.GetPeers()) }),
+	"voters":        countField("voters", func(region *core.RegionInfo) int { return len(region.GetVoters()) }),
+	"learners":      countField("learners", func(region *core.RegionInfo) int { return len(region.GetLearners()) }),
+	"witnesses":     countField("witnesses", func(region *core.RegionInfo) int { return len(region.GetWitnesses()) }),
+	"pending_peers": countField("pending_peers", func(region *core.RegionInfo) int { return len(region.GetPendingPeers()) }),
+	"down_peers":    countField("down_peers", func(region *core.RegionInfo) int { return len(region.GetDownPeers()) }),
+	"start_key":     keyField("start_key", (*core.RegionInfo).GetStartKey),
+	"end_key":       keyField("end_key", (*core.RegionInfo).GetEndKey),
+	"store": {
+		name:  "store",
+		kind:  kindNumber,
+		multi: true,
+		get: func(_ *evalContext, region *core.RegionInfo) []value {
+			peers := region.GetPeers()
+			values := make([]value, 0, len(peers))
+			for _, peer := range peers {
+				values = append(values, value{num: float64(peer.GetStoreId())})
+			}
+			return values
+		},
+	},
+	"leader.store": {
+		name: "leader.store",
+		kind: kindNumber,
+		get: func(_ *evalContext, region *core.RegionInfo) []value {
+			if leader := region.GetLeader(); leader != nil {
+				return []value{{num: float64(leader.GetStoreId())}}
+			}
+			return nil
+		},
+	},
+}
+
+// The prefixes of the fields whose names end with the label keys.
+const (
+	storeLabelPrefix       = "store.label."
+	leaderStoreLabelPrefix = "leader.store.label."
+	regionLabelPrefix      = "label."
+)
+
+func getField(name string) *field {
+	if f, ok := fields[name]; ok {
+		return f
+	}
+	switch {
+	case strings.HasPrefix(name, leaderStoreLabelPrefix) && len(name) > len(leaderStoreLabelPrefix):
+		key := name[len(leaderStoreLabelPrefix):]
+		return &field{
+			name: name,
+			kind: kindString,
+			get: func(ctx *evalContext, region *core.RegionInfo) []value {
+				if leader := region.GetLeader(); leader != nil {
+					return storeLabelValues(ctx, []*metapb.Peer{leader}, key)
+				}
+				return nil
+			},
+		}
+	case strings.HasPrefix(name, storeLabelPrefix) && len(name) > len(storeLabelPrefix):
+		key := name[len(storeLabelPrefix):]
+		return &field{
+			name:  name,
+			kind:  kindString,
+			multi: true,
+			get: func(ctx *evalContext, region *core.RegionInfo) []value {
+				return storeLabelValues(ctx, region.GetPeers(), key)
+			},
+		}
+	case strings.HasPrefix(name, regionLabelPrefix) && len(name) > len(regionLabelPrefix):
+		key := name[len(regionLabelPrefix):]
+		return &field{
+			name: name,
+			kind: kindString,
+			get: func(ctx *evalContext, region *core.RegionInfo) []value {
+				if ctx.labeler == nil {
+					return nil
+				}
+				if v := ctx.labeler.GetRegionLabel(region, key); v != "" {
+					return []value{{str: v}}
+				}
+				return nil
+			},
+		}
+	}
+	return nil
+}
+
+func storeLabelValues(ctx *evalContext, peers []*metapb.Peer, key string) []value {
+	values := make([]value, 0, len(peers))
+	for _, peer := range peers {
+		store := ctx.cluster.GetStore(peer.GetStoreId())
+		if store == nil {
+			continue
+		}
+		if v := store.GetLabelValue(key); v != "" {
+			values = append(values, value{str: v})
+		}
+	}
+	return values
+}
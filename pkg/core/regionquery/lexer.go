@@ -0,0 +1,167 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regionquery
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/errors"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	typ tokenType
+	pos int
+	// text is the identifier, the operator or the unquoted string.
+	text string
+	num  float64
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.text)
+}
+
+// isKeyword returns true if the token is the keyword, the keywords are case-insensitive.
+func (t token) isKeyword(keyword string) bool {
+	return t.typ == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || isLetter(c)
+}
+
+// The label keys may contain '.' and '-'.
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == '-'
+}
+
+func lex(text string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{typ: tokenLParen, pos: i, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{typ: tokenRParen, pos: i, text: ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{typ: tokenComma, pos: i, text: ","})
+			i++
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			op := text[i : i+1]
+			if i+1 < len(text) {
+				switch two := text[i : i+2]; two {
+				case "==", "!=", "<=", ">=", "<>":
+					op = two
+				}
+			}
+			pos := i
+			i += len(op)
+			switch op {
+			case "!":
+				return nil, errors.Errorf("unexpected %q at %d", c, pos)
+			case "==":
+				op = opEQ
+			case "<>":
+				op = opNE
+			}
+			tokens = append(tokens, token{typ: tokenOp, pos: pos, text: op})
+		case c == '"' || c == '\'':
+			// The double quoted strings support the escapes of Go, the single quoted ones don't.
+			j := i + 1
+			for ; j < len(text) && text[j] != c; j++ {
+				if c == '"' && text[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(text) {
+				return nil, errors.Errorf("unterminated string at %d", i)
+			}
+			s := text[i+1 : j]
+			if c == '"' {
+				var err error
+				if s, err = strconv.Unquote(text[i : j+1]); err != nil {
+					return nil, errors.Errorf("invalid string at %d", i)
+				}
+			}
+			tokens = append(tokens, token{typ: tokenString, pos: i, text: s})
+			i = j + 1
+		case isDigit(c) || (c == '.' && i+1 < len(text) && isDigit(text[i+1])):
+			j := i
+			for j < len(text) && (isDigit(text[j]) || text[j] == '.') {
+				j++
+			}
+			// The number may have a unit suffix like MiB.
+			for j < len(text) && isLetter(text[j]) {
+				j++
+			}
+			num, err := parseNumber(text[i:j])
+			if err != nil {
+				return nil, errors.Errorf("invalid number %q at %d", text[i:j], i)
+			}
+			tokens = append(tokens, token{typ: tokenNumber, pos: i, text: text[i:j], num: num})
+			i = j
+		case isIdentStart(c):
+			j := i
+			for j < len(text) && isIdentPart(text[j]) {
+				j++
+			}
+			tokens = append(tokens, token{typ: tokenIdent, pos: i, text: text[i:j]})
+			i = j
+		default:
+			return nil, errors.Errorf("unexpected %q at %d", c, i)
+		}
+	}
+	return append(tokens, token{typ: tokenEOF, pos: len(text)}), nil
+}
+
+// parseNumber parses the number which may have a size unit, the units are in the base of 1024.
+func parseNumber(s string) (float64, error) {
+	if strings.IndexFunc(s, unicode.IsLetter) == -1 {
+		return strconv.ParseFloat(s, 64)
+	}
+	n, err := units.RAMInBytes(s)
+	return float64(n), err
+}
@@ -0,0 +1,335 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regionquery
+
+import (
+	"encoding/hex"
+	"math"
+	"strconv"
+
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/core"
+)
+
+// The comparison operators.
+const (
+	opEQ    = "="
+	opNE    = "!="
+	opLT    = "<"
+	opLE    = "<="
+	opGT    = ">"
+	opGE    = ">="
+	opIn    = "in"
+	opNotIn = "not in"
+)
+
+type expr interface {
+	eval(ctx *evalContext, region *core.RegionInfo) bool
+}
+
+type andExpr []expr
+
+func (e andExpr) eval(ctx *evalContext, region *core.RegionInfo) bool {
+	for _, child := range e {
+		if !child.eval(ctx, region) {
+			return false
+		}
+	}
+	return true
+}
+
+type orExpr []expr
+
+func (e orExpr) eval(ctx *evalContext, region *core.RegionInfo) bool {
+	for _, child := range e {
+		if child.eval(ctx, region) {
+			return true
+		}
+	}
+	return false
+}
+
+type notExpr struct {
+	child expr
+}
+
+func (e *notExpr) eval(ctx *evalContext, region *core.RegionInfo) bool {
+	return !e.child.eval(ctx, region)
+}
+
+type compareExpr struct {
+	field  *field
+	op     string
+	values []value
+}
+
+func (e *compareExpr) eval(ctx *evalContext, region *core.RegionInfo) bool {
+	values := e.field.get(ctx, region)
+	switch e.op {
+	case opNE, opNotIn:
+		return !e.anyValue(values, func(c int) bool { return c == 0 })
+	case opEQ, opIn:
+		return e.anyValue(values, func(c int) bool { return c == 0 })
+	case opLT:
+		return e.anyValue(values, func(c int) bool { return c < 0 })
+	case opLE:
+		return e.anyValue(values, func(c int) bool { return c <= 0 })
+	case opGT:
+		return e.anyValue(values, func(c int) bool { return c > 0 })
+	case opGE:
+		return e.anyValue(values, func(c int) bool { return c >= 0 })
+	}
+	return false
+}
+
+// anyValue returns true if any of the values of the region satisfies the comparison with
+// any of the values of the expression.
+func (e *compareExpr) anyValue(values []value, satisfy func(c int) bool) bool {
+	for _, v := range values {
+		for _, target := range e.values {
+			if satisfy(compareValues(e.field.kind, v, target)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func unexpected(t token) error {
+	return errors.Errorf("unexpected %s at %d", t, t.pos)
+}
+
+func (p *parser) expect(typ tokenType) (token, error) {
+	t := p.next()
+	if t.typ != typ {
+		return t, unexpected(t)
+	}
+	return t, nil
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.isKeyword(keyword) {
+		return unexpected(t)
+	}
+	return nil
+}
+
+// atClauseEnd returns true if the expression ends, which is followed by the order by or
+// limit clause.
+func (p *parser) atClauseEnd() bool {
+	t := p.peek()
+	return t.typ == tokenEOF || t.isKeyword("order") || t.isKeyword("limit")
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	q := &Query{}
+	var err error
+	if !p.atClauseEnd() {
+		if q.expr, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().isKeyword("order") {
+		p.next()
+		if err = p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+		t, err := p.expect(tokenIdent)
+		if err != nil {
+			return nil, err
+		}
+		if q.orderBy = getField(t.text); q.orderBy == nil {
+			return nil, errors.Errorf("unknown field %s at %d", t.text, t.pos)
+		}
+		if q.orderBy.multi {
+			return nil, errors.Errorf("can't order by %s which has multiple values", t.text)
+		}
+		if p.peek().isKeyword("desc") {
+			p.next()
+			q.desc = true
+		} else if p.peek().isKeyword("asc") {
+			p.next()
+		}
+	}
+	if p.peek().isKeyword("limit") {
+		p.next()
+		t, err := p.expect(tokenNumber)
+		if err != nil {
+			return nil, err
+		}
+		if t.num < 1 || t.num != math.Trunc(t.num) || t.num > math.MaxInt32 {
+			return nil, errors.Errorf("invalid limit %s at %d", t.text, t.pos)
+		}
+		q.limit = int(t.num)
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, unexpected(t)
+	}
+	return q, nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := orExpr{e}
+	for p.peek().isKeyword("or") {
+		p.next()
+		if e, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		children = append(children, e)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return children, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	e, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	children := andExpr{e}
+	for p.peek().isKeyword("and") {
+		p.next()
+		if e, err = p.parseNot(); err != nil {
+			return nil, err
+		}
+		children = append(children, e)
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return children, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.peek().isKeyword("not") {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{child: e}, nil
+	}
+	if p.peek().typ == tokenLParen {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokenRParen); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (expr, error) {
+	t, err := p.expect(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+	e := &compareExpr{field: getField(t.text)}
+	if e.field == nil {
+		return nil, errors.Errorf("unknown field %s at %d", t.text, t.pos)
+	}
+	t = p.next()
+	switch {
+	case t.typ == tokenOp:
+		e.op = t.text
+		v, err := p.parseValue(e.field)
+		if err != nil {
+			return nil, err
+		}
+		e.values = []value{v}
+		return e, nil
+	case t.isKeyword("not"):
+		if err = p.expectKeyword("in"); err != nil {
+			return nil, err
+		}
+		e.op = opNotIn
+	case t.isKeyword("in"):
+		e.op = opIn
+	default:
+		return nil, unexpected(t)
+	}
+	if _, err = p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+	for {
+		v, err := p.parseValue(e.field)
+		if err != nil {
+			return nil, err
+		}
+		e.values = append(e.values, v)
+		t = p.next()
+		if t.typ == tokenRParen {
+			return e, nil
+		}
+		if t.typ != tokenComma {
+			return nil, unexpected(t)
+		}
+	}
+}
+
+// parseValue parses the literal which is compared with the field.
+func (p *parser) parseValue(f *field) (value, error) {
+	t := p.next()
+	switch f.kind {
+	case kindNumber:
+		if t.typ == tokenNumber {
+			return value{num: t.num}, nil
+		}
+	case kindString:
+		// The label values may be written without quotes if they are numbers.
+		if t.typ == tokenString || t.typ == tokenNumber {
+			return value{str: t.text}, nil
+		}
+	case kindKey:
+		if t.typ == tokenString {
+			key, err := hex.DecodeString(t.text)
+			if err != nil {
+				return value{}, errors.Errorf("invalid hex encoded key %s at %d", strconv.Quote(t.text), t.pos)
+			}
+			return value{str: string(key)}, nil
+		}
+	}
+	if t.typ == tokenEOF || t.typ == tokenNumber || t.typ == tokenString {
+		return value{}, errors.Errorf("invalid value %s of %s at %d", t, f.name, t.pos)
+	}
+	return value{}, unexpected(t)
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package regionquery implements a small query language to select the regions, like
+//
+//	size > 96MiB and store in (1, 4) and leader.store.label.zone = "z1" and pending_peers > 0 order by size desc limit 10
+//
+// An expression consists of the comparisons of the fields, combined by and, or, not and the
+// parentheses. A comparison is `field op value`, where op is one of =, !=, <, <=, > and >=,
+// or `field [not] in (value, ...)`. The numbers may have the size units like MiB, and the
+// strings are quoted. The keys are compared with the hex encoded strings. The expression may
+// be followed by `order by field [asc|desc]` and `limit n`, and the regions are ordered by
+// the ID by default.
+//
+// The fields are:
+//   - id, size (in bytes), keys, written_bytes, written_keys, read_bytes, read_keys,
+//     cpu_usage, conf_ver, version
+//   - peers, voters, learners, witnesses, pending_peers, down_peers: the count of the peers
+//   - start_key, end_key
+//   - store: the stores of the peers, leader.store: the store of the leader
+//   - store.label.<key>: the labels of the stores of the peers
+//   - leader.store.label.<key>: the label of the store of the leader
+//   - label.<key>: the label of the region
+//
+// A region matches a comparison of store or store.label.<key> if any of the stores matches,
+// except that it matches != and not in if none of the stores equals.
+package regionquery
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/tikv/pd/pkg/core"
+)
+
+// Cluster provides the regions and the stores to run the queries.
+type Cluster interface {
+	GetStore(storeID uint64) *core.StoreInfo
+	GetRegion(regionID uint64) *core.RegionInfo
+	GetRegions() []*core.RegionInfo
+	GetStoreRegions(storeID uint64) []*core.RegionInfo
+	ScanRegionsWithIterator(startKey []byte, iterator func(region *core.RegionInfo) bool)
+}
+
+// Labeler provides the labels of the regions.
+type Labeler interface {
+	GetRegionLabel(region *core.RegionInfo, key string) string
+}
+
+// Query is a parsed query.
+type Query struct {
+	expr    expr
+	orderBy *field
+	desc    bool
+	limit   int
+}
+
+// Parse parses the query. An empty query selects all the regions.
+func Parse(text string) (*Query, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	return p.parseQuery()
+}
+
+// Run returns the regions which match the query. The labeler can be nil, and then no region
+// has a region label.
+func (q *Query) Run(cluster Cluster, labeler Labeler) []*core.RegionInfo {
+	ctx := &evalContext{cluster: cluster, labeler: labeler}
+	orderBy, desc := q.orderBy, q.desc
+	if orderBy == nil {
+		orderBy, desc = fields["id"], false
+	}
+	type item struct {
+		region *core.RegionInfo
+		key    []value
+	}
+	var items []item
+	q.scan(cluster, func(region *core.RegionInfo) bool {
+		if q.expr == nil || q.expr.eval(ctx, region) {
+			items = append(items, item{region: region, key: orderBy.get(ctx, region)})
+		}
+		return true
+	})
+	// The regions without the value of the field are put in the end, and the ties are
+	// broken by the region ID to make the order deterministic.
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i].key, items[j].key
+		if len(a) == 0 || len(b) == 0 {
+			if len(a) != len(b) {
+				return len(a) > 0
+			}
+		} else if c := compareValues(orderBy.kind, a[0], b[0]); c != 0 {
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return items[i].region.GetID() < items[j].region.GetID()
+	})
+	regions := make([]*core.RegionInfo, 0, len(items))
+	for _, item := range items {
+		regions = append(regions, item.region)
+	}
+	if q.limit > 0 && len(regions) > q.limit {
+		regions = regions[:q.limit]
+	}
+	return regions
+}
+
+// scan iterates the candidates of the regions which may match the query. It uses the
+// indexes of the cluster if the query requires the region ID, the store or the range of the
+// start key. Otherwise, all the regions are scanned.
+func (q *Query) scan(cluster Cluster, iterator func(region *core.RegionInfo) bool) {
+	conditions := []expr{q.expr}
+	if and, ok := q.expr.(andExpr); ok {
+		conditions = and
+	}
+	var (
+		ids, storeIDs      []value
+		startKey, endKey   []byte
+		hasEndKey, inclEnd bool
+	)
+	for _, cond := range conditions {
+		e, ok := cond.(*compareExpr)
+		if !ok {
+			continue
+		}
+		switch e.field.name {
+		case "id":
+			if e.op == opEQ || e.op == opIn {
+				ids = e.values
+			}
+		case "store", "leader.store":
+			if e.op == opEQ || e.op == opIn {
+				storeIDs = e.values
+			}
+		case "start_key":
+			key := []byte(e.values[0].str)
+			switch e.op {
+			case opEQ:
+				startKey, endKey, hasEndKey, inclEnd = key, key, true, true
+			case opGT, opGE:
+				if bytes.Compare(key, startKey) > 0 {
+					startKey = key
+				}
+			case opLT, opLE:
+				if !hasEndKey || bytes.Compare(key, endKey) < 0 {
+					endKey, hasEndKey, inclEnd = key, true, e.op == opLE
+				}
+			}
+		}
+	}
+	switch {
+	case ids != nil:
+		seen := make(map[uint64]struct{}, len(ids))
+		for _, id := range ids {
+			if _, ok := seen[uint64(id.num)]; ok {
+				continue
+			}
+			seen[uint64(id.num)] = struct{}{}
+			if region := cluster.GetRegion(uint64(id.num)); region != nil && !iterator(region) {
+				return
+			}
+		}
+	case storeIDs != nil:
+		seen := make(map[uint64]struct{})
+		for _, storeID := range storeIDs {
+			for _, region := range cluster.GetStoreRegions(uint64(storeID.num)) {
+				if _, ok := seen[region.GetID()]; ok {
+					continue
+				}
+				seen[region.GetID()] = struct{}{}
+				if !iterator(region) {
+					return
+				}
+			}
+		}
+	case startKey != nil || hasEndKey:
+		cluster.ScanRegionsWithIterator(startKey, func(region *core.RegionInfo) bool {
+			if hasEndKey {
+				c := bytes.Compare(region.GetStartKey(), endKey)
+				if c > 0 || (c == 0 && !inclEnd) {
+					return false
+				}
+			}
+			return iterator(region)
+		})
+	default:
+		for _, region := range cluster.GetRegions() {
+			if !iterator(region) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package regionquery
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+)
+
+type testCluster struct {
+	*core.BasicCluster
+	scanned int
+}
+
+func (c *testCluster) GetRegions() []*core.RegionInfo {
+	regions := c.BasicCluster.GetRegions()
+	c.scanned += len(regions)
+	return regions
+}
+
+func (c *testCluster) ScanRegionsWithIterator(startKey []byte, iterator func(region *core.RegionInfo) bool) {
+	c.ScanRangeWithIterator(startKey, func(region *core.RegionInfo) bool {
+		c.scanned++
+		return iterator(region)
+	})
+}
+
+type testLabeler map[uint64]string
+
+func (l testLabeler) GetRegionLabel(region *core.RegionInfo, key string) string {
+	if key != "env" {
+		return ""
+	}
+	return l[region.GetID()]
+}
+
+func newTestCluster() *testCluster {
+	c := &testCluster{BasicCluster: core.NewBasicCluster()}
+	for id, zone := range map[uint64]string{1: "z1", 2: "z1", 3: "z2", 4: "z3"} {
+		c.PutStore(core.NewStoreInfo(&metapb.Store{Id: id, Labels: []*metapb.StoreLabel{{Key: "zone", Value: zone}}}))
+	}
+	// Region i is [k{i}, k{i+1}), its leader is on store i%4+1 and a follower is on the next
+	// store. The size of region i is i*32MiB.
+	for i := uint64(1); i <= 8; i++ {
+		leader := &metapb.Peer{Id: i * 10, StoreId: i%4 + 1}
+		follower := &metapb.Peer{Id: i*10 + 1, StoreId: (i+1)%4 + 1}
+		opts := []core.RegionCreateOption{
+			core.SetApproximateSize(int64(i * 32)),
+			core.SetWrittenBytes(i * 1000),
+		}
+		if i%3 == 0 {
+			opts = append(opts, core.WithPendingPeers([]*metapb.Peer{follower}))
+		}
+		c.PutRegion(core.NewRegionInfo(&metapb.Region{
+			Id:          i,
+			StartKey:    []byte(fmt.Sprintf("k%d", i)),
+			EndKey:      []byte(fmt.Sprintf("k%d", i+1)),
+			Peers:       []*metapb.Peer{leader, follower},
+			RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: i},
+		}, leader, opts...))
+	}
+	return c
+}
+
+func regionIDs(regions []*core.RegionInfo) []uint64 {
+	ids := make([]uint64, 0, len(regions))
+	for _, region := range regions {
+		ids = append(ids, region.GetID())
+	}
+	return ids
+}
+
+func TestQuery(t *testing.T) {
+	re := require.New(t)
+	c := newTestCluster()
+	labeler := testLabeler{2: "prod", 5: "prod", 6: "test"}
+	testCases := []struct {
+		query    string
+		expected []uint64
+		// scanned is the number of the scanned regions, 0 means not checked.
+		scanned int
+	}{
+		{"", []uint64{1, 2, 3, 4, 5, 6, 7, 8}, 8},
+		{"size > 96MiB", []uint64{4, 5, 6, 7, 8}, 0},
+		{"size >= 96MiB and size < 192MiB", []uint64{3, 4, 5}, 0},
+		{"id = 3", []uint64{3}, 0},
+		{"id in (3, 5, 100)", []uint64{3, 5}, 0},
+		{"id != 3 and id not in (1, 2)", []uint64{4, 5, 6, 7, 8}, 0},
+		{"store = 1", []uint64{3, 4, 7, 8}, 0},
+		{"store in (1, 4)", []uint64{2, 3, 4, 6, 7, 8}, 0},
+		{"store != 1", []uint64{1, 2, 5, 6}, 0},
+		{"leader.store = 1", []uint64{4, 8}, 0},
+		{"leader.store.label.zone = \"z1\"", []uint64{1, 4, 5, 8}, 0},
+		{"store.label.zone = 'z3'", []uint64{2, 3, 6, 7}, 0},
+		{"leader.store.label.zone in ('z2', 'z3') and not store.label.zone = 'z1'", []uint64{2, 6}, 0},
+		{"pending_peers > 0", []uint64{3, 6}, 0},
+		{"size > 96MiB and store in (1,4) and leader.store.label.zone = \"z1\"", []uint64{4, 8}, 0},
+		{"label.env = 'prod' or version = 6", []uint64{2, 5, 6}, 0},
+		{"(id < 3 or id > 6) and written_bytes >= 2000", []uint64{2, 7, 8}, 0},
+		{"peers = 2 and voters = 2 and learners = 0", []uint64{1, 2, 3, 4, 5, 6, 7, 8}, 0},
+		// The range of the start key is scanned.
+		{"start_key >= '6b33' and start_key < '6b36'", []uint64{3, 4, 5}, 4},
+		{"start_key = '6b35'", []uint64{5}, 2},
+		{"end_key <= '6b33'", []uint64{1, 2}, 8},
+		// The order and the limit.
+		{"order by size desc limit 3", []uint64{8, 7, 6}, 0},
+		{"store = 2 order by written_bytes", []uint64{1, 4, 5, 8}, 0},
+		{"store = 2 ORDER BY written_bytes DESC LIMIT 1", []uint64{8}, 0},
+		{"label.env in ('prod', 'test') order by label.env desc", []uint64{6, 2, 5}, 0},
+		// The ties and the regions without the value are ordered by the region ID.
+		{"order by label.env limit 5", []uint64{2, 5, 6, 1, 3}, 0},
+	}
+	for _, testCase := range testCases {
+		q, err := Parse(testCase.query)
+		re.NoError(err, testCase.query)
+		c.scanned = 0
+		re.Equal(testCase.expected, regionIDs(q.Run(c, labeler)), testCase.query)
+		if testCase.scanned > 0 {
+			re.Equal(testCase.scanned, c.scanned, testCase.query)
+		}
+	}
+
+	// The label fields don't match without the labeler.
+	q, err := Parse("label.env = 'prod'")
+	re.NoError(err)
+	re.Empty(q.Run(c, nil))
+}
+
+func TestParseError(t *testing.T) {
+	re := require.New(t)
+	testCases := []struct {
+		query string
+		err   string
+	}{
+		{"size >", "invalid value end of query of size at 6"},
+		{"size > 'a'", `invalid value "a" of size at 7`},
+		{"unknown = 1", "unknown field unknown at 0"},
+		{"id = 1 and", "unexpected end of query at 10"},
+		{"id = 1 id = 2", `unexpected "id" at 7`},
+		{"id ! 1", "unexpected '!' at 3"},
+		{"id in (1, 2", "unexpected end of query at 11"},
+		{"(id = 1", "unexpected end of query at 7"},
+		{"start_key = 'xyz'", `invalid hex encoded key "xyz" at 12`},
+		{"leader.store.label.zone = \"z1", "unterminated string at 26"},
+		{"size > 96XiB", `invalid number "96XiB" at 7`},
+		{"order by store", "can't order by store which has multiple values"},
+		{"limit 0", "invalid limit 0 at 6"},
+		{"id = 1 limit 1.5", "invalid limit 1.5 at 13"},
+		{"order size", `unexpected "size" at 6`},
+	}
+	for _, testCase := range testCases {
+		_, err := Parse(testCase.query)
+		re.Error(err, testCase.query)
+		re.Equal(testCase.err, err.Error(), testCase.query)
+	}
+}
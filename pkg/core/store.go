@@ -45,6 +45,38 @@ const (
 	EngineTiKV = "tikv"
 )
 
+// StoreHealthState is the health state of a store detected by the store health scheduler.
+type StoreHealthState int
+
+const (
+	// StoreHealthy means the store is healthy, it's the default state.
+	StoreHealthy StoreHealthState = iota
+	// StoreSuspect means the store shows some unhealthy signals, no more peers should be added to it.
+	StoreSuspect
+	// StoreQuarantined means the store is confirmed unhealthy, its leaders should be evicted
+	// and no more peers or leaders should be added to it.
+	StoreQuarantined
+	// StoreRecovering means the store has been healthy for a while after being quarantined,
+	// peers can be added to it again, and it takes the leaders back gradually with a stepped
+	// leader weight until it becomes healthy.
+	StoreRecovering
+)
+
+var storeHealthStateNames = [...]string{
+	StoreHealthy:     "healthy",
+	StoreSuspect:     "suspect",
+	StoreQuarantined: "quarantined",
+	StoreRecovering:  "recovering",
+}
+
+// String implements fmt.Stringer interface.
+func (s StoreHealthState) String() string {
+	if s >= 0 && int(s) < len(storeHealthStateNames) {
+		return storeHealthStateNames[s]
+	}
+	return "unknown"
+}
+
 // StoreInfo contains information about a store.
 // NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
 type StoreInfo struct {
@@ -53,6 +85,9 @@ type StoreInfo struct {
 	pauseLeaderTransfer bool // not allow to be used as source or target of transfer leader
 	slowStoreEvicted    bool // this store has been evicted as a slow store, should not transfer leader to it
 	slowTrendEvicted    bool // this store has been evicted as a slow store by trend, should not transfer leader to it
+	healthState         StoreHealthState
+	healthSignals       []string
+	healthLeaderRatio   float64 // the ratio applied to the leader weight when the store is recovering
 	leaderCount         int
 	regionCount         int
 	witnessCount        int
@@ -136,6 +171,30 @@ func (s *StoreInfo) IsEvictedAsSlowTrend() bool {
 	return s.slowTrendEvicted
 }
 
+// GetHealthState returns the health state of the store detected by the store health scheduler.
+func (s *StoreInfo) GetHealthState() StoreHealthState {
+	return s.healthState
+}
+
+// GetHealthSignals returns the unhealthy signals of the store detected by the store health scheduler.
+func (s *StoreInfo) GetHealthSignals() []string {
+	return s.healthSignals
+}
+
+// GetHealthLeaderRatio returns the ratio applied to the leader weight of the store.
+// It's always 1 unless the store is recovering.
+func (s *StoreInfo) GetHealthLeaderRatio() float64 {
+	if s.healthState != StoreRecovering || s.healthLeaderRatio <= 0 || s.healthLeaderRatio > 1 {
+		return 1
+	}
+	return s.healthLeaderRatio
+}
+
+// getEffectiveLeaderWeight returns the leader weight scaled by the health leader ratio.
+func (s *StoreInfo) getEffectiveLeaderWeight() float64 {
+	return s.GetLeaderWeight() * s.GetHealthLeaderRatio()
+}
+
 // IsAvailable returns if the store bucket of limitation is available
 func (s *StoreInfo) IsAvailable(limitType storelimit.Type) bool {
 	s.mu.RLock()
@@ -313,9 +372,9 @@ const maxScore = 1024 * 1024 * 1024
 func (s *StoreInfo) LeaderScore(policy constant.SchedulePolicy, delta int64) float64 {
 	switch policy {
 	case constant.BySize:
-		return float64(s.GetLeaderSize()+delta) / math.Max(s.GetLeaderWeight(), minWeight)
+		return float64(s.GetLeaderSize()+delta) / math.Max(s.getEffectiveLeaderWeight(), minWeight)
 	case constant.ByCount:
-		return float64(int64(s.GetLeaderCount())+delta) / math.Max(s.GetLeaderWeight(), minWeight)
+		return float64(int64(s.GetLeaderCount())+delta) / math.Max(s.getEffectiveLeaderWeight(), minWeight)
 	default:
 		return 0
 	}
@@ -475,7 +534,7 @@ func (s *StoreInfo) ResourceSize(kind constant.ResourceKind) int64 {
 func (s *StoreInfo) ResourceWeight(kind constant.ResourceKind) float64 {
 	switch kind {
 	case constant.LeaderKind:
-		leaderWeight := s.GetLeaderWeight()
+		leaderWeight := s.getEffectiveLeaderWeight()
 		if leaderWeight <= 0 {
 			return minWeight
 		}
@@ -701,6 +760,17 @@ func (s *StoresInfo) SlowTrendRecovered(storeID uint64) {
 	s.stores[storeID] = store.Clone(SlowTrendRecovered())
 }
 
+// SetStoreHealthState sets the health state, the unhealthy signals and the leader ratio of a store.
+func (s *StoresInfo) SetStoreHealthState(storeID uint64, state StoreHealthState, signals []string, leaderRatio float64) {
+	store, ok := s.stores[storeID]
+	if !ok {
+		log.Warn("try to set a store's health state, but it is not found. It may be cleanup",
+			zap.Uint64("store-id", storeID))
+		return
+	}
+	s.stores[storeID] = store.Clone(SetHealthState(state, signals, leaderRatio))
+}
+
 // ResetStoreLimit resets the limit for a specific store.
 func (s *StoresInfo) ResetStoreLimit(storeID uint64, limitType storelimit.Type, ratePerSec ...float64) {
 	if store, ok := s.stores[storeID]; ok {
@@ -151,6 +151,15 @@ func SlowStoreRecovered() StoreCreateOption {
 	}
 }
 
+// SetHealthState sets the health state, the unhealthy signals and the leader ratio for the store.
+func SetHealthState(state StoreHealthState, signals []string, leaderRatio float64) StoreCreateOption {
+	return func(store *StoreInfo) {
+		store.healthState = state
+		store.healthSignals = signals
+		store.healthLeaderRatio = leaderRatio
+	}
+}
+
 // SetLeaderCount sets the leader count for the store.
 func SetLeaderCount(leaderCount int) StoreCreateOption {
 	return func(store *StoreInfo) {
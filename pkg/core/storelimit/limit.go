@@ -38,6 +38,8 @@ type StoreLimit interface {
 	Available(cost int64, typ Type, level constant.PriorityLevel) bool
 	// Take takes the cost of the operator, it returns false if the store can't accept any operators.
 	Take(count int64, typ Type, level constant.PriorityLevel) bool
+	// Refund returns the cost taken by the operator whose steps won't be executed.
+	Refund(cost int64, typ Type)
 	// Reset resets the store limit
 	Reset(rate float64, typ Type)
 }
@@ -33,6 +33,16 @@ func TestStoreLimit(t *testing.T) {
 	re.False(limit.Available(influence, AddPeer, constant.Low))
 	re.False(limit.Take(influence, AddPeer, constant.Low))
 
+	// The refunded cost can be taken again, but it can't exceed the capacity.
+	limit.Refund(influence, AddPeer)
+	re.True(limit.Available(influence, AddPeer, constant.Low))
+	re.False(limit.Available(2*influence, AddPeer, constant.Low))
+	re.True(limit.Take(influence, AddPeer, constant.Low))
+	re.False(limit.Available(influence, AddPeer, constant.Low))
+	limit.Refund(2*influence*rate, AddPeer)
+	re.True(limit.Take(influence*rate, AddPeer, constant.Low))
+	re.False(limit.Available(influence, AddPeer, constant.Low))
+
 	limit.Reset(0, AddPeer)
 	re.True(limit.Available(influence, AddPeer, constant.Low))
 	re.True(limit.Take(influence, AddPeer, constant.Low))
@@ -108,3 +118,27 @@ func TestWindow(t *testing.T) {
 	re.EqualValues(s.ack(minSnapSize*2), minSnapSize)
 	re.EqualValues(s.getUsed(), 0)
 }
+
+func TestMovementBudget(t *testing.T) {
+	re := require.New(t)
+	b := NewMovementBudget()
+	// No limit.
+	re.True(b.Available(map[string]int64{"z1": 1 << 20}))
+
+	b.Reset(100, 60)
+	re.True(b.Available(map[string]int64{"z1": 50, "": 50}))
+	b.Take(map[string]int64{"z1": 50, "": 10})
+	// The zone budget is exceeded.
+	re.False(b.Available(map[string]int64{"z1": 20}))
+	re.True(b.Available(map[string]int64{"z2": 20}))
+	b.Take(map[string]int64{"z2": 30})
+	// The cluster budget is exceeded.
+	re.False(b.Available(map[string]int64{"z2": 20}))
+	re.False(b.Available(map[string]int64{"": 20}))
+
+	// The budget is refilled after reset.
+	b.Reset(200, 0)
+	re.True(b.Available(map[string]int64{"z1": 150}))
+	// The region larger than the budget can be moved if the budget is full.
+	re.True(b.Available(map[string]int64{"z1": 1000}))
+}
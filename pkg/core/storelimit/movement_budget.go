@@ -0,0 +1,117 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storelimit
+
+import (
+	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// ZoneLabel is the label key of the store to specify the zone of the movement budget.
+const ZoneLabel = "zone"
+
+// MovementBudget limits the size of the data moved across stores in the whole cluster
+// and in each zone. Unlike the store limit, it caps the total movement no matter how
+// many stores are involved. The size is in MB and the limits are in MB per minute.
+type MovementBudget struct {
+	syncutil.Mutex
+	clusterLimit uint64
+	zoneLimit    uint64
+	cluster      *ratelimit.RateLimiter
+	zones        map[string]*ratelimit.RateLimiter
+}
+
+// NewMovementBudget creates a MovementBudget without limit.
+func NewMovementBudget() *MovementBudget {
+	return &MovementBudget{
+		zones: make(map[string]*ratelimit.RateLimiter),
+	}
+}
+
+// Reset resets the limits of the budget, 0 means no limit.
+func (b *MovementBudget) Reset(clusterLimit, zoneLimit uint64) {
+	b.Lock()
+	defer b.Unlock()
+	if b.clusterLimit != clusterLimit {
+		b.clusterLimit = clusterLimit
+		b.cluster = newMovementLimiter(clusterLimit)
+	}
+	if b.zoneLimit != zoneLimit {
+		b.zoneLimit = zoneLimit
+		b.zones = make(map[string]*ratelimit.RateLimiter)
+	}
+}
+
+// Available returns whether the budget is enough for moving the data of the given size
+// into each zone. The data moved into the stores without zone are only counted for the
+// whole cluster.
+func (b *MovementBudget) Available(sizes map[string]int64) bool {
+	b.Lock()
+	defer b.Unlock()
+	var total int64
+	for zone, size := range sizes {
+		total += size
+		if l := b.getZoneLimiterLocked(zone); l != nil && !l.Available(clampCost(size, b.zoneLimit)) {
+			return false
+		}
+	}
+	return b.cluster == nil || b.cluster.Available(clampCost(total, b.clusterLimit))
+}
+
+// Take consumes the budget for moving the data of the given size into each zone.
+func (b *MovementBudget) Take(sizes map[string]int64) {
+	b.Lock()
+	defer b.Unlock()
+	var total int64
+	for zone, size := range sizes {
+		total += size
+		if l := b.getZoneLimiterLocked(zone); l != nil {
+			l.AllowN(clampCost(size, b.zoneLimit))
+		}
+	}
+	if b.cluster != nil {
+		b.cluster.AllowN(clampCost(total, b.clusterLimit))
+	}
+}
+
+func (b *MovementBudget) getZoneLimiterLocked(zone string) *ratelimit.RateLimiter {
+	if zone == "" || b.zoneLimit == 0 {
+		return nil
+	}
+	l, ok := b.zones[zone]
+	if !ok {
+		l = newMovementLimiter(b.zoneLimit)
+		b.zones[zone] = l
+	}
+	return l
+}
+
+// newMovementLimiter creates a limiter which allows moving the data of the size per minute,
+// and the burst is the size of one minute.
+func newMovementLimiter(sizePerMin uint64) *ratelimit.RateLimiter {
+	if sizePerMin == 0 {
+		return nil
+	}
+	return ratelimit.NewRateLimiter(float64(sizePerMin)/60, int(sizePerMin))
+}
+
+// clampCost makes sure that a region larger than the budget of one minute can still be
+// moved when the budget is full.
+func clampCost(cost int64, limit uint64) int {
+	if limit > 0 && cost > int64(limit) {
+		return int(limit)
+	}
+	return int(cost)
+}
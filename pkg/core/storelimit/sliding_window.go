@@ -104,6 +104,14 @@ func (s *SlidingWindows) Take(token int64, typ Type, level constant.PriorityLeve
 	return false
 }
 
+// Refund returns the token taken by the operator whose steps won't be executed.
+func (s *SlidingWindows) Refund(token int64, typ Type) {
+	if typ != SendSnapshot {
+		return
+	}
+	s.Ack(token)
+}
+
 // Ack indicates that some executing operator has been finished.
 // The order of refilling windows is from high to low.
 // It will refill the highest window first.
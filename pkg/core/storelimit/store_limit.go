@@ -17,6 +17,7 @@ package storelimit
 import (
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
 const (
@@ -99,6 +100,14 @@ func (l *StoreRateLimit) Take(cost int64, typ Type, _ constant.PriorityLevel) bo
 	return l.limits[typ].Take(cost)
 }
 
+// Refund returns the cost taken by the operator whose steps won't be executed.
+func (l *StoreRateLimit) Refund(cost int64, typ Type) {
+	if typ == SendSnapshot {
+		return
+	}
+	l.limits[typ].Refund(cost)
+}
+
 // Reset resets the rate limit.
 func (l *StoreRateLimit) Reset(rate float64, typ Type) {
 	if typ == SendSnapshot {
@@ -111,6 +120,11 @@ func (l *StoreRateLimit) Reset(rate float64, typ Type) {
 type limit struct {
 	limiter    *ratelimit.RateLimiter
 	ratePerSec float64
+	mu         syncutil.Mutex
+	// refunded is the cost returned by the operators which won't be executed,
+	// it's consumed before the tokens of the limiter.
+	refunded int64
+	capacity int64
 }
 
 // Reset resets the rate limit.
@@ -118,6 +132,8 @@ func (l *limit) Reset(ratePerSec float64) {
 	if l.ratePerSec == ratePerSec {
 		return
 	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	capacity := int64(influence)
 	rate := ratePerSec
 	// unlimited
@@ -131,22 +147,49 @@ func (l *limit) Reset(ratePerSec float64) {
 	}
 	l.limiter = ratelimit.NewRateLimiter(ratePerSec, int(capacity))
 	l.ratePerSec = rate
+	l.capacity = capacity
+	l.refunded = 0
 }
 
 // Available returns the number of available tokens
 // It returns true if the rate per second is zero.
 func (l *limit) Available(n int64) bool {
-	if l.ratePerSec == 0 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ratePerSec == 0 || l.refunded >= n {
 		return true
 	}
 	// Unlimited = 1e8, so can convert int64 to int
-	return l.limiter.Available(int(n))
+	return l.limiter.Available(int(n - l.refunded))
 }
 
 // Take takes count tokens from the bucket without blocking.
 func (l *limit) Take(count int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	if l.ratePerSec == 0 {
 		return true
 	}
-	return l.limiter.AllowN(int(count))
+	if l.refunded >= count {
+		l.refunded -= count
+		return true
+	}
+	if !l.limiter.AllowN(int(count - l.refunded)) {
+		return false
+	}
+	l.refunded = 0
+	return true
+}
+
+// Refund returns count tokens to the bucket, which can't exceed the capacity.
+func (l *limit) Refund(count int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ratePerSec == 0 {
+		return
+	}
+	l.refunded += count
+	if l.refunded > l.capacity {
+		l.refunded = l.capacity
+	}
 }
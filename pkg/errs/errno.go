@@ -39,6 +39,7 @@ var (
 	ErrGenerateTimestamp  = errors.Normalize("generate timestamp failed, %s", errors.RFCCodeText("PD:tso:ErrGenerateTimestamp"))
 	ErrLogicOverflow      = errors.Normalize("logic part overflow", errors.RFCCodeText("PD:tso:ErrLogicOverflow"))
 	ErrProxyTSOTimeout    = errors.Normalize("proxy tso timeout", errors.RFCCodeText("PD:tso:ErrProxyTSOTimeout"))
+	ErrKeyspaceTransfer   = errors.Normalize("keyspace %d is being transferred to keyspace group %d", errors.RFCCodeText("PD:tso:ErrKeyspaceTransfer"))
 )
 
 // member errors
@@ -120,11 +121,28 @@ var (
 	ErrRegionRuleNotFound = errors.Normalize("region label rule not found for id %s", errors.RFCCodeText("PD:region:ErrRegionRuleNotFound"))
 )
 
+// rbac errors
+var (
+	ErrRoleNotFound     = errors.Normalize("role %s not found", errors.RFCCodeText("PD:rbac:ErrRoleNotFound"))
+	ErrRoleBuiltIn      = errors.Normalize("role %s is built-in and can't be changed", errors.RFCCodeText("PD:rbac:ErrRoleBuiltIn"))
+	ErrRoleInUse        = errors.Normalize("role %s is still used by %s", errors.RFCCodeText("PD:rbac:ErrRoleInUse"))
+	ErrBindingNotFound  = errors.Normalize("binding %s not found", errors.RFCCodeText("PD:rbac:ErrBindingNotFound"))
+	ErrLastAdminBinding = errors.Normalize("binding %s is the last admin and can't be removed while RBAC is enabled", errors.RFCCodeText("PD:rbac:ErrLastAdminBinding"))
+	ErrPermissionDenied = errors.Normalize("permission denied for %s", errors.RFCCodeText("PD:rbac:ErrPermissionDenied"))
+)
+
+// event errors
+var (
+	ErrEventResumeToken = errors.Normalize("the events after the resume token %s are not available", errors.RFCCodeText("PD:event:ErrEventResumeToken"))
+)
+
 // cluster errors
 var (
-	ErrNotBootstrapped = errors.Normalize("TiKV cluster not bootstrapped, please start TiKV first", errors.RFCCodeText("PD:cluster:ErrNotBootstrapped"))
-	ErrStoreIsUp       = errors.Normalize("store is still up, please remove store gracefully", errors.RFCCodeText("PD:cluster:ErrStoreIsUp"))
-	ErrInvalidStoreID  = errors.Normalize("invalid store id %d, not found", errors.RFCCodeText("PD:cluster:ErrInvalidStoreID"))
+	ErrNotBootstrapped    = errors.Normalize("TiKV cluster not bootstrapped, please start TiKV first", errors.RFCCodeText("PD:cluster:ErrNotBootstrapped"))
+	ErrStoreIsUp          = errors.Normalize("store is still up, please remove store gracefully", errors.RFCCodeText("PD:cluster:ErrStoreIsUp"))
+	ErrInvalidStoreID     = errors.Normalize("invalid store id %d, not found", errors.RFCCodeText("PD:cluster:ErrInvalidStoreID"))
+	ErrStoreDrainNotFound = errors.Normalize("store %v is not being drained", errors.RFCCodeText("PD:cluster:ErrStoreDrainNotFound"))
+	ErrStoreDrainExisted  = errors.Normalize("store %v is already being drained", errors.RFCCodeText("PD:cluster:ErrStoreDrainExisted"))
 )
 
 // versioninfo errors
@@ -189,6 +207,7 @@ var (
 	ErrGRPCRecv         = errors.Normalize("receive response error", errors.RFCCodeText("PD:grpc:ErrGRPCRecv"))
 	ErrGRPCCloseSend    = errors.Normalize("close send error", errors.RFCCodeText("PD:grpc:ErrGRPCCloseSend"))
 	ErrGRPCCreateStream = errors.Normalize("create stream error", errors.RFCCodeText("PD:grpc:ErrGRPCCreateStream"))
+	ErrGRPCRateLimit    = errors.Normalize("rate limit exceeded for %s", errors.RFCCodeText("PD:grpc:ErrGRPCRateLimit"))
 )
 
 // proto errors
@@ -0,0 +1,304 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/slice"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// Type is the type of the event.
+type Type string
+
+// The types of the events.
+const (
+	TypeStore         Type = "store"
+	TypeOperator      Type = "operator"
+	TypeScheduler     Type = "scheduler"
+	TypeLeader        Type = "leader"
+	TypePlacementRule Type = "placement-rule"
+	TypeLabelRule     Type = "label-rule"
+	TypeKeyspace      Type = "keyspace"
+	// TypeAlert is the type of the critical conditions of the cluster, the action is the name of the alert.
+	TypeAlert Type = "alert"
+)
+
+// Types is all the types of the events.
+var Types = []Type{
+	TypeStore,
+	TypeOperator,
+	TypeScheduler,
+	TypeLeader,
+	TypePlacementRule,
+	TypeLabelRule,
+	TypeKeyspace,
+	TypeAlert,
+}
+
+// The names of the alerts.
+const (
+	AlertRegionQuorumLost      = "region-quorum-lost"
+	AlertStoreDown             = "store-down"
+	AlertGCSafePointStalled    = "gc-safepoint-stalled"
+	AlertDRAutoSyncStateChange = "dr-auto-sync-state-change"
+	AlertTSOFallback           = "tso-fallback"
+	AlertSlowStoreEvicted      = "slow-store-evicted"
+)
+
+// Alerts is the names of all the alerts.
+var Alerts = []string{
+	AlertRegionQuorumLost,
+	AlertStoreDown,
+	AlertGCSafePointStalled,
+	AlertDRAutoSyncStateChange,
+	AlertTSOFallback,
+	AlertSlowStoreEvicted,
+}
+
+const (
+	// DefaultBacklogSize is the default number of the recent events kept to resume the subscriptions.
+	DefaultBacklogSize = 4096
+	// subscriberBufferSize is the number of the events buffered for a subscriber, the subscriber is
+	// closed if it's too slow to receive the events.
+	subscriberBufferSize = 256
+)
+
+// Event is a state change of the cluster.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type Event struct {
+	// ID is the resume token of the event, the subscription can be resumed after it.
+	ID      string                 `json:"id"`
+	Time    time.Time              `json:"time"`
+	Type    Type                   `json:"type"`
+	Action  string                 `json:"action"`
+	Subject string                 `json:"subject"`
+	Detail  map[string]interface{} `json:"detail,omitempty"`
+
+	seq uint64
+}
+
+// Filter is used to subscribe the events. The empty field matches all.
+type Filter struct {
+	Types   []Type
+	Actions []string
+	Subject string
+}
+
+// Match returns whether the event matches the filter.
+func (f *Filter) Match(e *Event) bool {
+	return (len(f.Types) == 0 || slice.Contains(f.Types, e.Type)) &&
+		(len(f.Actions) == 0 || slice.Contains(f.Actions, e.Action)) &&
+		(f.Subject == "" || f.Subject == e.Subject)
+}
+
+// Hub publishes the events to the subscribers and keeps the recent events to resume the
+// subscriptions. The events are kept in memory, so the resume tokens of a hub can't be used
+// after the server restarts. All the methods are safe to be called with a nil hub, which
+// publishes nothing.
+type Hub struct {
+	mu syncutil.RWMutex
+	// epoch distinguishes the resume tokens of the different hubs, it's renewed with a unique ID
+	// of the cluster when the server becomes the leader. The seq isn't reset in the new epoch, so
+	// the tokens of all the epochs of the hub can be resumed as long as the events are kept.
+	epoch string
+	// epochs are the epochs which still have the events in the backlog, the current one is the last.
+	epochs []epochRange
+	seq    uint64
+	// backlog is a ring buffer of the recent events, head is the index of the oldest one.
+	backlog     []*Event
+	head        int
+	subscribers map[*Subscriber]struct{}
+}
+
+// epochRange is an epoch and the seq before its first event.
+type epochRange struct {
+	name     string
+	startSeq uint64
+}
+
+// NewHub creates a Hub which keeps the recent backlogSize events.
+func NewHub(backlogSize int) *Hub {
+	epoch := strconv.FormatInt(time.Now().UnixNano(), 36)
+	return &Hub{
+		epoch:       epoch,
+		epochs:      []epochRange{{name: epoch}},
+		backlog:     make([]*Event, 0, backlogSize),
+		subscribers: make(map[*Subscriber]struct{}),
+	}
+}
+
+// RenewEpoch starts a new epoch of the resume tokens. The epoch should be unique in the cluster
+// like the term of the leader, so the tokens issued by the other members or by the server before it
+// restarts are rejected instead of being resumed from the wrong events.
+func (h *Hub) RenewEpoch(epoch uint64) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.epoch = strconv.FormatUint(epoch, 10)
+	h.epochs = append(h.epochs, epochRange{name: h.epoch, startSeq: h.seq})
+	h.pruneEpochs()
+}
+
+// pruneEpochs removes the previous epochs which have no event can be resumed after, so the epochs
+// are bounded by the backlog size no matter how many times the epoch is renewed.
+func (h *Hub) pruneEpochs() {
+	// The oldest token which can be resumed is the one before the oldest kept event.
+	minSeq := h.seq
+	if len(h.backlog) > 0 {
+		minSeq = h.backlogAt(0).seq - 1
+	}
+	kept := h.epochs[:0]
+	for i, e := range h.epochs {
+		if i < len(h.epochs)-1 {
+			// The last event of the epoch is the one before the next epoch starts.
+			endSeq := h.epochs[i+1].startSeq
+			if endSeq == e.startSeq || endSeq < minSeq {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+	h.epochs = kept
+}
+
+// Subscriber receives the events matching its filter.
+type Subscriber struct {
+	filter Filter
+	ch     chan *Event
+}
+
+// Events returns the channel of the events, which is closed when the subscriber is unsubscribed
+// or it's too slow to receive the events.
+func (s *Subscriber) Events() <-chan *Event {
+	return s.ch
+}
+
+// Publish publishes an event to the subscribers.
+func (h *Hub) Publish(typ Type, action, subject string, detail map[string]interface{}) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq++
+	e := &Event{
+		ID:      fmt.Sprintf("%s-%d", h.epoch, h.seq),
+		Time:    time.Now(),
+		Type:    typ,
+		Action:  action,
+		Subject: subject,
+		Detail:  detail,
+		seq:     h.seq,
+	}
+	if len(h.backlog) < cap(h.backlog) {
+		h.backlog = append(h.backlog, e)
+	} else if len(h.backlog) > 0 {
+		h.backlog[h.head] = e
+		h.head = (h.head + 1) % len(h.backlog)
+	}
+	for s := range h.subscribers {
+		if !s.filter.Match(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			// The subscriber can resume from the last received event after it's closed.
+			close(s.ch)
+			delete(h.subscribers, s)
+		}
+	}
+}
+
+// Subscribe subscribes the events matching the filter. If the resume token isn't empty, the kept
+// events after it are sent first, and ErrEventResumeToken is returned if some of them are lost.
+func (h *Hub) Subscribe(filter Filter, resumeToken string) (*Subscriber, error) {
+	if h == nil {
+		return nil, errs.ErrEventResumeToken.FastGenByArgs(resumeToken)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var replay []*Event
+	if resumeToken != "" {
+		seq, ok := h.parseToken(resumeToken)
+		if !ok || seq > h.seq {
+			return nil, errs.ErrEventResumeToken.FastGenByArgs(resumeToken)
+		}
+		// The oldest kept event must be the next one of the token.
+		if seq < h.seq && h.backlogAt(0).seq > seq+1 {
+			return nil, errs.ErrEventResumeToken.FastGenByArgs(resumeToken)
+		}
+		for i := 0; i < len(h.backlog); i++ {
+			if e := h.backlogAt(i); e.seq > seq && filter.Match(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+	s := &Subscriber{filter: filter, ch: make(chan *Event, subscriberBufferSize+len(replay))}
+	for _, e := range replay {
+		s.ch <- e
+	}
+	h.subscribers[s] = struct{}{}
+	return s, nil
+}
+
+// Unsubscribe stops sending the events to the subscriber.
+func (h *Hub) Unsubscribe(s *Subscriber) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		close(s.ch)
+		delete(h.subscribers, s)
+	}
+}
+
+func (h *Hub) backlogAt(i int) *Event {
+	return h.backlog[(h.head+i)%len(h.backlog)]
+}
+
+func (h *Hub) parseToken(token string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(token, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	for i, e := range h.epochs {
+		if e.name != epoch {
+			continue
+		}
+		// The token must be issued in the epoch.
+		endSeq := h.seq
+		if i < len(h.epochs)-1 {
+			endSeq = h.epochs[i+1].startSeq
+		}
+		return n, n > e.startSeq && n <= endSeq
+	}
+	return 0, false
+}
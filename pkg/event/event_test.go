@@ -0,0 +1,196 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/errs"
+)
+
+func receive(re *require.Assertions, s *Subscriber, n int) []*Event {
+	events := make([]*Event, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case e, ok := <-s.Events():
+			re.True(ok)
+			events = append(events, e)
+		default:
+			re.FailNow("missing events", "expected %d, got %d", n, i)
+		}
+	}
+	select {
+	case e := <-s.Events():
+		re.FailNow("unexpected event", "%v", e)
+	default:
+	}
+	return events
+}
+
+func TestFilter(t *testing.T) {
+	re := require.New(t)
+	hub := NewHub(DefaultBacklogSize)
+	all, err := hub.Subscribe(Filter{}, "")
+	re.NoError(err)
+	stores, err := hub.Subscribe(Filter{Types: []Type{TypeStore}, Actions: []string{"offline", "tombstone"}}, "")
+	re.NoError(err)
+	store1, err := hub.Subscribe(Filter{Types: []Type{TypeStore}, Subject: "1"}, "")
+	re.NoError(err)
+
+	hub.Publish(TypeStore, "up", "1", nil)
+	hub.Publish(TypeStore, "offline", "2", map[string]interface{}{"address": "127.0.0.1:20160"})
+	hub.Publish(TypeOperator, "create", "1", nil)
+	hub.Publish(TypeStore, "tombstone", "1", nil)
+
+	re.Len(receive(re, all, 4), 4)
+	events := receive(re, stores, 2)
+	re.Equal("2", events[0].Subject)
+	re.Equal("127.0.0.1:20160", events[0].Detail["address"])
+	re.Equal("tombstone", events[1].Action)
+	events = receive(re, store1, 2)
+	re.Equal("up", events[0].Action)
+	re.Equal("tombstone", events[1].Action)
+
+	hub.Unsubscribe(all)
+	_, ok := <-all.Events()
+	re.False(ok)
+	// unsubscribing twice is fine.
+	hub.Unsubscribe(all)
+}
+
+func TestResume(t *testing.T) {
+	re := require.New(t)
+	hub := NewHub(4)
+	for i := 0; i < 3; i++ {
+		hub.Publish(TypeStore, "up", strconv.Itoa(i), nil)
+	}
+	s, err := hub.Subscribe(Filter{}, "")
+	re.NoError(err)
+	events := receive(re, s, 0)
+	re.Empty(events)
+	hub.Publish(TypeStore, "up", "3", nil)
+	token := receive(re, s, 1)[0].ID
+	hub.Unsubscribe(s)
+
+	hub.Publish(TypeStore, "offline", "1", nil)
+	hub.Publish(TypeLeader, "elected", "pd-1", nil)
+	// the events after the token are replayed.
+	s, err = hub.Subscribe(Filter{Types: []Type{TypeStore}}, token)
+	re.NoError(err)
+	events = receive(re, s, 1)
+	re.Equal("offline", events[0].Action)
+	hub.Publish(TypeStore, "tombstone", "1", nil)
+	re.Equal("tombstone", receive(re, s, 1)[0].Action)
+	hub.Unsubscribe(s)
+
+	// resuming from the latest event replays nothing.
+	s, err = hub.Subscribe(Filter{}, events[0].ID)
+	re.NoError(err)
+	re.Len(receive(re, s, 2), 2)
+	hub.Unsubscribe(s)
+
+	// the events after the token are evicted.
+	hub.Publish(TypeStore, "up", "4", nil)
+	hub.Publish(TypeStore, "up", "5", nil)
+	_, err = hub.Subscribe(Filter{}, token)
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+	// the token of the other hub is rejected.
+	_, err = NewHub(4).Subscribe(Filter{}, token)
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+	_, err = hub.Subscribe(Filter{}, "invalid")
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+}
+
+func TestRenewEpoch(t *testing.T) {
+	re := require.New(t)
+	hub := NewHub(4)
+	hub.Publish(TypeStore, "up", "1", nil)
+	s, err := hub.Subscribe(Filter{}, "")
+	re.NoError(err)
+	hub.Publish(TypeStore, "up", "2", nil)
+	token := receive(re, s, 1)[0].ID
+	hub.Unsubscribe(s)
+
+	hub.RenewEpoch(100)
+	hub.Publish(TypeLeader, "elected", "pd-1", nil)
+	// the token of the previous epoch can still be resumed.
+	s, err = hub.Subscribe(Filter{}, token)
+	re.NoError(err)
+	events := receive(re, s, 1)
+	re.Equal("100-3", events[0].ID)
+	hub.Unsubscribe(s)
+
+	// the token of the same epoch issued by another hub is rejected.
+	other := NewHub(4)
+	other.RenewEpoch(101)
+	other.Publish(TypeStore, "up", "1", nil)
+	_, err = hub.Subscribe(Filter{}, "101-1")
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+	_, err = other.Subscribe(Filter{}, events[0].ID)
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+}
+
+func TestEpochsPruned(t *testing.T) {
+	re := require.New(t)
+	hub := NewHub(2)
+	for i := uint64(1); i <= 100; i++ {
+		hub.RenewEpoch(i)
+		hub.Publish(TypeLeader, "elected", "pd-1", nil)
+	}
+	// the epochs are bounded by the kept events.
+	re.Len(hub.epochs, 4)
+	for i := uint64(101); i <= 200; i++ {
+		hub.RenewEpoch(i)
+	}
+	re.Len(hub.epochs, 4)
+	_, err := hub.Subscribe(Filter{}, "97-97")
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+	s, err := hub.Subscribe(Filter{}, "98-98")
+	re.NoError(err)
+	events := receive(re, s, 2)
+	re.Equal("99-99", events[0].ID)
+	re.Equal("100-100", events[1].ID)
+	hub.Unsubscribe(s)
+	// the seq must be issued in the epoch of the token.
+	_, err = hub.Subscribe(Filter{}, "98-99")
+	re.ErrorIs(err, errs.ErrEventResumeToken)
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	re := require.New(t)
+	hub := NewHub(DefaultBacklogSize)
+	s, err := hub.Subscribe(Filter{}, "")
+	re.NoError(err)
+	for i := 0; i <= subscriberBufferSize; i++ {
+		hub.Publish(TypeOperator, "create", strconv.Itoa(i), nil)
+	}
+	// the slow subscriber is closed after receiving the buffered events.
+	var last *Event
+	for e := range s.Events() {
+		last = e
+	}
+	re.Equal(strconv.Itoa(subscriberBufferSize-1), last.Subject)
+	// it can resume from the last received event.
+	s, err = hub.Subscribe(Filter{}, last.ID)
+	re.NoError(err)
+	re.Equal(strconv.Itoa(subscriberBufferSize), receive(re, s, 1)[0].Subject)
+
+	// the nil hub publishes nothing.
+	var nilHub *Hub
+	nilHub.Publish(TypeStore, "up", "1", nil)
+	nilHub.Unsubscribe(s)
+}
@@ -0,0 +1,292 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/slice"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookMaxRetries    = 3
+	defaultWebhookRetryInterval = time.Second
+	defaultWebhookTimeout       = 5 * time.Second
+	// webhookQueueSize is the number of the alerts waiting to be delivered to a webhook, the alerts
+	// exceeding it are written into the dead-letter log directly.
+	webhookQueueSize = 1024
+)
+
+// WebhookConfig is the config of a webhook receiving the alerts.
+type WebhookConfig struct {
+	Name string `toml:"name" json:"name"`
+	URL  string `toml:"url" json:"url"`
+	// Alerts is the names of the alerts sent to the webhook, all the alerts are sent if it's empty.
+	Alerts []string `toml:"alerts" json:"alerts"`
+	// MaxRetries is the max number of the retries after the first delivery fails.
+	MaxRetries int `toml:"max-retries" json:"max-retries"`
+	// RetryInterval is the interval before the first retry, it's doubled for the next retries.
+	RetryInterval typeutil.Duration `toml:"retry-interval" json:"retry-interval"`
+	Timeout       typeutil.Duration `toml:"timeout" json:"timeout"`
+}
+
+// Adjust validates the config and fills the default values.
+func (c *WebhookConfig) Adjust() error {
+	if c.URL == "" {
+		return errors.Errorf("the url of webhook %s is empty", c.Name)
+	}
+	if c.Name == "" {
+		c.Name = c.URL
+	}
+	for _, alert := range c.Alerts {
+		if !slice.Contains(Alerts, alert) {
+			return errors.Errorf("unknown alert %s of webhook %s", alert, c.Name)
+		}
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	} else if c.MaxRetries == 0 {
+		c.MaxRetries = defaultWebhookMaxRetries
+	}
+	if c.RetryInterval.Duration <= 0 {
+		c.RetryInterval = typeutil.NewDuration(defaultWebhookRetryInterval)
+	}
+	if c.Timeout.Duration <= 0 {
+		c.Timeout = typeutil.NewDuration(defaultWebhookTimeout)
+	}
+	return nil
+}
+
+// DeadLetter is an alert which fails to be delivered to a webhook. If the alerts are lost before they
+// are dispatched to the webhooks, the event is empty and the alerts after LastEventID are lost.
+type DeadLetter struct {
+	Time        time.Time `json:"time"`
+	Webhook     string    `json:"webhook"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error"`
+	Event       *Event    `json:"event,omitempty"`
+	LastEventID string    `json:"last-event-id,omitempty"`
+}
+
+// Notifier sends the alerts published to the hub to the webhooks. The alerts which fail to be
+// delivered after the retries are appended to the dead-letter log as JSON lines.
+type Notifier struct {
+	hub            *Hub
+	client         *http.Client
+	webhooks       []*webhook
+	deadLetterPath string
+	deadLetterMu   syncutil.Mutex
+}
+
+type webhook struct {
+	cfg   WebhookConfig
+	queue chan *Event
+}
+
+// NewNotifier creates a Notifier. The configs of the webhooks must have been adjusted.
+func NewNotifier(hub *Hub, webhooks []WebhookConfig, deadLetterPath string) *Notifier {
+	n := &Notifier{
+		hub:            hub,
+		client:         &http.Client{},
+		deadLetterPath: deadLetterPath,
+	}
+	for _, cfg := range webhooks {
+		n.webhooks = append(n.webhooks, &webhook{cfg: cfg, queue: make(chan *Event, webhookQueueSize)})
+	}
+	return n
+}
+
+// Run dispatches the alerts to the webhooks until the context is canceled.
+func (n *Notifier) Run(ctx context.Context) {
+	if n.hub == nil || len(n.webhooks) == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, w := range n.webhooks {
+		wg.Add(1)
+		go func(w *webhook) {
+			defer logutil.LogPanic()
+			defer wg.Done()
+			n.deliverLoop(ctx, w)
+		}(w)
+	}
+	n.dispatchLoop(ctx)
+	wg.Wait()
+}
+
+func (n *Notifier) dispatchLoop(ctx context.Context) {
+	var lastID string
+	for {
+		sub, err := n.hub.Subscribe(Filter{Types: []Type{TypeAlert}}, lastID)
+		if err != nil {
+			n.writeLostDeadLetters(lastID, err)
+			lastID = ""
+			continue
+		}
+		for closed := false; !closed; {
+			select {
+			case <-ctx.Done():
+				n.hub.Unsubscribe(sub)
+				return
+			case e, ok := <-sub.Events():
+				if !ok {
+					// It's too slow to receive the alerts, resume after the last received one.
+					closed = true
+					continue
+				}
+				lastID = e.ID
+				n.dispatch(e)
+			}
+		}
+	}
+}
+
+func (n *Notifier) dispatch(e *Event) {
+	for _, w := range n.webhooks {
+		if len(w.cfg.Alerts) > 0 && !slice.Contains(w.cfg.Alerts, e.Action) {
+			continue
+		}
+		select {
+		case w.queue <- e:
+		default:
+			n.writeDeadLetter(w, e, 0, errors.New("the queue of the webhook is full"))
+		}
+	}
+}
+
+func (n *Notifier) deliverLoop(ctx context.Context, w *webhook) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-w.queue:
+			n.deliver(ctx, w, e)
+		}
+	}
+}
+
+// deliver sends the alert to the webhook and retries with the exponential backoff if it fails.
+func (n *Notifier) deliver(ctx context.Context, w *webhook, e *Event) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		n.writeDeadLetter(w, e, 0, err)
+		return
+	}
+	interval := w.cfg.RetryInterval.Duration
+	attempts := 0
+	for {
+		attempts++
+		if err = n.post(ctx, w, body); err == nil {
+			return
+		}
+		if attempts > w.cfg.MaxRetries {
+			break
+		}
+		log.Warn("failed to send the alert to the webhook, retrying",
+			zap.String("webhook", w.cfg.Name), zap.String("alert", e.Action), zap.Int("attempts", attempts), errs.ZapError(err))
+		select {
+		case <-ctx.Done():
+			n.writeDeadLetter(w, e, attempts, err)
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+	n.writeDeadLetter(w, e, attempts, err)
+}
+
+func (n *Notifier) post(ctx context.Context, w *webhook, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.Timeout.Duration)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (n *Notifier) writeDeadLetter(w *webhook, e *Event, attempts int, cause error) {
+	log.Error("failed to send the alert to the webhook",
+		zap.String("webhook", w.cfg.Name), zap.String("alert", e.Action), zap.String("subject", e.Subject),
+		zap.Int("attempts", attempts), errs.ZapError(cause))
+	n.appendDeadLetter(&DeadLetter{
+		Time:     time.Now(),
+		Webhook:  w.cfg.Name,
+		Attempts: attempts,
+		Error:    cause.Error(),
+		Event:    e,
+	})
+}
+
+// writeLostDeadLetters records the alerts which are evicted from the hub before they are dispatched,
+// e.g. the dispatcher is too slow to receive them, so they are lost for all the webhooks.
+func (n *Notifier) writeLostDeadLetters(lastID string, cause error) {
+	log.Error("some alerts are lost before they are sent to the webhooks", zap.String("last-event-id", lastID), errs.ZapError(cause))
+	for _, w := range n.webhooks {
+		n.appendDeadLetter(&DeadLetter{
+			Time:        time.Now(),
+			Webhook:     w.cfg.Name,
+			Error:       cause.Error(),
+			LastEventID: lastID,
+		})
+	}
+}
+
+func (n *Notifier) appendDeadLetter(deadLetter *DeadLetter) {
+	if n.deadLetterPath == "" {
+		return
+	}
+	data, err := json.Marshal(deadLetter)
+	if err != nil {
+		return
+	}
+	n.deadLetterMu.Lock()
+	defer n.deadLetterMu.Unlock()
+	if err = os.MkdirAll(filepath.Dir(n.deadLetterPath), 0o700); err == nil {
+		var f *os.File
+		if f, err = os.OpenFile(n.deadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600); err == nil {
+			_, err = f.Write(append(data, '\n'))
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if err != nil {
+		log.Error("failed to write the dead-letter log", zap.String("path", n.deadLetterPath), errs.ZapError(err))
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+type testReceiver struct {
+	mu sync.Mutex
+	// failures is the number of the requests to be failed before succeeding.
+	failures int
+	requests int
+	received []*Event
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests++
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	e := &Event{}
+	if err := json.NewDecoder(req.Body).Decode(e); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.received = append(r.received, e)
+}
+
+func (r *testReceiver) status() (requests int, received []*Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests, append([]*Event(nil), r.received...)
+}
+
+func readDeadLetters(re *require.Assertions, path string) []*DeadLetter {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var deadLetters []*DeadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		l := &DeadLetter{}
+		re.NoError(json.Unmarshal(scanner.Bytes(), l))
+		deadLetters = append(deadLetters, l)
+	}
+	return deadLetters
+}
+
+func newTestWebhookConfig(re *require.Assertions, name, url string, alerts ...string) WebhookConfig {
+	cfg := WebhookConfig{Name: name, URL: url, Alerts: alerts, MaxRetries: 2}
+	re.NoError(cfg.Adjust())
+	cfg.RetryInterval = typeutil.NewDuration(10 * time.Millisecond)
+	return cfg
+}
+
+func TestWebhookConfig(t *testing.T) {
+	re := require.New(t)
+	cfg := WebhookConfig{URL: "http://127.0.0.1:8080"}
+	re.NoError(cfg.Adjust())
+	re.Equal(cfg.URL, cfg.Name)
+	re.Equal(defaultWebhookMaxRetries, cfg.MaxRetries)
+	re.Equal(defaultWebhookTimeout, cfg.Timeout.Duration)
+	cfg = WebhookConfig{URL: "http://127.0.0.1:8080", Alerts: []string{"unknown"}}
+	re.Error(cfg.Adjust())
+	cfg = WebhookConfig{Name: "empty"}
+	re.Error(cfg.Adjust())
+}
+
+func TestNotifier(t *testing.T) {
+	re := require.New(t)
+	// the first two requests fail, and they are retried.
+	flaky := &testReceiver{failures: 2}
+	flakyServer := httptest.NewServer(flaky)
+	defer flakyServer.Close()
+	storeDown := &testReceiver{}
+	storeDownServer := httptest.NewServer(storeDown)
+	defer storeDownServer.Close()
+	// the requests always fail, and the alerts are written into the dead-letter log.
+	broken := &testReceiver{failures: 100}
+	brokenServer := httptest.NewServer(broken)
+	defer brokenServer.Close()
+
+	hub := NewHub(DefaultBacklogSize)
+	deadLetterPath := filepath.Join(t.TempDir(), "dead-letter.log")
+	notifier := NewNotifier(hub, []WebhookConfig{
+		newTestWebhookConfig(re, "flaky", flakyServer.URL),
+		newTestWebhookConfig(re, "store-down", storeDownServer.URL, AlertStoreDown),
+		newTestWebhookConfig(re, "broken", brokenServer.URL, AlertTSOFallback),
+	}, deadLetterPath)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		notifier.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	// wait for the notifier to subscribe.
+	re.Eventually(func() bool {
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return len(hub.subscribers) == 1
+	}, time.Second, time.Millisecond)
+
+	// the events which aren't alerts are ignored.
+	hub.Publish(TypeStore, "offline", "1", nil)
+	hub.Publish(TypeAlert, AlertStoreDown, "1", map[string]interface{}{"address": "127.0.0.1:20160"})
+	hub.Publish(TypeAlert, AlertTSOFallback, "global", nil)
+
+	re.Eventually(func() bool {
+		requests, received := flaky.status()
+		return requests == 4 && len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	_, received := flaky.status()
+	re.Equal(AlertStoreDown, received[0].Action)
+	re.Equal("127.0.0.1:20160", received[0].Detail["address"])
+	re.Equal(AlertTSOFallback, received[1].Action)
+
+	re.Eventually(func() bool {
+		_, received := storeDown.status()
+		return len(received) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	_, received = storeDown.status()
+	re.Equal("1", received[0].Subject)
+
+	var deadLetters []*DeadLetter
+	re.Eventually(func() bool {
+		deadLetters = readDeadLetters(re, deadLetterPath)
+		return len(deadLetters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	re.Equal("broken", deadLetters[0].Webhook)
+	re.Equal(3, deadLetters[0].Attempts)
+	re.Equal(AlertTSOFallback, deadLetters[0].Event.Action)
+	requests, _ := broken.status()
+	re.Equal(3, requests)
+}
+
+func TestLostAlertDeadLetters(t *testing.T) {
+	re := require.New(t)
+	hub := NewHub(1)
+	deadLetterPath := filepath.Join(t.TempDir(), "dead-letter.log")
+	notifier := NewNotifier(hub, []WebhookConfig{
+		newTestWebhookConfig(re, "a", "http://127.0.0.1:1"),
+		newTestWebhookConfig(re, "b", "http://127.0.0.1:2"),
+	}, deadLetterPath)
+	hub.Publish(TypeAlert, AlertStoreDown, "1", nil)
+	lastID := hub.backlogAt(0).ID
+	hub.Publish(TypeAlert, AlertStoreDown, "2", nil)
+	hub.Publish(TypeAlert, AlertStoreDown, "3", nil)
+	// the alert after the last dispatched one is evicted.
+	_, err := hub.Subscribe(Filter{Types: []Type{TypeAlert}}, lastID)
+	re.Error(err)
+	notifier.writeLostDeadLetters(lastID, err)
+	deadLetters := readDeadLetters(re, deadLetterPath)
+	re.Len(deadLetters, 2)
+	for i, name := range []string{"a", "b"} {
+		re.Equal(name, deadLetters[i].Webhook)
+		re.Equal(lastID, deadLetters[i].LastEventID)
+		re.Nil(deadLetters[i].Event)
+	}
+}
@@ -67,6 +67,9 @@ func NewService(srv *rmserver.Service) *Service {
 		c.Next()
 	})
 	apiHandlerEngine.Use(multiservicesapi.ServiceRedirector())
+	// All the APIs are checked as the resource-manager service, since the resource groups are
+	// managed as a whole.
+	apiHandlerEngine.Use(multiservicesapi.AccessChecker(apiServiceGroup.Name))
 	endpoint := apiHandlerEngine.Group(APIPathPrefix)
 	s := &Service{
 		manager:          manager,
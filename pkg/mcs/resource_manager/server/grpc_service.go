@@ -91,11 +91,23 @@ func (s *Service) checkServing() error {
 	return nil
 }
 
+// checkPermission checks whether the client is allowed to call the method, if the server which the
+// service is installed in supports the access control, like the PD server with RBAC enabled.
+func (s *Service) checkPermission(ctx context.Context) error {
+	if ac, ok := s.manager.srv.(bs.AccessController); ok {
+		return ac.CheckGRPCPermission(ctx)
+	}
+	return nil
+}
+
 // GetResourceGroup implements ResourceManagerServer.GetResourceGroup.
 func (s *Service) GetResourceGroup(ctx context.Context, req *rmpb.GetResourceGroupRequest) (*rmpb.GetResourceGroupResponse, error) {
 	if err := s.checkServing(); err != nil {
 		return nil, err
 	}
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	rg := s.manager.GetResourceGroup(req.ResourceGroupName)
 	if rg == nil {
 		return nil, errors.New("resource group not found")
@@ -110,6 +122,9 @@ func (s *Service) ListResourceGroups(ctx context.Context, req *rmpb.ListResource
 	if err := s.checkServing(); err != nil {
 		return nil, err
 	}
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	groups := s.manager.GetResourceGroupList()
 	resp := &rmpb.ListResourceGroupsResponse{
 		Groups: make([]*rmpb.ResourceGroup, 0, len(groups)),
@@ -125,6 +140,9 @@ func (s *Service) AddResourceGroup(ctx context.Context, req *rmpb.PutResourceGro
 	if err := s.checkServing(); err != nil {
 		return nil, err
 	}
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	rg := FromProtoResourceGroup(req.GetGroup())
 	err := s.manager.AddResourceGroup(rg)
 	if err != nil {
@@ -138,6 +156,9 @@ func (s *Service) DeleteResourceGroup(ctx context.Context, req *rmpb.DeleteResou
 	if err := s.checkServing(); err != nil {
 		return nil, err
 	}
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	err := s.manager.DeleteResourceGroup(req.ResourceGroupName)
 	if err != nil {
 		return nil, err
@@ -150,6 +171,9 @@ func (s *Service) ModifyResourceGroup(ctx context.Context, req *rmpb.PutResource
 	if err := s.checkServing(); err != nil {
 		return nil, err
 	}
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	err := s.manager.ModifyResourceGroup(req.GetGroup())
 	if err != nil {
 		return nil, err
@@ -159,6 +183,9 @@ func (s *Service) ModifyResourceGroup(ctx context.Context, req *rmpb.PutResource
 
 // AcquireTokenBuckets implements ResourceManagerServer.AcquireTokenBuckets.
 func (s *Service) AcquireTokenBuckets(stream rmpb.ResourceManager_AcquireTokenBucketsServer) error {
+	if err := s.checkPermission(stream.Context()); err != nil {
+		return err
+	}
 	for {
 		select {
 		case <-s.ctx.Done():
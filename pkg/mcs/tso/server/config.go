@@ -76,6 +76,17 @@ type Config struct {
 	// be automatically clamped to the range.
 	TSOUpdatePhysicalInterval typeutil.Duration `toml:"tso-update-physical-interval" json:"tso-update-physical-interval"`
 
+	// TSOTimeSource is the source of the TSO physical time, which is "local" for the local wall clock
+	// by default, or "ntp://host[:port]" to follow the time of an NTP server with the uncertainty bound
+	// estimated from the round trip and the root dispersion of the server.
+	TSOTimeSource string `toml:"tso-time-source" json:"tso-time-source"`
+
+	// TSOMaxClockUncertainty is the max uncertainty bound of the TSO physical time source.
+	// Once the uncertainty exceeds it, the TSO leader stops advancing the physical time, which slows
+	// down and finally rejects the TSO allocation. The latest uncertainty is also reported in the header of
+	// every TSO response once it is set. Zero means no limit.
+	TSOMaxClockUncertainty typeutil.Duration `toml:"tso-max-clock-uncertainty" json:"tso-max-clock-uncertainty"`
+
 	// MaxResetTSGap is the max gap to reset the TSO.
 	MaxResetTSGap typeutil.Duration `toml:"max-gap-reset-ts" json:"max-gap-reset-ts"`
 
@@ -98,6 +109,16 @@ func NewConfig() *Config {
 	return &Config{}
 }
 
+// GetListenAddr returns the listen address of the TSO server.
+func (c *Config) GetListenAddr() string {
+	return c.ListenAddr
+}
+
+// GetLeaderLease returns the leader lease in seconds.
+func (c *Config) GetLeaderLease() int64 {
+	return c.LeaderLease
+}
+
 // IsLocalTSOEnabled returns if the local TSO is enabled.
 func (c *Config) IsLocalTSOEnabled() bool {
 	return c.EnableLocalTSO
@@ -108,11 +129,26 @@ func (c *Config) GetTSOUpdatePhysicalInterval() time.Duration {
 	return c.TSOUpdatePhysicalInterval.Duration
 }
 
+// GetTSOTimeSource returns the source of the TSO physical time.
+func (c *Config) GetTSOTimeSource() string {
+	return c.TSOTimeSource
+}
+
+// GetTSOMaxClockUncertainty returns the max uncertainty bound of the TSO physical time source.
+func (c *Config) GetTSOMaxClockUncertainty() time.Duration {
+	return c.TSOMaxClockUncertainty.Duration
+}
+
 // GetTSOSaveInterval returns TSO save interval.
 func (c *Config) GetTSOSaveInterval() time.Duration {
 	return c.TSOSaveInterval.Duration
 }
 
+// GetMaxResetTSGap returns the max gap to reset the TSO.
+func (c *Config) GetMaxResetTSGap() time.Duration {
+	return c.MaxResetTSGap.Duration
+}
+
 // GetTLSConfig returns the TLS config.
 func (c *Config) GetTLSConfig() *grpcutil.TLSConfig {
 	return &c.Security.TLSConfig
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/tsopb"
 	"github.com/pingcap/log"
 	"github.com/pkg/errors"
+	"github.com/tikv/pd/client/tsoheader"
 	bs "github.com/tikv/pd/pkg/basicserver"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mcs/registry"
@@ -138,13 +139,23 @@ func (s *Service) Tso(stream tsopb.TSO_TsoServer) error {
 			return status.Errorf(codes.FailedPrecondition, "mismatch cluster id, need %d but got %d", s.clusterID, request.GetHeader().GetClusterId())
 		}
 		count := request.GetCount()
-		ts, err := s.tsoAllocatorManager.HandleTSORequest(request.GetDcLocation(), count)
+		keyspaceID := request.GetHeader().GetKeyspaceId()
+		ts, keyspaceGroupID, err := s.keyspaceGroupManager.HandleTSORequest(keyspaceID, request.GetDcLocation(), count)
 		if err != nil {
 			return status.Errorf(codes.Unknown, err.Error())
 		}
 		tsoHandleDuration.Observe(time.Since(start).Seconds())
+		var (
+			uncertainty time.Duration
+			report      bool
+		)
+		if am, err := s.keyspaceGroupManager.GetAllocatorManager(keyspaceGroupID); err == nil {
+			uncertainty, report = am.GetClockUncertainty()
+		}
+		header := s.tsoHeader(uncertainty, report)
+		header.KeyspaceId, header.KeyspaceGroupId = keyspaceID, keyspaceGroupID
 		response := &tsopb.TsoResponse{
-			Header:    s.header(),
+			Header:    header,
 			Timestamp: &ts,
 			Count:     count,
 		}
@@ -161,6 +172,16 @@ func (s *Service) header() *tsopb.ResponseHeader {
 	return &tsopb.ResponseHeader{ClusterId: s.clusterID}
 }
 
+// tsoHeader returns the header of the TSO response, which also carries the clock uncertainty
+// bound of the TSO physical time if it's reported.
+func (s *Service) tsoHeader(uncertainty time.Duration, report bool) *tsopb.ResponseHeader {
+	header := s.header()
+	if report {
+		header.XXX_unrecognized = tsoheader.AppendClockUncertainty(header.XXX_unrecognized, uncertainty)
+	}
+	return header
+}
+
 func (s *Service) wrapErrorToHeader(errorType tsopb.ErrorType, message string) *tsopb.ResponseHeader {
 	return s.errorHeader(&tsopb.Error{
 		Type:    errorType,
@@ -295,7 +316,8 @@ func (s *Service) processTSORequests(forwardStream tsopb.TSO_TsoClient, requests
 	// This is different from the logic of client batch, for example, if we have a largest ts whose logical part is 10,
 	// count is 5, then the splitting results should be 5 and 10.
 	firstLogical := addLogical(logical, -int64(count), suffixBits)
-	return s.finishTSORequest(requests, physical, firstLogical, suffixBits)
+	uncertainty, ok := tsoheader.ParseClockUncertainty(resp.GetHeader().XXX_unrecognized)
+	return s.finishTSORequest(requests, physical, firstLogical, suffixBits, uncertainty, ok)
 }
 
 // Because of the suffix, we need to shift the count before we add it to the logical part.
@@ -303,13 +325,14 @@ func addLogical(logical, count int64, suffixBits uint32) int64 {
 	return logical + count<<suffixBits
 }
 
-func (s *Service) finishTSORequest(requests []*tsoRequest, physical, firstLogical int64, suffixBits uint32) error {
+func (s *Service) finishTSORequest(requests []*tsoRequest, physical, firstLogical int64, suffixBits uint32,
+	uncertainty time.Duration, reportUncertainty bool) error {
 	countSum := int64(0)
 	for i := 0; i < len(requests); i++ {
 		count := requests[i].request.GetCount()
 		countSum += int64(count)
 		response := &tsopb.TsoResponse{
-			Header: s.header(),
+			Header: s.tsoHeader(uncertainty, reportUncertainty),
 			Count:  count,
 			Timestamp: &pdpb.Timestamp{
 				Physical:   physical,
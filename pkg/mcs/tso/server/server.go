@@ -44,14 +44,11 @@ import (
 	"github.com/tikv/pd/pkg/mcs/discovery"
 	"github.com/tikv/pd/pkg/mcs/utils"
 	"github.com/tikv/pd/pkg/member"
-	"github.com/tikv/pd/pkg/storage/endpoint"
-	"github.com/tikv/pd/pkg/storage/kv"
 	"github.com/tikv/pd/pkg/systimemon"
 	"github.com/tikv/pd/pkg/tso"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/logutil"
-	"github.com/tikv/pd/pkg/utils/memberutil"
 	"github.com/tikv/pd/pkg/utils/metricutil"
 	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/versioninfo"
@@ -66,10 +63,6 @@ import (
 const (
 	// pdRootPath is the old path for storing the tso related root path.
 	pdRootPath = "/pd"
-	// tsoPrimaryPrefix defines the key prefix for keyspace group primary election.
-	// The entire key is in the format of "/ms/<cluster-id>/tso/<group-id>/primary" in which
-	// <group-id> is 5 digits integer with leading zeros. For now we use 0 as the default cluster id.
-	tsoPrimaryPrefix = "/ms/0/tso"
 )
 
 var _ bs.Server = (*Server)(nil)
@@ -94,19 +87,21 @@ type Server struct {
 	cfg                  *Config
 	clusterID            uint64
 	defaultGroupRootPath string
-	defaultGroupStorage  endpoint.TSOStorage
 	listenURL            *url.URL
 	backendUrls          []url.URL
 
-	// for the primary election in the TSO cluster
+	// for the primary election of the default keyspace group
 	participant *member.Participant
 	// etcd client
 	etcdClient *clientv3.Client
 	// http client
 	httpClient *http.Client
 
-	muxListener         net.Listener
-	service             *Service
+	muxListener net.Listener
+	service     *Service
+	// keyspaceGroupManager manages the TSO allocators of all keyspace groups.
+	keyspaceGroupManager *tso.KeyspaceGroupManager
+	// tsoAllocatorManager is the allocator manager of the default keyspace group.
 	tsoAllocatorManager *tso.AllocatorManager
 	// Store as map[string]*grpc.ClientConn
 	clientConns sync.Map
@@ -153,130 +148,8 @@ func (s *Server) Run() error {
 	if err := s.initClient(); err != nil {
 		return err
 	}
-	if err := s.startServer(); err != nil {
-		return err
-	}
-
-	s.startServerLoop()
-
-	return nil
-}
-
-func (s *Server) startServerLoop() {
 	s.serverLoopCtx, s.serverLoopCancel = context.WithCancel(s.ctx)
-	s.serverLoopWg.Add(2)
-	go s.primaryElectionLoop()
-	go s.tsoAllocatorLoop()
-}
-
-// tsoAllocatorLoop is used to run the TSO Allocator updating daemon.
-func (s *Server) tsoAllocatorLoop() {
-	defer logutil.LogPanic()
-	defer s.serverLoopWg.Done()
-
-	ctx, cancel := context.WithCancel(s.serverLoopCtx)
-	defer cancel()
-	s.tsoAllocatorManager.AllocatorDaemon(ctx)
-	log.Info("tso server is closed, exit allocator loop")
-}
-
-func (s *Server) primaryElectionLoop() {
-	defer logutil.LogPanic()
-	defer s.serverLoopWg.Done()
-
-	for {
-		if s.IsClosed() {
-			log.Info("server is closed, exit tso primary election loop")
-			return
-		}
-
-		primary, rev, checkAgain := s.participant.CheckLeader()
-		if checkAgain {
-			continue
-		}
-		if primary != nil {
-			// TODO: if enable-local-tso is true, check the cluster dc-location after the primary/leader is elected
-			// go s.tsoAllocatorManager.ClusterDCLocationChecker()
-
-			log.Info("start to watch the primary/leader", zap.Stringer("tso-primary", primary))
-			// WatchLeader will keep looping and never return unless the primary/leader has changed.
-			s.participant.WatchLeader(s.serverLoopCtx, primary, rev)
-			log.Info("the tso primary/leader has changed, try to re-campaign a primary/leader")
-		}
-
-		s.campaignLeader()
-	}
-}
-
-func (s *Server) campaignLeader() {
-	log.Info("start to campaign the primary/leader", zap.String("campaign-tso-primary-name", s.participant.Member().Name))
-	if err := s.participant.CampaignLeader(s.cfg.LeaderLease); err != nil {
-		if err.Error() == errs.ErrEtcdTxnConflict.Error() {
-			log.Info("campaign tso primary/leader meets error due to txn conflict, another tso server may campaign successfully",
-				zap.String("campaign-tso-primary-name", s.participant.Member().Name))
-		} else {
-			log.Error("campaign tso primary/leader meets error due to etcd error",
-				zap.String("campaign-tso-primary-name", s.participant.Member().Name),
-				errs.ZapError(err))
-		}
-		return
-	}
-
-	// Start keepalive the leadership and enable TSO service.
-	// TSO service is strictly enabled/disabled by the leader lease for 2 reasons:
-	//   1. lease based approach is not affected by thread pause, slow runtime schedule, etc.
-	//   2. load region could be slow. Based on lease we can recover TSO service faster.
-	ctx, cancel := context.WithCancel(s.serverLoopCtx)
-	var resetLeaderOnce sync.Once
-	defer resetLeaderOnce.Do(func() {
-		cancel()
-		s.participant.ResetLeader()
-	})
-
-	// maintain the the leadership, after this, TSO can be service.
-	s.participant.KeepLeader(ctx)
-	log.Info("campaign tso primary ok", zap.String("campaign-tso-primary-name", s.participant.Member().Name))
-
-	allocator, err := s.tsoAllocatorManager.GetAllocator(tso.GlobalDCLocation)
-	if err != nil {
-		log.Error("failed to get the global tso allocator", errs.ZapError(err))
-		return
-	}
-	log.Info("initializing the global tso allocator")
-	if err := allocator.Initialize(0); err != nil {
-		log.Error("failed to initialize the global tso allocator", errs.ZapError(err))
-		return
-	}
-	defer func() {
-		s.tsoAllocatorManager.ResetAllocatorGroup(tso.GlobalDCLocation)
-	}()
-
-	log.Info("triggering the primary callback functions")
-	for _, cb := range s.primaryCallbacks {
-		cb(ctx)
-	}
-
-	s.participant.EnableLeader()
-	// TODO: if enable-local-tso is true, check the cluster dc-location after the primary/leader is elected
-	// go s.tsoAllocatorManager.ClusterDCLocationChecker()
-	log.Info("tso primary is ready to serve", zap.String("tso-primary-name", s.participant.Member().Name))
-
-	leaderTicker := time.NewTicker(utils.LeaderTickInterval)
-	defer leaderTicker.Stop()
-
-	for {
-		select {
-		case <-leaderTicker.C:
-			if !s.participant.IsLeader() {
-				log.Info("no longer a primary/leader because lease has expired, the tso primary/leader will step down")
-				return
-			}
-		case <-ctx.Done():
-			// Server is closed and it should return nil.
-			log.Info("server is closed")
-			return
-		}
-	}
+	return s.startServer()
 }
 
 // Close closes the server.
@@ -291,6 +164,7 @@ func (s *Server) Close() {
 	// TODO: double check when muxListener is closed, grpc.Server.serve() and http.Server.serve()
 	// will also close with error cmux.ErrListenerClosed.
 	s.muxListener.Close()
+	s.keyspaceGroupManager.Close()
 	s.serverLoopCancel()
 	s.serverLoopWg.Wait()
 
@@ -349,7 +223,12 @@ func (s *Server) IsClosed() bool {
 	return atomic.LoadInt64(&s.isServing) == 0
 }
 
-// GetTSOAllocatorManager returns the manager of TSO Allocator.
+// GetKeyspaceGroupManager returns the manager of the keyspace groups.
+func (s *Server) GetKeyspaceGroupManager() *tso.KeyspaceGroupManager {
+	return s.keyspaceGroupManager
+}
+
+// GetTSOAllocatorManager returns the manager of TSO Allocator of the default keyspace group.
 func (s *Server) GetTSOAllocatorManager() *tso.AllocatorManager {
 	return s.tsoAllocatorManager
 }
@@ -573,24 +452,20 @@ func (s *Server) startServer() (err error) {
 	serverInfo.WithLabelValues(versioninfo.PDReleaseVersion, versioninfo.PDGitHash).Set(float64(time.Now().Unix()))
 	s.defaultGroupRootPath = path.Join(pdRootPath, strconv.FormatUint(s.clusterID, 10))
 
-	// TODO: Figure out how we should generated the unique id and name passed to Participant.
-	// For now, set the name to be listen address and generate the unique id from the name with sha256.
-	uniqueName := s.cfg.ListenAddr
-	uniqueID := memberutil.GenerateUniqueID(uniqueName)
-	log.Info("joining primary election", zap.String("participant-name", uniqueName), zap.Uint64("participant-id", uniqueID))
-
-	s.participant = member.NewParticipant(s.etcdClient, uniqueID)
-	s.participant.InitInfo(uniqueName, path.Join(tsoPrimaryPrefix, fmt.Sprintf("%05d", 0)), "primary", "keyspace group primary election", s.cfg.ListenAddr)
+	s.keyspaceGroupManager = tso.NewKeyspaceGroupManager(s.serverLoopCtx, s.clusterID, s.etcdClient, s.defaultGroupRootPath, s.cfg, s.primaryCallbacks)
+	if err := s.keyspaceGroupManager.Initialize(); err != nil {
+		return err
+	}
+	if s.participant, err = s.keyspaceGroupManager.GetElectionMember(utils.DefaultKeyspaceGroupID); err != nil {
+		return err
+	}
+	log.Info("joining primary election", zap.String("participant-name", s.participant.Member().Name), zap.Uint64("participant-id", s.participant.ID()))
 	s.participant.SetMemberDeployPath(s.participant.ID())
 	s.participant.SetMemberBinaryVersion(s.participant.ID(), versioninfo.PDReleaseVersion)
 	s.participant.SetMemberGitHash(s.participant.ID(), versioninfo.PDGitHash)
-
-	s.defaultGroupStorage = endpoint.NewStorageEndpoint(kv.NewEtcdKVBase(s.GetClient(), s.defaultGroupRootPath), nil)
-	s.tsoAllocatorManager = tso.NewAllocatorManager(
-		s.participant, s.defaultGroupRootPath, s.defaultGroupStorage, s.cfg.IsLocalTSOEnabled(), s.cfg.GetTSOSaveInterval(), s.cfg.GetTSOUpdatePhysicalInterval(),
-		s.cfg.GetTLSConfig(), func() time.Duration { return s.cfg.MaxResetTSGap.Duration })
-	// Set up the Global TSO Allocator here, it will be initialized once this TSO participant campaigns leader successfully.
-	s.tsoAllocatorManager.SetUpAllocator(s.ctx, tso.GlobalDCLocation, s.participant.GetLeadership())
+	if s.tsoAllocatorManager, err = s.keyspaceGroupManager.GetAllocatorManager(utils.DefaultKeyspaceGroupID); err != nil {
+		return err
+	}
 
 	s.service = &Service{Server: s}
 
@@ -34,4 +34,15 @@ const (
 	DefaultLeaderLease = 3
 	// LeaderTickInterval is the interval to check leader
 	LeaderTickInterval = 50 * time.Millisecond
+
+	// TSOPrimaryPrefix defines the key prefix for keyspace group primary election.
+	// The entire key is in the format of "/ms/<cluster-id>/tso/<group-id>/primary" in which
+	// <group-id> is 5 digits integer with leading zeros. For now we use 0 as the default cluster id.
+	TSOPrimaryPrefix = "/ms/0/tso"
+	// DefaultKeyspaceGroupID is the ID of the default keyspace group, which serves all the keyspaces
+	// that don't belong to any other keyspace group.
+	DefaultKeyspaceGroupID = uint32(0)
+	// MaxKeyspaceGroupCount is the max count of keyspace groups. The keyspace group ID
+	// must be less than it.
+	MaxKeyspaceGroupCount = uint32(4096)
 )
@@ -17,6 +17,7 @@ package mockcluster
 import (
 	"time"
 
+	"github.com/tikv/pd/pkg/schedule/maintenance"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server/config"
@@ -37,6 +38,21 @@ func (mc *Cluster) SetSplitMergeInterval(v time.Duration) {
 	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.SplitMergeInterval = typeutil.NewDuration(v) })
 }
 
+// SetMergeHotRegionCoolDown updates the MergeHotRegionCoolDown configuration.
+func (mc *Cluster) SetMergeHotRegionCoolDown(v time.Duration) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.MergeHotRegionCoolDown = typeutil.NewDuration(v) })
+}
+
+// SetColdRegionMergeDuration updates the ColdRegionMergeDuration configuration.
+func (mc *Cluster) SetColdRegionMergeDuration(v time.Duration) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.ColdRegionMergeDuration = typeutil.NewDuration(v) })
+}
+
+// SetColdRegionMergeRateLimit updates the ColdRegionMergeRateLimit configuration.
+func (mc *Cluster) SetColdRegionMergeRateLimit(v int) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.ColdRegionMergeRateLimit = uint64(v) })
+}
+
 // SetEnableOneWayMerge updates the EnableOneWayMerge configuration.
 func (mc *Cluster) SetEnableOneWayMerge(v bool) {
 	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.EnableOneWayMerge = v })
@@ -112,6 +128,37 @@ func (mc *Cluster) SetHotRegionCacheHitsThreshold(v int) {
 	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.HotRegionCacheHitsThreshold = uint64(v) })
 }
 
+// SetEnableOperatorPreemption updates the EnableOperatorPreemption configuration.
+func (mc *Cluster) SetEnableOperatorPreemption(v bool) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) { s.EnableOperatorPreemption = v })
+}
+
+// SetOperatorClassQuota updates the quota of the priority class in the OperatorClassQuota configuration.
+func (mc *Cluster) SetOperatorClassQuota(class string, v uint64) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) {
+		if s.OperatorClassQuota == nil {
+			s.OperatorClassQuota = make(map[string]uint64)
+		}
+		s.OperatorClassQuota[class] = v
+	})
+}
+
+// SetMovementLimit updates the ClusterMovementLimit and ZoneMovementLimit configuration.
+func (mc *Cluster) SetMovementLimit(cluster, zone typeutil.ByteSize) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) {
+		s.ClusterMovementLimit = cluster
+		s.ZoneMovementLimit = zone
+	})
+}
+
+// SetMaintenanceWindows updates the MaintenanceWindows and BlackoutPeriods configuration.
+func (mc *Cluster) SetMaintenanceWindows(windows []maintenance.Window, blackouts []maintenance.Blackout) {
+	mc.updateScheduleConfig(func(s *config.ScheduleConfig) {
+		s.MaintenanceWindows = windows
+		s.BlackoutPeriods = blackouts
+	})
+}
+
 // SetEnablePlacementRules updates the EnablePlacementRules configuration.
 func (mc *Cluster) SetEnablePlacementRules(v bool) {
 	mc.updateReplicationConfig(func(r *config.ReplicationConfig) { r.EnablePlacementRules = v })
@@ -32,6 +32,7 @@ import (
 	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/statistics/buckets"
 	"github.com/tikv/pd/pkg/storage"
@@ -57,7 +58,8 @@ type Cluster struct {
 	suspectRegions map[uint64]struct{}
 	*config.StoreConfigManager
 	*buckets.HotBucketCache
-	ctx context.Context
+	scatterGroupManager *scattergroup.Manager
+	ctx                 context.Context
 }
 
 // NewCluster creates a new Cluster
@@ -78,6 +80,7 @@ func NewCluster(ctx context.Context, opts *config.PersistOptions) *Cluster {
 	// It should be updated to the latest feature version.
 	clus.PersistOptions.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.HotScheduleWithQuery))
 	clus.RegionLabeler, _ = labeler.NewRegionLabeler(ctx, storage.NewStorageWithMemoryBackend(), time.Second*5)
+	clus.scatterGroupManager, _ = scattergroup.NewManager(ctx, storage.NewStorageWithMemoryBackend(), time.Second*5)
 	return clus
 }
 
@@ -203,6 +206,21 @@ func (mc *Cluster) GetRegionLabeler() *labeler.RegionLabeler {
 	return mc.RegionLabeler
 }
 
+// GetScatterGroupManager returns the scatter group manager.
+func (mc *Cluster) GetScatterGroupManager() *scattergroup.Manager {
+	return mc.scatterGroupManager
+}
+
+// IsStoreDraining returns whether the store is being drained, which is not supported by the mock cluster.
+func (mc *Cluster) IsStoreDraining(storeID uint64) bool {
+	return false
+}
+
+// IsStoreDrainBlocked returns whether the peer of the region can't be moved out of the draining store.
+func (mc *Cluster) IsStoreDrainBlocked(storeID, regionID uint64) bool {
+	return false
+}
+
 // SetStoreUp sets store state to be up.
 func (mc *Cluster) SetStoreUp(storeID uint64) {
 	store := mc.GetStore(storeID)
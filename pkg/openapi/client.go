@@ -0,0 +1,657 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pingcap/errors"
+)
+
+// GenerateClient generates the Go client of the document. Every operation becomes a method
+// of the Client, which is defined by the hand-written part of the package together with
+// the doJSON, doText and doStream helpers. The component schemas of the exported types in
+// the packages which can be imported by a client reuse the Go types, the others become
+// generated models.
+func GenerateClient(doc *Document, pkg string) ([]byte, error) {
+	g := &clientGenerator{
+		doc:     doc,
+		imports: make(map[string]string),
+		aliases: make(map[string]string),
+		std:     make(map[string]bool),
+		models:  make(map[string]string),
+		queued:  make(map[string]bool),
+	}
+	g.nameModels()
+
+	type operation struct {
+		path, method string
+		op           *Operation
+	}
+	var ops []operation
+	ids := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if !token.IsIdentifier(op.OperationID) || !token.IsExported(op.OperationID) {
+				return nil, errors.Errorf("operation ID %q of %s [%s] is not an exported Go identifier", op.OperationID, path, method)
+			}
+			if ids[op.OperationID] {
+				return nil, errors.Errorf("duplicated operation ID %s", op.OperationID)
+			}
+			ids[op.OperationID] = true
+			ops = append(ops, operation{path: path, method: method, op: op})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].op.OperationID < ops[j].op.OperationID })
+
+	var body bytes.Buffer
+	for _, o := range ops {
+		if err := g.writeOperation(&body, o.path, o.method, o.op); err != nil {
+			return nil, err
+		}
+	}
+	models := make(map[string]string)
+	for len(g.pending) > 0 {
+		name := g.pending[0]
+		g.pending = g.pending[1:]
+		models[g.models[name]] = g.model(name)
+	}
+	modelNames := make([]string, 0, len(models))
+	for name := range models {
+		modelNames = append(modelNames, name)
+	}
+	sort.Strings(modelNames)
+	for _, name := range modelNames {
+		body.WriteString(models[name])
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by openapi-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	out.WriteString("import (\n")
+	g.std["context"] = true
+	g.std["net/http"] = true
+	std := make([]string, 0, len(g.std))
+	for path := range g.std {
+		std = append(std, path)
+	}
+	sort.Strings(std)
+	for _, path := range std {
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	if len(g.imports) > 0 {
+		out.WriteString("\n")
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			alias := g.imports[path]
+			if alias == path[strings.LastIndex(path, "/")+1:] {
+				fmt.Fprintf(&out, "%q\n", path)
+			} else {
+				fmt.Fprintf(&out, "%s %q\n", alias, path)
+			}
+		}
+	}
+	out.WriteString(")\n\n")
+	basePath := ""
+	if len(doc.Servers) > 0 {
+		basePath = doc.Servers[0].URL
+	}
+	fmt.Fprintf(&out, "// basePath is the path prefix of the API.\nconst basePath = %q\n\n", basePath)
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to format the generated client")
+	}
+	return src, nil
+}
+
+type clientGenerator struct {
+	doc *Document
+	// imports maps the import paths to the aliases, aliases is the reverse.
+	imports map[string]string
+	aliases map[string]string
+	// std is the imported standard packages.
+	std map[string]bool
+	// models maps the component names to the names of the generated models.
+	models map[string]string
+	queued map[string]bool
+	// pending is the component names whose models are not generated yet.
+	pending []string
+}
+
+// The names of the standard packages which may be imported by the generated client.
+var stdPackageNames = map[string]bool{
+	"context": true, "http": true, "url": true, "strconv": true, "json": true, "time": true, "io": true,
+}
+
+// importable reports whether the Go type of the component schema can be used by the client.
+// The client must not depend on the server.
+func importable(s *Schema) bool {
+	if s.GoPackage == "" || s.GoType == "" {
+		return false
+	}
+	name := s.GoType[strings.LastIndex(s.GoType, ".")+1:]
+	return token.IsExported(name) &&
+		!strings.Contains(s.GoPackage+"/", "/internal/") &&
+		s.GoPackage != "github.com/tikv/pd/server" &&
+		!strings.HasPrefix(s.GoPackage, "github.com/tikv/pd/server/")
+}
+
+// nameModels names the models of the components which can't be imported. The name of the
+// Go type is used if it's unique, otherwise it's prefixed by the package name.
+func (g *clientGenerator) nameModels() {
+	count := make(map[string]int)
+	for _, s := range g.doc.Components.Schemas {
+		if !importable(s) {
+			count[exportedName(goTypeBaseName(s))]++
+		}
+	}
+	for name, s := range g.doc.Components.Schemas {
+		if importable(s) {
+			continue
+		}
+		model := exportedName(goTypeBaseName(s))
+		if count[model] > 1 {
+			model = exportedName(s.GoType)
+		}
+		g.models[name] = model
+	}
+}
+
+func goTypeBaseName(s *Schema) string {
+	return s.GoType[strings.LastIndex(s.GoType, ".")+1:]
+}
+
+// importAlias returns the alias of the imported package.
+func (g *clientGenerator) importAlias(path, name string) string {
+	if alias, ok := g.imports[path]; ok {
+		return alias
+	}
+	alias := name
+	for i := 2; g.aliases[alias] != "" || stdPackageNames[alias]; i++ {
+		alias = name + strconv.Itoa(i)
+	}
+	g.imports[path] = alias
+	g.aliases[alias] = path
+	return alias
+}
+
+// goType returns the Go type of the schema.
+func (g *clientGenerator) goType(s *Schema) string {
+	if s == nil {
+		g.std["encoding/json"] = true
+		return "json.RawMessage"
+	}
+	if name := s.RefName(); name != "" {
+		c := g.doc.Components.Schemas[name]
+		if c == nil {
+			g.std["encoding/json"] = true
+			return "json.RawMessage"
+		}
+		if importable(c) {
+			pkgName := c.GoType[:strings.LastIndex(c.GoType, ".")]
+			pkgName = pkgName[strings.LastIndex(pkgName, ".")+1:]
+			return "*" + g.importAlias(c.GoPackage, pkgName) + "." + goTypeBaseName(c)
+		}
+		if c.Type != "object" || c.Properties == nil {
+			return g.goType(c)
+		}
+		if !g.queued[name] {
+			g.queued[name] = true
+			g.pending = append(g.pending, name)
+		}
+		return "*" + g.models[name]
+	}
+	switch s.Type {
+	case "boolean":
+		return "bool"
+	case "integer":
+		switch s.Format {
+		case "int32", "int64", "uint32", "uint64":
+			return s.Format
+		}
+		return "int"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "string":
+		switch s.Format {
+		case "date-time":
+			g.std["time"] = true
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "array":
+		return "[]" + g.goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + g.goType(s.AdditionalProperties)
+		}
+		if s.Properties != nil {
+			var buf bytes.Buffer
+			buf.WriteString("struct {\n")
+			g.writeFields(&buf, s)
+			buf.WriteString("}")
+			return buf.String()
+		}
+		return "map[string]interface{}"
+	}
+	g.std["encoding/json"] = true
+	return "json.RawMessage"
+}
+
+// model returns the declaration of the model of the component.
+func (g *clientGenerator) model(name string) string {
+	s := g.doc.Components.Schemas[name]
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// %s is the model of %s.\n", g.models[name], s.GoType)
+	if s.Description != "" {
+		buf.WriteString("//\n")
+		writeComment(&buf, s.Description)
+	}
+	fmt.Fprintf(&buf, "type %s struct {\n", g.models[name])
+	g.writeFields(&buf, s)
+	buf.WriteString("}\n\n")
+	return buf.String()
+}
+
+func (g *clientGenerator) writeFields(buf *bytes.Buffer, s *Schema) {
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	used := make(map[string]bool)
+	for _, prop := range props {
+		field := exportedName(prop)
+		if !token.IsIdentifier(field) {
+			field = "X" + field
+		}
+		for i := 2; used[field]; i++ {
+			field = exportedName(prop) + strconv.Itoa(i)
+		}
+		used[field] = true
+		ps := s.Properties[prop]
+		if ps.Description != "" {
+			writeComment(buf, ps.Description)
+		}
+		fmt.Fprintf(buf, "%s %s `json:\"%s,omitempty\"`\n", field, g.goType(ps), prop)
+	}
+}
+
+func writeComment(buf *bytes.Buffer, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(buf, "// %s\n", strings.TrimSpace(line))
+	}
+}
+
+var httpMethods = map[string]string{
+	"get":     "http.MethodGet",
+	"put":     "http.MethodPut",
+	"post":    "http.MethodPost",
+	"delete":  "http.MethodDelete",
+	"patch":   "http.MethodPatch",
+	"head":    "http.MethodHead",
+	"options": "http.MethodOptions",
+}
+
+// The names which are used by the generated methods.
+var reservedNames = map[string]bool{
+	"c": true, "ctx": true, "opts": true, "body": true, "out": true, "err": true, "uri": true,
+	"query": true, "header": true, "url": true, "http": true, "strconv": true, "json": true,
+	"time": true, "io": true, "context": true,
+}
+
+// paramType returns the Go type of the parameter and the expression formatting a value of
+// the type as a string.
+func paramType(s *Schema) (typ string, format func(string) string) {
+	switch s.Type {
+	case "integer":
+		return "uint64", func(v string) string { return "strconv.FormatUint(" + v + ", 10)" }
+	case "boolean":
+		return "bool", func(v string) string { return "strconv.FormatBool(" + v + ")" }
+	case "number":
+		return "float64", func(v string) string { return "strconv.FormatFloat(" + v + ", 'f', -1, 64)" }
+	case "array":
+		if s.Items == nil {
+			return "[]string", func(v string) string { return v }
+		}
+		typ, format := paramType(s.Items)
+		return "[]" + typ, format
+	}
+	return "string", func(v string) string { return v }
+}
+
+func paramGoType(s *Schema) string {
+	typ, _ := paramType(s)
+	return typ
+}
+
+// successResponse returns the first successful response of the operation.
+func successResponse(op *Operation) *Response {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if len(op.Responses[code].Content) > 0 {
+			return op.Responses[code]
+		}
+	}
+	return nil
+}
+
+func (g *clientGenerator) writeOperation(buf *bytes.Buffer, path, method string, op *Operation) error {
+	httpMethod, ok := httpMethods[method]
+	if !ok {
+		return errors.Errorf("unsupported method %s of %s", method, op.OperationID)
+	}
+	id := op.OperationID
+	var (
+		args, optional []*Parameter
+		pathParams     = make(map[string]*Parameter)
+		names          = make(map[*Parameter]string)
+		usedNames      = make(map[string]bool)
+	)
+	// The path parameters are ordered by the positions in the path.
+	for _, p := range op.Parameters {
+		if p.In == InPath {
+			pathParams[p.Name] = p
+		}
+	}
+	var segments []string
+	for rest := path; rest != ""; {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			segments = append(segments, strconv.Quote(rest))
+			break
+		}
+		end := strings.Index(rest, "}")
+		if end < start {
+			return errors.Errorf("invalid path %s of %s", path, id)
+		}
+		p := pathParams[rest[start+1:end]]
+		if p == nil {
+			return errors.Errorf("undeclared path parameter %s of %s", rest[start+1:end], id)
+		}
+		if start > 0 {
+			segments = append(segments, strconv.Quote(rest[:start]))
+		}
+		args = append(args, p)
+		segments = append(segments, "")
+		rest = rest[end+1:]
+	}
+	for _, p := range op.Parameters {
+		switch {
+		case p.In == InPath:
+		case p.Required:
+			args = append(args, p)
+		default:
+			optional = append(optional, p)
+		}
+	}
+	for _, p := range args {
+		name := unexportedName(p.Name)
+		if token.IsKeyword(name) || reservedNames[name] || !token.IsIdentifier(name) {
+			name += "Param"
+		}
+		for i := 2; usedNames[name]; i++ {
+			name = unexportedName(p.Name) + "Param" + strconv.Itoa(i)
+		}
+		usedNames[name] = true
+		names[p] = name
+	}
+
+	// The options of the optional parameters.
+	optionsType := id + "Options"
+	if len(optional) > 0 {
+		fmt.Fprintf(buf, "// %s is the optional parameters of %s.\n", optionsType, id)
+		fmt.Fprintf(buf, "type %s struct {\n", optionsType)
+		usedFields := make(map[string]bool)
+		for _, p := range optional {
+			field := exportedName(p.Name)
+			for i := 2; usedFields[field]; i++ {
+				field = exportedName(p.Name) + strconv.Itoa(i)
+			}
+			usedFields[field] = true
+			names[p] = "opts." + field
+			if p.Description != "" {
+				writeComment(buf, p.Description)
+			}
+			fmt.Fprintf(buf, "%s %s\n", field, paramGoType(p.Schema))
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	// The signature.
+	params := []string{"ctx context.Context"}
+	for _, p := range args {
+		params = append(params, names[p]+" "+paramGoType(p.Schema))
+	}
+	var bodyType string
+	if op.RequestBody != nil {
+		bodyType = "interface{}"
+		if mt := op.RequestBody.Content["application/json"]; mt != nil {
+			bodyType = g.goType(mt.Schema)
+		} else {
+			g.std["io"] = true
+			bodyType = "io.Reader"
+		}
+		params = append(params, "body "+bodyType)
+	}
+	if len(optional) > 0 {
+		params = append(params, "opts *"+optionsType)
+	}
+	var result, helper string
+	if resp := successResponse(op); resp != nil {
+		switch {
+		case resp.Content["application/json"] != nil:
+			result, helper = g.goType(resp.Content["application/json"].Schema), "doJSON"
+		case resp.Content["text/plain"] != nil:
+			result, helper = "string", "doText"
+		default:
+			g.std["io"] = true
+			result, helper = "io.ReadCloser", "doStream"
+		}
+	} else {
+		helper = "doJSON"
+	}
+
+	summary := strings.TrimSuffix(strings.TrimSpace(op.Summary), ".")
+	if summary != "" {
+		fmt.Fprintf(buf, "// %s calls %s %s: %s.\n", id, strings.ToUpper(method), path, summary)
+	} else {
+		fmt.Fprintf(buf, "// %s calls %s %s.\n", id, strings.ToUpper(method), path)
+	}
+	if result != "" {
+		fmt.Fprintf(buf, "func (c *Client) %s(%s) (%s, error) {\n", id, strings.Join(params, ", "), result)
+	} else {
+		fmt.Fprintf(buf, "func (c *Client) %s(%s) error {\n", id, strings.Join(params, ", "))
+	}
+
+	// The URI.
+	exprs := []string{"basePath"}
+	pathIndex := 0
+	for _, seg := range segments {
+		if seg != "" {
+			exprs = append(exprs, seg)
+			continue
+		}
+		p := args[pathIndex]
+		pathIndex++
+		_, f := paramType(p.Schema)
+		if p.Schema.Type != "string" {
+			g.std["strconv"] = true
+		}
+		g.std["net/url"] = true
+		exprs = append(exprs, "url.PathEscape("+f(names[p])+")")
+	}
+	fmt.Fprintf(buf, "uri := %s\n", strings.Join(exprs, " + "))
+
+	// The query and the header.
+	query, header := "nil", "nil"
+	for _, in := range []string{InQuery, InHeader} {
+		var ps []*Parameter
+		for _, p := range op.Parameters {
+			if p.In == in {
+				ps = append(ps, p)
+			}
+		}
+		if len(ps) == 0 {
+			continue
+		}
+		v := "query"
+		if in == InQuery {
+			g.std["net/url"] = true
+			query = v
+			buf.WriteString("query := url.Values{}\n")
+		} else {
+			v = "header"
+			header = v
+			buf.WriteString("header := http.Header{}\n")
+		}
+		var opts []*Parameter
+		for _, p := range ps {
+			if p.Required {
+				g.writeParam(buf, v, p, names[p])
+			} else {
+				opts = append(opts, p)
+			}
+		}
+		if len(opts) > 0 {
+			buf.WriteString("if opts != nil {\n")
+			for _, p := range opts {
+				switch p.Schema.Type {
+				case "array":
+					g.writeParam(buf, v, p, names[p])
+				case "string":
+					fmt.Fprintf(buf, "if %s != \"\" {\n", names[p])
+					g.writeParam(buf, v, p, names[p])
+					buf.WriteString("}\n")
+				case "boolean":
+					fmt.Fprintf(buf, "if %s {\n", names[p])
+					g.writeParam(buf, v, p, names[p])
+					buf.WriteString("}\n")
+				default:
+					fmt.Fprintf(buf, "if %s != 0 {\n", names[p])
+					g.writeParam(buf, v, p, names[p])
+					buf.WriteString("}\n")
+				}
+			}
+			buf.WriteString("}\n")
+		}
+	}
+
+	in := "nil"
+	if bodyType != "" {
+		in = "body"
+	}
+	call := fmt.Sprintf("c.%s(ctx, %s, uri, %s, %s, %s", helper, httpMethod, query, header, in)
+	switch {
+	case result == "":
+		fmt.Fprintf(buf, "return %s, nil)\n", call)
+	case helper == "doJSON":
+		fmt.Fprintf(buf, "var out %s\nerr := %s, &out)\nreturn out, err\n", result, call)
+	default:
+		fmt.Fprintf(buf, "return %s)\n", call)
+	}
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+// writeParam writes the statements adding the parameter to the query or the header.
+func (g *clientGenerator) writeParam(buf *bytes.Buffer, v string, p *Parameter, name string) {
+	_, f := paramType(p.Schema)
+	if p.Schema.Type == "array" {
+		if p.Schema.Items != nil && p.Schema.Items.Type != "string" {
+			g.std["strconv"] = true
+		}
+		fmt.Fprintf(buf, "for _, v := range %s {\n%s.Add(%q, %s)\n}\n", name, v, p.Name, f("v"))
+		return
+	}
+	if p.Schema.Type != "string" {
+		g.std["strconv"] = true
+	}
+	fmt.Fprintf(buf, "%s.Set(%q, %s)\n", v, p.Name, f(name))
+}
+
+// The initialisms which are kept in the same case in the Go names.
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "DR": true, "GC": true, "HTTP": true, "ID": true, "IDS": true, "IP": true, "JSON": true,
+	"PD": true, "TS": true, "TSO": true, "TTL": true, "URL": true,
+}
+
+// nameWords splits the name into words by the separators and the case changes.
+func nameWords(name string) []string {
+	var words []string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		start := 0
+		runes := []rune(part)
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, w := range nameWords(name) {
+		upper := strings.ToUpper(w)
+		switch {
+		case upper == "IDS":
+			b.WriteString("IDs")
+		case initialisms[upper]:
+			b.WriteString(upper)
+		default:
+			b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+		}
+	}
+	return b.String()
+}
+
+func unexportedName(name string) string {
+	words := nameWords(name)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToLower(words[0]) + exportedName(strings.Join(words[1:], "_"))
+}
@@ -0,0 +1,624 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi generates the OpenAPI document of the HTTP API from the swag style
+// annotations of the handlers, and generates the typed client from the document.
+//
+// An annotation block is a comment group with a @Router annotation. It's usually the doc
+// comment of the handler, and the operation ID is the name of the handler. A block which
+// isn't a doc comment, or has several @Router annotations, must have the same number of @ID
+// annotations, which are the operation IDs of the routes in order.
+//
+// The parameters shared by several operations can be declared once in a block with a
+// @ParamSet annotation, which names the @Param annotations of the block, and an operation
+// takes them in place by a @Params annotation with the name.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// Options is the options to generate the document.
+type Options struct {
+	// Dir is the directory to run the go command, usually the root of the module.
+	Dir string
+	// Packages is the patterns of the packages to parse the annotations.
+	Packages []string
+}
+
+type generator struct {
+	fset    *token.FileSet
+	doc     *Document
+	schemas *schemaBuilder
+	ids     map[string]token.Position
+	// paramSets are the @Param annotations declared by @ParamSet.
+	paramSets map[string]*paramSet
+}
+
+type paramSet struct {
+	pkg         *sourcePackage
+	file        *ast.File
+	pos         token.Position
+	annotations []annotation
+}
+
+// Generate generates the OpenAPI document from the annotations of the packages.
+func Generate(opts Options) (*Document, error) {
+	g := &generator{
+		fset: token.NewFileSet(),
+		doc: &Document{
+			OpenAPI: Version,
+			Paths:   make(map[string]PathItem),
+		},
+		schemas:   newSchemaBuilder(),
+		ids:       make(map[string]token.Position),
+		paramSets: make(map[string]*paramSet),
+	}
+	pkgs, err := loadPackages(g.fset, opts.Dir, opts.Packages)
+	if err != nil {
+		return nil, err
+	}
+	// Report all the invalid annotations at once.
+	var errs []string
+	// The parameter sets are collected first, so they can be used before being declared.
+	for _, pkg := range pkgs {
+		for _, file := range pkg.files {
+			for _, cg := range file.Comments {
+				if err := g.parseParamSet(pkg, file, cg); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.files {
+			docs := make(map[*ast.CommentGroup]*ast.FuncDecl)
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil {
+					docs[fn.Doc] = fn
+				}
+			}
+			for _, cg := range file.Comments {
+				if err := g.parseBlock(pkg, file, cg, docs[cg]); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+	g.doc.Components.Schemas = g.schemas.schemas
+	return g.doc, nil
+}
+
+// Marshal encodes the document as the indented JSON.
+func Marshal(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+type annotation struct {
+	pos   token.Position
+	key   string
+	value string
+}
+
+func (g *generator) parseAnnotations(cg *ast.CommentGroup) []annotation {
+	var annotations []annotation
+	for _, c := range cg.List {
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if !strings.HasPrefix(text, "@") {
+			continue
+		}
+		key, value, _ := strings.Cut(text, " ")
+		annotations = append(annotations, annotation{
+			pos:   g.fset.Position(c.Pos()),
+			key:   key,
+			value: strings.TrimSpace(value),
+		})
+	}
+	return annotations
+}
+
+func (g *generator) parseParamSet(pkg *sourcePackage, file *ast.File, cg *ast.CommentGroup) error {
+	annotations := g.parseAnnotations(cg)
+	var set *paramSet
+	for _, a := range annotations {
+		if a.key != "@ParamSet" {
+			continue
+		}
+		if set != nil {
+			return errors.Errorf("%s: duplicated @ParamSet in a block", a.pos)
+		}
+		if prev, ok := g.paramSets[a.value]; ok {
+			return errors.Errorf("%s: duplicated parameter set %s with %s", a.pos, a.value, prev.pos)
+		}
+		set = &paramSet{pkg: pkg, file: file, pos: a.pos}
+		g.paramSets[a.value] = set
+	}
+	if set == nil {
+		return nil
+	}
+	for _, a := range annotations {
+		switch a.key {
+		case "@ParamSet":
+		case "@Param":
+			set.annotations = append(set.annotations, a)
+		default:
+			return errors.Errorf("%s: unexpected annotation %s in the parameter set", a.pos, a.key)
+		}
+	}
+	return nil
+}
+
+func (g *generator) parseBlock(pkg *sourcePackage, file *ast.File, cg *ast.CommentGroup, fn *ast.FuncDecl) error {
+	annotations := g.parseAnnotations(cg)
+	var isGeneral, isOperation bool
+	for _, a := range annotations {
+		switch a.key {
+		case "@title":
+			isGeneral = true
+		case "@Router":
+			isOperation = true
+		case "@ParamSet":
+			// It's parsed by parseParamSet.
+			return nil
+		}
+	}
+	if isGeneral {
+		g.parseGeneralInfo(annotations)
+	}
+	if !isOperation {
+		return nil
+	}
+	return g.parseOperations(pkg, file, annotations, fn)
+}
+
+func (g *generator) parseGeneralInfo(annotations []annotation) {
+	info := &g.doc.Info
+	for _, a := range annotations {
+		switch a.key {
+		case "@title":
+			info.Title = a.value
+		case "@version":
+			info.Version = a.value
+		case "@description":
+			info.Description = a.value
+		case "@contact.name", "@contact.url", "@contact.email":
+			if info.Contact == nil {
+				info.Contact = &Contact{}
+			}
+			switch a.key {
+			case "@contact.name":
+				info.Contact.Name = a.value
+			case "@contact.url":
+				info.Contact.URL = a.value
+			default:
+				info.Contact.Email = a.value
+			}
+		case "@license.name":
+			if info.License == nil {
+				info.License = &License{}
+			}
+			info.License.Name = a.value
+		case "@license.url":
+			if info.License == nil {
+				info.License = &License{}
+			}
+			info.License.URL = a.value
+		case "@BasePath":
+			g.doc.Servers = []Server{{URL: a.value}}
+		}
+	}
+}
+
+type route struct {
+	pos    token.Position
+	path   string
+	method string
+}
+
+var routerPattern = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+
+func (g *generator) parseOperations(pkg *sourcePackage, file *ast.File, annotations []annotation, fn *ast.FuncDecl) error {
+	op := &Operation{Responses: make(map[string]*Response)}
+	var (
+		routes   []route
+		ids      []string
+		accepts  []string
+		produces []string
+	)
+	for _, a := range annotations {
+		switch a.key {
+		case "@Tags":
+			for _, tag := range strings.Split(a.value, ",") {
+				op.Tags = append(op.Tags, strings.TrimSpace(tag))
+			}
+		case "@Summary":
+			op.Summary = a.value
+		case "@Description":
+			if op.Description != "" {
+				op.Description += "\n"
+			}
+			op.Description += a.value
+		case "@ID":
+			ids = append(ids, a.value)
+		case "@Accept":
+			accepts = append(accepts, mimeTypes(a.value)...)
+		case "@Produce":
+			produces = append(produces, mimeTypes(a.value)...)
+		case "@Router":
+			m := routerPattern.FindStringSubmatch(a.value)
+			if m == nil {
+				return errors.Errorf("%s: invalid router %q", a.pos, a.value)
+			}
+			routes = append(routes, route{pos: a.pos, path: m[1], method: strings.ToLower(m[2])})
+		case "@Param", "@Params", "@Success", "@Failure":
+			// They are parsed after the media types are known.
+		default:
+			return errors.Errorf("%s: unknown annotation %s", a.pos, a.key)
+		}
+	}
+	if len(accepts) == 0 {
+		accepts = []string{"application/json"}
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+	for _, a := range annotations {
+		var err error
+		switch a.key {
+		case "@Param":
+			err = g.parseParam(pkg, file, op, a, accepts[0])
+		case "@Params":
+			set, ok := g.paramSets[a.value]
+			if !ok {
+				return errors.Errorf("%s: unknown parameter set %s", a.pos, a.value)
+			}
+			for _, p := range set.annotations {
+				if err = g.parseParam(set.pkg, set.file, op, p, accepts[0]); err != nil {
+					break
+				}
+			}
+		case "@Success", "@Failure":
+			err = g.parseResponse(pkg, file, op, a, produces[0])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case len(ids) == len(routes):
+	case len(ids) == 0 && len(routes) == 1 && fn != nil:
+		ids = []string{fn.Name.Name}
+	default:
+		return errors.Errorf("%s: the number of @ID must be the same as @Router", annotations[0].pos)
+	}
+	for i, r := range routes {
+		if pos, ok := g.ids[ids[i]]; ok {
+			return errors.Errorf("%s: duplicated operation ID %s with %s", r.pos, ids[i], pos)
+		}
+		g.ids[ids[i]] = r.pos
+		if err := checkPathParams(r, op); err != nil {
+			return err
+		}
+		item, ok := g.doc.Paths[r.path]
+		if !ok {
+			item = make(PathItem)
+			g.doc.Paths[r.path] = item
+		}
+		if _, ok := item[r.method]; ok {
+			return errors.Errorf("%s: duplicated route %s [%s]", r.pos, r.path, r.method)
+		}
+		routeOp := *op
+		routeOp.OperationID = ids[i]
+		item[r.method] = &routeOp
+	}
+	return nil
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// checkPathParams checks the path parameters are the same as the ones in the path.
+func checkPathParams(r route, op *Operation) error {
+	names := make(map[string]bool)
+	for _, m := range pathParamPattern.FindAllStringSubmatch(r.path, -1) {
+		names[m[1]] = true
+	}
+	for _, p := range op.Parameters {
+		if p.In != InPath {
+			continue
+		}
+		if !names[p.Name] {
+			return errors.Errorf("%s: path parameter %s isn't in the path %s", r.pos, p.Name, r.path)
+		}
+		delete(names, p.Name)
+	}
+	for name := range names {
+		return errors.Errorf("%s: path parameter %s isn't declared", r.pos, name)
+	}
+	return nil
+}
+
+var shortMimeTypes = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"octet-stream":          "application/octet-stream",
+}
+
+func mimeTypes(value string) []string {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if full, ok := shortMimeTypes[t]; ok {
+			t = full
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// splitFields splits the value of an annotation by the spaces. The spaces in the quotes or
+// the parentheses are kept, and the quotes are removed.
+func splitFields(value string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+		inField bool
+		quoted  bool
+		depth   int
+	)
+	for _, r := range value {
+		switch {
+		case quoted:
+			if r == '"' {
+				quoted = false
+			} else {
+				current.WriteRune(r)
+			}
+			continue
+		case r == '"':
+			quoted, inField = true, true
+			continue
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case (r == ' ' || r == '\t') && depth == 0:
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+			continue
+		}
+		current.WriteRune(r)
+		inField = true
+	}
+	if inField {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+func (g *generator) parseParam(pkg *sourcePackage, file *ast.File, op *Operation, a annotation, accept string) error {
+	fields := splitFields(a.value)
+	if len(fields) < 4 {
+		return errors.Errorf("%s: invalid parameter %q", a.pos, a.value)
+	}
+	name, in, typ := fields[0], fields[1], fields[2]
+	required, err := strconv.ParseBool(fields[3])
+	if err != nil {
+		return errors.Errorf("%s: invalid required of parameter %s", a.pos, name)
+	}
+	var desc string
+	if len(fields) > 4 {
+		desc = fields[4]
+	}
+
+	if in == "body" {
+		if op.RequestBody != nil {
+			return errors.Errorf("%s: duplicated body parameter", a.pos)
+		}
+		schema, err := g.resolveType(pkg, file, typ)
+		if err != nil {
+			return errors.Errorf("%s: %v", a.pos, err)
+		}
+		op.RequestBody = &RequestBody{
+			Description: desc,
+			Required:    required,
+			Content:     map[string]*MediaType{accept: {Schema: schema}},
+		}
+		return nil
+	}
+	if in != InPath && in != InQuery && in != InHeader {
+		return errors.Errorf("%s: unsupported parameter location %s", a.pos, in)
+	}
+	if in == InPath && !required {
+		return errors.Errorf("%s: path parameter %s must be required", a.pos, name)
+	}
+	schema, ok := primitiveSchema(typ)
+	if !ok || schema.Type == "object" {
+		return errors.Errorf("%s: unsupported type %s of parameter %s", a.pos, typ, name)
+	}
+	for _, attr := range fields[5:] {
+		key, value, ok := strings.Cut(strings.TrimSuffix(attr, ")"), "(")
+		if !ok {
+			return errors.Errorf("%s: invalid attribute %q", a.pos, attr)
+		}
+		switch strings.ToLower(key) {
+		case "enums":
+			for _, v := range strings.Split(value, ",") {
+				schema.Enum = append(schema.Enum, parseValue(schema.Type, strings.TrimSpace(v)))
+			}
+		case "default":
+			schema.Default = parseValue(schema.Type, value)
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return errors.Errorf("%s: invalid attribute %q", a.pos, attr)
+			}
+			if strings.ToLower(key) == "minimum" {
+				schema.Minimum = &n
+			} else {
+				schema.Maximum = &n
+			}
+		default:
+			return errors.Errorf("%s: unknown attribute %q", a.pos, attr)
+		}
+	}
+	op.Parameters = append(op.Parameters, &Parameter{
+		Name:        name,
+		In:          in,
+		Description: desc,
+		Required:    required,
+		Schema:      schema,
+	})
+	return nil
+}
+
+func parseValue(typ, value string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func (g *generator) parseResponse(pkg *sourcePackage, file *ast.File, op *Operation, a annotation, produce string) error {
+	fields := splitFields(a.value)
+	if len(fields) == 0 {
+		return errors.Errorf("%s: invalid response %q", a.pos, a.value)
+	}
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return errors.Errorf("%s: invalid status code %s", a.pos, fields[0])
+	}
+	resp := &Response{Description: http.StatusText(code)}
+	if len(fields) > 2 && strings.HasPrefix(fields[1], "{") {
+		var schema *Schema
+		switch fields[1] {
+		case "{string}":
+			schema = &Schema{Type: "string"}
+		case "{object}", "{array}":
+			if schema, err = g.resolveType(pkg, file, fields[2]); err != nil {
+				return errors.Errorf("%s: %v", a.pos, err)
+			}
+			if fields[1] == "{array}" {
+				schema = &Schema{Type: "array", Items: schema}
+			}
+		default:
+			return errors.Errorf("%s: invalid response kind %s", a.pos, fields[1])
+		}
+		resp.Content = map[string]*MediaType{produce: {Schema: schema}}
+		fields = fields[3:]
+	} else {
+		fields = fields[1:]
+	}
+	if len(fields) > 0 {
+		resp.Description = fields[0]
+	}
+	key := strconv.Itoa(code)
+	if prev, ok := op.Responses[key]; ok {
+		// Several cases share the status code.
+		prev.Description += "\n" + resp.Description
+		return nil
+	}
+	op.Responses[key] = resp
+	return nil
+}
+
+func primitiveSchema(typ string) (*Schema, bool) {
+	switch typ {
+	case "string":
+		return &Schema{Type: "string"}, true
+	case "integer", "int":
+		return &Schema{Type: "integer"}, true
+	case "number":
+		return &Schema{Type: "number"}, true
+	case "bool", "boolean":
+		return &Schema{Type: "boolean"}, true
+	case "array":
+		return &Schema{Type: "array", Items: &Schema{Type: "string"}}, true
+	case "object":
+		return &Schema{Type: "object"}, true
+	case "interface{}", "any":
+		return &Schema{}, true
+	}
+	return nil, false
+}
+
+// resolveType returns the schema of the type expression in the scope of the file. The type
+// expression is a primitive type, a Go type, or a slice or map of them.
+func (g *generator) resolveType(pkg *sourcePackage, file *ast.File, expr string) (*Schema, error) {
+	if elem := strings.TrimPrefix(expr, "[]"); elem != expr {
+		items, err := g.resolveType(pkg, file, elem)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	}
+	if elem := strings.TrimPrefix(expr, "map[string]"); elem != expr {
+		values, err := g.resolveType(pkg, file, elem)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	}
+	if schema, ok := primitiveSchema(expr); ok {
+		return schema, nil
+	}
+	var obj *types.TypeName
+	if pkgName, name, ok := strings.Cut(expr, "."); ok {
+		var err error
+		if obj, err = pkg.lookupType(file, pkgName, name); err != nil {
+			return nil, err
+		}
+	} else if obj, ok = pkg.pkg.Scope().Lookup(expr).(*types.TypeName); !ok {
+		return nil, errors.Errorf("unknown type %s", expr)
+	}
+	return g.schemas.schemaOf(obj.Type()), nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pingcap/errors"
+)
+
+// sourcePackage is a package type-checked from the source, so that the comments and the
+// unexported types are available.
+type sourcePackage struct {
+	pkg   *types.Package
+	files []*ast.File
+	info  *types.Info
+}
+
+type listedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	Export     string
+	DepOnly    bool
+	Error      *struct{ Err string }
+}
+
+// loadPackages type-checks the packages matching the patterns from the source. The
+// dependencies are imported from the export data built by the go command in dir.
+func loadPackages(fset *token.FileSet, dir string, patterns []string) ([]*sourcePackage, error) {
+	args := append([]string{"list", "-e", "-export", "-deps", "-json=ImportPath,Name,Dir,GoFiles,Export,DepOnly,Error"}, patterns...)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Annotatef(err, "go list: %s", stderr.String())
+	}
+
+	exports := make(map[string]string)
+	var targets []*listedPackage
+	for dec := json.NewDecoder(bytes.NewReader(out)); dec.More(); {
+		p := &listedPackage{}
+		if err := dec.Decode(p); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if p.Error != nil {
+			return nil, errors.Errorf("failed to load package %s: %s", p.ImportPath, p.Error.Err)
+		}
+		exports[p.ImportPath] = p.Export
+		if !p.DepOnly {
+			targets = append(targets, p)
+		}
+	}
+
+	imp := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		export, ok := exports[path]
+		if !ok || export == "" {
+			return nil, errors.Errorf("no export data for package %s", path)
+		}
+		return os.Open(export)
+	})
+	pkgs := make([]*sourcePackage, 0, len(targets))
+	for _, t := range targets {
+		files := make([]*ast.File, 0, len(t.GoFiles))
+		for _, name := range t.GoFiles {
+			f, err := parser.ParseFile(fset, filepath.Join(t.Dir, name), nil, parser.ParseComments)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			files = append(files, f)
+		}
+		info := &types.Info{
+			Defs:      make(map[*ast.Ident]types.Object),
+			Implicits: make(map[ast.Node]types.Object),
+		}
+		conf := types.Config{Importer: imp}
+		pkg, err := conf.Check(t.ImportPath, fset, files, info)
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to type-check package %s", t.ImportPath)
+		}
+		pkgs = append(pkgs, &sourcePackage{pkg: pkg, files: files, info: info})
+	}
+	return pkgs, nil
+}
+
+// lookupType returns the type qualified by the package name in the scope of the file. The
+// package is looked up in the imports of the file first, then the transitive imports of the
+// package like swag does.
+func (p *sourcePackage) lookupType(file *ast.File, pkgName, name string) (*types.TypeName, error) {
+	for _, spec := range file.Imports {
+		var obj types.Object
+		if spec.Name != nil {
+			obj = p.info.Defs[spec.Name]
+		} else {
+			obj = p.info.Implicits[spec]
+		}
+		if imported, ok := obj.(*types.PkgName); ok && imported.Name() == pkgName {
+			if typeName, ok := imported.Imported().Scope().Lookup(name).(*types.TypeName); ok {
+				return typeName, nil
+			}
+			return nil, errors.Errorf("unknown type %s.%s", pkgName, name)
+		}
+	}
+	var found *types.TypeName
+	visited := make(map[*types.Package]bool)
+	queue := []*types.Package{p.pkg}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if visited[pkg] {
+			continue
+		}
+		visited[pkg] = true
+		if pkg.Name() == pkgName {
+			if typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+				if found != nil {
+					return nil, errors.Errorf("ambiguous type %s.%s in %s and %s", pkgName, name, found.Pkg().Path(), pkg.Path())
+				}
+				found = typeName
+			}
+		}
+		queue = append(queue, pkg.Imports()...)
+	}
+	if found == nil {
+		return nil, errors.Errorf("unknown type %s.%s", pkgName, name)
+	}
+	return found, nil
+}
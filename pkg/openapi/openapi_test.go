@@ -0,0 +1,90 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFields(t *testing.T) {
+	re := require.New(t)
+	re.Equal([]string{"id", "path", "integer", "true", "Store Id"},
+		splitFields(`id  path integer true "Store Id"`))
+	re.Equal([]string{"state", "query", "[]string", "false", "The states", "Enums(Up, Offline)"},
+		splitFields(`state query []string false "The states" Enums(Up, Offline)`))
+}
+
+func TestGoNames(t *testing.T) {
+	re := require.New(t)
+	for name, expected := range map[string]string{
+		"region_id":    "RegionID",
+		"startKey":     "StartKey",
+		"next-leader":  "NextLeader",
+		"ttlSecond":    "TTLSecond",
+		"HTTPStatus":   "HTTPStatus",
+		"ids":          "IDs",
+		"gc_safepoint": "GCSafepoint",
+	} {
+		re.Equal(expected, exportedName(name), name)
+	}
+	re.Equal("regionID", unexportedName("region_id"))
+	re.Equal("nextLeader", unexportedName("next_leader"))
+	re.Equal("key", unexportedName("key"))
+}
+
+func TestGenerateClient(t *testing.T) {
+	re := require.New(t)
+	doc := &Document{
+		OpenAPI: Version,
+		Servers: []Server{{URL: "/api"}},
+		Paths: map[string]PathItem{
+			"/store/{id}/label": {
+				"post": &Operation{
+					OperationID: "SetStoreLabel",
+					Parameters: []*Parameter{
+						{Name: "id", In: InPath, Required: true, Schema: &Schema{Type: "integer"}},
+						{Name: "force", In: InQuery, Schema: &Schema{Type: "boolean"}},
+					},
+					RequestBody: &RequestBody{Content: map[string]*MediaType{"application/json": {Schema: &Schema{Type: "object"}}}},
+					Responses: map[string]*Response{
+						"200": {Content: map[string]*MediaType{"application/json": {Schema: refSchema("api.Store")}}},
+					},
+				},
+			},
+		},
+		Components: Components{Schemas: map[string]*Schema{
+			"api.Store": {
+				Type:       "object",
+				Properties: map[string]*Schema{"store_id": {Type: "integer", Format: "uint64"}},
+				GoType:     "api.Store",
+				GoPackage:  "github.com/tikv/pd/server/api",
+			},
+		}},
+	}
+	src, err := GenerateClient(doc, "client")
+	re.NoError(err)
+	code := string(src)
+	re.Contains(code, "func (c *Client) SetStoreLabel(ctx context.Context, id uint64, body map[string]interface{}, opts *SetStoreLabelOptions) (*Store, error)")
+	re.Contains(code, `uri := basePath + "/store/" + url.PathEscape(strconv.FormatUint(id, 10)) + "/label"`)
+	re.Contains(code, "StoreID uint64 `json:\"store_id,omitempty\"`")
+	re.NotContains(code, "github.com/tikv/pd/server/api")
+
+	// The operation ID must be a Go identifier.
+	doc.Paths["/store/{id}/label"]["post"].OperationID = "set-store-label"
+	_, err = GenerateClient(doc, "client")
+	re.Error(err)
+}
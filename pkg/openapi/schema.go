@@ -0,0 +1,206 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// schemaBuilder builds the schemas of the Go types by the rules of encoding/json. The named
+// struct types are added to the component schemas and referenced.
+type schemaBuilder struct {
+	schemas map[string]*Schema
+	// names is the component names of the named types.
+	names map[*types.TypeName]string
+	// owners is the package paths of the component names, to resolve the conflicts.
+	owners map[string]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		schemas: make(map[string]*Schema),
+		names:   make(map[*types.TypeName]string),
+		owners:  make(map[string]string),
+	}
+}
+
+// wellKnownSchemas is the schemas of the types with the custom JSON encoding.
+var wellKnownSchemas = map[string]Schema{
+	"time.Time":     {Type: "string", Format: "date-time"},
+	"time.Duration": {Type: "integer", Format: "int64"},
+	"github.com/tikv/pd/pkg/utils/typeutil.Duration": {Type: "string", Format: "duration"},
+	"github.com/tikv/pd/pkg/utils/typeutil.ByteSize": {Type: "string"},
+	"github.com/tikv/pd/pkg/utils/typeutil.StringSlice": {Type: "string",
+		Description: "Comma separated strings"},
+	"github.com/coreos/go-semver/semver.Version": {Type: "string"},
+}
+
+func (b *schemaBuilder) schemaOf(t types.Type) *Schema {
+	switch t := t.(type) {
+	case *types.Named:
+		return b.namedSchema(t)
+	case *types.Pointer:
+		return b.schemaOf(t.Elem())
+	case *types.Basic:
+		return basicSchema(t)
+	case *types.Slice:
+		if basic, ok := t.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case *types.Array:
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case *types.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case *types.Struct:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		b.addFields(s, t, make(map[*types.Struct]bool))
+		return s
+	default:
+		// The interfaces accept any value.
+		return &Schema{}
+	}
+}
+
+func (b *schemaBuilder) namedSchema(t *types.Named) *Schema {
+	obj := t.Obj()
+	if obj.Pkg() == nil {
+		// The error type.
+		return &Schema{Type: "string"}
+	}
+	if s, ok := wellKnownSchemas[obj.Pkg().Path()+"."+obj.Name()]; ok {
+		return &s
+	}
+	if hasMethod(t, "MarshalJSON") {
+		return &Schema{Description: "The value of " + goTypeName(obj) + " in its JSON encoding."}
+	}
+	if hasMethod(t, "MarshalText") {
+		return &Schema{Type: "string"}
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || t.TypeArgs().Len() > 0 {
+		return b.schemaOf(t.Underlying())
+	}
+	if name, ok := b.names[obj]; ok {
+		return refSchema(name)
+	}
+	name := b.componentName(obj)
+	s := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+		GoType:     goTypeName(obj),
+		GoPackage:  obj.Pkg().Path(),
+	}
+	// Register it before the fields, so that the recursive types can be referenced.
+	b.names[obj] = name
+	b.schemas[name] = s
+	b.addFields(s, st, make(map[*types.Struct]bool))
+	return refSchema(name)
+}
+
+// componentName returns the name of the component schema of the type. It's qualified by the
+// package name, or the package path if the package name is used by another package.
+func (b *schemaBuilder) componentName(obj *types.TypeName) string {
+	name := goTypeName(obj)
+	if owner, ok := b.owners[name]; ok && owner != obj.Pkg().Path() {
+		name = strings.ReplaceAll(obj.Pkg().Path(), "/", ".") + "." + obj.Name()
+	}
+	b.owners[name] = obj.Pkg().Path()
+	return name
+}
+
+// addFields adds the fields of the struct to the properties by the rules of encoding/json.
+// The fields of the embedded structs are promoted if they don't have the JSON names.
+func (b *schemaBuilder) addFields(s *Schema, st *types.Struct, visited map[*types.Struct]bool) {
+	if visited[st] {
+		return
+	}
+	visited[st] = true
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Embedded() && name == "" {
+			typ := field.Type()
+			if p, ok := typ.(*types.Pointer); ok {
+				typ = p.Elem()
+			}
+			if embedded, ok := typ.Underlying().(*types.Struct); ok {
+				if named, ok := typ.(*types.Named); !ok || !hasMethod(named, "MarshalJSON") {
+					b.addFields(s, embedded, visited)
+					continue
+				}
+			}
+		}
+		if !field.Exported() {
+			continue
+		}
+		switch field.Type().Underlying().(type) {
+		case *types.Chan, *types.Signature:
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+		if _, ok := s.Properties[name]; ok {
+			// The shallower field takes precedence.
+			continue
+		}
+		if strings.Contains(opts, "string") {
+			s.Properties[name] = &Schema{Type: "string"}
+			continue
+		}
+		s.Properties[name] = b.schemaOf(field.Type())
+	}
+}
+
+func basicSchema(t *types.Basic) *Schema {
+	switch t.Kind() {
+	case types.Bool:
+		return &Schema{Type: "boolean"}
+	case types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case types.Uint32:
+		return &Schema{Type: "integer", Format: "uint32"}
+	case types.Int, types.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case types.Uint, types.Uint64, types.Uintptr:
+		return &Schema{Type: "integer", Format: "uint64"}
+	case types.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case types.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case types.String:
+		return &Schema{Type: "string"}
+	default:
+		return &Schema{}
+	}
+}
+
+// hasMethod returns whether the type or its pointer has the method.
+func hasMethod(t *types.Named, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, t.Obj().Pkg(), name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+func goTypeName(obj *types.TypeName) string {
+	return obj.Pkg().Name() + "." + obj.Name()
+}
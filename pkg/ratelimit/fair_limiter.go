@@ -0,0 +1,317 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"time"
+
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	defaultFairMinConcurrency = 8
+	defaultFairMaxConcurrency = 256
+	defaultFairMaxQueueLength = 64
+	defaultFairQueueTimeout   = 5 * time.Second
+
+	// latencyWindow is the number of the samples of a label to refresh its no-load latency.
+	latencyWindow = 1000
+	// latencyTolerance is the ratio of the latency to the no-load latency regarded as overloaded.
+	latencyTolerance = 2.0
+	// latencySlack is the minimal difference between the latency and the no-load latency regarded
+	// as overloaded, it avoids treating the jitter of the fast requests as overloaded.
+	latencySlack = 10 * time.Millisecond
+	// backoffRatio is the ratio to decrease the concurrency limit when it's overloaded.
+	backoffRatio = 0.9
+	// maxIdleCallers is the number of the tracked callers to trigger cleaning the idle ones.
+	maxIdleCallers = 1024
+)
+
+// FairQueuingConfig is the config of FairLimiter.
+type FairQueuingConfig struct {
+	// MinConcurrency and MaxConcurrency are the range of the adaptive concurrency limit.
+	MinConcurrency uint64 `json:"min-concurrency"`
+	MaxConcurrency uint64 `json:"max-concurrency"`
+	// MaxQueueLength is the max number of the waiting requests of a caller.
+	MaxQueueLength uint64 `json:"max-queue-length"`
+	// QueueTimeout is the max time of a request waiting in the queue.
+	QueueTimeout typeutil.Duration `json:"queue-timeout"`
+	// CallerWeights is the weights of the callers, the weight of the caller which isn't in it is 1.
+	CallerWeights map[string]float64 `json:"caller-weights"`
+}
+
+// NewFairQueuingConfig returns a FairQueuingConfig with the default values.
+func NewFairQueuingConfig() FairQueuingConfig {
+	return FairQueuingConfig{
+		MinConcurrency: defaultFairMinConcurrency,
+		MaxConcurrency: defaultFairMaxConcurrency,
+		MaxQueueLength: defaultFairMaxQueueLength,
+		QueueTimeout:   typeutil.NewDuration(defaultFairQueueTimeout),
+		CallerWeights:  make(map[string]float64),
+	}
+}
+
+// Clone returns a cloned FairQueuingConfig.
+func (c FairQueuingConfig) Clone() FairQueuingConfig {
+	weights := make(map[string]float64, len(c.CallerWeights))
+	for caller, w := range c.CallerWeights {
+		weights[caller] = w
+	}
+	c.CallerWeights = weights
+	return c
+}
+
+func (c *FairQueuingConfig) weight(caller string) float64 {
+	if w, ok := c.CallerWeights[caller]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// FairLimiter limits the concurrency of the requests and shares it between the callers fairly.
+// The requests exceeding the limit wait in the queue and are scheduled by the start-time fair
+// queuing, so a caller sending too many requests only delays its own requests. The limit adapts
+// to the observed latency of the handlers: it decreases when the latency of a label is much
+// higher than its no-load latency and increases slowly when all the concurrency is in use.
+type FairLimiter struct {
+	mu  syncutil.Mutex
+	cfg FairQueuingConfig
+
+	limit    float64
+	inflight int
+	// virtualTime is the start tag of the latest scheduled request.
+	virtualTime float64
+	// lastFinish is the finish tag of the latest request of the caller.
+	lastFinish map[string]float64
+	queued     map[string]uint64
+	waiters    waiterHeap
+	seq        uint64
+
+	latencies     map[string]*latencyStats
+	sinceDecrease int
+}
+
+type latencyStats struct {
+	// noLoad is the lowest latency in the last window, which is regarded as the latency without load.
+	noLoad    time.Duration
+	windowMin time.Duration
+	samples   int
+}
+
+// NewFairLimiter returns a FairLimiter with the config.
+func NewFairLimiter(cfg FairQueuingConfig) *FairLimiter {
+	l := &FairLimiter{
+		lastFinish: make(map[string]float64),
+		queued:     make(map[string]uint64),
+		latencies:  make(map[string]*latencyStats),
+	}
+	l.UpdateConfig(cfg)
+	return l
+}
+
+// UpdateConfig updates the config, the concurrency limit is adjusted into the new range.
+func (l *FairLimiter) UpdateConfig(cfg FairQueuingConfig) {
+	cfg = cfg.Clone()
+	if cfg.MinConcurrency < 1 {
+		cfg.MinConcurrency = 1
+	}
+	if cfg.MaxConcurrency < cfg.MinConcurrency {
+		cfg.MaxConcurrency = cfg.MinConcurrency
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit == 0 {
+		l.limit = float64(cfg.MaxConcurrency)
+	}
+	l.cfg = cfg
+	l.limit = math.Min(math.Max(l.limit, float64(cfg.MinConcurrency)), float64(cfg.MaxConcurrency))
+	l.dispatch()
+}
+
+// Acquire waits until the request of the caller is allowed to be handled. It returns false if the queue
+// of the caller is full or the request waits too long. Otherwise, the returned function must be called
+// after the request is handled, and the latency is observed with the label to adapt the limit.
+func (l *FairLimiter) Acquire(ctx context.Context, label, caller string) (func(), bool) {
+	l.mu.Lock()
+	start := math.Max(l.virtualTime, l.lastFinish[caller])
+	if l.inflight < int(l.limit) && len(l.waiters) == 0 {
+		l.lastFinish[caller] = start + 1/l.cfg.weight(caller)
+		l.virtualTime = start
+		l.inflight++
+		l.mu.Unlock()
+		return l.releaseFunc(label), true
+	}
+	if l.queued[caller] >= l.cfg.MaxQueueLength {
+		l.mu.Unlock()
+		return nil, false
+	}
+	l.lastFinish[caller] = start + 1/l.cfg.weight(caller)
+	l.queued[caller]++
+	l.seq++
+	w := &waiter{caller: caller, start: start, seq: l.seq, ready: make(chan struct{})}
+	heap.Push(&l.waiters, w)
+	timeout := l.cfg.QueueTimeout.Duration
+	l.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-w.ready:
+		return l.releaseFunc(label), true
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w.index < 0 {
+		// It's scheduled before giving up, so give the concurrency back.
+		l.inflight--
+		l.dispatch()
+		return nil, false
+	}
+	heap.Remove(&l.waiters, w.index)
+	l.dequeue(w.caller)
+	return nil, false
+}
+
+func (l *FairLimiter) releaseFunc(label string) func() {
+	start := time.Now()
+	return func() {
+		latency := time.Since(start)
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.inflight--
+		l.observe(label, latency)
+		l.dispatch()
+	}
+}
+
+// dispatch schedules the waiting requests with the lowest start tags until the limit is reached.
+func (l *FairLimiter) dispatch() {
+	for l.inflight < int(l.limit) && len(l.waiters) > 0 {
+		w := heap.Pop(&l.waiters).(*waiter)
+		l.dequeue(w.caller)
+		l.virtualTime = math.Max(l.virtualTime, w.start)
+		l.inflight++
+		close(w.ready)
+	}
+	if len(l.lastFinish) > maxIdleCallers {
+		// The callers whose finish tags are behind the virtual time have no effect on the scheduling.
+		for caller, finish := range l.lastFinish {
+			if finish <= l.virtualTime && l.queued[caller] == 0 {
+				delete(l.lastFinish, caller)
+			}
+		}
+	}
+}
+
+func (l *FairLimiter) dequeue(caller string) {
+	if l.queued[caller] <= 1 {
+		delete(l.queued, caller)
+		return
+	}
+	l.queued[caller]--
+}
+
+// observe adapts the concurrency limit by the latency of the label.
+func (l *FairLimiter) observe(label string, latency time.Duration) {
+	stats, ok := l.latencies[label]
+	if !ok {
+		stats = &latencyStats{noLoad: latency, windowMin: latency}
+		l.latencies[label] = stats
+	}
+	if latency < stats.noLoad {
+		stats.noLoad = latency
+	}
+	if latency < stats.windowMin {
+		stats.windowMin = latency
+	}
+	stats.samples++
+	if stats.samples >= latencyWindow {
+		stats.noLoad, stats.windowMin, stats.samples = stats.windowMin, latency, 0
+	}
+
+	l.sinceDecrease++
+	threshold := time.Duration(float64(stats.noLoad) * latencyTolerance)
+	if threshold < stats.noLoad+latencySlack {
+		threshold = stats.noLoad + latencySlack
+	}
+	if latency > threshold {
+		// Decrease at most once every limit samples, which is about the time all the inflight
+		// requests are finished, to avoid decreasing too much by the same overload.
+		if float64(l.sinceDecrease) >= l.limit {
+			l.limit = math.Max(l.limit*backoffRatio, float64(l.cfg.MinConcurrency))
+			l.sinceDecrease = 0
+		}
+		return
+	}
+	// The released request is still counted to check whether all the concurrency is in use.
+	if len(l.waiters) > 0 || l.inflight+1 >= int(l.limit) {
+		l.limit = math.Min(l.limit+1/l.limit, float64(l.cfg.MaxConcurrency))
+	}
+}
+
+// GetStatus returns the current concurrency limit, the inflight and waiting requests.
+func (l *FairLimiter) GetStatus() (limit, inflight, waiting int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit), l.inflight, len(l.waiters)
+}
+
+type waiter struct {
+	caller string
+	start  float64
+	seq    uint64
+	index  int
+	ready  chan struct{}
+}
+
+// waiterHeap orders the waiters by the start tags and then the arrival order.
+type waiterHeap []*waiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].start != h[j].start {
+		return h[i].start < h[j].start
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*h = old[:n-1]
+	return w
+}
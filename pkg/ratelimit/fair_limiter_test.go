@@ -0,0 +1,172 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func newTestFairQueuingConfig(concurrency, queueLength uint64) FairQueuingConfig {
+	cfg := NewFairQueuingConfig()
+	cfg.MinConcurrency, cfg.MaxConcurrency = concurrency, concurrency
+	cfg.MaxQueueLength = queueLength
+	return cfg
+}
+
+func TestFairLimiterQueue(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	limiter := NewFairLimiter(newTestFairQueuingConfig(2, 1))
+	ctx := context.Background()
+
+	release1, ok := limiter.Acquire(ctx, "GetStores", "a")
+	re.True(ok)
+	release2, ok := limiter.Acquire(ctx, "GetStores", "a")
+	re.True(ok)
+	// the request waits until it's timeout.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	_, ok = limiter.Acquire(timeoutCtx, "GetStores", "a")
+	cancel()
+	re.False(ok)
+	_, inflight, waiting := limiter.GetStatus()
+	re.Equal(2, inflight)
+	re.Equal(0, waiting)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		release, ok := limiter.Acquire(ctx, "GetStores", "a")
+		re.True(ok)
+		release()
+	}()
+	re.Eventually(func() bool {
+		_, _, waiting := limiter.GetStatus()
+		return waiting == 1
+	}, time.Second, time.Millisecond)
+	// the queue of the caller is full, but the other callers can still wait.
+	_, ok = limiter.Acquire(ctx, "GetStores", "a")
+	re.False(ok)
+	timeoutCtx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	_, ok = limiter.Acquire(timeoutCtx, "GetStores", "b")
+	cancel()
+	re.False(ok)
+
+	release1()
+	<-done
+	release2()
+	_, inflight, waiting = limiter.GetStatus()
+	re.Equal(0, inflight)
+	re.Equal(0, waiting)
+
+	// the queue timeout is also respected.
+	cfg := newTestFairQueuingConfig(1, 1)
+	cfg.QueueTimeout = typeutil.NewDuration(10 * time.Millisecond)
+	limiter.UpdateConfig(cfg)
+	release, ok := limiter.Acquire(ctx, "GetStores", "a")
+	re.True(ok)
+	_, ok = limiter.Acquire(ctx, "GetStores", "b")
+	re.False(ok)
+	release()
+}
+
+func TestFairLimiterFairness(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	cfg := newTestFairQueuingConfig(1, 10)
+	cfg.CallerWeights["weighted"] = 2
+	limiter := NewFairLimiter(cfg)
+	ctx := context.Background()
+
+	release, ok := limiter.Acquire(ctx, "GetRegions", "heavy")
+	re.True(ok)
+	var (
+		mu    sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+	enqueue := func(caller string) {
+		_, _, waiting := limiter.GetStatus()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, ok := limiter.Acquire(ctx, "GetRegions", caller)
+			re.True(ok)
+			mu.Lock()
+			order = append(order, caller)
+			mu.Unlock()
+			release()
+		}()
+		re.Eventually(func() bool {
+			_, _, w := limiter.GetStatus()
+			return w == waiting+1
+		}, time.Second, time.Millisecond)
+	}
+	for i := 0; i < 3; i++ {
+		enqueue("heavy")
+	}
+	enqueue("light")
+	for i := 0; i < 4; i++ {
+		enqueue("weighted")
+	}
+	release()
+	wg.Wait()
+	// the light caller isn't starved by the heavy one which comes earlier, and the weighted
+	// caller gets twice as many chances as the others.
+	re.Equal([]string{"light", "weighted", "weighted", "heavy", "weighted", "weighted", "heavy", "heavy"}, order)
+}
+
+func TestFairLimiterAdaptive(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	cfg := NewFairQueuingConfig()
+	cfg.MinConcurrency, cfg.MaxConcurrency = 10, 100
+	limiter := NewFairLimiter(cfg)
+	limit, _, _ := limiter.GetStatus()
+	re.Equal(100, limit)
+
+	limiter.mu.Lock()
+	limiter.observe("GetRegions", time.Millisecond)
+	// the slow requests decrease the limit to the min concurrency at most.
+	for i := 0; i < 10000; i++ {
+		limiter.observe("GetRegions", time.Second)
+	}
+	re.Equal(10., limiter.limit)
+	// the jitter of the fast requests doesn't decrease the limit.
+	limiter.observe("GetStores", 100*time.Microsecond)
+	for i := 0; i < 100; i++ {
+		limiter.observe("GetStores", time.Millisecond)
+	}
+	re.Equal(10., limiter.limit)
+	// the limit increases if all the concurrency is in use.
+	for i := 0; i < 100; i++ {
+		limiter.inflight = int(limiter.limit) - 1
+		limiter.observe("GetStores", time.Millisecond)
+	}
+	re.Greater(limiter.limit, 15.)
+	limiter.inflight = 0
+	limiter.mu.Unlock()
+
+	// the limit is adjusted into the new range.
+	cfg.MinConcurrency, cfg.MaxConcurrency = 1, 5
+	limiter.UpdateConfig(cfg)
+	limit, _, _ = limiter.GetStatus()
+	re.Equal(5, limit)
+}
@@ -0,0 +1,277 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"go.uber.org/zap"
+)
+
+// Manager manages the custom roles and the role bindings persisted in the storage.
+type Manager struct {
+	mu       syncutil.RWMutex
+	storage  endpoint.RBACStorage
+	roles    map[string]*Role    // role name -> custom role
+	bindings map[string]*Binding // subject -> binding
+	// enabled returns whether RBAC is enabled, the last admin binding can't be removed then.
+	enabled func() bool
+}
+
+// NewManager creates a RBAC manager.
+func NewManager(storage endpoint.RBACStorage, enabled func() bool) *Manager {
+	return &Manager{
+		storage:  storage,
+		enabled:  enabled,
+		roles:    make(map[string]*Role),
+		bindings: make(map[string]*Binding),
+	}
+}
+
+// Load loads the custom roles and the role bindings from the storage.
+func (m *Manager) Load() error {
+	roles := make(map[string]*Role)
+	if err := m.storage.LoadRBACRoles(func(k, v string) {
+		role := &Role{}
+		if err := json.Unmarshal([]byte(v), role); err != nil {
+			log.Error("failed to unmarshal role", zap.String("role-key", k), errs.ZapError(errs.ErrJSONUnmarshal, err))
+			return
+		}
+		roles[role.Name] = role
+	}); err != nil {
+		return err
+	}
+	bindings := make(map[string]*Binding)
+	if err := m.storage.LoadRBACBindings(func(k, v string) {
+		binding := &Binding{}
+		if err := json.Unmarshal([]byte(v), binding); err != nil {
+			log.Error("failed to unmarshal role binding", zap.String("binding-key", k), errs.ZapError(errs.ErrJSONUnmarshal, err))
+			return
+		}
+		bindings[binding.Subject()] = binding
+	}); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roles, m.bindings = roles, bindings
+	return nil
+}
+
+// GetRoles returns all the built-in and custom roles.
+func (m *Manager) GetRoles() []*Role {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	roles := make([]*Role, 0, len(builtInRoles)+len(m.roles))
+	for _, role := range builtInRoles {
+		roles = append(roles, role)
+	}
+	for _, role := range m.roles {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles
+}
+
+// GetRole returns the role by name.
+func (m *Manager) GetRole(name string) (*Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	role := m.getRoleLocked(name)
+	if role == nil {
+		return nil, errs.ErrRoleNotFound.FastGenByArgs(name)
+	}
+	return role, nil
+}
+
+func (m *Manager) getRoleLocked(name string) *Role {
+	if role, ok := builtInRoles[name]; ok {
+		return role
+	}
+	return m.roles[name]
+}
+
+// SetRole creates or updates a custom role.
+func (m *Manager) SetRole(role *Role) error {
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	if _, ok := builtInRoles[role.Name]; ok {
+		return errs.ErrRoleBuiltIn.FastGenByArgs(role.Name)
+	}
+	role.BuiltIn = false
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.storage.SaveRBACRole(role.Name, role); err != nil {
+		return err
+	}
+	m.roles[role.Name] = role
+	log.Info("role is updated", zap.Reflect("role", role))
+	return nil
+}
+
+// DeleteRole deletes a custom role which isn't used by any binding.
+func (m *Manager) DeleteRole(name string) error {
+	if _, ok := builtInRoles[name]; ok {
+		return errs.ErrRoleBuiltIn.FastGenByArgs(name)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.roles[name]; !ok {
+		return errs.ErrRoleNotFound.FastGenByArgs(name)
+	}
+	for _, binding := range m.bindings {
+		for _, r := range binding.Roles {
+			if r == name {
+				return errs.ErrRoleInUse.FastGenByArgs(name, binding.Subject())
+			}
+		}
+	}
+	if err := m.storage.DeleteRBACRole(name); err != nil {
+		return err
+	}
+	delete(m.roles, name)
+	log.Info("role is deleted", zap.String("role", name))
+	return nil
+}
+
+// GetBindings returns all the role bindings.
+func (m *Manager) GetBindings() []*Binding {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	bindings := make([]*Binding, 0, len(m.bindings))
+	for _, binding := range m.bindings {
+		bindings = append(bindings, binding)
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Subject() < bindings[j].Subject() })
+	return bindings
+}
+
+// SetBinding binds the roles to the subject, the existing binding of the subject is replaced.
+// The value of the token binding is the token itself, and only its hash is stored.
+func (m *Manager) SetBinding(binding *Binding) error {
+	if err := binding.Validate(); err != nil {
+		return err
+	}
+	if binding.Kind == KindToken {
+		binding.Value = HashToken(binding.Value)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, name := range binding.Roles {
+		if m.getRoleLocked(name) == nil {
+			return errs.ErrRoleNotFound.FastGenByArgs(name)
+		}
+	}
+	if !binding.hasRole(RoleAdmin) {
+		if err := m.checkLastAdminLocked(binding.Subject()); err != nil {
+			return err
+		}
+	}
+	if err := m.storage.SaveRBACBinding(binding.Subject(), binding); err != nil {
+		return err
+	}
+	m.bindings[binding.Subject()] = binding
+	log.Info("role binding is updated", zap.String("subject", binding.Subject()), zap.Strings("roles", binding.Roles))
+	return nil
+}
+
+// DeleteBinding deletes the role binding of the subject. The value of the token binding
+// can be either the token or its hash.
+func (m *Manager) DeleteBinding(kind, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subject := kind + ":" + value
+	if _, ok := m.bindings[subject]; !ok && kind == KindToken {
+		subject = kind + ":" + HashToken(value)
+	}
+	if _, ok := m.bindings[subject]; !ok {
+		return errs.ErrBindingNotFound.FastGenByArgs(subject)
+	}
+	if err := m.checkLastAdminLocked(subject); err != nil {
+		return err
+	}
+	if err := m.storage.DeleteRBACBinding(subject); err != nil {
+		return err
+	}
+	delete(m.bindings, subject)
+	log.Info("role binding is deleted", zap.String("subject", subject))
+	return nil
+}
+
+// HasAdmin returns whether any subject is bound to the admin role. It's required before
+// enabling RBAC, otherwise nobody is able to manage the access control.
+func (m *Manager) HasAdmin() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.hasAdminLocked("")
+}
+
+// hasAdminLocked returns whether any subject except the given one is bound to the admin role.
+func (m *Manager) hasAdminLocked(except string) bool {
+	for subject, binding := range m.bindings {
+		if subject != except && binding.hasRole(RoleAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLastAdminLocked returns an error if the binding of the subject is the last admin one and
+// RBAC is enabled, since nobody is able to manage the access control after it's removed.
+func (m *Manager) checkLastAdminLocked(subject string) error {
+	if m.enabled == nil || !m.enabled() {
+		return nil
+	}
+	if binding, ok := m.bindings[subject]; ok && binding.hasRole(RoleAdmin) && !m.hasAdminLocked(subject) {
+		return errs.ErrLastAdminBinding.FastGenByArgs(subject)
+	}
+	return nil
+}
+
+// HasRole returns whether any of the subjects is bound to the role.
+func (m *Manager) HasRole(subjects []string, role string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, subject := range subjects {
+		if binding, ok := m.bindings[subject]; ok && binding.hasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowed returns whether any of the subjects is allowed to do the action on the service.
+func (m *Manager) IsAllowed(subjects []string, service, action string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, subject := range subjects {
+		binding, ok := m.bindings[subject]
+		if !ok {
+			continue
+		}
+		for _, name := range binding.Roles {
+			if role := m.getRoleLocked(name); role != nil && role.IsAllowed(service, action) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// The actions of the services. The HTTP services with the method GET, HEAD or OPTIONS and the gRPC
+// services whose names start with Get, Scan, Load, Watch or Is are read, others are write.
+const (
+	ActionRead  = "read"
+	ActionWrite = "write"
+)
+
+// The kinds of the subjects which the roles are bound to.
+const (
+	// KindCN is the common name of the client certificate.
+	KindCN = "cn"
+	// KindSAN is the DNS name, IP address, email address or URI in the subject alternative names of the client certificate.
+	KindSAN = "san"
+	// KindToken is the bearer token in the Authorization header, only its SHA-256 hash is stored.
+	KindToken = "token"
+)
+
+// The built-in roles.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// AdminServices are the services only allowed for the admin role, which are excluded from the
+// operator role. They are the HTTP services which may break the cluster or the access control.
+var AdminServices = []string{
+	"RemoveFailedStores",
+	"ApproveFailedStoresRemovalPlan",
+	"ResetTS",
+	"RecoverAllocID",
+	"MarkSnapshotRecovering",
+	"UnmarkSnapshotRecovering",
+	"SavePersistFile",
+	"LoadPlugin",
+	"UnloadPlugin",
+	"SetServiceMiddlewareConfig",
+	"SetRatelimitConfig",
+	"SetRBACRole",
+	"DeleteRBACRole",
+	"SetRBACBinding",
+	"DeleteRBACBinding",
+}
+
+// Permission allows the actions on the services. The services are the HTTP service labels like
+// "GetStore" or the gRPC methods like "pdpb.PD/GetRegion", which support the glob pattern and
+// "*" matches all the services.
+type Permission struct {
+	Actions          []string `json:"actions"`
+	Services         []string `json:"services"`
+	ExcludedServices []string `json:"excluded-services,omitempty"`
+}
+
+// Role is a set of the permissions.
+type Role struct {
+	Name        string        `json:"name"`
+	Permissions []*Permission `json:"permissions"`
+	BuiltIn     bool          `json:"built-in,omitempty"`
+}
+
+// Binding binds the roles to a subject.
+type Binding struct {
+	Kind  string   `json:"kind"`
+	Value string   `json:"value"`
+	Roles []string `json:"roles"`
+}
+
+var builtInRoles = map[string]*Role{
+	RoleViewer: {
+		Name:        RoleViewer,
+		Permissions: []*Permission{{Actions: []string{ActionRead}, Services: []string{"*"}}},
+		BuiltIn:     true,
+	},
+	RoleOperator: {
+		Name:        RoleOperator,
+		Permissions: []*Permission{{Actions: []string{ActionRead, ActionWrite}, Services: []string{"*"}, ExcludedServices: AdminServices}},
+		BuiltIn:     true,
+	},
+	RoleAdmin: {
+		Name:        RoleAdmin,
+		Permissions: []*Permission{{Actions: []string{ActionRead, ActionWrite}, Services: []string{"*"}}},
+		BuiltIn:     true,
+	},
+}
+
+// Validate checks whether the role is valid.
+func (r *Role) Validate() error {
+	if r.Name == "" {
+		return errors.New("the name of the role is empty")
+	}
+	if strings.Contains(r.Name, "/") {
+		return errors.Errorf("the name of the role %s contains '/'", r.Name)
+	}
+	if len(r.Permissions) == 0 {
+		return errors.Errorf("the role %s has no permission", r.Name)
+	}
+	for _, p := range r.Permissions {
+		if len(p.Actions) == 0 || len(p.Services) == 0 {
+			return errors.Errorf("the permission of the role %s should have both actions and services", r.Name)
+		}
+		for _, action := range p.Actions {
+			if action != ActionRead && action != ActionWrite {
+				return errors.Errorf("invalid action %s, it should be %s or %s", action, ActionRead, ActionWrite)
+			}
+		}
+		for _, patterns := range [][]string{p.Services, p.ExcludedServices} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return errors.Errorf("invalid service pattern %s", pattern)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// IsAllowed returns whether the role allows the action on the service.
+func (r *Role) IsAllowed(service, action string) bool {
+	for _, p := range r.Permissions {
+		if p.isAllowed(service, action) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Permission) isAllowed(service, action string) bool {
+	found := false
+	for _, a := range p.Actions {
+		if a == action {
+			found = true
+			break
+		}
+	}
+	return found && matchServices(p.Services, service) && !matchServices(p.ExcludedServices, service)
+}
+
+func matchServices(patterns []string, service string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		if ok, _ := path.Match(pattern, service); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Subject returns the subject of the binding.
+func (b *Binding) Subject() string {
+	return b.Kind + ":" + b.Value
+}
+
+func (b *Binding) hasRole(role string) bool {
+	for _, r := range b.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks whether the binding is valid.
+func (b *Binding) Validate() error {
+	switch b.Kind {
+	case KindCN, KindSAN, KindToken:
+	default:
+		return errors.Errorf("invalid kind %s, it should be %s, %s or %s", b.Kind, KindCN, KindSAN, KindToken)
+	}
+	if b.Value == "" {
+		return errors.New("the value of the binding is empty")
+	}
+	if len(b.Roles) == 0 {
+		return errors.Errorf("the binding %s has no role", b.Subject())
+	}
+	return nil
+}
+
+// HashToken returns the SHA-256 hash of the token in hex.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// HTTPAction returns the action of the HTTP method.
+func HTTPAction(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ActionRead
+	default:
+		return ActionWrite
+	}
+}
+
+// GRPCAction returns the action of the gRPC method like "pdpb.PD/GetRegion".
+func GRPCAction(method string) string {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Scan", "Load", "Watch", "Subscribe", "Is"} {
+		if strings.HasPrefix(name, prefix) {
+			return ActionRead
+		}
+	}
+	return ActionWrite
+}
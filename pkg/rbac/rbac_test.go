@@ -0,0 +1,176 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestBuiltInRoles(t *testing.T) {
+	re := require.New(t)
+	re.True(builtInRoles[RoleViewer].IsAllowed("GetStore", ActionRead))
+	re.False(builtInRoles[RoleViewer].IsAllowed("SetStoreState", ActionWrite))
+	re.True(builtInRoles[RoleOperator].IsAllowed("SetStoreState", ActionWrite))
+	re.True(builtInRoles[RoleOperator].IsAllowed("pdpb.PD/RegionHeartbeat", ActionWrite))
+	re.False(builtInRoles[RoleOperator].IsAllowed("RemoveFailedStores", ActionWrite))
+	re.False(builtInRoles[RoleOperator].IsAllowed("SetRBACBinding", ActionWrite))
+	re.True(builtInRoles[RoleAdmin].IsAllowed("RemoveFailedStores", ActionWrite))
+}
+
+func TestRoleValidate(t *testing.T) {
+	re := require.New(t)
+	re.Error((&Role{}).Validate())
+	re.Error((&Role{Name: "a/b", Permissions: []*Permission{{Actions: []string{ActionRead}, Services: []string{"*"}}}}).Validate())
+	re.Error((&Role{Name: "r"}).Validate())
+	re.Error((&Role{Name: "r", Permissions: []*Permission{{Actions: []string{"delete"}, Services: []string{"*"}}}}).Validate())
+	re.Error((&Role{Name: "r", Permissions: []*Permission{{Actions: []string{ActionRead}, Services: []string{"["}}}}).Validate())
+	re.Error((&Role{Name: "r", Permissions: []*Permission{{Actions: []string{ActionRead}, Services: []string{"*"}, ExcludedServices: []string{"["}}}}).Validate())
+
+	role := &Role{Name: "r", Permissions: []*Permission{
+		{Actions: []string{ActionRead}, Services: []string{"Get*"}},
+		{Actions: []string{ActionRead, ActionWrite}, Services: []string{"pdpb.PD/*"}, ExcludedServices: []string{"pdpb.PD/Bootstrap"}},
+	}}
+	re.NoError(role.Validate())
+	re.True(role.IsAllowed("GetStore", ActionRead))
+	re.False(role.IsAllowed("GetStore", ActionWrite))
+	re.False(role.IsAllowed("SetStoreState", ActionRead))
+	re.True(role.IsAllowed("pdpb.PD/AllocID", ActionWrite))
+	re.False(role.IsAllowed("pdpb.PD/Bootstrap", ActionWrite))
+}
+
+func TestAction(t *testing.T) {
+	re := require.New(t)
+	re.Equal(ActionRead, HTTPAction(http.MethodGet))
+	re.Equal(ActionWrite, HTTPAction(http.MethodPost))
+	re.Equal(ActionWrite, HTTPAction(http.MethodDelete))
+	re.Equal(ActionRead, GRPCAction("pdpb.PD/GetRegion"))
+	re.Equal(ActionRead, GRPCAction("pdpb.PD/ScanRegions"))
+	re.Equal(ActionRead, GRPCAction("pdpb.PD/IsBootstrapped"))
+	re.Equal(ActionRead, GRPCAction("resource_manager.ResourceManager/ListResourceGroups"))
+	re.Equal(ActionRead, GRPCAction("pdlocal.Events/Subscribe"))
+	re.Equal(ActionWrite, GRPCAction("pdpb.PD/AllocID"))
+	re.Equal(ActionWrite, GRPCAction("pdpb.PD/RegionHeartbeat"))
+}
+
+func TestManager(t *testing.T) {
+	re := require.New(t)
+	s := storage.NewStorageWithMemoryBackend()
+	enabled := false
+	m := NewManager(s, func() bool { return enabled })
+	re.NoError(m.Load())
+	re.Len(m.GetRoles(), 3)
+	re.False(m.HasAdmin())
+
+	// built-in roles can't be changed.
+	re.True(errs.ErrRoleBuiltIn.Equal(m.SetRole(&Role{Name: RoleAdmin, Permissions: builtInRoles[RoleViewer].Permissions})))
+	re.True(errs.ErrRoleBuiltIn.Equal(m.DeleteRole(RoleViewer)))
+	re.True(errs.ErrRoleNotFound.Equal(m.DeleteRole("reader")))
+
+	reader := &Role{Name: "reader", Permissions: []*Permission{{Actions: []string{ActionRead}, Services: []string{"GetStore"}}}}
+	re.NoError(m.SetRole(reader))
+	role, err := m.GetRole("reader")
+	re.NoError(err)
+	re.Equal(reader, role)
+
+	// bindings must refer to the existing roles.
+	re.True(errs.ErrRoleNotFound.Equal(m.SetBinding(&Binding{Kind: KindCN, Value: "tidb", Roles: []string{"unknown"}})))
+	re.Error(m.SetBinding(&Binding{Kind: "ip", Value: "127.0.0.1", Roles: []string{RoleAdmin}}))
+	re.NoError(m.SetBinding(&Binding{Kind: KindCN, Value: "tidb", Roles: []string{"reader"}}))
+	re.NoError(m.SetBinding(&Binding{Kind: KindToken, Value: "secret", Roles: []string{RoleAdmin}}))
+	re.True(m.HasAdmin())
+	re.True(m.HasRole([]string{"token:" + HashToken("secret")}, RoleAdmin))
+
+	// the token is stored as its hash.
+	for _, b := range m.GetBindings() {
+		re.NotEqual("secret", b.Value)
+	}
+	re.True(m.IsAllowed([]string{"cn:tidb"}, "GetStore", ActionRead))
+	re.False(m.IsAllowed([]string{"cn:tidb"}, "GetRegion", ActionRead))
+	re.False(m.IsAllowed([]string{"cn:tikv"}, "GetStore", ActionRead))
+	re.True(m.IsAllowed([]string{"cn:tikv", "token:" + HashToken("secret")}, "RemoveFailedStores", ActionWrite))
+
+	// the role in use can't be deleted.
+	re.True(errs.ErrRoleInUse.Equal(m.DeleteRole("reader")))
+
+	// reload from the storage.
+	m2 := NewManager(s, func() bool { return false })
+	re.NoError(m2.Load())
+	re.Len(m2.GetRoles(), 4)
+	re.Len(m2.GetBindings(), 2)
+	re.True(m2.IsAllowed([]string{"cn:tidb"}, "GetStore", ActionRead))
+
+	re.NoError(m.DeleteBinding(KindCN, "tidb"))
+	re.True(errs.ErrBindingNotFound.Equal(m.DeleteBinding(KindCN, "tidb")))
+	re.NoError(m.DeleteRole("reader"))
+	// the last admin binding can't be removed or downgraded while RBAC is enabled.
+	enabled = true
+	re.True(errs.ErrLastAdminBinding.Equal(m.DeleteBinding(KindToken, "secret")))
+	re.True(errs.ErrLastAdminBinding.Equal(m.SetBinding(&Binding{Kind: KindToken, Value: "secret", Roles: []string{RoleViewer}})))
+	re.NoError(m.SetBinding(&Binding{Kind: KindCN, Value: "admin", Roles: []string{RoleAdmin}}))
+	re.NoError(m.DeleteBinding(KindCN, "admin"))
+	enabled = false
+	// the token binding can be deleted by the token itself.
+	re.NoError(m.DeleteBinding(KindToken, "secret"))
+	re.False(m.HasAdmin())
+	re.NoError(m2.Load())
+	re.Len(m2.GetRoles(), 3)
+	re.Empty(m2.GetBindings())
+}
+
+func TestClientSubjectsFromHTTP(t *testing.T) {
+	re := require.New(t)
+	m := NewManager(storage.NewStorageWithMemoryBackend(), func() bool { return true })
+	re.NoError(m.Load())
+	re.NoError(m.SetBinding(&Binding{Kind: KindCN, Value: "pd", Roles: []string{RoleAdmin}}))
+	newRequest := func(cn string) *http.Request {
+		r, err := http.NewRequest(http.MethodGet, "/pd/api/v1/stores", http.NoBody)
+		re.NoError(err)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: cn}}}}
+		return r
+	}
+
+	r := newRequest("pd")
+	re.Equal([]string{"cn:pd"}, m.ClientSubjectsFromHTTP(r))
+	// the request redirected by the admin peer uses the forwarded subjects.
+	r.Header.Set(ForwardedByHeader, "pd-1")
+	r.Header.Add(ForwardedSubjectsHeader, "cn:tidb")
+	re.Equal([]string{"cn:tidb"}, m.ClientSubjectsFromHTTP(r))
+	// and never the certificate of the peer if nothing is forwarded.
+	r.Header.Del(ForwardedSubjectsHeader)
+	re.Empty(m.ClientSubjectsFromHTTP(r))
+	r.Header.Set(authorizationHeader, "Bearer secret")
+	re.Equal([]string{"token:" + HashToken("secret")}, m.ClientSubjectsFromHTTP(r))
+	// the forwarded subjects of the peer which isn't an admin are ignored.
+	r = newRequest("tidb")
+	r.Header.Set(ForwardedByHeader, "pd-1")
+	r.Header.Add(ForwardedSubjectsHeader, "cn:pd")
+	re.Equal([]string{"cn:tidb"}, m.ClientSubjectsFromHTTP(r))
+
+	// the subjects of the client are forwarded to the request sent by the admin peer on behalf of it.
+	r = newRequest("tidb")
+	r.Header.Set(authorizationHeader, "Bearer secret")
+	req := newRequest("pd")
+	m.ForwardClientSubjects(r, req, "pd-1")
+	re.Equal("pd-1", req.Header.Get(ForwardedByHeader))
+	re.Equal([]string{"cn:tidb", "token:" + HashToken("secret")}, m.ClientSubjectsFromHTTP(req))
+}
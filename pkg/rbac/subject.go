@@ -0,0 +1,156 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	// ForwardedSubjectsHeader carries the certificate subjects of the client when a PD follower
+	// redirects the request to the leader, since the leader only sees the certificate of the follower.
+	ForwardedSubjectsHeader = "PD-Forwarded-Subjects"
+	// ForwardedByHeader carries the name of the server which forwards the subjects.
+	ForwardedByHeader = "PD-Forwarded-By"
+)
+
+// SubjectsFromHTTP returns the subjects of the HTTP request, which are from the client
+// certificate and the bearer token.
+func SubjectsFromHTTP(r *http.Request) []string {
+	return append(CertSubjectsFromHTTP(r), TokenSubjectsFromHTTP(r)...)
+}
+
+// CertSubjectsFromHTTP returns the subjects of the client certificate of the HTTP request.
+func CertSubjectsFromHTTP(r *http.Request) []string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	return subjectsFromCert(r.TLS.PeerCertificates[0])
+}
+
+// TokenSubjectsFromHTTP returns the subjects of the bearer token of the HTTP request.
+func TokenSubjectsFromHTTP(r *http.Request) []string {
+	if token := parseBearerToken(r.Header.Get(authorizationHeader)); token != "" {
+		return []string{KindToken + ":" + HashToken(token)}
+	}
+	return nil
+}
+
+// ClientSubjectsFromHTTP returns the subjects of the client of the HTTP request. The request
+// redirected by a PD follower carries the certificate of the follower, so if the follower is an
+// admin, only the subjects it forwards are used, and never the certificate of the follower itself.
+func (m *Manager) ClientSubjectsFromHTTP(r *http.Request) []string {
+	subjects := CertSubjectsFromHTTP(r)
+	if isRedirected(r) && m.HasRole(subjects, RoleAdmin) {
+		subjects = ForwardedSubjectsFromHTTP(r)
+	}
+	return append(subjects, TokenSubjectsFromHTTP(r)...)
+}
+
+func isRedirected(r *http.Request) bool {
+	return r.Header.Get(ForwardedByHeader) != "" || len(r.Header.Values(ForwardedSubjectsHeader)) > 0
+}
+
+// ForwardSubjects sets the certificate subjects of the client to the request which is redirected
+// by the server, since the receiver only sees the certificate of the server.
+func ForwardSubjects(r *http.Request, server string) {
+	r.Header.Set(ForwardedByHeader, server)
+	r.Header.Del(ForwardedSubjectsHeader)
+	for _, subject := range CertSubjectsFromHTTP(r) {
+		r.Header.Add(ForwardedSubjectsHeader, subject)
+	}
+}
+
+// ForwardClientSubjects sets the subjects of the client of the request r to the request req, which
+// is sent by the server on behalf of the client, e.g. to collect the data from the other members.
+func (m *Manager) ForwardClientSubjects(r, req *http.Request, server string) {
+	req.Header.Set(ForwardedByHeader, server)
+	req.Header.Del(ForwardedSubjectsHeader)
+	for _, subject := range m.ClientSubjectsFromHTTP(r) {
+		if strings.HasPrefix(subject, KindCN+":") || strings.HasPrefix(subject, KindSAN+":") {
+			req.Header.Add(ForwardedSubjectsHeader, subject)
+		}
+	}
+	if auth := r.Header.Get(authorizationHeader); auth != "" {
+		req.Header.Set(authorizationHeader, auth)
+	}
+}
+
+// ForwardedSubjectsFromHTTP returns the subjects forwarded by the PD follower.
+func ForwardedSubjectsFromHTTP(r *http.Request) []string {
+	var subjects []string
+	for _, subject := range r.Header.Values(ForwardedSubjectsHeader) {
+		// Only the certificate subjects are forwarded, the token is sent by the client itself.
+		if strings.HasPrefix(subject, KindCN+":") || strings.HasPrefix(subject, KindSAN+":") {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
+
+// SubjectsFromGRPC returns the subjects of the gRPC request, which are from the client
+// certificate and the bearer token in the metadata.
+func SubjectsFromGRPC(ctx context.Context) []string {
+	var subjects []string
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) > 0 {
+			subjects = subjectsFromCert(info.State.PeerCertificates[0])
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(strings.ToLower(authorizationHeader)) {
+			if token := parseBearerToken(v); token != "" {
+				subjects = append(subjects, KindToken+":"+HashToken(token))
+			}
+		}
+	}
+	return subjects
+}
+
+func subjectsFromCert(cert *x509.Certificate) []string {
+	var subjects []string
+	if cert.Subject.CommonName != "" {
+		subjects = append(subjects, KindCN+":"+cert.Subject.CommonName)
+	}
+	for _, name := range cert.DNSNames {
+		subjects = append(subjects, KindSAN+":"+name)
+	}
+	for _, ip := range cert.IPAddresses {
+		subjects = append(subjects, KindSAN+":"+ip.String())
+	}
+	for _, email := range cert.EmailAddresses {
+		subjects = append(subjects, KindSAN+":"+email)
+	}
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, KindSAN+":"+uri.String())
+	}
+	return subjects
+}
+
+func parseBearerToken(v string) string {
+	if len(v) <= len(bearerPrefix) || !strings.EqualFold(v[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(v[len(bearerPrefix):])
+}
@@ -242,3 +242,9 @@ func (c *Controller) GetPauseController(name string) (*PauseController, error) {
 		return nil, errs.ErrCheckerNotFound.FastGenByArgs()
 	}
 }
+
+// isDrainingPeer returns whether the peer of the region is moved out of the store by the store drain
+// instead of the checkers. The peers which the drain can't move out are still repaired by the checkers.
+func isDrainingPeer(cluster schedule.Cluster, storeID, regionID uint64) bool {
+	return cluster.IsStoreDraining(storeID) && !cluster.IsStoreDrainBlocked(storeID, regionID)
+}
@@ -31,12 +31,18 @@ import (
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/utils/logutil"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 const (
 	maxTargetRegionSize   = 500
 	maxTargetRegionFactor = 4
+	// coldRegionMergeFactor is the factor of the merge thresholds for the regions which have been cold
+	// for a long time, so that they can be merged more aggressively.
+	coldRegionMergeFactor = 2
 )
 
 // When a region has label `merge_option=deny`, skip merging the region.
@@ -58,6 +64,9 @@ var (
 	mergeCheckerSpecialPeerCounter          = checkerCounter.WithLabelValues(mergeCheckerName, "special-peer")
 	mergeCheckerAbnormalReplicaCounter      = checkerCounter.WithLabelValues(mergeCheckerName, "abnormal-replica")
 	mergeCheckerHotRegionCounter            = checkerCounter.WithLabelValues(mergeCheckerName, "hot-region")
+	mergeCheckerRecentlyHotCounter          = checkerCounter.WithLabelValues(mergeCheckerName, "recently-hot")
+	mergeCheckerColdMergeLimitCounter       = checkerCounter.WithLabelValues(mergeCheckerName, "cold-merge-limit")
+	mergeCheckerNewColdOpCounter            = checkerCounter.WithLabelValues(mergeCheckerName, "new-cold-operator")
 	mergeCheckerNoTargetCounter             = checkerCounter.WithLabelValues(mergeCheckerName, "no-target")
 	mergeCheckerTargetTooLargeCounter       = checkerCounter.WithLabelValues(mergeCheckerName, "target-too-large")
 	mergeCheckerSplitSizeAfterMergeCounter  = checkerCounter.WithLabelValues(mergeCheckerName, "split-size-after-merge")
@@ -67,6 +76,7 @@ var (
 	mergeCheckerAdjNotExistCounter          = checkerCounter.WithLabelValues(mergeCheckerName, "adj-not-exist")
 	mergeCheckerAdjRecentlySplitCounter     = checkerCounter.WithLabelValues(mergeCheckerName, "adj-recently-split")
 	mergeCheckerAdjRegionHotCounter         = checkerCounter.WithLabelValues(mergeCheckerName, "adj-region-hot")
+	mergeCheckerAdjRecentlyHotCounter       = checkerCounter.WithLabelValues(mergeCheckerName, "adj-recently-hot")
 	mergeCheckerAdjDisallowMergeCounter     = checkerCounter.WithLabelValues(mergeCheckerName, "adj-disallow-merge")
 	mergeCheckerAdjAbnormalPeerStoreCounter = checkerCounter.WithLabelValues(mergeCheckerName, "adj-abnormal-peerstore")
 	mergeCheckerAdjSpecialPeerCounter       = checkerCounter.WithLabelValues(mergeCheckerName, "adj-special-peer")
@@ -79,17 +89,26 @@ type MergeChecker struct {
 	cluster    schedule.Cluster
 	conf       config.Config
 	splitCache *cache.TTLUint64
-	startTime  time.Time // it's used to judge whether server recently start.
+	// hotCache records the regions which were hot recently, they are not merged until the cool-down expires.
+	hotCache *cache.TTLUint64
+	// coldCache records the time since when the region has no flow.
+	coldCache *cache.TTLUint64
+	// coldMergeLimiter limits the rate of the cold region merge operators.
+	coldMergeLimiter *rate.Limiter
+	startTime        time.Time // it's used to judge whether server recently start.
 }
 
 // NewMergeChecker creates a merge checker.
 func NewMergeChecker(ctx context.Context, cluster schedule.Cluster, conf config.Config) *MergeChecker {
 	splitCache := cache.NewIDTTL(ctx, time.Minute, conf.GetSplitMergeInterval())
 	return &MergeChecker{
-		cluster:    cluster,
-		conf:       conf,
-		splitCache: splitCache,
-		startTime:  time.Now(),
+		cluster:          cluster,
+		conf:             conf,
+		splitCache:       splitCache,
+		hotCache:         cache.NewIDTTL(ctx, time.Minute, conf.GetMergeHotRegionCoolDown()),
+		coldCache:        cache.NewIDTTL(ctx, time.Minute, conf.GetColdRegionMergeDuration()),
+		coldMergeLimiter: rate.NewLimiter(coldMergeRate(conf.GetColdRegionMergeRateLimit()), int(conf.GetColdRegionMergeRateLimit())),
+		startTime:        time.Now(),
 	}
 }
 
@@ -110,7 +129,7 @@ func (m *MergeChecker) RecordRegionSplit(regionIDs []uint64) {
 func (m *MergeChecker) Check(region *core.RegionInfo) []*operator.Operator {
 	mergeCheckerCounter.Inc()
 
-	if m.IsPaused() {
+	if m.IsPaused() || !m.conf.IsInMaintenanceWindow("merge") {
 		mergeCheckerPausedCounter.Inc()
 		return nil
 	}
@@ -133,12 +152,23 @@ func (m *MergeChecker) Check(region *core.RegionInfo) []*operator.Operator {
 		return nil
 	}
 
-	// region is not small enough
-	if !region.NeedMerge(int64(m.conf.GetMaxMergeRegionSize()), int64(m.conf.GetMaxMergeRegionKeys())) {
-		mergeCheckerNoNeedCounter.Inc()
+	if m.isRecentlyHot(region) {
+		mergeCheckerRecentlyHotCounter.Inc()
 		return nil
 	}
 
+	// region is not small enough
+	maxSize, maxKeys := int64(m.conf.GetMaxMergeRegionSize()), int64(m.conf.GetMaxMergeRegionKeys())
+	coldMerge := false
+	if !region.NeedMerge(maxSize, maxKeys) {
+		// the region which has been cold for a long time is allowed to be merged with larger thresholds.
+		if !m.isColdForLong(region) || !region.NeedMerge(maxSize*coldRegionMergeFactor, maxKeys*coldRegionMergeFactor) {
+			mergeCheckerNoNeedCounter.Inc()
+			return nil
+		}
+		coldMerge = true
+	}
+
 	// skip region has down peers or pending peers
 	if !filter.IsRegionHealthy(region) {
 		mergeCheckerSpecialPeerCounter.Inc()
@@ -194,15 +224,24 @@ func (m *MergeChecker) Check(region *core.RegionInfo) []*operator.Operator {
 		return nil
 	}
 
+	if coldMerge && !m.allowColdMerge() {
+		mergeCheckerColdMergeLimitCounter.Inc()
+		return nil
+	}
+
 	log.Debug("try to merge region",
 		logutil.ZapRedactStringer("from", core.RegionToHexMeta(region.GetMeta())),
-		logutil.ZapRedactStringer("to", core.RegionToHexMeta(target.GetMeta())))
+		logutil.ZapRedactStringer("to", core.RegionToHexMeta(target.GetMeta())),
+		zap.Bool("cold-merge", coldMerge))
 	ops, err := operator.CreateMergeRegionOperator("merge-region", m.cluster, region, target, operator.OpMerge)
 	if err != nil {
 		log.Warn("create merge region operator failed", errs.ZapError(err))
 		return nil
 	}
 	mergeCheckerNewOpCounter.Inc()
+	if coldMerge {
+		mergeCheckerNewColdOpCounter.Inc()
+	}
 	if region.GetApproximateSize() > target.GetApproximateSize() ||
 		region.GetApproximateKeys() > target.GetApproximateKeys() {
 		mergeCheckerLargerSourceCounter.Inc()
@@ -226,6 +265,11 @@ func (m *MergeChecker) checkTarget(region, adjacent *core.RegionInfo) bool {
 		return false
 	}
 
+	if m.isRecentlyHot(adjacent) {
+		mergeCheckerAdjRecentlyHotCounter.Inc()
+		return false
+	}
+
 	if !AllowMerge(m.cluster, region, adjacent) {
 		mergeCheckerAdjDisallowMergeCounter.Inc()
 		return false
@@ -249,6 +293,94 @@ func (m *MergeChecker) checkTarget(region, adjacent *core.RegionInfo) bool {
 	return true
 }
 
+// isRecentlyHot returns true if the region is hot or its flow is above the hot thresholds now,
+// or it was found so within the merge hot region cool-down.
+func (m *MergeChecker) isRecentlyHot(region *core.RegionInfo) bool {
+	coolDown := m.conf.GetMergeHotRegionCoolDown()
+	if m.cluster.IsRegionHot(region) || isRegionFlowHot(region) {
+		if coolDown > 0 {
+			m.hotCache.PutWithTTL(region.GetID(), nil, coolDown)
+		}
+		return true
+	}
+	return coolDown > 0 && m.hotCache.Exists(region.GetID())
+}
+
+// isColdForLong returns true if the region has no read and write flow for the cold region merge duration.
+func (m *MergeChecker) isColdForLong(region *core.RegionInfo) bool {
+	duration := m.conf.GetColdRegionMergeDuration()
+	if duration <= 0 {
+		return false
+	}
+	rates, ok := getRegionFlowRates(region)
+	if !ok {
+		return false
+	}
+	for _, r := range rates {
+		if r > 0 {
+			m.coldCache.Remove(region.GetID())
+			return false
+		}
+	}
+	now := time.Now()
+	since := now
+	if v, ok := m.coldCache.Get(region.GetID()); ok {
+		since = v.(time.Time)
+	}
+	// Keep the record alive as long as the region is checked in time, the patrol may be slow in a large cluster.
+	m.coldCache.PutWithTTL(region.GetID(), since, 2*duration)
+	return now.Sub(since) >= duration
+}
+
+// allowColdMerge returns true if a cold region merge operator can be created under the rate limit.
+func (m *MergeChecker) allowColdMerge() bool {
+	limit := m.conf.GetColdRegionMergeRateLimit()
+	if limit == 0 {
+		return false
+	}
+	if m.coldMergeLimiter.Burst() != int(limit) {
+		m.coldMergeLimiter = rate.NewLimiter(coldMergeRate(limit), int(limit))
+	}
+	return m.coldMergeLimiter.Allow()
+}
+
+// coldMergeRate converts the limit per minute to the rate of the limiter.
+func coldMergeRate(limitPerMinute uint64) rate.Limit {
+	if limitPerMinute == 0 {
+		return 0
+	}
+	return rate.Every(time.Minute / time.Duration(limitPerMinute))
+}
+
+// getRegionFlowRates returns the read and write flow rates of the region reported by the last heartbeat,
+// which are in the order of statistics.RegionStatKind. It returns false if the report interval is invalid.
+func getRegionFlowRates(region *core.RegionInfo) ([]float64, bool) {
+	interval := region.GetInterval()
+	if interval.GetEndTimestamp() <= interval.GetStartTimestamp() {
+		return nil, false
+	}
+	seconds := interval.GetEndTimestamp() - interval.GetStartTimestamp()
+	rates := region.GetLoads()
+	for i := range rates {
+		rates[i] /= float64(seconds)
+	}
+	return rates, true
+}
+
+// isRegionFlowHot returns true if any flow rate of the region reaches the min hot threshold.
+func isRegionFlowHot(region *core.RegionInfo) bool {
+	rates, ok := getRegionFlowRates(region)
+	if !ok {
+		return false
+	}
+	for i, r := range rates {
+		if r >= statistics.MinHotThresholds[i] {
+			return true
+		}
+	}
+	return false
+}
+
 // AllowMerge returns true if two regions can be merged according to the key type.
 func AllowMerge(cluster schedule.Cluster, region, adjacent *core.RegionInfo) bool {
 	var start, end []byte
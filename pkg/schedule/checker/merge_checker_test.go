@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/stretchr/testify/suite"
 	"github.com/tikv/pd/pkg/core"
@@ -30,6 +31,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/maintenance"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/testutil"
@@ -540,6 +542,80 @@ func (suite *mergeCheckerTestSuite) TestCache() {
 	suite.NotNil(ops)
 }
 
+func (suite *mergeCheckerTestSuite) TestLoadAwareMerge() {
+	suite.cluster.SetSplitMergeInterval(0)
+	suite.cluster.SetMergeHotRegionCoolDown(time.Hour)
+
+	// The region with flow above the hot thresholds is not merged.
+	loaded := suite.regions[2].Clone(core.SetWrittenBytes(100*units.KiB), core.SetReportInterval(0, 10))
+	suite.cluster.PutRegion(loaded)
+	suite.Nil(suite.mc.Check(loaded))
+	// It is still not merged after the flow goes down during the cool-down.
+	idle := suite.regions[2].Clone(core.SetReportInterval(10, 20))
+	suite.cluster.PutRegion(idle)
+	suite.Nil(suite.mc.Check(idle))
+	// Nor is it merged into by the adjacent region.
+	suite.cluster.PutRegion(suite.regions[1].Clone(core.SetApproximateSize(1), core.SetApproximateKeys(1)))
+	suite.Nil(suite.mc.Check(suite.cluster.GetRegion(2)))
+
+	suite.cluster.SetMergeHotRegionCoolDown(0)
+	ops := suite.mc.Check(idle)
+	suite.NotNil(ops)
+	// The region which is hot now is never merged even without the cool-down.
+	suite.cluster.PutRegion(loaded)
+	suite.Nil(suite.mc.Check(loaded))
+}
+
+func (suite *mergeCheckerTestSuite) TestColdRegionMerge() {
+	suite.cluster.SetSplitMergeInterval(0)
+	suite.cluster.SetColdRegionMergeDuration(time.Hour)
+	suite.cluster.SetColdRegionMergeRateLimit(1)
+
+	// The size is above the max merge region size but within the cold merge threshold.
+	cold := suite.regions[2].Clone(core.SetApproximateSize(3), core.SetApproximateKeys(3), core.SetReportInterval(0, 10))
+	suite.cluster.PutRegion(cold)
+	suite.Nil(suite.mc.Check(cold))
+	// The region has been cold for long enough.
+	suite.mc.coldCache.PutWithTTL(cold.GetID(), time.Now().Add(-time.Hour), 2*time.Hour)
+	ops := suite.mc.Check(cold)
+	suite.NotNil(ops)
+	suite.Equal(cold.GetID(), ops[0].RegionID())
+	// Limited by the cold merge rate limit.
+	suite.Nil(suite.mc.Check(cold))
+	suite.cluster.SetColdRegionMergeRateLimit(10)
+	suite.NotNil(suite.mc.Check(cold))
+
+	// Any flow resets the cold duration.
+	warm := cold.Clone(core.SetReadBytes(1))
+	suite.cluster.PutRegion(warm)
+	suite.Nil(suite.mc.Check(warm))
+	suite.Nil(suite.mc.Check(cold))
+
+	// The region is too large even for the cold merge.
+	large := cold.Clone(core.SetApproximateSize(5), core.SetApproximateKeys(5))
+	suite.cluster.PutRegion(large)
+	suite.mc.coldCache.PutWithTTL(cold.GetID(), time.Now().Add(-time.Hour), 2*time.Hour)
+	suite.Nil(suite.mc.Check(large))
+}
+
+func (suite *mergeCheckerTestSuite) TestMaintenanceWindow() {
+	suite.cluster.SetSplitMergeInterval(0)
+	suite.cluster.PutRegion(suite.regions[1].Clone(core.SetApproximateSize(1), core.SetApproximateKeys(1)))
+	suite.NotNil(suite.mc.Check(suite.regions[2]))
+
+	now := time.Now()
+	// The merge checker is paused in the blackout period.
+	suite.cluster.SetMaintenanceWindows(nil, []maintenance.Blackout{{Targets: []string{"merge"}, Start: now.Add(-time.Minute), End: now.Add(time.Minute)}})
+	suite.Nil(suite.mc.Check(suite.regions[2]))
+	// The merge checker only runs within the window.
+	window := maintenance.Window{Targets: []string{"merge"}, Start: now.UTC().Add(time.Hour).Format("15:04"), End: now.UTC().Add(2 * time.Hour).Format("15:04")}
+	suite.cluster.SetMaintenanceWindows([]maintenance.Window{window}, nil)
+	suite.Nil(suite.mc.Check(suite.regions[2]))
+	window.Start, window.End = window.End, window.Start
+	suite.cluster.SetMaintenanceWindows([]maintenance.Window{window}, nil)
+	suite.NotNil(suite.mc.Check(suite.regions[2]))
+}
+
 func makeKeyRanges(keys ...string) []interface{} {
 	var res []interface{}
 	for i := 0; i < len(keys); i += 2 {
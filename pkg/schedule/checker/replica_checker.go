@@ -90,16 +90,19 @@ func (r *ReplicaChecker) Check(region *core.RegionInfo) *operator.Operator {
 	if op := r.checkDownPeer(region); op != nil {
 		replicaCheckerNewOpCounter.Inc()
 		op.SetPriorityLevel(constant.High)
+		op.SetPriorityClass(constant.RepairClass)
 		return op
 	}
 	if op := r.checkOfflinePeer(region); op != nil {
 		replicaCheckerNewOpCounter.Inc()
 		op.SetPriorityLevel(constant.High)
+		op.SetPriorityClass(constant.RepairClass)
 		return op
 	}
 	if op := r.checkMakeUpReplica(region); op != nil {
 		replicaCheckerNewOpCounter.Inc()
 		op.SetPriorityLevel(constant.High)
+		op.SetPriorityClass(constant.RepairClass)
 		return op
 	}
 	if op := r.checkRemoveExtraReplica(region); op != nil {
@@ -155,7 +158,7 @@ func (r *ReplicaChecker) checkOfflinePeer(region *core.RegionInfo) *operator.Ope
 			log.Warn("lost the store, maybe you are recovering the PD cluster", zap.Uint64("store-id", storeID))
 			return nil
 		}
-		if store.IsUp() {
+		if store.IsUp() || isDrainingPeer(r.cluster, storeID, region.GetID()) {
 			continue
 		}
 
@@ -147,6 +147,7 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 		log.Debug("fail to fix orphan peer", errs.ZapError(err))
 	} else if op != nil {
 		c.pendingList.Remove(region.GetID())
+		op.SetPriorityClass(constant.RuleFixClass)
 		return op
 	}
 	for _, rf := range fit.RuleFits {
@@ -157,6 +158,13 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 		}
 		if op != nil {
 			c.pendingList.Remove(region.GetID())
+			// The operators making up or replacing the unhealthy peers are the repair ones,
+			// which have the high priority level.
+			if op.GetPriorityLevel() >= constant.High {
+				op.SetPriorityClass(constant.RepairClass)
+			} else {
+				op.SetPriorityClass(constant.RuleFixClass)
+			}
 			return op
 		}
 	}
@@ -201,7 +209,7 @@ func (c *RuleChecker) fixRulePeer(region *core.RegionInfo, fit *placement.Region
 				}
 			}
 		}
-		if c.isOfflinePeer(peer) {
+		if c.isOfflinePeer(region, peer) {
 			ruleCheckerReplaceOfflineCounter.Inc()
 			return c.replaceUnexpectRulePeer(region, rf, fit, peer, offlineStatus)
 		}
@@ -486,13 +494,13 @@ func (c *RuleChecker) isStoreDownTimeHitMaxDownTime(storeID uint64) bool {
 	return store.DownTime() >= c.cluster.GetOpts().GetMaxStoreDownTime()
 }
 
-func (c *RuleChecker) isOfflinePeer(peer *metapb.Peer) bool {
+func (c *RuleChecker) isOfflinePeer(region *core.RegionInfo, peer *metapb.Peer) bool {
 	store := c.cluster.GetStore(peer.GetStoreId())
 	if store == nil {
 		log.Warn("lost the store, maybe you are recovering the PD cluster", zap.Uint64("store-id", peer.StoreId))
 		return false
 	}
-	return !store.IsPreparing() && !store.IsServing()
+	return !store.IsPreparing() && !store.IsServing() && !isDrainingPeer(c.cluster, store.GetID(), region.GetID())
 }
 
 func (c *RuleChecker) hasAvailableWitness(region *core.RegionInfo, peer *metapb.Peer) (*metapb.Peer, bool) {
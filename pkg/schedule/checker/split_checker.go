@@ -59,7 +59,7 @@ func (c *SplitChecker) GetType() string {
 func (c *SplitChecker) Check(region *core.RegionInfo) *operator.Operator {
 	splitCheckerCounter.Inc()
 
-	if c.IsPaused() {
+	if c.IsPaused() || !c.cluster.GetOpts().IsInMaintenanceWindow("split") {
 		splitCheckerPausedCounter.Inc()
 		return nil
 	}
@@ -17,6 +17,7 @@ package schedule
 import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/statistics/buckets"
 )
@@ -37,4 +38,7 @@ type Cluster interface {
 	AddSuspectRegions(ids ...uint64)
 	SetHotPendingInfluenceMetrics(storeLabel, rwTy, dim string, load float64)
 	RecordOpStepWithTTL(regionID uint64)
+	GetScatterGroupManager() *scattergroup.Manager
+	IsStoreDraining(storeID uint64) bool
+	IsStoreDrainBlocked(storeID, regionID uint64) bool
 }
@@ -43,6 +43,9 @@ type Config interface {
 	GetSplitMergeInterval() time.Duration
 	GetMaxMergeRegionSize() uint64
 	GetMaxMergeRegionKeys() uint64
+	GetMergeHotRegionCoolDown() time.Duration
+	GetColdRegionMergeDuration() time.Duration
+	GetColdRegionMergeRateLimit() uint64
 	GetKeyType() constant.KeyType
 	IsOneWayMergeEnabled() bool
 	IsCrossTableMergeEnabled() bool
@@ -64,6 +67,12 @@ type Config interface {
 	GetSwitchWitnessInterval() time.Duration
 	IsWitnessAllowed() bool
 
+	IsOperatorPreemptionEnabled() bool
+	GetOperatorClassQuota(class constant.PriorityClass) uint64
+	GetClusterMovementLimit() uint64
+	GetZoneMovementLimit() uint64
+	IsInMaintenanceWindow(target string) bool
+
 	GetLowSpaceRatio() float64
 	GetHighSpaceRatio() float64
 	GetTolerantSizeRatio() float64
@@ -127,6 +127,7 @@ const (
 	storeStateTooManyPendingPeer
 	storeStateRejectLeader
 	storeStateSlowTrend
+	storeStateUnhealthy
 
 	filtersLen
 )
@@ -156,6 +157,7 @@ var filters = [filtersLen]string{
 	"store-state-too-many-pending-peers-filter",
 	"store-state-reject-leader-filter",
 	"store-state-slow-trend-filter",
+	"store-state-unhealthy-filter",
 }
 
 // String implements fmt.Stringer interface.
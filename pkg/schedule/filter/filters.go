@@ -398,6 +398,25 @@ func (f *StoreStateFilter) slowTrendEvicted(_ config.Config, store *core.StoreIn
 	return statusOK
 }
 
+func (f *StoreStateFilter) unhealthyRejectLeader(_ config.Config, store *core.StoreInfo) *plan.Status {
+	// A recovering store takes the leaders back gradually by its stepped leader weight.
+	if store.GetHealthState() == core.StoreQuarantined {
+		f.Reason = storeStateUnhealthy
+		return statusStoreRejectLeader
+	}
+	f.Reason = storeStateOK
+	return statusOK
+}
+
+func (f *StoreStateFilter) unhealthyRejectRegion(_ config.Config, store *core.StoreInfo) *plan.Status {
+	if state := store.GetHealthState(); state == core.StoreSuspect || state == core.StoreQuarantined {
+		f.Reason = storeStateUnhealthy
+		return statusStoreBusy
+	}
+	f.Reason = storeStateOK
+	return statusOK
+}
+
 func (f *StoreStateFilter) isDisconnected(_ config.Config, store *core.StoreInfo) *plan.Status {
 	if !f.AllowTemporaryStates && store.IsDisconnected() {
 		f.Reason = storeStateDisconnected
@@ -469,13 +488,13 @@ func (f *StoreStateFilter) hasRejectLeaderProperty(conf config.Config, store *co
 // N: the condition is expected to be true for a long time.
 // X means when the condition is true, the store CANNOT be selected.
 //
-// Condition    Down Offline Tomb Pause Disconn Busy RmLimit AddLimit Snap Pending Reject
-// IsTemporary  N    N       N    N     Y       Y    Y       Y        Y    Y       N
+// Condition    Down Offline Tomb Pause Disconn Busy RmLimit AddLimit Snap Pending Reject Unhealthy
+// IsTemporary  N    N       N    N     Y       Y    Y       Y        Y    Y       N      Y
 //
 // LeaderSource X            X    X     X
 // RegionSource                                 X    X                X
-// LeaderTarget X    X       X    X     X       X                                  X
-// RegionTarget X    X       X          X       X            X        X    X
+// LeaderTarget X    X       X    X     X       X                                  X      X
+// RegionTarget X    X       X          X       X            X        X    X              X
 
 const (
 	leaderSource = iota
@@ -499,10 +518,10 @@ func (f *StoreStateFilter) anyConditionMatch(typ int, conf config.Config, store
 		funcs = []conditionFunc{f.isBusy}
 	case leaderTarget:
 		funcs = []conditionFunc{f.isRemoved, f.isRemoving, f.isDown, f.pauseLeaderTransfer,
-			f.slowStoreEvicted, f.slowTrendEvicted, f.isDisconnected, f.isBusy, f.hasRejectLeaderProperty}
+			f.slowStoreEvicted, f.slowTrendEvicted, f.unhealthyRejectLeader, f.isDisconnected, f.isBusy, f.hasRejectLeaderProperty}
 	case regionTarget:
 		funcs = []conditionFunc{f.isRemoved, f.isRemoving, f.isDown, f.isDisconnected, f.isBusy,
-			f.exceedAddLimit, f.tooManySnapshots, f.tooManyPendingPeers}
+			f.unhealthyRejectRegion, f.exceedAddLimit, f.tooManySnapshots, f.tooManyPendingPeers}
 	case witnessTarget:
 		funcs = []conditionFunc{f.isRemoved, f.isRemoving, f.isDown, f.isDisconnected, f.isBusy}
 	case scatterRegionTarget:
@@ -198,6 +198,24 @@ func TestStoreStateFilter(t *testing.T) {
 		{3, plan.StatusOK, plan.StatusOK},
 	}
 	check(store, testCases)
+
+	// Unhealthy
+	store = store.Clone(core.SetStoreStats(&pdpb.StoreStats{}))
+	for _, state := range []core.StoreHealthState{core.StoreSuspect, core.StoreQuarantined, core.StoreRecovering} {
+		store = store.Clone(core.SetHealthState(state, nil, 0.5))
+		leaderTargetRes, regionTargetRes := plan.StatusOK, plan.StatusOK
+		if state == core.StoreQuarantined {
+			leaderTargetRes = plan.StatusStoreRejectLeader
+		}
+		if state == core.StoreSuspect || state == core.StoreQuarantined {
+			regionTargetRes = plan.StatusStoreBusy
+		}
+		testCases = []testCase{
+			{0, plan.StatusOK, leaderTargetRes},
+			{1, plan.StatusOK, regionTargetRes},
+		}
+		check(store, testCases)
+	}
 }
 
 func TestStoreStateFilterReason(t *testing.T) {
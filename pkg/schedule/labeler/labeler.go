@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/schedule/rangelist"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/syncutil"
@@ -37,6 +38,7 @@ type RegionLabeler struct {
 	rangeList  rangelist.List // sorted LabelRules of the type `KeyRange`
 	ctx        context.Context
 	minExpire  *time.Time
+	eventHub   *event.Hub
 }
 
 // NewRegionLabeler creates a Labeler instance.
@@ -213,6 +215,7 @@ func (l *RegionLabeler) SetLabelRule(rule *LabelRule) error {
 	}
 	l.labelRules[rule.ID] = rule
 	l.buildRangeList()
+	l.eventHub.Publish(event.TypeLabelRule, "set", rule.ID, nil)
 	return nil
 }
 
@@ -228,6 +231,7 @@ func (l *RegionLabeler) DeleteLabelRule(id string) error {
 	}
 	delete(l.labelRules, id)
 	l.buildRangeList()
+	l.eventHub.Publish(event.TypeLabelRule, "delete", id, nil)
 	return nil
 }
 
@@ -257,14 +261,23 @@ func (l *RegionLabeler) Patch(patch LabelRulePatch) error {
 
 	for _, key := range patch.DeleteRules {
 		delete(l.labelRules, key)
+		l.eventHub.Publish(event.TypeLabelRule, "delete", key, nil)
 	}
 	for _, rule := range patch.SetRules {
 		l.labelRules[rule.ID] = rule
+		l.eventHub.Publish(event.TypeLabelRule, "set", rule.ID, nil)
 	}
 	l.buildRangeList()
 	return nil
 }
 
+// SetEventHub sets the hub to publish the events of the label rules.
+func (l *RegionLabeler) SetEventHub(hub *event.Hub) {
+	l.Lock()
+	defer l.Unlock()
+	l.eventHub = hub
+}
+
 // GetRegionLabel returns the label of the region for a key.
 // If there are multiple rules that match the key, the one with max rule index will be returned.
 func (l *RegionLabeler) GetRegionLabel(region *core.RegionInfo, key string) string {
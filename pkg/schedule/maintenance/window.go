@@ -0,0 +1,286 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/errors"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+)
+
+const timeOfDayLayout = "15:04"
+
+// repairCheckers are the checkers which always run and can't be restricted by the windows.
+var repairCheckers = map[string]struct{}{
+	"replica":     {},
+	"rule":        {},
+	"joint-state": {},
+	"learner":     {},
+}
+
+// restrictableCheckers are the checkers which can be restricted by the windows.
+var restrictableCheckers = map[string]struct{}{
+	"merge": {},
+	"split": {},
+}
+
+// Window is a recurring daily window in UTC. The targets, which are scheduler types
+// like "balance-region" or the checker names "merge" and "split", only run within the windows.
+// A window which ends earlier than it starts crosses the midnight.
+type Window struct {
+	Targets []string `toml:"targets" json:"targets"`
+	Start   string   `toml:"start" json:"start"`
+	End     string   `toml:"end" json:"end"`
+
+	// parsed indicates the start and end are parsed from Start and End by Validate,
+	// so that they're not parsed again every time the window is checked.
+	parsed     bool
+	start, end time.Duration
+}
+
+// Blackout is a one-off period, the targets are paused within it.
+type Blackout struct {
+	Targets []string  `toml:"targets" json:"targets"`
+	Start   time.Time `toml:"start" json:"start"`
+	End     time.Time `toml:"end" json:"end"`
+}
+
+// Status is the maintenance status of a target.
+type Status struct {
+	Target  string `json:"target"`
+	Allowed bool   `json:"allowed"`
+	// ActiveWindow is the window or the blackout period the target is in now.
+	ActiveWindow string `json:"active-window,omitempty"`
+	// NextTransition is the time when the target becomes allowed or not allowed.
+	NextTransition *time.Time `json:"next-transition,omitempty"`
+}
+
+// Validate checks whether the window is valid.
+func (w *Window) Validate() error {
+	if err := validateTargets(w.Targets); err != nil {
+		return err
+	}
+	start, err := parseTimeOfDay(w.Start)
+	if err != nil {
+		return err
+	}
+	end, err := parseTimeOfDay(w.End)
+	if err != nil {
+		return err
+	}
+	if start == end {
+		return errors.Errorf("maintenance window %s-%s is empty", w.Start, w.End)
+	}
+	w.start, w.end, w.parsed = start, end, true
+	return nil
+}
+
+// timeOfDay returns the start and end of the window as the offsets of the day.
+func (w *Window) timeOfDay() (start, end time.Duration) {
+	if w.parsed {
+		return w.start, w.end
+	}
+	start, _ = parseTimeOfDay(w.Start)
+	end, _ = parseTimeOfDay(w.End)
+	return start, end
+}
+
+// String implements fmt.Stringer.
+func (w *Window) String() string {
+	return fmt.Sprintf("%s-%s UTC", w.Start, w.End)
+}
+
+// Contains returns whether the time is within the window.
+func (w *Window) Contains(t time.Time) bool {
+	start, end := w.timeOfDay()
+	t = t.UTC()
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if start < end {
+		return start <= now && now < end
+	}
+	return now >= start || now < end
+}
+
+// boundaries returns the start and end time of the window in (from, from+24h].
+func (w *Window) boundaries(from time.Time) []time.Time {
+	start, end := w.timeOfDay()
+	from = from.UTC()
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	var ts []time.Time
+	for i := 0; i <= 1; i++ {
+		d := day.AddDate(0, 0, i)
+		for _, offset := range []time.Duration{start, end} {
+			if t := d.Add(offset); t.After(from) && !t.After(from.Add(24*time.Hour)) {
+				ts = append(ts, t)
+			}
+		}
+	}
+	return ts
+}
+
+// Validate checks whether the blackout period is valid.
+func (b *Blackout) Validate() error {
+	if err := validateTargets(b.Targets); err != nil {
+		return err
+	}
+	if !b.End.After(b.Start) {
+		return errors.Errorf("blackout period %s is empty", b)
+	}
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (b *Blackout) String() string {
+	return fmt.Sprintf("%s~%s", b.Start.Format(time.RFC3339), b.End.Format(time.RFC3339))
+}
+
+// Contains returns whether the time is within the blackout period.
+func (b *Blackout) Contains(t time.Time) bool {
+	return !t.Before(b.Start) && t.Before(b.End)
+}
+
+// IsAllowed returns whether the target is allowed to run at the time. A target restricted by
+// windows only runs within one of them, and it never runs within a blackout period.
+func IsAllowed(windows []Window, blackouts []Blackout, target string, t time.Time) bool {
+	restricted, inWindow := false, false
+	for i := range windows {
+		if hasTarget(windows[i].Targets, target) {
+			restricted = true
+			inWindow = inWindow || windows[i].Contains(t)
+		}
+	}
+	if restricted && !inWindow {
+		return false
+	}
+	for i := range blackouts {
+		if hasTarget(blackouts[i].Targets, target) && blackouts[i].Contains(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetStatus returns the maintenance status of the target at the time.
+func GetStatus(windows []Window, blackouts []Blackout, target string, t time.Time) *Status {
+	status := &Status{
+		Target:  target,
+		Allowed: IsAllowed(windows, blackouts, target, t),
+	}
+	for i := range blackouts {
+		if hasTarget(blackouts[i].Targets, target) && blackouts[i].Contains(t) {
+			status.ActiveWindow = "blackout " + blackouts[i].String()
+			break
+		}
+	}
+	if status.ActiveWindow == "" {
+		for i := range windows {
+			if hasTarget(windows[i].Targets, target) && windows[i].Contains(t) {
+				status.ActiveWindow = windows[i].String()
+				break
+			}
+		}
+	}
+
+	// Check the boundaries in order to find the next transition. The boundaries of
+	// the windows repeat every day, so the search is limited to several days.
+	from := t
+	for i := 0; i < 8; i++ {
+		var ts []time.Time
+		for j := range windows {
+			if hasTarget(windows[j].Targets, target) {
+				ts = append(ts, windows[j].boundaries(from)...)
+			}
+		}
+		for j := range blackouts {
+			if !hasTarget(blackouts[j].Targets, target) {
+				continue
+			}
+			for _, b := range []time.Time{blackouts[j].Start, blackouts[j].End} {
+				if b.After(from) {
+					ts = append(ts, b)
+				}
+			}
+		}
+		if len(ts) == 0 {
+			break
+		}
+		sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+		for _, next := range ts {
+			if IsAllowed(windows, blackouts, target, next) != status.Allowed {
+				next := next
+				status.NextTransition = &next
+				return status
+			}
+		}
+		from = ts[len(ts)-1]
+	}
+	return status
+}
+
+// GetTargets returns all the targets restricted by the windows and the blackout periods.
+func GetTargets(windows []Window, blackouts []Blackout) []string {
+	set := make(map[string]struct{})
+	for i := range windows {
+		for _, target := range windows[i].Targets {
+			set[target] = struct{}{}
+		}
+	}
+	for i := range blackouts {
+		for _, target := range blackouts[i].Targets {
+			set[target] = struct{}{}
+		}
+	}
+	targets := make([]string, 0, len(set))
+	for target := range set {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func hasTarget(targets []string, target string) bool {
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+func validateTargets(targets []string) error {
+	if len(targets) == 0 {
+		return errors.New("the targets of the maintenance window are empty")
+	}
+	for _, target := range targets {
+		if _, ok := repairCheckers[target]; ok {
+			return errors.Errorf("the repair checker %s can't be restricted by the maintenance window", target)
+		}
+		if _, ok := restrictableCheckers[target]; !ok && !sc.IsSchedulerRegistered(target) {
+			return errors.Errorf("unknown target %s of the maintenance window, it should be a scheduler type or a checker name", target)
+		}
+	}
+	return nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse(timeOfDayLayout, s)
+	if err != nil {
+		return 0, errors.Errorf("invalid time %s of the maintenance window, it should be in format HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+)
+
+func at(hour, min int) time.Time {
+	return time.Date(2023, 5, 1, hour, min, 0, 0, time.UTC)
+}
+
+func TestValidate(t *testing.T) {
+	re := require.New(t)
+	re.NoError((&Window{Targets: []string{"merge"}, Start: "22:00", End: "06:00"}).Validate())
+	re.Error((&Window{Start: "00:00", End: "06:00"}).Validate())
+	re.Error((&Window{Targets: []string{"replica"}, Start: "00:00", End: "06:00"}).Validate())
+	re.Error((&Window{Targets: []string{"merge"}, Start: "0:00am", End: "06:00"}).Validate())
+	re.Error((&Window{Targets: []string{"merge"}, Start: "06:00", End: "06:00"}).Validate())
+	re.NoError((&Blackout{Targets: []string{"merge"}, Start: at(1, 0), End: at(2, 0)}).Validate())
+	re.Error((&Blackout{Targets: []string{"merge"}, Start: at(2, 0), End: at(1, 0)}).Validate())
+	// The targets must be the registered scheduler types or the restrictable checkers.
+	re.Error((&Window{Targets: []string{"balance-regions"}, Start: "00:00", End: "06:00"}).Validate())
+	re.Error((&Blackout{Targets: []string{"split", "unknown"}, Start: at(1, 0), End: at(2, 0)}).Validate())
+	sc.RegisterScheduler("balance-region")
+	window := &Window{Targets: []string{"balance-region", "split"}, Start: "22:00", End: "06:00"}
+	re.NoError(window.Validate())
+	// The time of day is parsed by the validation.
+	re.True(window.parsed)
+	re.Equal(22*time.Hour, window.start)
+	re.Equal(6*time.Hour, window.end)
+	re.True(window.Contains(at(23, 0)))
+}
+
+func TestIsAllowed(t *testing.T) {
+	re := require.New(t)
+	windows := []Window{
+		{Targets: []string{"balance-region", "merge"}, Start: "00:00", End: "06:00"},
+		{Targets: []string{"balance-leader"}, Start: "22:00", End: "02:00"},
+	}
+	blackouts := []Blackout{
+		{Targets: []string{"balance-region", "hot-region"}, Start: at(3, 0), End: at(4, 0)},
+	}
+	re.True(IsAllowed(windows, blackouts, "balance-region", at(0, 0)))
+	re.True(IsAllowed(windows, blackouts, "merge", at(5, 59)))
+	re.False(IsAllowed(windows, blackouts, "merge", at(6, 0)))
+	re.True(IsAllowed(windows, blackouts, "balance-leader", at(23, 0)))
+	re.True(IsAllowed(windows, blackouts, "balance-leader", at(1, 0)))
+	re.False(IsAllowed(windows, blackouts, "balance-leader", at(12, 0)))
+	// The blackout period pauses the targets even in the window.
+	re.False(IsAllowed(windows, blackouts, "balance-region", at(3, 30)))
+	re.True(IsAllowed(windows, blackouts, "merge", at(3, 30)))
+	re.False(IsAllowed(windows, blackouts, "hot-region", at(3, 30)))
+	re.True(IsAllowed(windows, blackouts, "hot-region", at(4, 0)))
+	// The targets without window are not restricted.
+	re.True(IsAllowed(windows, blackouts, "split", at(12, 0)))
+
+	re.Equal([]string{"balance-leader", "balance-region", "hot-region", "merge"}, GetTargets(windows, blackouts))
+}
+
+func TestGetStatus(t *testing.T) {
+	re := require.New(t)
+	windows := []Window{
+		{Targets: []string{"balance-region", "merge"}, Start: "00:00", End: "06:00"},
+	}
+	blackouts := []Blackout{
+		{Targets: []string{"balance-region"}, Start: at(3, 0), End: at(4, 0)},
+		{Targets: []string{"hot-region"}, Start: at(3, 0), End: at(4, 0).AddDate(0, 0, 3)},
+	}
+	status := GetStatus(windows, blackouts, "balance-region", at(1, 0))
+	re.True(status.Allowed)
+	re.Equal("00:00-06:00 UTC", status.ActiveWindow)
+	re.Equal(at(3, 0), *status.NextTransition)
+
+	status = GetStatus(windows, blackouts, "balance-region", at(3, 30))
+	re.False(status.Allowed)
+	re.Contains(status.ActiveWindow, "blackout")
+	re.Equal(at(4, 0), *status.NextTransition)
+
+	status = GetStatus(windows, blackouts, "merge", at(12, 0))
+	re.False(status.Allowed)
+	re.Empty(status.ActiveWindow)
+	re.Equal(at(0, 0).AddDate(0, 0, 1), *status.NextTransition)
+
+	status = GetStatus(windows, blackouts, "hot-region", at(12, 0))
+	re.False(status.Allowed)
+	re.Equal(at(4, 0).AddDate(0, 0, 3), *status.NextTransition)
+
+	status = GetStatus(windows, blackouts, "split", at(12, 0))
+	re.True(status.Allowed)
+	re.Nil(status.NextTransition)
+}
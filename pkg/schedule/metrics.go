@@ -84,6 +84,38 @@ var (
 			Name:      "scatter_distribution",
 			Help:      "Counter of the distribution in scatter.",
 		}, []string{"store", "is_leader", "engine"})
+
+	movementBudgetCostCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "movement_budget_cost",
+			Help:      "Size (MB) of the data moved into each zone which costs the movement budget.",
+		}, []string{"zone"})
+
+	operatorClassGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "running_operators_by_class",
+			Help:      "Gauge of the running operators of each priority class.",
+		}, []string{"class"})
+
+	operatorPreemptedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operators_preempted_count",
+			Help:      "Counter of the running operators preempted by the ones of higher priority classes.",
+		}, []string{"class", "by", "action"})
+
+	operatorClassLimitedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operators_class_limited_count",
+			Help:      "Counter of the operators rejected because of the store limit or the quota of the priority class.",
+		}, []string{"class", "reason"})
 )
 
 func init() {
@@ -95,4 +127,8 @@ func init() {
 	prometheus.MustRegister(scatterCounter)
 	prometheus.MustRegister(scatterDistributionCounter)
 	prometheus.MustRegister(operatorSizeHist)
+	prometheus.MustRegister(movementBudgetCostCounter)
+	prometheus.MustRegister(operatorClassGauge)
+	prometheus.MustRegister(operatorPreemptedCounter)
+	prometheus.MustRegister(operatorClassLimitedCounter)
 }
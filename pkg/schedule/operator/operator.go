@@ -37,16 +37,22 @@ const (
 // Operator contains execution steps generated by scheduler.
 // NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
 type Operator struct {
-	desc             string
-	brief            string
-	regionID         uint64
-	regionEpoch      *metapb.RegionEpoch
-	kind             OpKind
-	steps            []OpStep
-	stepsTime        []int64 // step finish time
-	currentStep      int32
+	desc        string
+	brief       string
+	regionID    uint64
+	regionEpoch *metapb.RegionEpoch
+	kind        OpKind
+	steps       []OpStep
+	stepsTime   []int64 // step finish time
+	currentStep int32
+	// pausedStep is the index of the first step held by the pause plus one, zero means not paused.
+	pausedStep int32
+	// dispatchedStep is the index of the last step dispatched to the stores plus one.
+	dispatchedStep   int32
 	status           OpStatusTracker
 	level            constant.PriorityLevel
+	class            constant.PriorityClass
+	hasClass         bool
 	Counters         []prometheus.Counter
 	FinishedCounters []prometheus.Counter
 	AdditionalInfos  map[string]string
@@ -137,6 +143,23 @@ func (o *Operator) Kind() OpKind {
 	return o.kind
 }
 
+// GetRelatedMergeRegion returns the ID of the other region of the merge operation,
+// it returns 0 if the operator doesn't merge regions.
+func (o *Operator) GetRelatedMergeRegion() uint64 {
+	if o.kind&OpMerge == 0 {
+		return 0
+	}
+	for _, step := range o.steps {
+		if merge, ok := step.(MergeRegion); ok {
+			if merge.FromRegion.GetId() == o.regionID {
+				return merge.ToRegion.GetId()
+			}
+			return merge.FromRegion.GetId()
+		}
+	}
+	return 0
+}
+
 // SchedulerKind return the highest OpKind even if the operator has many OpKind
 // fix #3778
 func (o *Operator) SchedulerKind() OpKind {
@@ -331,6 +354,84 @@ func (o *Operator) GetPriorityLevel() constant.PriorityLevel {
 	return o.level
 }
 
+// SetPriorityClass sets the priority class for operator.
+func (o *Operator) SetPriorityClass(class constant.PriorityClass) {
+	o.class = class
+	o.hasClass = true
+}
+
+// GetPriorityClass gets the priority class. If the class is not set explicitly,
+// it's derived from the kind of the operator.
+func (o *Operator) GetPriorityClass() constant.PriorityClass {
+	if o.hasClass {
+		return o.class
+	}
+	switch {
+	case o.kind&OpAdmin != 0:
+		return constant.RepairClass
+	case o.kind&OpReplica != 0:
+		return constant.RuleFixClass
+	case o.kind&OpHotRegion != 0:
+		return constant.HotClass
+	case o.kind&OpMerge != 0:
+		return constant.MergeClass
+	default:
+		return constant.BalanceClass
+	}
+}
+
+// SetDispatched marks that the current step of the operator has been dispatched to the stores.
+func (o *Operator) SetDispatched() {
+	atomic.StoreInt32(&o.dispatchedStep, atomic.LoadInt32(&o.currentStep)+1)
+}
+
+// HasDispatched returns whether any step of the operator has been dispatched.
+func (o *Operator) HasDispatched() bool {
+	return atomic.LoadInt32(&o.dispatchedStep) != 0
+}
+
+// Pause holds the steps not dispatched yet, which won't be dispatched until the operator
+// is resumed. The dispatched steps may have been executed by the stores already.
+func (o *Operator) Pause() {
+	atomic.StoreInt32(&o.pausedStep, o.firstPendingStep()+1)
+}
+
+// Resume releases the steps held by the pause.
+func (o *Operator) Resume() {
+	atomic.StoreInt32(&o.pausedStep, 0)
+}
+
+// IsPaused returns whether the operator is paused.
+func (o *Operator) IsPaused() bool {
+	return atomic.LoadInt32(&o.pausedStep) != 0
+}
+
+// IsHeld returns whether the current step of the operator is held by the pause.
+func (o *Operator) IsHeld() bool {
+	paused := atomic.LoadInt32(&o.pausedStep)
+	return paused != 0 && atomic.LoadInt32(&o.currentStep) >= paused-1
+}
+
+// PendingInfluence calculates the store difference which the steps not dispatched yet make,
+// which are the steps held by the pause or the steps after the current one.
+func (o *Operator) PendingInfluence(opInfluence OpInfluence, region *core.RegionInfo) {
+	step := atomic.LoadInt32(&o.pausedStep) - 1
+	if step < 0 {
+		step = o.firstPendingStep()
+	}
+	for ; int(step) < len(o.steps); step++ {
+		o.steps[int(step)].Influence(opInfluence, region)
+	}
+}
+
+func (o *Operator) firstPendingStep() int32 {
+	step := atomic.LoadInt32(&o.currentStep)
+	if dispatched := atomic.LoadInt32(&o.dispatchedStep); dispatched > step {
+		return dispatched
+	}
+	return step
+}
+
 // UnfinishedInfluence calculates the store difference which unfinished operator steps make.
 func (o *Operator) UnfinishedInfluence(opInfluence OpInfluence, region *core.RegionInfo) {
 	for step := atomic.LoadInt32(&o.currentStep); int(step) < len(o.steps); step++ {
@@ -297,6 +297,28 @@ func (suite *operatorTestSuite) TestOperatorKind() {
 	suite.Error(err)
 }
 
+func (suite *operatorTestSuite) TestOperatorPriorityClass() {
+	newOp := func(kind OpKind) *Operator {
+		return NewTestOperator(1, &metapb.RegionEpoch{}, kind, TransferLeader{FromStore: 1, ToStore: 2})
+	}
+	suite.Equal(constant.RepairClass, newOp(OpAdmin|OpLeader).GetPriorityClass())
+	suite.Equal(constant.RuleFixClass, newOp(OpReplica|OpRegion).GetPriorityClass())
+	suite.Equal(constant.HotClass, newOp(OpHotRegion|OpLeader).GetPriorityClass())
+	suite.Equal(constant.MergeClass, newOp(OpMerge|OpRegion).GetPriorityClass())
+	suite.Equal(constant.BalanceClass, newOp(OpRegion).GetPriorityClass())
+	op := newOp(OpReplica | OpRegion)
+	op.SetPriorityClass(constant.RepairClass)
+	suite.Equal(constant.RepairClass, op.GetPriorityClass())
+
+	for class := constant.PriorityClass(0); class < constant.PriorityClassLen; class++ {
+		c, ok := constant.StringToPriorityClass(class.String())
+		suite.True(ok)
+		suite.Equal(class, c)
+	}
+	_, ok := constant.StringToPriorityClass("unknown")
+	suite.False(ok)
+}
+
 func (suite *operatorTestSuite) TestCheckSuccess() {
 	{
 		steps := []OpStep{
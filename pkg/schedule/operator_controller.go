@@ -18,6 +18,7 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
@@ -64,10 +66,13 @@ type OperatorController struct {
 	hbStreams       *hbstream.HeartbeatStreams
 	fastOperators   *cache.TTLUint64
 	counts          map[operator.OpKind]uint64
+	classCounts     map[constant.PriorityClass]uint64
+	movementBudget  *storelimit.MovementBudget
 	opRecords       *OperatorRecords
 	wop             WaitingOperator
 	wopStatus       *WaitingOperatorStatus
 	opNotifierQueue operatorQueue
+	eventHub        *event.Hub
 }
 
 // NewOperatorController creates a OperatorController.
@@ -79,6 +84,8 @@ func NewOperatorController(ctx context.Context, cluster Cluster, hbStreams *hbst
 		hbStreams:       hbStreams,
 		fastOperators:   cache.NewIDTTL(ctx, time.Minute, FastOperatorFinishTime),
 		counts:          make(map[operator.OpKind]uint64),
+		classCounts:     make(map[constant.PriorityClass]uint64),
+		movementBudget:  storelimit.NewMovementBudget(),
 		opRecords:       NewOperatorRecords(ctx),
 		wop:             NewRandBuckets(),
 		wopStatus:       NewWaitingOperatorStatus(),
@@ -86,6 +93,11 @@ func NewOperatorController(ctx context.Context, cluster Cluster, hbStreams *hbst
 	}
 }
 
+// SetEventHub sets the hub to publish the events of the operators.
+func (oc *OperatorController) SetEventHub(hub *event.Hub) {
+	oc.eventHub = hub
+}
+
 // Ctx returns a context which will be canceled once RaftCluster is stopped.
 // For now, it is only used to control the lifetime of TTL cache in schedulers.
 func (oc *OperatorController) Ctx() context.Context {
@@ -117,7 +129,10 @@ func (oc *OperatorController) Dispatch(region *core.RegionInfo, source string) {
 			if source == DispatchFromHeartBeat && oc.checkStaleOperator(op, step, region) {
 				return
 			}
-			oc.SendScheduleCommand(region, step, source)
+			if op.IsHeld() && !oc.resumeOperator(op, region) {
+				return
+			}
+			oc.dispatchStep(op, region, step, source)
 		case operator.SUCCESS:
 			if op.ContainNonWitnessStep() {
 				oc.cluster.RecordOpStepWithTTL(op.RegionID())
@@ -324,13 +339,15 @@ func (oc *OperatorController) AddOperator(ops ...*operator.Operator) bool {
 	// note: checkAddOperator uses false param for `isPromoting`.
 	// This is used to keep check logic before fixing issue #4946,
 	// but maybe user want to add operator when waiting queue is busy
-	if oc.exceedStoreLimitLocked(ops...) || !oc.checkAddOperator(false, ops...) {
+	allowed, victims := oc.checkStoreLimitLocked(ops...)
+	if !allowed || oc.exceedMovementBudgetLocked(ops...) || !oc.checkAddOperator(false, ops...) {
 		for _, op := range ops {
 			_ = op.Cancel()
 			oc.buryOperator(op)
 		}
 		return false
 	}
+	oc.preemptOperatorsLocked(victims, ops[0])
 	for _, op := range ops {
 		if !oc.addOperatorLocked(op) {
 			return false
@@ -352,7 +369,8 @@ func (oc *OperatorController) PromoteWaitingOperator() {
 		}
 		operatorWaitCounter.WithLabelValues(ops[0].Desc(), "get").Inc()
 
-		if oc.exceedStoreLimitLocked(ops...) || !oc.checkAddOperator(true, ops...) {
+		allowed, victims := oc.checkStoreLimitLocked(ops...)
+		if !allowed || oc.exceedMovementBudgetLocked(ops...) || !oc.checkAddOperator(true, ops...) {
 			for _, op := range ops {
 				operatorWaitCounter.WithLabelValues(op.Desc(), "promote-canceled").Inc()
 				_ = op.Cancel()
@@ -362,6 +380,7 @@ func (oc *OperatorController) PromoteWaitingOperator() {
 			continue
 		}
 		oc.wopStatus.ops[ops[0].Desc()]--
+		oc.preemptOperatorsLocked(victims, ops[0])
 		break
 	}
 
@@ -378,6 +397,7 @@ func (oc *OperatorController) PromoteWaitingOperator() {
 // - The epoch of the operator and the epoch of the corresponding region are no longer consistent.
 // - The region already has a higher priority or same priority operator.
 // - Exceed the max number of waiting operators
+// - Exceed the quota of the priority class
 // - At least one operator is expired.
 func (oc *OperatorController) checkAddOperator(isPromoting bool, ops ...*operator.Operator) bool {
 	for _, op := range ops {
@@ -424,6 +444,13 @@ func (oc *OperatorController) checkAddOperator(isPromoting bool, ops ...*operato
 		if op.SchedulerKind() == operator.OpAdmin || op.IsLeaveJointStateOperator() {
 			continue
 		}
+		class := op.GetPriorityClass()
+		if quota := oc.cluster.GetOpts().GetOperatorClassQuota(class); quota > 0 && oc.classCounts[class] >= quota {
+			log.Debug("exceed the quota of the priority class", zap.Uint64("region-id", op.RegionID()), zap.Stringer("class", class), zap.Uint64("quota", quota))
+			operatorWaitCounter.WithLabelValues(op.Desc(), "exceed-class-quota").Inc()
+			operatorClassLimitedCounter.WithLabelValues(class.String(), "quota").Inc()
+			return false
+		}
 		if cl, ok := oc.cluster.(interface{ GetRegionLabeler() *labeler.RegionLabeler }); ok {
 			l := cl.GetRegionLabeler()
 			if l.ScheduleDisabled(region) {
@@ -475,6 +502,7 @@ func (oc *OperatorController) addOperatorLocked(op *operator.Operator) bool {
 	}
 	oc.operators[regionID] = op
 	operatorCounter.WithLabelValues(op.Desc(), "start").Inc()
+	oc.publishEvent(op, "create")
 	operatorSizeHist.WithLabelValues(op.Desc()).Observe(float64(op.ApproximateSize))
 	operatorWaitDuration.WithLabelValues(op.Desc()).Observe(op.ElapsedTime().Seconds())
 	opInfluence := NewTotalOpInfluence([]*operator.Operator{op}, oc.cluster)
@@ -494,12 +522,19 @@ func (oc *OperatorController) addOperatorLocked(op *operator.Operator) bool {
 			storeLimitCostCounter.WithLabelValues(strconv.FormatUint(storeID, 10), n).Add(float64(stepCost) / float64(storelimit.RegionInfluence[v]))
 		}
 	}
+	if !isMovementBudgetExempt(op) {
+		sizes := oc.getMovementSizes(op)
+		oc.movementBudget.Take(sizes)
+		for zone, size := range sizes {
+			movementBudgetCostCounter.WithLabelValues(zone).Add(float64(size))
+		}
+	}
 	oc.updateCounts(oc.operators)
 
 	var step operator.OpStep
 	if region := oc.cluster.GetRegion(op.RegionID()); region != nil {
 		if step = op.Check(region); step != nil {
-			oc.SendScheduleCommand(region, step, DispatchFromCreate)
+			oc.dispatchStep(op, region, step, DispatchFromCreate)
 		}
 	}
 
@@ -568,6 +603,7 @@ func (oc *OperatorController) buryOperator(op *operator.Operator, extraFields ..
 			zap.Reflect("operator", op),
 			zap.String("additional-info", op.GetAdditionalInfo()))
 		operatorCounter.WithLabelValues(op.Desc(), "finish").Inc()
+		oc.publishEvent(op, "finish")
 		operatorDuration.WithLabelValues(op.Desc()).Observe(op.RunningTime().Seconds())
 		for _, counter := range op.FinishedCounters {
 			counter.Inc()
@@ -579,12 +615,14 @@ func (oc *OperatorController) buryOperator(op *operator.Operator, extraFields ..
 			zap.Reflect("operator", op),
 			zap.String("additional-info", op.GetAdditionalInfo()))
 		operatorCounter.WithLabelValues(op.Desc(), "replace").Inc()
+		oc.publishEvent(op, "replace")
 	case operator.EXPIRED:
 		log.Info("operator expired",
 			zap.Uint64("region-id", op.RegionID()),
 			zap.Duration("lives", op.ElapsedTime()),
 			zap.Reflect("operator", op))
 		operatorCounter.WithLabelValues(op.Desc(), "expire").Inc()
+		oc.publishEvent(op, "expire")
 	case operator.TIMEOUT:
 		log.Info("operator timeout",
 			zap.Uint64("region-id", op.RegionID()),
@@ -592,6 +630,7 @@ func (oc *OperatorController) buryOperator(op *operator.Operator, extraFields ..
 			zap.Reflect("operator", op),
 			zap.String("additional-info", op.GetAdditionalInfo()))
 		operatorCounter.WithLabelValues(op.Desc(), "timeout").Inc()
+		oc.publishEvent(op, "timeout")
 	case operator.CANCELED:
 		fields := []zap.Field{
 			zap.Uint64("region-id", op.RegionID()),
@@ -604,11 +643,20 @@ func (oc *OperatorController) buryOperator(op *operator.Operator, extraFields ..
 			fields...,
 		)
 		operatorCounter.WithLabelValues(op.Desc(), "cancel").Inc()
+		oc.publishEvent(op, "cancel")
 	}
 
 	oc.opRecords.Put(op)
 }
 
+func (oc *OperatorController) publishEvent(op *operator.Operator, action string) {
+	oc.eventHub.Publish(event.TypeOperator, action, strconv.FormatUint(op.RegionID(), 10), map[string]interface{}{
+		"desc":     op.Desc(),
+		"kind":     op.Kind().String(),
+		"operator": op.String(),
+	})
+}
+
 // GetOperatorStatus gets the operator and its status with the specify id.
 func (oc *OperatorController) GetOperatorStatus(id uint64) *OperatorWithStatus {
 	oc.Lock()
@@ -661,6 +709,12 @@ func (oc *OperatorController) SendScheduleCommand(region *core.RegionInfo, step
 	oc.hbStreams.SendMsg(region, cmd)
 }
 
+// dispatchStep sends the step of the operator to the stores and marks the operator dispatched.
+func (oc *OperatorController) dispatchStep(op *operator.Operator, region *core.RegionInfo, step operator.OpStep, source string) {
+	oc.SendScheduleCommand(region, step, source)
+	op.SetDispatched()
+}
+
 func (oc *OperatorController) pushFastOperator(op *operator.Operator) {
 	oc.fastOperators.Put(op.RegionID(), op)
 }
@@ -696,8 +750,15 @@ func (oc *OperatorController) updateCounts(operators map[uint64]*operator.Operat
 	for k := range oc.counts {
 		delete(oc.counts, k)
 	}
+	for k := range oc.classCounts {
+		delete(oc.classCounts, k)
+	}
 	for _, op := range operators {
 		oc.counts[op.SchedulerKind()]++
+		oc.classCounts[op.GetPriorityClass()]++
+	}
+	for class := constant.PriorityClass(0); class < constant.PriorityClassLen; class++ {
+		operatorClassGauge.WithLabelValues(class.String()).Set(float64(oc.classCounts[class]))
 	}
 }
 
@@ -709,6 +770,13 @@ func (oc *OperatorController) OperatorCount(kind operator.OpKind) uint64 {
 	return oc.counts[kind]
 }
 
+// OperatorClassCount gets the count of running operators of the priority class.
+func (oc *OperatorController) OperatorClassCount(class constant.PriorityClass) uint64 {
+	oc.RLock()
+	defer oc.RUnlock()
+	return oc.classCounts[class]
+}
+
 // GetOpInfluence gets OpInfluence.
 func (oc *OperatorController) GetOpInfluence(cluster Cluster) operator.OpInfluence {
 	influence := operator.OpInfluence{
@@ -857,6 +925,262 @@ func (oc *OperatorController) exceedStoreLimitLocked(ops ...*operator.Operator)
 	return false
 }
 
+// isMovementBudgetExempt returns true if the operator is neither limited by nor charged to the movement budget.
+// Only the operators to repair the replicas are exempt, because the budget is meant to smooth the rebalancing
+// rather than to delay the recovery.
+func isMovementBudgetExempt(op *operator.Operator) bool {
+	return op.GetPriorityClass() == constant.RepairClass
+}
+
+// exceedMovementBudgetLocked returns true if the data moved by the operators exceeds the movement budget of
+// the cluster or the zones.
+func (oc *OperatorController) exceedMovementBudgetLocked(ops ...*operator.Operator) bool {
+	if len(ops) == 0 || isMovementBudgetExempt(ops[0]) {
+		return false
+	}
+	opts := oc.cluster.GetOpts()
+	oc.movementBudget.Reset(opts.GetClusterMovementLimit(), opts.GetZoneMovementLimit())
+	if oc.movementBudget.Available(oc.getMovementSizes(ops...)) {
+		return false
+	}
+	operatorWaitCounter.WithLabelValues(ops[0].Desc(), "exceed-movement-budget").Inc()
+	return true
+}
+
+// getMovementSizes returns the size of the data moved into each zone by the operators, which is
+// the size of the regions sent to the stores by snapshots.
+func (oc *OperatorController) getMovementSizes(ops ...*operator.Operator) map[string]int64 {
+	sizes := make(map[string]int64)
+	for _, op := range ops {
+		region := oc.cluster.GetRegion(op.RegionID())
+		if region == nil {
+			continue
+		}
+		opInfluence := NewTotalOpInfluence([]*operator.Operator{op}, oc.cluster)
+		for storeID, influence := range opInfluence.StoresInfluence {
+			if influence.GetStepCost(storelimit.AddPeer) == 0 {
+				continue
+			}
+			if store := oc.cluster.GetStore(storeID); store != nil {
+				sizes[store.GetLabelValue(storelimit.ZoneLabel)] += region.GetApproximateSize()
+			}
+		}
+	}
+	return sizes
+}
+
+// checkStoreLimitLocked checks whether the operators can be added under the store limit. If the store limit
+// is exceeded, it tries to find the running operators of lower priority classes to preempt, whose tokens are
+// taken over by the new operators. It returns whether the operators can be added and the operators to preempt.
+func (oc *OperatorController) checkStoreLimitLocked(ops ...*operator.Operator) (bool, []*operator.Operator) {
+	if !oc.exceedStoreLimitLocked(ops...) {
+		return true, nil
+	}
+	class := ops[0].GetPriorityClass()
+	victims := oc.findPreemptVictimsLocked(ops...)
+	if len(victims) == 0 {
+		operatorClassLimitedCounter.WithLabelValues(class.String(), "store-limit").Inc()
+		return false, nil
+	}
+	return true, victims
+}
+
+type storeLimitKey struct {
+	storeID uint64
+	typ     storelimit.Type
+}
+
+// findPreemptVictimsLocked finds the running operators which can be preempted by the given operators.
+// Only the operators of lower priority classes can be preempted, and the lowest class and the newest
+// ones are preempted first. The tokens of the steps not dispatched yet are taken over from the victims.
+// It returns nil if the preemption is disabled or the preemptible operators don't hold enough tokens.
+func (oc *OperatorController) findPreemptVictimsLocked(ops ...*operator.Operator) []*operator.Operator {
+	if len(ops) == 0 || !oc.cluster.GetOpts().IsOperatorPreemptionEnabled() {
+		return nil
+	}
+	class := ops[0].GetPriorityClass()
+	// needs records the cost of the limits which are exceeded.
+	needs := make(map[storeLimitKey]int64)
+	opInfluence := NewTotalOpInfluence(ops, oc.cluster)
+	for storeID := range opInfluence.StoresInfluence {
+		for _, v := range storelimit.TypeNameValue {
+			stepCost := opInfluence.GetStoreInfluence(storeID).GetStepCost(v)
+			if stepCost == 0 {
+				continue
+			}
+			limiter := oc.getOrCreateStoreLimit(storeID, v)
+			if limiter != nil && !limiter.Available(stepCost, v, ops[0].GetPriorityLevel()) {
+				needs[storeLimitKey{storeID, v}] = stepCost
+			}
+		}
+	}
+	if len(needs) == 0 {
+		return nil
+	}
+
+	regionIDs := make(map[uint64]struct{}, len(ops))
+	for _, op := range ops {
+		regionIDs[op.RegionID()] = struct{}{}
+	}
+	preemptible := func(op *operator.Operator) bool {
+		_, ok := regionIDs[op.RegionID()]
+		return !ok && op.GetPriorityClass() < class && !op.IsPaused()
+	}
+	// The merge operators are preempted in pairs, the candidate is the one of the pair
+	// with the lower priority class, so that the pair is only picked once.
+	var candidates [][]*operator.Operator
+	for _, op := range oc.operators {
+		if !preemptible(op) {
+			continue
+		}
+		if op.Kind()&operator.OpMerge == 0 {
+			candidates = append(candidates, []*operator.Operator{op})
+			continue
+		}
+		related, ok := oc.operators[op.GetRelatedMergeRegion()]
+		if !ok || !preemptible(related) {
+			continue
+		}
+		if related.GetPriorityClass() < op.GetPriorityClass() ||
+			(related.GetPriorityClass() == op.GetPriorityClass() && related.RegionID() < op.RegionID()) {
+			continue
+		}
+		candidates = append(candidates, []*operator.Operator{op, related})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if ci, cj := candidates[i][0].GetPriorityClass(), candidates[j][0].GetPriorityClass(); ci != cj {
+			return ci < cj
+		}
+		return candidates[i][0].GetStartTime().After(candidates[j][0].GetStartTime())
+	})
+
+	var victims []*operator.Operator
+	for _, group := range candidates {
+		useful := false
+		for _, op := range group {
+			influence := oc.pendingInfluence(op)
+			for key, need := range needs {
+				cost := influence.GetStoreInfluence(key.storeID).GetStepCost(key.typ)
+				if cost == 0 {
+					continue
+				}
+				useful = true
+				if need -= cost; need <= 0 {
+					delete(needs, key)
+				} else {
+					needs[key] = need
+				}
+			}
+		}
+		if useful {
+			victims = append(victims, group...)
+		}
+		if len(needs) == 0 {
+			return victims
+		}
+	}
+	return nil
+}
+
+// preemptOperatorsLocked preempts the operators and refunds the tokens of their steps not dispatched yet,
+// so that they can be taken by the preempting operators. The operators not dispatched yet are canceled.
+// The others are paused because their current steps may have been executed by the stores, and they
+// will be resumed once the store limit allows their remaining steps. The two operators of a merge
+// are always canceled or paused together.
+func (oc *OperatorController) preemptOperatorsLocked(victims []*operator.Operator, by *operator.Operator) {
+	dispatched := make(map[uint64]bool, len(victims))
+	for _, op := range victims {
+		dispatched[op.RegionID()] = op.HasDispatched()
+	}
+	for _, op := range victims {
+		if op.Kind()&operator.OpMerge != 0 {
+			related := op.GetRelatedMergeRegion()
+			dispatched[op.RegionID()] = dispatched[op.RegionID()] || dispatched[related]
+		}
+	}
+	for _, op := range victims {
+		influence := oc.pendingInfluence(op)
+		action := "pause"
+		if !dispatched[op.RegionID()] {
+			if !oc.removeOperatorLocked(op) {
+				continue
+			}
+			_ = op.Cancel()
+			action = "cancel"
+		} else {
+			op.Pause()
+		}
+		oc.refundStoreLimitLocked(influence)
+		log.Info("operator preempted",
+			zap.Uint64("region-id", op.RegionID()),
+			zap.Stringer("class", op.GetPriorityClass()),
+			zap.String("action", action),
+			zap.Uint64("preempted-by", by.RegionID()),
+			zap.Stringer("preempted-by-class", by.GetPriorityClass()))
+		operatorPreemptedCounter.WithLabelValues(op.GetPriorityClass().String(), by.GetPriorityClass().String(), action).Inc()
+		if action == "cancel" {
+			oc.buryOperator(op, zap.String("preempted-by", by.Desc()))
+		}
+	}
+}
+
+// resumeOperator resumes the paused operator if the store limit allows its remaining steps.
+func (oc *OperatorController) resumeOperator(op *operator.Operator, region *core.RegionInfo) bool {
+	oc.Lock()
+	defer oc.Unlock()
+	if !op.IsPaused() {
+		return true
+	}
+	influence := *operator.NewOpInfluence()
+	op.PendingInfluence(influence, region)
+	for storeID, storeInfluence := range influence.StoresInfluence {
+		for _, v := range storelimit.TypeNameValue {
+			stepCost := storeInfluence.GetStepCost(v)
+			if stepCost == 0 {
+				continue
+			}
+			limiter := oc.getOrCreateStoreLimit(storeID, v)
+			if limiter == nil || !limiter.Available(stepCost, v, op.GetPriorityLevel()) {
+				return false
+			}
+		}
+	}
+	for storeID, storeInfluence := range influence.StoresInfluence {
+		for _, v := range storelimit.TypeNameValue {
+			if stepCost := storeInfluence.GetStepCost(v); stepCost != 0 {
+				oc.getOrCreateStoreLimit(storeID, v).Take(stepCost, v, op.GetPriorityLevel())
+			}
+		}
+	}
+	op.Resume()
+	log.Info("operator resumed", zap.Uint64("region-id", op.RegionID()), zap.Stringer("class", op.GetPriorityClass()))
+	return true
+}
+
+// pendingInfluence returns the influence of the steps of the operator which are not dispatched yet.
+func (oc *OperatorController) pendingInfluence(op *operator.Operator) operator.OpInfluence {
+	influence := *operator.NewOpInfluence()
+	if region := oc.cluster.GetRegion(op.RegionID()); region != nil {
+		op.PendingInfluence(influence, region)
+	}
+	return influence
+}
+
+// refundStoreLimitLocked returns the tokens of the given influence to the store limits.
+func (oc *OperatorController) refundStoreLimitLocked(influence operator.OpInfluence) {
+	for storeID, storeInfluence := range influence.StoresInfluence {
+		for _, v := range storelimit.TypeNameValue {
+			stepCost := storeInfluence.GetStepCost(v)
+			if stepCost == 0 {
+				continue
+			}
+			if limiter := oc.getOrCreateStoreLimit(storeID, v); limiter != nil {
+				limiter.Refund(stepCost, v)
+			}
+		}
+	}
+}
+
 // getOrCreateStoreLimit is used to get or create the limit of a store.
 func (oc *OperatorController) getOrCreateStoreLimit(storeID uint64, limitType storelimit.Type) storelimit.StoreLimit {
 	ratePerSec := oc.cluster.GetOpts().GetStoreLimitByType(storeID, limitType) / StoreBalanceBaseTime
@@ -23,11 +23,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/stretchr/testify/suite"
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
@@ -434,6 +436,211 @@ func (suite *operatorControllerTestSuite) TestStoreLimit() {
 	suite.False(oc.RemoveOperator(op))
 }
 
+func (suite *operatorControllerTestSuite) TestOperatorPreemption() {
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc.ID, tc, false /* no need to run */)
+	oc := NewOperatorController(suite.ctx, tc, stream)
+	tc.AddLeaderStore(1, 0)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderStore(3, 0)
+	for i := uint64(1); i <= 100; i++ {
+		tc.AddLeaderRegion(i, 1)
+		tc.PutRegion(tc.GetRegion(i).Clone(core.SetApproximateSize(10)))
+	}
+	tc.SetStoreLimit(2, storelimit.AddPeer, 60)
+	tc.SetStoreLimit(3, storelimit.AddPeer, storelimit.Unlimited)
+	cost := storelimit.SmallRegionInfluence[storelimit.AddPeer]
+	store2Available := func() bool {
+		return tc.GetStore(2).GetStoreLimit().Available(cost, storelimit.AddPeer, constant.Low)
+	}
+
+	// Fill the store limit of store 2 with the balance operators, whose first steps are dispatched.
+	var balanceOps []*operator.Operator
+	for i := uint64(1); ; i++ {
+		op := operator.NewTestOperator(i, &metapb.RegionEpoch{}, operator.OpRegion,
+			operator.AddPeer{ToStore: 3, PeerID: 1000 + i}, operator.AddPeer{ToStore: 2, PeerID: i})
+		if !oc.AddOperator(op) {
+			break
+		}
+		suite.True(op.HasDispatched())
+		balanceOps = append(balanceOps, op)
+	}
+	suite.NotEmpty(balanceOps)
+	suite.Equal(uint64(len(balanceOps)), oc.OperatorClassCount(constant.BalanceClass))
+	suite.False(store2Available())
+
+	newRepairOp := func(regionID uint64) *operator.Operator {
+		op := operator.NewTestOperator(regionID, &metapb.RegionEpoch{}, operator.OpRegion, operator.AddPeer{ToStore: 2, PeerID: regionID})
+		op.SetPriorityClass(constant.RepairClass)
+		return op
+	}
+	// The preemption is disabled.
+	suite.False(oc.AddOperator(newRepairOp(100)))
+
+	// The newest balance operator is paused, and its tokens are taken over by the repair operator.
+	tc.SetEnableOperatorPreemption(true)
+	op := newRepairOp(100)
+	suite.True(oc.AddOperator(op))
+	suite.Equal(op, oc.GetOperator(100))
+	last := balanceOps[len(balanceOps)-1]
+	suite.True(last.IsPaused())
+	suite.Equal(operator.STARTED, last.Status())
+	suite.Equal(last, oc.GetOperator(last.RegionID()))
+	suite.Equal(uint64(len(balanceOps)), oc.OperatorClassCount(constant.BalanceClass))
+	suite.Equal(uint64(1), oc.OperatorClassCount(constant.RepairClass))
+	suite.False(store2Available())
+
+	// The paused operator is held after its dispatched step finishes until the tokens are available.
+	region := tc.GetRegion(last.RegionID()).Clone(core.WithAddPeer(&metapb.Peer{Id: 1000 + last.RegionID(), StoreId: 3}))
+	tc.PutRegion(region)
+	oc.Dispatch(region, DispatchFromHeartBeat)
+	suite.True(last.IsHeld())
+	tc.SetStoreLimit(2, storelimit.AddPeer, 600)
+	oc.Dispatch(region, DispatchFromHeartBeat)
+	suite.False(last.IsPaused())
+
+	// The operator which isn't dispatched yet is canceled.
+	pending := operator.NewTestOperator(99, &metapb.RegionEpoch{}, operator.OpRegion, operator.AddPeer{ToStore: 2, PeerID: 99})
+	suite.True(pending.Start())
+	oc.SetOperator(pending)
+	for store2Available() {
+		tc.GetStore(2).GetStoreLimit().Take(cost, storelimit.AddPeer, constant.Low)
+	}
+	op = newRepairOp(98)
+	suite.True(oc.AddOperator(op))
+	suite.Equal(operator.CANCELED, pending.Status())
+	suite.Nil(oc.GetOperator(99))
+	suite.False(store2Available())
+
+	// The operator can't preempt the ones of the same class.
+	op = newRepairOp(97)
+	op.SetPriorityClass(constant.BalanceClass)
+	suite.False(oc.AddOperator(op))
+	suite.Equal(operator.STARTED, balanceOps[0].Status())
+}
+
+func (suite *operatorControllerTestSuite) TestMergeOperatorPreemption() {
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc.ID, tc, false /* no need to run */)
+	oc := NewOperatorController(suite.ctx, tc, stream)
+	tc.AddLeaderStore(1, 0)
+	tc.AddLeaderStore(2, 0)
+	for i := uint64(1); i <= 5; i++ {
+		tc.AddLeaderRegion(i, 1)
+		tc.PutRegion(tc.GetRegion(i).Clone(core.SetApproximateSize(10)))
+	}
+	tc.SetStoreLimit(2, storelimit.AddPeer, 60)
+	tc.SetEnableOperatorPreemption(true)
+	cost := storelimit.SmallRegionInfluence[storelimit.AddPeer]
+	exhaustStore2 := func() {
+		limit := oc.getOrCreateStoreLimit(2, storelimit.AddPeer)
+		for limit.Available(cost, storelimit.AddPeer, constant.Low) {
+			limit.Take(cost, storelimit.AddPeer, constant.Low)
+		}
+	}
+	newMergeOps := func(source, target uint64) (*operator.Operator, *operator.Operator) {
+		from, to := tc.GetRegion(source).GetMeta(), tc.GetRegion(target).GetMeta()
+		sourceOp := operator.NewTestOperator(source, &metapb.RegionEpoch{}, operator.OpMerge,
+			operator.AddPeer{ToStore: 2, PeerID: 100 + source}, operator.MergeRegion{FromRegion: from, ToRegion: to})
+		targetOp := operator.NewTestOperator(target, &metapb.RegionEpoch{}, operator.OpMerge,
+			operator.MergeRegion{FromRegion: from, ToRegion: to, IsPassive: true})
+		for _, op := range []*operator.Operator{sourceOp, targetOp} {
+			suite.True(op.Start())
+			oc.SetOperator(op)
+		}
+		return sourceOp, targetOp
+	}
+	newRepairOp := func(regionID uint64) *operator.Operator {
+		op := operator.NewTestOperator(regionID, &metapb.RegionEpoch{}, operator.OpRegion, operator.AddPeer{ToStore: 2, PeerID: regionID})
+		op.SetPriorityClass(constant.RepairClass)
+		return op
+	}
+
+	// Both operators of the merge are canceled, although only the source one takes the tokens.
+	sourceOp, targetOp := newMergeOps(1, 2)
+	suite.Equal(uint64(2), sourceOp.GetRelatedMergeRegion())
+	suite.Equal(uint64(1), targetOp.GetRelatedMergeRegion())
+	exhaustStore2()
+	suite.True(oc.AddOperator(newRepairOp(5)))
+	suite.Equal(operator.CANCELED, sourceOp.Status())
+	suite.Equal(operator.CANCELED, targetOp.Status())
+	suite.Nil(oc.GetOperator(1))
+	suite.Nil(oc.GetOperator(2))
+
+	// Both operators of the merge are paused if any of them has been dispatched.
+	sourceOp, targetOp = newMergeOps(3, 4)
+	targetOp.SetDispatched()
+	exhaustStore2()
+	suite.True(oc.AddOperator(newRepairOp(1)))
+	suite.True(sourceOp.IsPaused())
+	suite.True(targetOp.IsPaused())
+	suite.Equal(sourceOp, oc.GetOperator(3))
+	suite.Equal(targetOp, oc.GetOperator(4))
+}
+
+func (suite *operatorControllerTestSuite) TestOperatorClassQuota() {
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc.ID, tc, false /* no need to run */)
+	oc := NewOperatorController(suite.ctx, tc, stream)
+	tc.AddLeaderStore(1, 0)
+	tc.AddLeaderStore(2, 0)
+	for i := uint64(1); i <= 3; i++ {
+		tc.AddLeaderRegion(i, 1, 2)
+	}
+	tc.SetOperatorClassQuota(constant.BalanceClass.String(), 1)
+
+	suite.True(oc.AddOperator(operator.NewTestOperator(1, &metapb.RegionEpoch{}, operator.OpLeader, operator.TransferLeader{FromStore: 1, ToStore: 2})))
+	suite.False(oc.AddOperator(operator.NewTestOperator(2, &metapb.RegionEpoch{}, operator.OpLeader, operator.TransferLeader{FromStore: 1, ToStore: 2})))
+	// The quota doesn't limit the other classes.
+	suite.True(oc.AddOperator(operator.NewTestOperator(3, &metapb.RegionEpoch{}, operator.OpHotRegion|operator.OpLeader, operator.TransferLeader{FromStore: 1, ToStore: 2})))
+	suite.Equal(uint64(1), oc.OperatorClassCount(constant.BalanceClass))
+	suite.Equal(uint64(1), oc.OperatorClassCount(constant.HotClass))
+}
+
+func (suite *operatorControllerTestSuite) TestMovementBudget() {
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc.ID, tc, false /* no need to run */)
+	oc := NewOperatorController(suite.ctx, tc, stream)
+	tc.AddLabelsStore(1, 0, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(2, 0, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(3, 0, map[string]string{"zone": "z2"})
+	tc.SetAllStoresLimit(storelimit.AddPeer, storelimit.Unlimited)
+	for i := uint64(1); i <= 10; i++ {
+		tc.AddLeaderRegion(i, 1)
+		tc.PutRegion(tc.GetRegion(i).Clone(core.SetApproximateSize(10)))
+	}
+	tc.SetMovementLimit(35*units.MiB, 25*units.MiB)
+
+	addPeer := func(regionID, storeID uint64) *operator.Operator {
+		return operator.NewTestOperator(regionID, &metapb.RegionEpoch{}, operator.OpRegion, operator.AddPeer{ToStore: storeID, PeerID: regionID})
+	}
+	suite.True(oc.AddOperator(addPeer(1, 2)))
+	suite.True(oc.AddOperator(addPeer(2, 2)))
+	// The budget of zone z1 is exceeded.
+	suite.False(oc.AddOperator(addPeer(3, 2)))
+	suite.True(oc.AddOperator(addPeer(4, 3)))
+	// The budget of the cluster is exceeded.
+	suite.False(oc.AddOperator(addPeer(5, 3)))
+	// The operator without data movement is not limited.
+	suite.True(oc.AddOperator(operator.NewTestOperator(6, &metapb.RegionEpoch{}, operator.OpLeader, operator.TransferLeader{FromStore: 1, ToStore: 2})))
+	// The operators of high priority, like the hot region and scatter ones, are limited too.
+	op := addPeer(7, 3)
+	op.SetPriorityLevel(constant.High)
+	suite.False(oc.AddOperator(op))
+	// Only the operators of repair class are not limited.
+	op = addPeer(9, 3)
+	op.SetPriorityClass(constant.RepairClass)
+	suite.True(oc.AddOperator(op))
+
+	// Remove the limit.
+	tc.SetMovementLimit(0, 0)
+	suite.True(oc.AddOperator(addPeer(8, 2)))
+}
+
 // #1652
 func (suite *operatorControllerTestSuite) TestDispatchOutdatedRegion() {
 	cluster := mockcluster.NewCluster(suite.ctx, mockconfig.NewTestOptions())
@@ -29,6 +29,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
@@ -51,6 +52,7 @@ type RuleManager struct {
 	storeSetInformer core.StoreSetInformer
 	cache            *RegionRuleFitCacheManager
 	conf             config.Config
+	eventHub         *event.Hub
 }
 
 // NewRuleManager creates a RuleManager instance.
@@ -432,9 +434,34 @@ func (m *RuleManager) tryCommitPatch(patch *ruleConfigPatch) error {
 	// update in-memory state
 	patch.commit()
 	m.ruleList = ruleList
+	m.publishPatch(patch.mut)
 	return nil
 }
 
+// SetEventHub sets the hub to publish the events of the rules and the rule groups.
+func (m *RuleManager) SetEventHub(hub *event.Hub) {
+	m.Lock()
+	defer m.Unlock()
+	m.eventHub = hub
+}
+
+func (m *RuleManager) publishPatch(p *ruleConfig) {
+	for key, r := range p.rules {
+		action := "set"
+		if r == nil {
+			action = "delete"
+		}
+		m.eventHub.Publish(event.TypePlacementRule, action, key[0]+"/"+key[1], map[string]interface{}{"kind": "rule"})
+	}
+	for id, g := range p.groups {
+		action := "set"
+		if g.isDefault() {
+			action = "delete"
+		}
+		m.eventHub.Publish(event.TypePlacementRule, action, id, map[string]interface{}{"kind": "group"})
+	}
+}
+
 func (m *RuleManager) savePatch(p *ruleConfig) error {
 	// TODO: it is not completely safe
 	// 1. in case that half of rules applied, error.. we have to cancel persisted rules
@@ -239,9 +239,11 @@ func (r *RegionScatterer) scatterRegions(regions map[uint64]*core.RegionInfo, fa
 		retryLimit = maxRetryLimit
 	}
 	opsCount := 0
+	scattered := make([]uint64, 0, len(regions))
+	defer func() { r.recordGroup(group, scattered...) }()
 	for currentRetry := 0; currentRetry <= retryLimit; currentRetry++ {
 		for _, region := range regions {
-			op, err := r.Scatter(region, group)
+			op, err := r.scatter(region, group)
 			failpoint.Inject("scatterFail", func() {
 				if region.GetID() == 1 {
 					err = errors.New("mock error")
@@ -252,6 +254,7 @@ func (r *RegionScatterer) scatterRegions(regions map[uint64]*core.RegionInfo, fa
 				continue
 			}
 			delete(regions, region.GetID())
+			scattered = append(scattered, region.GetID())
 			opsCount++
 			if op != nil {
 				if ok := r.opController.AddOperator(op); !ok {
@@ -279,6 +282,27 @@ func (r *RegionScatterer) scatterRegions(regions map[uint64]*core.RegionInfo, fa
 // Scatter relocates the region. If the group is defined, the regions' leader with the same group would be scattered
 // in a group level instead of cluster level.
 func (r *RegionScatterer) Scatter(region *core.RegionInfo, group string) (*operator.Operator, error) {
+	op, err := r.scatter(region, group)
+	if err == nil {
+		r.recordGroup(group, region.GetID())
+	}
+	return op, err
+}
+
+// recordGroup records the scattered regions into the scatter group, so that they
+// can be kept evenly distributed by the scatter group scheduler later.
+func (r *RegionScatterer) recordGroup(group string, regionIDs ...uint64) {
+	if len(group) == 0 || len(regionIDs) == 0 {
+		return
+	}
+	manager := r.cluster.GetScatterGroupManager()
+	if manager == nil {
+		return
+	}
+	manager.AddRegions(group, regionIDs...)
+}
+
+func (r *RegionScatterer) scatter(region *core.RegionInfo, group string) (*operator.Operator, error) {
 	if !filter.IsRegionReplicated(r.cluster, region) {
 		r.cluster.AddSuspectRegions(region.GetID())
 		scatterSkipNotReplicatedCounter.Inc()
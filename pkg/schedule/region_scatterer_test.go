@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
@@ -570,6 +571,39 @@ func TestRegionFromDifferentGroups(t *testing.T) {
 	check(scatterer.ordinaryEngine.selectedPeer)
 }
 
+func TestScatterGroupPersisted(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc.ID, tc, false)
+	oc := NewOperatorController(ctx, tc, stream)
+	for i := uint64(1); i <= 5; i++ {
+		tc.AddRegionStore(i, 0)
+	}
+	tc.SetAllStoresLimit(storelimit.AddPeer, storelimit.Unlimited)
+	tc.SetAllStoresLimit(storelimit.RemovePeer, storelimit.Unlimited)
+	scatterer := NewRegionScatterer(ctx, tc, oc)
+	_, err := scatterer.Scatter(tc.AddLeaderRegion(1, 1, 2, 3), "g1")
+	re.NoError(err)
+	_, err = scatterer.Scatter(tc.AddLeaderRegion(2, 1, 2, 3), "")
+	re.NoError(err)
+	regions := map[uint64]*core.RegionInfo{
+		3: tc.AddLeaderRegion(3, 1, 2, 3),
+		4: tc.AddLeaderRegion(4, 2, 3, 4),
+	}
+	failures := make(map[uint64]error)
+	_, err = scatterer.scatterRegions(regions, failures, "g2", 1)
+	re.NoError(err)
+	re.Empty(failures)
+
+	manager := tc.GetScatterGroupManager()
+	re.Len(manager.GetGroups(), 2)
+	re.Equal([]uint64{1}, manager.GetGroup("g1").RegionIDs)
+	re.Equal([]uint64{3, 4}, manager.GetGroup("g2").RegionIDs)
+}
+
 func TestRegionHasLearner(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,333 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scattergroup
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"go.uber.org/zap"
+)
+
+// defaultRegionTTL is how long a region stays in a group after it's scattered in
+// the group the last time, so that the groups don't grow forever.
+const defaultRegionTTL = 24 * time.Hour
+
+// Group is a group of the regions scattered together. It's persisted so that
+// the regions of the group can be kept evenly distributed after being scattered.
+type Group struct {
+	Name      string   `json:"name"`
+	RegionIDs []uint64 `json:"region-ids"`
+}
+
+// storedGroup is the persisted form of a group.
+type storedGroup struct {
+	Name string `json:"name"`
+	// Regions maps the region ID to the unix time in seconds when it leaves the group.
+	Regions map[uint64]int64 `json:"regions"`
+}
+
+// Manager manages the persisted scatter groups. The changes are kept in memory
+// and flushed to the storage in batch periodically, so the changes made within
+// the last flush interval may be lost if the PD leader changes.
+type Manager struct {
+	ctx context.Context
+	// flushMu serializes the writes to the storage, so a flush never saves a
+	// group again after it's deleted.
+	flushMu syncutil.Mutex
+	mu      syncutil.RWMutex
+	storage endpoint.ScatterGroupStorage
+	ttl     time.Duration
+	groups  map[string]map[uint64]time.Time // group name -> region ID -> expire time
+	// regionGroups is the index of the groups which a region belongs to.
+	regionGroups map[uint64]map[string]struct{}
+	// dirty is the groups changed since the last flush.
+	dirty map[string]struct{}
+}
+
+// NewManager creates a scatter group manager, loads the groups from the storage
+// and starts to flush the changes and clean up the expired regions every interval.
+func NewManager(ctx context.Context, storage endpoint.ScatterGroupStorage, flushInterval time.Duration) (*Manager, error) {
+	m := &Manager{
+		ctx:          ctx,
+		storage:      storage,
+		ttl:          defaultRegionTTL,
+		groups:       make(map[string]map[uint64]time.Time),
+		regionGroups: make(map[uint64]map[string]struct{}),
+		dirty:        make(map[string]struct{}),
+	}
+	if err := storage.LoadScatterGroups(func(k, v string) {
+		var group storedGroup
+		if err := json.Unmarshal([]byte(v), &group); err != nil {
+			log.Error("failed to unmarshal scatter group", zap.String("group-key", k), errs.ZapError(errs.ErrJSONUnmarshal, err))
+			return
+		}
+		for id, expire := range group.Regions {
+			m.addRegionLocked(group.Name, id, time.Unix(expire, 0))
+		}
+	}); err != nil {
+		return nil, err
+	}
+	go m.run(flushInterval)
+	return m, nil
+}
+
+func (m *Manager) run(flushInterval time.Duration) {
+	defer logutil.LogPanic()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.gcExpiredRegions(time.Now())
+			if err := m.Flush(); err != nil {
+				log.Warn("failed to flush scatter groups", errs.ZapError(err))
+			}
+		case <-m.ctx.Done():
+			// Save the pending changes, otherwise they are lost after the PD leader changes.
+			if err := m.Flush(); err != nil {
+				log.Warn("failed to flush scatter groups before stopping", errs.ZapError(err))
+			}
+			log.Info("scatter group manager stopped")
+			return
+		}
+	}
+}
+
+// AddRegions adds the regions to the group, the group is created if it doesn't exist.
+// The regions already in the group stay for another TTL.
+func (m *Manager) AddRegions(name string, regionIDs ...uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expire := time.Now().Add(m.ttl)
+	for _, id := range regionIDs {
+		m.addRegionLocked(name, id, expire)
+	}
+	m.dirty[name] = struct{}{}
+}
+
+// InheritRegions adds the regions split from the parent region into all the groups
+// of the parent, and they leave the groups along with the parent.
+func (m *Manager) InheritRegions(parentID uint64, regionIDs ...uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name := range m.regionGroups[parentID] {
+		expire := m.groups[name][parentID]
+		for _, id := range regionIDs {
+			if expire.After(m.groups[name][id]) {
+				m.addRegionLocked(name, id, expire)
+			}
+		}
+		m.dirty[name] = struct{}{}
+	}
+}
+
+// RemoveRegions removes the regions from the group, the group is deleted if it becomes empty.
+func (m *Manager) RemoveRegions(name string, regionIDs ...uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.groups[name]; !ok {
+		return
+	}
+	for _, id := range regionIDs {
+		m.removeRegionLocked(name, id)
+	}
+	m.dirty[name] = struct{}{}
+}
+
+// DeleteGroup deletes the group from the storage at once.
+func (m *Manager) DeleteGroup(name string) error {
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	if m.GetGroup(name) == nil {
+		return nil
+	}
+	if err := m.storage.DeleteScatterGroup(name); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id := range m.groups[name] {
+		m.removeRegionLocked(name, id)
+	}
+	delete(m.dirty, name)
+	return nil
+}
+
+// Flush persists the groups changed since the last flush. The groups failed to
+// be persisted are retried in the next flush.
+func (m *Manager) Flush() error {
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	m.mu.Lock()
+	changes := make(map[string]*storedGroup, len(m.dirty))
+	for name := range m.dirty {
+		regions, ok := m.groups[name]
+		if !ok {
+			changes[name] = nil
+			continue
+		}
+		group := &storedGroup{Name: name, Regions: make(map[uint64]int64, len(regions))}
+		for id, expire := range regions {
+			group.Regions[id] = expire.Unix()
+		}
+		changes[name] = group
+	}
+	m.dirty = make(map[string]struct{})
+	m.mu.Unlock()
+
+	var lastErr error
+	for name, group := range changes {
+		var err error
+		if group == nil {
+			err = m.storage.DeleteScatterGroup(name)
+		} else {
+			err = m.storage.SaveScatterGroup(name, group)
+		}
+		if err != nil {
+			lastErr = err
+			m.mu.Lock()
+			m.dirty[name] = struct{}{}
+			m.mu.Unlock()
+		}
+	}
+	return lastErr
+}
+
+// gcExpiredRegions removes the regions which have stayed in the groups longer than the TTL.
+func (m *Manager) gcExpiredRegions(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, regions := range m.groups {
+		expired := 0
+		for id, expire := range regions {
+			if expire.Before(now) {
+				m.removeRegionLocked(name, id)
+				expired++
+			}
+		}
+		if expired > 0 {
+			m.dirty[name] = struct{}{}
+			log.Debug("expired regions are removed from scatter group", zap.String("group", name), zap.Int("count", expired))
+		}
+	}
+}
+
+func (m *Manager) addRegionLocked(name string, id uint64, expire time.Time) {
+	regions, ok := m.groups[name]
+	if !ok {
+		regions = make(map[uint64]time.Time)
+		m.groups[name] = regions
+	}
+	regions[id] = expire
+	groups, ok := m.regionGroups[id]
+	if !ok {
+		groups = make(map[string]struct{})
+		m.regionGroups[id] = groups
+	}
+	groups[name] = struct{}{}
+}
+
+func (m *Manager) removeRegionLocked(name string, id uint64) {
+	if regions, ok := m.groups[name]; ok {
+		delete(regions, id)
+		if len(regions) == 0 {
+			delete(m.groups, name)
+		}
+	}
+	if groups, ok := m.regionGroups[id]; ok {
+		delete(groups, name)
+		if len(groups) == 0 {
+			delete(m.regionGroups, id)
+		}
+	}
+}
+
+// GetGroup returns the group with the given name.
+func (m *Manager) GetGroup(name string) *Group {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	regions, ok := m.groups[name]
+	if !ok {
+		return nil
+	}
+	return newGroup(name, regions)
+}
+
+// GetGroups returns all the groups sorted by name.
+func (m *Manager) GetGroups() []*Group {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	groups := make([]*Group, 0, len(m.groups))
+	for name, regions := range m.groups {
+		groups = append(groups, newGroup(name, regions))
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+func newGroup(name string, regions map[uint64]time.Time) *Group {
+	group := &Group{Name: name, RegionIDs: make([]uint64, 0, len(regions))}
+	for id := range regions {
+		group.RegionIDs = append(group.RegionIDs, id)
+	}
+	sort.Slice(group.RegionIDs, func(i, j int) bool { return group.RegionIDs[i] < group.RegionIDs[j] })
+	return group
+}
+
+// Distribution is the distribution of the regions of a scatter group over the stores.
+type Distribution struct {
+	Name        string            `json:"name"`
+	RegionCount int               `json:"region-count"`
+	Peers       map[uint64]uint64 `json:"peers"`
+	Leaders     map[uint64]uint64 `json:"leaders"`
+}
+
+type regionGetter interface {
+	GetRegion(id uint64) *core.RegionInfo
+}
+
+// GetDistribution returns the current distribution of the group in the cluster.
+// The regions which don't exist anymore are not counted.
+func GetDistribution(cluster regionGetter, group *Group) *Distribution {
+	dist := &Distribution{
+		Name:    group.Name,
+		Peers:   make(map[uint64]uint64),
+		Leaders: make(map[uint64]uint64),
+	}
+	for _, id := range group.RegionIDs {
+		region := cluster.GetRegion(id)
+		if region == nil {
+			continue
+		}
+		dist.RegionCount++
+		for _, peer := range region.GetPeers() {
+			dist.Peers[peer.GetStoreId()]++
+		}
+		if leader := region.GetLeader(); leader != nil {
+			dist.Leaders[leader.GetStoreId()]++
+		}
+	}
+	return dist
+}
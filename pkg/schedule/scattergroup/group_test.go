@@ -0,0 +1,130 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scattergroup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestManager(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := storage.NewStorageWithMemoryBackend()
+	m, err := NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	re.Empty(m.GetGroups())
+	re.Nil(m.GetGroup("g1"))
+
+	m.AddRegions("g1", 3, 1, 2)
+	m.AddRegions("g1", 2, 4)
+	m.AddRegions("g2", 5)
+	re.Equal(&Group{Name: "g1", RegionIDs: []uint64{1, 2, 3, 4}}, m.GetGroup("g1"))
+	groups := m.GetGroups()
+	re.Len(groups, 2)
+	re.Equal("g1", groups[0].Name)
+	re.Equal("g2", groups[1].Name)
+
+	m.RemoveRegions("g1", 1, 6)
+	re.Equal([]uint64{2, 3, 4}, m.GetGroup("g1").RegionIDs)
+	// The group is deleted after all the regions are removed.
+	m.RemoveRegions("g2", 5)
+	re.Nil(m.GetGroup("g2"))
+
+	// The changes are persisted in batch by the flush.
+	reloaded, err := NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	re.Empty(reloaded.GetGroups())
+	re.NoError(m.Flush())
+	reloaded, err = NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	re.Len(reloaded.GetGroups(), 1)
+	re.Equal([]uint64{2, 3, 4}, reloaded.GetGroup("g1").RegionIDs)
+
+	// The deletion is persisted at once.
+	re.NoError(m.DeleteGroup("g1"))
+	re.NoError(m.Flush())
+	reloaded, err = NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	re.Empty(reloaded.GetGroups())
+}
+
+func TestInheritAndExpireRegions(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := storage.NewStorageWithMemoryBackend()
+	m, err := NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	m.ttl = time.Hour
+	m.AddRegions("g1", 1, 2)
+	m.AddRegions("g2", 1)
+
+	// The regions split from region 1 join all its groups.
+	m.InheritRegions(1, 3, 4)
+	m.InheritRegions(5, 6)
+	re.Equal([]uint64{1, 2, 3, 4}, m.GetGroup("g1").RegionIDs)
+	re.Equal([]uint64{1, 3, 4}, m.GetGroup("g2").RegionIDs)
+
+	// The regions leave the groups after the TTL, unless they're added again.
+	m.ttl = 2 * time.Hour
+	m.AddRegions("g1", 2)
+	m.gcExpiredRegions(time.Now().Add(time.Hour + time.Minute))
+	re.Equal([]uint64{2}, m.GetGroup("g1").RegionIDs)
+	re.Nil(m.GetGroup("g2"))
+	re.NoError(m.Flush())
+	reloaded, err := NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	re.Len(reloaded.GetGroups(), 1)
+	re.Equal([]uint64{2}, reloaded.GetGroup("g1").RegionIDs)
+}
+
+func TestDistribution(t *testing.T) {
+	re := require.New(t)
+	regions := core.NewBasicCluster()
+	for i := uint64(1); i <= 2; i++ {
+		peers := []*metapb.Peer{{Id: i*10 + 1, StoreId: 1}, {Id: i*10 + 2, StoreId: i + 1}}
+		regions.PutRegion(core.NewRegionInfo(&metapb.Region{Id: i, StartKey: []byte{byte(i)}, EndKey: []byte{byte(i + 1)}, Peers: peers}, peers[0]))
+	}
+	dist := GetDistribution(regions, &Group{Name: "g", RegionIDs: []uint64{1, 2, 3}})
+	re.Equal(2, dist.RegionCount)
+	re.Equal(map[uint64]uint64{1: 2, 2: 1, 3: 1}, dist.Peers)
+	re.Equal(map[uint64]uint64{1: 2}, dist.Leaders)
+}
+
+func TestFlushOnStop(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	store := storage.NewStorageWithMemoryBackend()
+	m, err := NewManager(ctx, store, time.Hour)
+	re.NoError(err)
+	m.AddRegions("g1", 1, 2)
+
+	// The pending changes are flushed when the manager stops.
+	cancel()
+	reloadCtx, reloadCancel := context.WithCancel(context.Background())
+	defer reloadCancel()
+	re.Eventually(func() bool {
+		reloaded, err := NewManager(reloadCtx, store, time.Hour)
+		return err == nil && reloaded.GetGroup("g1") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,390 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/unrolled/render"
+)
+
+const (
+	// BalanceTableName is balance table scheduler name.
+	BalanceTableName = "balance-table-scheduler"
+	// BalanceTableType is balance table scheduler type.
+	BalanceTableType = "balance-table"
+
+	// balanceTableGranularityTable balances the regions of each table.
+	balanceTableGranularityTable = "table"
+	// balanceTableGranularityKeyspace balances the regions of each keyspace.
+	balanceTableGranularityKeyspace = "keyspace"
+
+	defaultBalanceTableRangeLimit = 16
+	// balanceTableScanBatch is the number of the regions scanned at once to find the next range.
+	balanceTableScanBatch = 64
+	// balanceTableMaxScanRegions is the max number of the regions scanned in one round,
+	// which keeps a round cheap if there are many regions which don't belong to any range.
+	balanceTableMaxScanRegions = 4096
+)
+
+var (
+	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
+	balanceTableCounter                    = schedulerCounter.WithLabelValues(BalanceTableName, "schedule")
+	balanceTableNoRangeCounter             = schedulerCounter.WithLabelValues(BalanceTableName, "no-range")
+	balanceTableNewOperatorCounter         = schedulerCounter.WithLabelValues(BalanceTableName, "new-operator")
+	balanceTableNewLeaderOperatorCounter   = schedulerCounter.WithLabelValues(BalanceTableName, "new-leader-operator")
+	balanceTableNewRegionOperatorCounter   = schedulerCounter.WithLabelValues(BalanceTableName, "new-region-operator")
+	balanceTableNoNeedBalanceLeaderCounter = schedulerCounter.WithLabelValues(BalanceTableName, "no-need-balance-leader")
+	balanceTableNoNeedBalanceRegionCounter = schedulerCounter.WithLabelValues(BalanceTableName, "no-need-balance-region")
+)
+
+func initBalanceTableConfig() *balanceTableSchedulerConfig {
+	return &balanceTableSchedulerConfig{
+		Granularity: balanceTableGranularityTable,
+		RangeLimit:  defaultBalanceTableRangeLimit,
+	}
+}
+
+type balanceTableSchedulerConfig struct {
+	mu      syncutil.RWMutex
+	storage endpoint.ConfigStorage
+	// Granularity is the granularity of the ranges to balance, which is table or keyspace.
+	Granularity string `json:"granularity"`
+	// RangeLimit is the max number of the distinct ranges checked in one round.
+	RangeLimit uint64 `json:"range-limit"`
+}
+
+func isValidBalanceTableGranularity(granularity string) bool {
+	return granularity == balanceTableGranularityTable || granularity == balanceTableGranularityKeyspace
+}
+
+func (conf *balanceTableSchedulerConfig) Clone() *balanceTableSchedulerConfig {
+	conf.mu.RLock()
+	defer conf.mu.RUnlock()
+	return &balanceTableSchedulerConfig{
+		Granularity: conf.Granularity,
+		RangeLimit:  conf.RangeLimit,
+	}
+}
+
+func (conf *balanceTableSchedulerConfig) persistLocked() error {
+	data, err := schedule.EncodeConfig(conf)
+	failpoint.Inject("persistFail", func() {
+		err = errors.New("fail to persist")
+	})
+	if err != nil {
+		return err
+	}
+	return conf.storage.SaveScheduleConfig(BalanceTableName, data)
+}
+
+func (conf *balanceTableSchedulerConfig) Update(data []byte) (int, interface{}) {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if !isValidBalanceTableGranularity(conf.Granularity) || conf.RangeLimit == 0 {
+		json.Unmarshal(oldc, conf)
+		return http.StatusBadRequest, "invalid granularity or range-limit"
+	}
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if err := conf.persistLocked(); err != nil {
+			json.Unmarshal(oldc, conf)
+			return http.StatusInternalServerError, err.Error()
+		}
+		return http.StatusOK, "success"
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if reflectutil.FindSameFieldByJSON(conf, m) {
+		return http.StatusOK, "no changed"
+	}
+	return http.StatusBadRequest, "config item not found"
+}
+
+type balanceTableHandler struct {
+	rd     *render.Render
+	config *balanceTableSchedulerConfig
+}
+
+func newBalanceTableHandler(conf *balanceTableSchedulerConfig) http.Handler {
+	handler := &balanceTableHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.UpdateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.ListConfig).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *balanceTableHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.Update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *balanceTableHandler) ListConfig(w http.ResponseWriter, r *http.Request) {
+	conf := handler.config.Clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+// balanceTableRange is the key range of a table or a keyspace.
+type balanceTableRange struct {
+	startKey []byte
+	endKey   []byte
+}
+
+func (r *balanceTableRange) String() string {
+	return fmt.Sprintf("%s-%s", hex.EncodeToString(r.startKey), hex.EncodeToString(r.endKey))
+}
+
+// balanceTableScheduler balances the leaders and regions of each table or keyspace
+// across the stores. Unlike the scatter range scheduler, the ranges are derived from
+// the region keys, so a single scheduler covers all the tables or keyspaces. A few
+// ranges are checked in each round, and the next round continues from the last one.
+type balanceTableScheduler struct {
+	*BaseScheduler
+	conf          *balanceTableSchedulerConfig
+	handler       http.Handler
+	balanceLeader schedule.Scheduler
+	balanceRegion schedule.Scheduler
+	// nextKey is the key where the next round starts to find the ranges.
+	nextKey []byte
+}
+
+// newBalanceTableScheduler creates a scheduler that balances the distribution of leaders and regions of each table or keyspace.
+func newBalanceTableScheduler(opController *schedule.OperatorController, conf *balanceTableSchedulerConfig) *balanceTableScheduler {
+	return &balanceTableScheduler{
+		BaseScheduler: NewBaseScheduler(opController),
+		conf:          conf,
+		handler:       newBalanceTableHandler(conf),
+		balanceLeader: newBalanceLeaderScheduler(
+			opController,
+			&balanceLeaderSchedulerConfig{Ranges: []core.KeyRange{core.NewKeyRange("", "")}},
+			WithBalanceLeaderName("balance-table-leader"),
+			WithBalanceLeaderCounter(balanceTableLeaderCounter),
+		),
+		balanceRegion: newBalanceRegionScheduler(
+			opController,
+			&balanceRegionSchedulerConfig{Ranges: []core.KeyRange{core.NewKeyRange("", "")}},
+			WithBalanceRegionName("balance-table-region"),
+			WithBalanceRegionCounter(balanceTableRegionCounter),
+		),
+	}
+}
+
+func (s *balanceTableScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+func (s *balanceTableScheduler) GetName() string {
+	return BalanceTableName
+}
+
+func (s *balanceTableScheduler) GetType() string {
+	return BalanceTableType
+}
+
+func (s *balanceTableScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.mu.RLock()
+	defer s.conf.mu.RUnlock()
+	return schedule.EncodeConfig(s.conf)
+}
+
+func (s *balanceTableScheduler) IsScheduleAllowed(cluster schedule.Cluster) bool {
+	return s.allowBalanceLeader(cluster) || s.allowBalanceRegion(cluster)
+}
+
+func (s *balanceTableScheduler) allowBalanceLeader(cluster schedule.Cluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpRange) < cluster.GetOpts().GetLeaderScheduleLimit()
+	if !allowed {
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpLeader.String()).Inc()
+	}
+	return allowed
+}
+
+func (s *balanceTableScheduler) allowBalanceRegion(cluster schedule.Cluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpRange) < cluster.GetOpts().GetRegionScheduleLimit()
+	if !allowed {
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpRegion.String()).Inc()
+	}
+	return allowed
+}
+
+func (s *balanceTableScheduler) Schedule(cluster schedule.Cluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	balanceTableCounter.Inc()
+	conf := s.conf.Clone()
+	ranges := s.nextRanges(cluster, conf)
+	if len(ranges) == 0 {
+		balanceTableNoRangeCounter.Inc()
+		return nil, nil
+	}
+	for _, r := range ranges {
+		if op := s.balanceRange(cluster, r); op != nil {
+			return []*operator.Operator{op}, nil
+		}
+	}
+	return nil, nil
+}
+
+// balanceRange balances the leaders and regions in the range like the scatter range scheduler.
+func (s *balanceTableScheduler) balanceRange(cluster schedule.Cluster, r *balanceTableRange) *operator.Operator {
+	// isolate a new cluster according to the key range
+	c := schedule.GenRangeCluster(cluster, r.startKey, r.endKey)
+	c.SetTolerantSizeRatio(2)
+	if s.allowBalanceLeader(cluster) {
+		ops, _ := s.balanceLeader.Schedule(c, false)
+		if len(ops) > 0 {
+			ops[0].SetDesc(fmt.Sprintf("balance-table-leader-%s", r))
+			ops[0].AttachKind(operator.OpRange)
+			ops[0].Counters = append(ops[0].Counters,
+				balanceTableNewOperatorCounter,
+				balanceTableNewLeaderOperatorCounter)
+			return ops[0]
+		}
+		balanceTableNoNeedBalanceLeaderCounter.Inc()
+	}
+	if s.allowBalanceRegion(cluster) {
+		ops, _ := s.balanceRegion.Schedule(c, false)
+		if len(ops) > 0 {
+			ops[0].SetDesc(fmt.Sprintf("balance-table-region-%s", r))
+			ops[0].AttachKind(operator.OpRange)
+			ops[0].Counters = append(ops[0].Counters,
+				balanceTableNewOperatorCounter,
+				balanceTableNewRegionOperatorCounter)
+			return ops[0]
+		}
+		balanceTableNoNeedBalanceRegionCounter.Inc()
+	}
+	return nil
+}
+
+// nextRanges finds at most RangeLimit distinct ranges from the next key, and
+// wraps around to the beginning once it reaches the end of the key space.
+func (s *balanceTableScheduler) nextRanges(cluster schedule.Cluster, conf *balanceTableSchedulerConfig) []*balanceTableRange {
+	var (
+		ranges  []*balanceTableRange
+		seen    = make(map[string]struct{})
+		scanned int
+		wrapped bool
+	)
+	for uint64(len(ranges)) < conf.RangeLimit && scanned < balanceTableMaxScanRegions {
+		regions := cluster.ScanRegions(s.nextKey, nil, balanceTableScanBatch)
+		if len(regions) == 0 {
+			if wrapped || len(s.nextKey) == 0 {
+				break
+			}
+			s.nextKey, wrapped = nil, true
+			continue
+		}
+		scanned += len(regions)
+		var found *balanceTableRange
+		for _, region := range regions {
+			if r := s.getUnvisitedRange(conf.Granularity, region, seen); r != nil {
+				found = r
+				break
+			}
+			s.nextKey = region.GetEndKey()
+			if len(s.nextKey) == 0 {
+				break
+			}
+		}
+		if found != nil {
+			// Skip the rest regions of the range.
+			ranges = append(ranges, found)
+			seen[string(found.startKey)] = struct{}{}
+			s.nextKey = found.endKey
+			continue
+		}
+		if len(s.nextKey) == 0 {
+			if wrapped {
+				break
+			}
+			wrapped = true
+		}
+	}
+	return ranges
+}
+
+// getUnvisitedRange returns the range of the region if it's not visited yet. The range
+// is visited if it's found in this round, or it ends before the next key, which means
+// it has been found in the last round.
+func (s *balanceTableScheduler) getUnvisitedRange(granularity string, region *core.RegionInfo, seen map[string]struct{}) *balanceTableRange {
+	r := getBalanceTableRange(granularity, region.GetStartKey())
+	if r == nil {
+		return nil
+	}
+	if _, ok := seen[string(r.startKey)]; ok {
+		return nil
+	}
+	if len(s.nextKey) > 0 && bytes.Compare(r.endKey, s.nextKey) <= 0 {
+		return nil
+	}
+	return r
+}
+
+func getBalanceTableRange(granularity string, key []byte) *balanceTableRange {
+	var (
+		startKey, endKey []byte
+		ok               bool
+	)
+	switch granularity {
+	case balanceTableGranularityKeyspace:
+		startKey, endKey, ok = codec.Key(key).KeyspaceRange()
+	default:
+		startKey, endKey, ok = codec.Key(key).TableRange()
+	}
+	if !ok {
+		return nil
+	}
+	return &balanceTableRange{startKey: startKey, endKey: endKey}
+}
+
+// buildBalanceTableConfig sets the granularity from the args if it's specified.
+func buildBalanceTableConfig(conf *balanceTableSchedulerConfig, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if !isValidBalanceTableGranularity(args[0]) {
+		return errs.ErrSchedulerConfig.FastGenByArgs("granularity")
+	}
+	conf.Granularity = args[0]
+	return nil
+}
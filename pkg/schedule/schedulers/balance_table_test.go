@@ -0,0 +1,114 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func addTableRegion(tc *mockcluster.Cluster, regionID uint64, startKey, endKey []byte, leaderStoreID uint64, followerStoreIDs ...uint64) {
+	region := tc.AddLeaderRegion(regionID, leaderStoreID, followerStoreIDs...)
+	tc.PutRegion(region.Clone(core.WithStartKey(startKey), core.WithEndKey(endKey)))
+}
+
+func rowKey(tableID, rowID int64) []byte {
+	return codec.EncodeBytes(codec.GenerateRowKey(tableID, rowID))
+}
+
+func TestBalanceTableRanges(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	addTableRegion(tc, 1, []byte(""), rowKey(1, 0), 1, 2, 3)
+	addTableRegion(tc, 2, rowKey(1, 0), rowKey(1, 10), 1, 2, 3)
+	addTableRegion(tc, 3, rowKey(1, 10), rowKey(2, 0), 1, 2, 3)
+	addTableRegion(tc, 4, rowKey(2, 0), rowKey(3, 0), 1, 2, 3)
+	addTableRegion(tc, 5, rowKey(3, 0), []byte(""), 1, 2, 3)
+
+	s := newBalanceTableScheduler(oc, initBalanceTableConfig())
+	conf := s.conf.Clone()
+	conf.RangeLimit = 2
+	tableID := func(r *balanceTableRange) int64 { return codec.Key(r.startKey).TableID() }
+	ranges := s.nextRanges(tc, conf)
+	re.Len(ranges, 2)
+	re.Equal(int64(1), tableID(ranges[0]))
+	re.Equal(int64(2), tableID(ranges[1]))
+	// The next round continues from the last range and wraps around.
+	ranges = s.nextRanges(tc, conf)
+	re.Len(ranges, 2)
+	re.Equal(int64(3), tableID(ranges[0]))
+	re.Equal(int64(1), tableID(ranges[1]))
+	// All the ranges are returned only once in a round.
+	conf.RangeLimit = 10
+	re.Len(s.nextRanges(tc, conf), 3)
+
+	// No keyspace range is found.
+	conf.Granularity = balanceTableGranularityKeyspace
+	re.Empty(s.nextRanges(tc, conf))
+}
+
+func TestBalanceTable(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	sl, err := schedule.CreateScheduler(BalanceTableType, oc, nil, storage.NewStorageWithMemoryBackend(), schedule.ConfigSliceDecoder(BalanceTableType, []string{"table"}))
+	re.NoError(err)
+	re.True(sl.IsScheduleAllowed(tc))
+	ops, _ := sl.Schedule(tc, false)
+	re.Empty(ops)
+	_, err = schedule.CreateScheduler(BalanceTableType, oc, nil, storage.NewStorageWithMemoryBackend(), schedule.ConfigSliceDecoder(BalanceTableType, []string{"unknown"}))
+	re.Error(err)
+
+	for i := uint64(1); i <= 4; i++ {
+		tc.AddRegionStore(i, 10)
+	}
+	// All the leaders of table 1 are on store 1.
+	for i := int64(0); i < 8; i++ {
+		addTableRegion(tc, uint64(i+1), rowKey(1, i*10), rowKey(1, (i+1)*10), 1, 2, 3)
+	}
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.True(strings.HasPrefix(ops[0].Desc(), "balance-table-leader-"))
+	re.NotZero(ops[0].Kind() & operator.OpRange)
+	re.Equal(uint64(1), ops[0].Step(0).(operator.TransferLeader).FromStore)
+}
+
+func TestBalanceTableConfig(t *testing.T) {
+	re := require.New(t)
+	conf := initBalanceTableConfig()
+	conf.storage = storage.NewStorageWithMemoryBackend()
+	code, _ := conf.Update([]byte(`{"granularity":"keyspace","range-limit":4}`))
+	re.Equal(http.StatusOK, code)
+	re.Equal(balanceTableGranularityKeyspace, conf.Granularity)
+	re.Equal(uint64(4), conf.RangeLimit)
+	code, _ = conf.Update([]byte(`{"granularity":"unknown"}`))
+	re.Equal(http.StatusBadRequest, code)
+	code, _ = conf.Update([]byte(`{"range-limit":0}`))
+	re.Equal(http.StatusBadRequest, code)
+	re.Equal(balanceTableGranularityKeyspace, conf.Granularity)
+	re.Equal(uint64(4), conf.RangeLimit)
+}
@@ -57,9 +57,9 @@ func (suite *evictSlowStoreTestSuite) SetupTest() {
 
 	storage := storage.NewStorageWithMemoryBackend()
 	var err error
-	suite.es, err = schedule.CreateScheduler(EvictSlowStoreType, suite.oc, storage, schedule.ConfigSliceDecoder(EvictSlowStoreType, []string{}))
+	suite.es, err = schedule.CreateScheduler(EvictSlowStoreType, suite.oc, nil, storage, schedule.ConfigSliceDecoder(EvictSlowStoreType, []string{}))
 	suite.NoError(err)
-	suite.bs, err = schedule.CreateScheduler(BalanceLeaderType, suite.oc, storage, schedule.ConfigSliceDecoder(BalanceLeaderType, []string{}))
+	suite.bs, err = schedule.CreateScheduler(BalanceLeaderType, suite.oc, nil, storage, schedule.ConfigSliceDecoder(BalanceLeaderType, []string{}))
 	suite.NoError(err)
 }
 
@@ -69,9 +69,9 @@ func (suite *evictSlowTrendTestSuite) SetupTest() {
 
 	storage := storage.NewStorageWithMemoryBackend()
 	var err error
-	suite.es, err = schedule.CreateScheduler(EvictSlowTrendType, suite.oc, storage, schedule.ConfigSliceDecoder(EvictSlowTrendType, []string{}))
+	suite.es, err = schedule.CreateScheduler(EvictSlowTrendType, suite.oc, nil, storage, schedule.ConfigSliceDecoder(EvictSlowTrendType, []string{}))
 	suite.NoError(err)
-	suite.bs, err = schedule.CreateScheduler(BalanceLeaderType, suite.oc, storage, schedule.ConfigSliceDecoder(BalanceLeaderType, []string{}))
+	suite.bs, err = schedule.CreateScheduler(BalanceLeaderType, suite.oc, nil, storage, schedule.ConfigSliceDecoder(BalanceLeaderType, []string{}))
 	suite.NoError(err)
 }
 
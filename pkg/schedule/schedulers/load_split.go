@@ -0,0 +1,492 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/buckets"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+)
+
+const (
+	// LoadSplitName is the load split scheduler name.
+	LoadSplitName = "load-split-scheduler"
+	// LoadSplitType is the load split scheduler type.
+	LoadSplitType = "load-split"
+
+	defaultLoadSplitLimit         = 4
+	defaultLoadSplitTableLimit    = 2
+	defaultLoadSplitKeyspaceLimit = 4
+	// loadSplitScatterGroup is the scatter group of the regions split by the load split scheduler,
+	// each region leaves the group after the TTL of the scatter groups unless it's split by load again.
+	loadSplitScatterGroup = "load-split"
+	// loadSplitTaskTimeout is the max duration to wait for the split to finish before scattering.
+	loadSplitTaskTimeout = 10 * time.Minute
+	// loadSplitMaxLoadRatio is the max ratio of the load of the heavier half to the load of the region.
+	// If the load can't be spread better than it, which means the load is concentrated in one bucket,
+	// the region is left to the split bucket scheduler.
+	loadSplitMaxLoadRatio = 0.8
+)
+
+var (
+	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
+	loadSplitScheduleCounter           = schedulerCounter.WithLabelValues(LoadSplitName, "schedule")
+	loadSplitBucketDisableCounter      = schedulerCounter.WithLabelValues(LoadSplitName, "bucket-disable")
+	loadSplitLimitCounter              = schedulerCounter.WithLabelValues(LoadSplitName, "split-limit")
+	loadSplitNoRegionCounter           = schedulerCounter.WithLabelValues(LoadSplitName, "no-region")
+	loadSplitNotHotCounter             = schedulerCounter.WithLabelValues(LoadSplitName, "not-hot")
+	loadSplitOperatorExistCounter      = schedulerCounter.WithLabelValues(LoadSplitName, "operator-exist")
+	loadSplitTableLimitCounter         = schedulerCounter.WithLabelValues(LoadSplitName, "table-limit")
+	loadSplitKeyspaceLimitCounter      = schedulerCounter.WithLabelValues(LoadSplitName, "keyspace-limit")
+	loadSplitNoBalancedKeyCounter      = schedulerCounter.WithLabelValues(LoadSplitName, "no-balanced-key")
+	loadSplitNoCoolerStoreCounter      = schedulerCounter.WithLabelValues(LoadSplitName, "no-cooler-store")
+	loadSplitCreateOperatorFailCounter = schedulerCounter.WithLabelValues(LoadSplitName, "create-operator-fail")
+	loadSplitNewOperatorCounter        = schedulerCounter.WithLabelValues(LoadSplitName, "new-operator")
+	loadSplitScatterCounter            = schedulerCounter.WithLabelValues(LoadSplitName, "scatter")
+	loadSplitScatterFailCounter        = schedulerCounter.WithLabelValues(LoadSplitName, "scatter-fail")
+	loadSplitTaskTimeoutCounter        = schedulerCounter.WithLabelValues(LoadSplitName, "task-timeout")
+)
+
+func initLoadSplitConfig() *loadSplitSchedulerConfig {
+	return &loadSplitSchedulerConfig{
+		Degree:        defaultHotDegree,
+		SplitLimit:    defaultLoadSplitLimit,
+		TableLimit:    defaultLoadSplitTableLimit,
+		KeyspaceLimit: defaultLoadSplitKeyspaceLimit,
+	}
+}
+
+type loadSplitSchedulerConfig struct {
+	mu      syncutil.RWMutex
+	storage endpoint.ConfigStorage
+	// Degree is the min hot degree of the bucket to trigger the split.
+	Degree int `json:"degree"`
+	// SplitLimit is the max number of the split operators.
+	SplitLimit uint64 `json:"split-limit"`
+	// TableLimit is the max number of the regions being split and scattered in the same table.
+	TableLimit uint64 `json:"table-limit"`
+	// KeyspaceLimit is the max number of the regions being split and scattered in the same keyspace.
+	KeyspaceLimit uint64 `json:"keyspace-limit"`
+}
+
+func (conf *loadSplitSchedulerConfig) Clone() *loadSplitSchedulerConfig {
+	conf.mu.RLock()
+	defer conf.mu.RUnlock()
+	return &loadSplitSchedulerConfig{
+		Degree:        conf.Degree,
+		SplitLimit:    conf.SplitLimit,
+		TableLimit:    conf.TableLimit,
+		KeyspaceLimit: conf.KeyspaceLimit,
+	}
+}
+
+func (conf *loadSplitSchedulerConfig) persistLocked() error {
+	data, err := schedule.EncodeConfig(conf)
+	failpoint.Inject("persistFail", func() {
+		err = errors.New("fail to persist")
+	})
+	if err != nil {
+		return err
+	}
+	return conf.storage.SaveScheduleConfig(LoadSplitName, data)
+}
+
+func (conf *loadSplitSchedulerConfig) Update(data []byte) (int, interface{}) {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if err := conf.persistLocked(); err != nil {
+			json.Unmarshal(oldc, conf)
+			return http.StatusInternalServerError, err.Error()
+		}
+		return http.StatusOK, "success"
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if reflectutil.FindSameFieldByJSON(conf, m) {
+		return http.StatusOK, "no changed"
+	}
+	return http.StatusBadRequest, "config item not found"
+}
+
+type loadSplitHandler struct {
+	rd     *render.Render
+	config *loadSplitSchedulerConfig
+}
+
+func newLoadSplitHandler(conf *loadSplitSchedulerConfig) http.Handler {
+	handler := &loadSplitHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.UpdateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.ListConfig).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *loadSplitHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.Update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *loadSplitHandler) ListConfig(w http.ResponseWriter, r *http.Request) {
+	conf := handler.config.Clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+// loadSplitTask is a region split by the load split scheduler, whose halves are
+// scattered once the split is finished.
+type loadSplitTask struct {
+	regionID   uint64
+	startKey   []byte
+	endKey     []byte
+	tableID    int64
+	keyspaceID uint32
+	inKeyspace bool
+	createTime time.Time
+}
+
+// loadSplitScheduler splits the regions whose load is spread over multiple buckets,
+// and scatters the halves to spread the load across stores.
+type loadSplitScheduler struct {
+	*BaseScheduler
+	conf      *loadSplitSchedulerConfig
+	handler   http.Handler
+	scatterer *schedule.RegionScatterer
+	// tasks are the regions being split, keyed by the region ID.
+	tasks map[uint64]*loadSplitTask
+}
+
+func newLoadSplitScheduler(opController *schedule.OperatorController, scatterer *schedule.RegionScatterer, conf *loadSplitSchedulerConfig) *loadSplitScheduler {
+	return &loadSplitScheduler{
+		BaseScheduler: NewBaseScheduler(opController),
+		conf:          conf,
+		handler:       newLoadSplitHandler(conf),
+		scatterer:     scatterer,
+		tasks:         make(map[uint64]*loadSplitTask),
+	}
+}
+
+// GetName returns the name of the load split scheduler.
+func (s *loadSplitScheduler) GetName() string {
+	return LoadSplitName
+}
+
+// GetType returns the type of the load split scheduler.
+func (s *loadSplitScheduler) GetType() string {
+	return LoadSplitType
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *loadSplitScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *loadSplitScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.mu.RLock()
+	defer s.conf.mu.RUnlock()
+	return schedule.EncodeConfig(s.conf)
+}
+
+// IsScheduleAllowed returns true if there are split regions to scatter, or the split operators are under the limit.
+func (s *loadSplitScheduler) IsScheduleAllowed(cluster schedule.Cluster) bool {
+	if !cluster.GetStoreConfig().IsEnableRegionBucket() {
+		loadSplitBucketDisableCounter.Inc()
+		return false
+	}
+	if len(s.tasks) > 0 {
+		return true
+	}
+	return s.allowSplit()
+}
+
+func (s *loadSplitScheduler) allowSplit() bool {
+	allowed := s.OpController.OperatorCount(operator.OpSplit) < s.conf.Clone().SplitLimit
+	if !allowed {
+		loadSplitLimitCounter.Inc()
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpSplit.String()).Inc()
+	}
+	return allowed
+}
+
+// Schedule scatters the halves of the finished splits, and splits a hot region if possible.
+func (s *loadSplitScheduler) Schedule(cluster schedule.Cluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	loadSplitScheduleCounter.Inc()
+	ops := s.scatterSplitRegions(cluster)
+	if !s.allowSplit() {
+		return ops, nil
+	}
+	conf := s.conf.Clone()
+	// Get all the buckets of the hot regions to find the split key which spreads the load.
+	p := &loadSplitPlan{
+		conf:        conf,
+		cluster:     cluster,
+		buckets:     cluster.BucketsStats(math.MinInt),
+		storesLoads: cluster.GetStoresLoads(),
+	}
+	if op := s.splitByLoad(p); op != nil {
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// scatterSplitRegions scatters the regions split by the scheduler once the split is finished.
+func (s *loadSplitScheduler) scatterSplitRegions(cluster schedule.Cluster) []*operator.Operator {
+	var ops []*operator.Operator
+	for id, task := range s.tasks {
+		if s.OpController.GetOperator(id) != nil {
+			continue
+		}
+		regions := cluster.ScanRegions(task.startKey, task.endKey, -1)
+		if len(regions) <= 1 {
+			// The split is not finished or reported yet.
+			if time.Since(task.createTime) > loadSplitTaskTimeout {
+				loadSplitTaskTimeoutCounter.Inc()
+				delete(s.tasks, id)
+			}
+			continue
+		}
+		delete(s.tasks, id)
+		for _, region := range regions {
+			op, err := s.scatterer.Scatter(region, loadSplitScatterGroup)
+			if err != nil {
+				loadSplitScatterFailCounter.Inc()
+				log.Debug("failed to scatter the region split by load", zap.Uint64("region-id", region.GetID()), zap.Error(err))
+				continue
+			}
+			if op != nil {
+				loadSplitScatterCounter.Inc()
+				ops = append(ops, op)
+			}
+		}
+	}
+	return ops
+}
+
+type loadSplitPlan struct {
+	conf        *loadSplitSchedulerConfig
+	cluster     schedule.Cluster
+	buckets     map[uint64][]*buckets.BucketStat
+	storesLoads map[uint64][]float64
+}
+
+type loadSplitCandidate struct {
+	region    *core.RegionInfo
+	hotDegree int
+}
+
+// splitByLoad picks the hottest region which can be split, and creates the split operator
+// with the key that spreads the load of the region into two halves evenly.
+func (s *loadSplitScheduler) splitByLoad(p *loadSplitPlan) *operator.Operator {
+	candidates := make([]*loadSplitCandidate, 0)
+	for regionID, stats := range p.buckets {
+		hotDegree := math.MinInt
+		for _, stat := range stats {
+			if stat.HotDegree > hotDegree {
+				hotDegree = stat.HotDegree
+			}
+		}
+		if hotDegree < p.conf.Degree {
+			continue
+		}
+		region := p.cluster.GetRegion(regionID)
+		if region == nil {
+			loadSplitNoRegionCounter.Inc()
+			continue
+		}
+		candidates = append(candidates, &loadSplitCandidate{region: region, hotDegree: hotDegree})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].hotDegree != candidates[j].hotDegree {
+			return candidates[i].hotDegree > candidates[j].hotDegree
+		}
+		return candidates[i].region.GetID() < candidates[j].region.GetID()
+	})
+
+	for _, candidate := range candidates {
+		region := candidate.region
+		// Only split the region whose flow is hot, the hot buckets of a cold region may be stale.
+		if !p.cluster.IsRegionHot(region) {
+			loadSplitNotHotCounter.Inc()
+			continue
+		}
+		if _, ok := s.tasks[region.GetID()]; ok || s.OpController.GetOperator(region.GetID()) != nil {
+			loadSplitOperatorExistCounter.Inc()
+			continue
+		}
+		task := newLoadSplitTask(region)
+		if !s.checkTaskLimit(p.conf, task) {
+			continue
+		}
+		if !canSpreadLoad(p.cluster, region, p.storesLoads) {
+			loadSplitNoCoolerStoreCounter.Inc()
+			continue
+		}
+		splitKey, ratio := pickLoadSplitKey(region, p.buckets[region.GetID()])
+		if splitKey == nil {
+			loadSplitNoBalancedKeyCounter.Inc()
+			continue
+		}
+		op, err := operator.CreateSplitRegionOperator(LoadSplitType, region, operator.OpSplit,
+			pdpb.CheckPolicy_USEKEY, [][]byte{splitKey})
+		if err != nil {
+			loadSplitCreateOperatorFailCounter.Inc()
+			continue
+		}
+		loadSplitNewOperatorCounter.Inc()
+		op.AdditionalInfos["region-start-key"] = core.HexRegionKeyStr(region.GetStartKey())
+		op.AdditionalInfos["region-end-key"] = core.HexRegionKeyStr(region.GetEndKey())
+		op.AdditionalInfos["split-key"] = core.HexRegionKeyStr(splitKey)
+		op.AdditionalInfos["hot-degree"] = strconv.Itoa(candidate.hotDegree)
+		op.AdditionalInfos["load-ratio"] = strconv.FormatFloat(ratio, 'f', 2, 64)
+		s.tasks[region.GetID()] = task
+		return op
+	}
+	return nil
+}
+
+func newLoadSplitTask(region *core.RegionInfo) *loadSplitTask {
+	task := &loadSplitTask{
+		regionID:   region.GetID(),
+		startKey:   region.GetStartKey(),
+		endKey:     region.GetEndKey(),
+		createTime: time.Now(),
+	}
+	key := codec.Key(region.GetStartKey())
+	task.keyspaceID, task.inKeyspace = key.KeyspaceID()
+	task.tableID = key.TableID()
+	return task
+}
+
+// checkTaskLimit checks whether the region can be split under the per-table and per-keyspace limits.
+func (s *loadSplitScheduler) checkTaskLimit(conf *loadSplitSchedulerConfig, task *loadSplitTask) bool {
+	var tableCount, keyspaceCount uint64
+	for _, t := range s.tasks {
+		if task.tableID != 0 && t.tableID == task.tableID {
+			tableCount++
+		}
+		if task.inKeyspace && t.inKeyspace && t.keyspaceID == task.keyspaceID {
+			keyspaceCount++
+		}
+	}
+	if task.tableID != 0 && tableCount >= conf.TableLimit {
+		loadSplitTableLimitCounter.Inc()
+		return false
+	}
+	if task.inKeyspace && keyspaceCount >= conf.KeyspaceLimit {
+		loadSplitKeyspaceLimitCounter.Inc()
+		return false
+	}
+	return true
+}
+
+// canSpreadLoad returns true if there is a store cooler than the leader store of the region,
+// otherwise the halves would both stay on the same hot store after scattering.
+func canSpreadLoad(cluster schedule.Cluster, region *core.RegionInfo, storesLoads map[uint64][]float64) bool {
+	leaderLoads, ok := storesLoads[region.GetLeader().GetStoreId()]
+	if !ok {
+		// No load is reported yet, let the scatterer decide.
+		return true
+	}
+	leaderLoad := leaderLoads[statistics.StoreReadBytes] + leaderLoads[statistics.StoreWriteBytes]
+	for _, store := range cluster.GetStores() {
+		if store.GetID() == region.GetLeader().GetStoreId() || !store.IsUp() || !store.AllowLeaderTransfer() {
+			continue
+		}
+		loads, ok := storesLoads[store.GetID()]
+		if !ok || loads[statistics.StoreReadBytes]+loads[statistics.StoreWriteBytes] < leaderLoad {
+			return true
+		}
+	}
+	return false
+}
+
+// pickLoadSplitKey returns the bucket boundary which splits the byte flow of the region into two
+// halves as evenly as possible, and the ratio of the heavier half. It returns nil if there is no
+// boundary that keeps the heavier half under loadSplitMaxLoadRatio.
+func pickLoadSplitKey(region *core.RegionInfo, stats []*buckets.BucketStat) ([]byte, float64) {
+	stats = append([]*buckets.BucketStat(nil), stats...)
+	sort.Slice(stats, func(i, j int) bool {
+		return bytes.Compare(stats[i].StartKey, stats[j].StartKey) < 0
+	})
+	loads := make([]float64, len(stats))
+	var total float64
+	for i, stat := range stats {
+		if len(stat.Loads) > int(statistics.RegionWriteBytes) {
+			loads[i] = float64(stat.Loads[statistics.RegionReadBytes] + stat.Loads[statistics.RegionWriteBytes])
+		}
+		total += loads[i]
+	}
+	if total == 0 {
+		return nil, 0
+	}
+	var (
+		splitKey []byte
+		prefix   float64
+		minRatio = 1.0
+	)
+	for i := 0; i < len(stats)-1; i++ {
+		prefix += loads[i]
+		key := stats[i].EndKey
+		// The split key must be inside the region.
+		if bytes.Compare(key, region.GetStartKey()) <= 0 ||
+			(len(region.GetEndKey()) > 0 && bytes.Compare(key, region.GetEndKey()) >= 0) {
+			continue
+		}
+		ratio := math.Max(prefix, total-prefix) / total
+		if ratio < minRatio {
+			minRatio, splitKey = ratio, key
+		}
+	}
+	if splitKey == nil || minRatio > loadSplitMaxLoadRatio {
+		return nil, 0
+	}
+	return splitKey, minRatio
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/buckets"
+)
+
+func newTestBucketStats(regionID uint64, keys [][]byte, hotDegree int, loads ...uint64) []*buckets.BucketStat {
+	stats := make([]*buckets.BucketStat, 0, len(loads))
+	for i, load := range loads {
+		bucketLoads := make([]uint64, statistics.RegionStatCount)
+		bucketLoads[statistics.RegionWriteBytes] = load
+		stats = append(stats, &buckets.BucketStat{
+			RegionID:  regionID,
+			StartKey:  keys[i],
+			EndKey:    keys[i+1],
+			HotDegree: hotDegree,
+			Loads:     bucketLoads,
+		})
+	}
+	return stats
+}
+
+func newTestStoreLoads(byteRate float64) []float64 {
+	loads := make([]float64, statistics.StoreStatCount)
+	loads[statistics.StoreWriteBytes] = byteRate
+	return loads
+}
+
+func TestLoadSplit(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetHotRegionCacheHitsThreshold(0)
+	for i := uint64(1); i <= 4; i++ {
+		tc.AddRegionStore(i, 10)
+	}
+	// Region 1 is hot and region 2 is not.
+	tc.AddLeaderRegionWithWriteInfo(1, 1, 512*units.KiB*statistics.RegionHeartBeatReportInterval, 0, 0,
+		statistics.RegionHeartBeatReportInterval, []uint64{2, 3})
+	tc.AddLeaderRegion(2, 1, 2, 3)
+
+	end := []byte(fmt.Sprintf("%20d", 2))
+	keys := [][]byte{[]byte(fmt.Sprintf("%20d", 1)), []byte(fmt.Sprintf("%20da", 1)), []byte(fmt.Sprintf("%20db", 1)), end}
+	keys2 := [][]byte{end, []byte(fmt.Sprintf("%20da", 2)), []byte(fmt.Sprintf("%20d", 3))}
+
+	s := newLoadSplitScheduler(oc, schedule.NewRegionScatterer(oc.Ctx(), oc.GetCluster(), oc), initLoadSplitConfig())
+	p := &loadSplitPlan{
+		conf:    s.conf.Clone(),
+		cluster: tc,
+		buckets: map[uint64][]*buckets.BucketStat{
+			1: newTestBucketStats(1, keys, 5, 100, 100, 0),
+			2: newTestBucketStats(2, keys2, 10, 100, 100),
+		},
+		storesLoads: map[uint64][]float64{
+			1: newTestStoreLoads(1000),
+			2: newTestStoreLoads(2000),
+			3: newTestStoreLoads(2000),
+			4: newTestStoreLoads(2000),
+		},
+	}
+
+	// The halves would stay on the hottest store.
+	re.Nil(s.splitByLoad(p))
+	// The region is split by the key which spreads the load evenly.
+	p.storesLoads[4] = newTestStoreLoads(10)
+	op := s.splitByLoad(p)
+	re.NotNil(op)
+	re.Equal(uint64(1), op.RegionID())
+	re.Equal(LoadSplitType, op.Desc())
+	step := op.Step(0).(operator.SplitRegion)
+	re.Equal([][]byte{keys[1]}, step.SplitKeys)
+	re.Contains(s.tasks, uint64(1))
+	// The region being split is not split again.
+	re.Nil(s.splitByLoad(p))
+
+	// The load concentrated in one bucket is left to the split bucket scheduler.
+	delete(s.tasks, 1)
+	p.buckets[1] = newTestBucketStats(1, keys, 5, 0, 200, 0)
+	re.Nil(s.splitByLoad(p))
+	// Cold buckets don't trigger the split.
+	p.buckets[1] = newTestBucketStats(1, keys, 1, 100, 100, 0)
+	re.Nil(s.splitByLoad(p))
+
+	// Scatter the halves after the split is finished.
+	p.buckets[1] = newTestBucketStats(1, keys, 5, 100, 100, 0)
+	re.NotNil(s.splitByLoad(p))
+	re.Empty(s.scatterSplitRegions(tc))
+	re.Contains(s.tasks, uint64(1))
+	tc.PutRegion(tc.GetRegion(1).Clone(core.WithEndKey(keys[1]), core.WithIncVersion()))
+	tc.PutRegion(tc.AddLeaderRegion(10, 1, 2, 3).Clone(core.WithStartKey(keys[1]), core.WithEndKey(end)))
+	s.scatterSplitRegions(tc)
+	re.Empty(s.tasks)
+}
+
+func TestLoadSplitTaskLimit(t *testing.T) {
+	re := require.New(t)
+	cancel, _, _, oc := prepareSchedulersTest()
+	defer cancel()
+
+	s := newLoadSplitScheduler(oc, schedule.NewRegionScatterer(oc.Ctx(), oc.GetCluster(), oc), initLoadSplitConfig())
+	conf := s.conf.Clone()
+	conf.TableLimit, conf.KeyspaceLimit = 1, 2
+	s.tasks[1] = &loadSplitTask{regionID: 1, tableID: 100}
+	s.tasks[2] = &loadSplitTask{regionID: 2, keyspaceID: 1, inKeyspace: true}
+
+	re.False(s.checkTaskLimit(conf, &loadSplitTask{tableID: 100}))
+	re.True(s.checkTaskLimit(conf, &loadSplitTask{tableID: 101}))
+	re.True(s.checkTaskLimit(conf, &loadSplitTask{keyspaceID: 1, inKeyspace: true}))
+	s.tasks[3] = &loadSplitTask{regionID: 3, keyspaceID: 1, inKeyspace: true, tableID: 101}
+	re.False(s.checkTaskLimit(conf, &loadSplitTask{keyspaceID: 1, inKeyspace: true}))
+	re.True(s.checkTaskLimit(conf, &loadSplitTask{keyspaceID: 2, inKeyspace: true}))
+	re.True(s.checkTaskLimit(conf, &loadSplitTask{}))
+}
@@ -116,6 +116,22 @@ var scatterRangeRegionCounter = prometheus.NewCounterVec(
 		Help:      "Counter of scatter range region scheduler.",
 	}, []string{"type", "store"})
 
+var balanceTableLeaderCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "pd",
+		Subsystem: "scheduler",
+		Name:      "balance_table_leader",
+		Help:      "Counter of balance table leader scheduler.",
+	}, []string{"type", "store"})
+
+var balanceTableRegionCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "pd",
+		Subsystem: "scheduler",
+		Name:      "balance_table_region",
+		Help:      "Counter of balance table region scheduler.",
+	}, []string{"type", "store"})
+
 var hotPendingStatus = prometheus.NewGaugeVec(
 	prometheus.GaugeOpts{
 		Namespace: "pd",
@@ -157,6 +173,22 @@ var storeSlowTrendMiscGauge = prometheus.NewGaugeVec(
 		Help:      "Store trend internal uncatelogued values",
 	}, []string{"type"})
 
+var storeHealthStateGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "pd",
+		Subsystem: "scheduler",
+		Name:      "store_health_state",
+		Help:      "Store health state detected by the store health scheduler, 1 means the store is in the state.",
+	}, []string{"store", "state"})
+
+var storeHealthTransitionCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "pd",
+		Subsystem: "scheduler",
+		Name:      "store_health_transition",
+		Help:      "Counter of store health state transitions.",
+	}, []string{"store", "from", "to"})
+
 func init() {
 	prometheus.MustRegister(schedulerCounter)
 	prometheus.MustRegister(schedulerStatus)
@@ -168,6 +200,8 @@ func init() {
 	prometheus.MustRegister(balanceDirectionCounter)
 	prometheus.MustRegister(scatterRangeLeaderCounter)
 	prometheus.MustRegister(scatterRangeRegionCounter)
+	prometheus.MustRegister(balanceTableLeaderCounter)
+	prometheus.MustRegister(balanceTableRegionCounter)
 	prometheus.MustRegister(opInfluenceStatus)
 	prometheus.MustRegister(tolerantResourceStatus)
 	prometheus.MustRegister(hotPendingStatus)
@@ -175,4 +209,6 @@ func init() {
 	prometheus.MustRegister(storeSlowTrendEvictedStatusGauge)
 	prometheus.MustRegister(storeSlowTrendActionStatusGauge)
 	prometheus.MustRegister(storeSlowTrendMiscGauge)
+	prometheus.MustRegister(storeHealthStateGauge)
+	prometheus.MustRegister(storeHealthTransitionCounter)
 }
@@ -0,0 +1,233 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
+	"go.uber.org/zap"
+)
+
+const (
+	// ScatterGroupName is scatter group scheduler name.
+	ScatterGroupName = "scatter-group-scheduler"
+	// ScatterGroupType is scatter group scheduler type.
+	ScatterGroupType = "scatter-group"
+)
+
+var (
+	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
+	scatterGroupCounter                   = schedulerCounter.WithLabelValues(ScatterGroupName, "schedule")
+	scatterGroupNoManagerCounter          = schedulerCounter.WithLabelValues(ScatterGroupName, "no-manager")
+	scatterGroupBalancedCounter           = schedulerCounter.WithLabelValues(ScatterGroupName, "balanced")
+	scatterGroupNoRegionCounter           = schedulerCounter.WithLabelValues(ScatterGroupName, "no-region")
+	scatterGroupPruneCounter              = schedulerCounter.WithLabelValues(ScatterGroupName, "prune-region")
+	scatterGroupCreateOperatorFailCounter = schedulerCounter.WithLabelValues(ScatterGroupName, "create-operator-fail")
+	scatterGroupNewPeerOperatorCounter    = schedulerCounter.WithLabelValues(ScatterGroupName, "new-peer-operator")
+	scatterGroupNewLeaderOperatorCounter  = schedulerCounter.WithLabelValues(ScatterGroupName, "new-leader-operator")
+	scatterGroupNotEnoughStoreCounter     = schedulerCounter.WithLabelValues(ScatterGroupName, "not-enough-store")
+	scatterGroupTransferLeaderSkipCounter = schedulerCounter.WithLabelValues(ScatterGroupName, "transfer-leader-skip")
+	scatterGroupMovePeerSkipCounter       = schedulerCounter.WithLabelValues(ScatterGroupName, "move-peer-skip")
+)
+
+// scatterGroupScheduler keeps the regions of every persisted scatter group
+// evenly distributed over the stores. The groups are recorded by the region
+// scatterer, so the scatter is not undone when the stores change.
+type scatterGroupScheduler struct {
+	*BaseScheduler
+}
+
+// newScatterGroupScheduler creates a scheduler that rebalances the persisted scatter groups.
+func newScatterGroupScheduler(opController *schedule.OperatorController) schedule.Scheduler {
+	return &scatterGroupScheduler{
+		BaseScheduler: NewBaseScheduler(opController),
+	}
+}
+
+func (s *scatterGroupScheduler) GetName() string {
+	return ScatterGroupName
+}
+
+func (s *scatterGroupScheduler) GetType() string {
+	return ScatterGroupType
+}
+
+func (s *scatterGroupScheduler) IsScheduleAllowed(cluster schedule.Cluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpRegion) < cluster.GetOpts().GetRegionScheduleLimit()
+	if !allowed {
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpRegion.String()).Inc()
+	}
+	return allowed
+}
+
+func (s *scatterGroupScheduler) Schedule(cluster schedule.Cluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	scatterGroupCounter.Inc()
+	manager := cluster.GetScatterGroupManager()
+	if manager == nil {
+		scatterGroupNoManagerCounter.Inc()
+		return nil, nil
+	}
+	for _, group := range manager.GetGroups() {
+		regions := s.collectGroupRegions(cluster, manager, group)
+		if len(regions) == 0 {
+			continue
+		}
+		if op := s.balancePeer(cluster, group.Name, regions); op != nil {
+			return []*operator.Operator{op}, nil
+		}
+		if op := s.balanceLeader(cluster, group.Name, regions); op != nil {
+			return []*operator.Operator{op}, nil
+		}
+		scatterGroupBalancedCounter.Inc()
+	}
+	return nil, nil
+}
+
+// collectGroupRegions returns the existing regions of the group, and the
+// regions which don't exist anymore are removed from the group.
+func (s *scatterGroupScheduler) collectGroupRegions(cluster schedule.Cluster, manager *scattergroup.Manager, group *scattergroup.Group) []*core.RegionInfo {
+	regions := make([]*core.RegionInfo, 0, len(group.RegionIDs))
+	var missing []uint64
+	for _, id := range group.RegionIDs {
+		region := cluster.GetRegion(id)
+		if region == nil {
+			missing = append(missing, id)
+			continue
+		}
+		regions = append(regions, region)
+	}
+	if len(missing) > 0 {
+		scatterGroupPruneCounter.Add(float64(len(missing)))
+		manager.RemoveRegions(group.Name, missing...)
+	}
+	return regions
+}
+
+// balancePeer moves a peer of the group from the store with the most peers of
+// the group to the store with the fewest if the difference is more than one.
+func (s *scatterGroupScheduler) balancePeer(cluster schedule.Cluster, group string, regions []*core.RegionInfo) *operator.Operator {
+	counts := make(map[uint64]int)
+	for _, region := range regions {
+		for _, peer := range region.GetPeers() {
+			counts[peer.GetStoreId()]++
+		}
+	}
+	stateFilter := &filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true}
+	source, target := s.pickStores(cluster, counts, stateFilter)
+	if source == nil || target == nil {
+		return nil
+	}
+
+	pendingFilter := filter.NewRegionPendingFilter()
+	downFilter := filter.NewRegionDownFilter()
+	replicaFilter := filter.NewRegionReplicatedFilter(cluster)
+	for _, region := range regions {
+		oldPeer := region.GetStorePeer(source.GetID())
+		if oldPeer == nil || region.GetStorePeer(target.GetID()) != nil {
+			continue
+		}
+		if filter.SelectOneRegion([]*core.RegionInfo{region}, nil, pendingFilter, downFilter, replicaFilter) == nil {
+			scatterGroupMovePeerSkipCounter.Inc()
+			continue
+		}
+		scoreGuard := filter.NewPlacementSafeguard(s.GetName(), cluster.GetOpts(), cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, nil)
+		if !filter.Target(cluster.GetOpts(), target, []filter.Filter{scoreGuard}) {
+			scatterGroupMovePeerSkipCounter.Inc()
+			continue
+		}
+		newPeer := &metapb.Peer{StoreId: target.GetID(), Role: oldPeer.GetRole()}
+		op, err := operator.CreateMovePeerOperator(ScatterGroupType, cluster, region, operator.OpRegion, source.GetID(), newPeer)
+		if err != nil {
+			scatterGroupCreateOperatorFailCounter.Inc()
+			log.Debug("fail to create scatter group operator", zap.String("group", group), errs.ZapError(err))
+			continue
+		}
+		op.Counters = append(op.Counters, scatterGroupNewPeerOperatorCounter)
+		op.SetPriorityLevel(constant.Low)
+		return op
+	}
+	scatterGroupNoRegionCounter.Inc()
+	return nil
+}
+
+// balanceLeader transfers a leader of the group from the store with the most
+// leaders of the group to the store with the fewest if the difference is more than one.
+func (s *scatterGroupScheduler) balanceLeader(cluster schedule.Cluster, group string, regions []*core.RegionInfo) *operator.Operator {
+	counts := make(map[uint64]int)
+	for _, region := range regions {
+		if leader := region.GetLeader(); leader != nil {
+			counts[leader.GetStoreId()]++
+		}
+	}
+	stateFilter := &filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true}
+	source, target := s.pickStores(cluster, counts, stateFilter)
+	if source == nil || target == nil {
+		return nil
+	}
+	for _, region := range regions {
+		if region.GetLeader().GetStoreId() != source.GetID() || region.GetStoreVoter(target.GetID()) == nil {
+			continue
+		}
+		if !filter.IsRegionHealthy(region) {
+			scatterGroupTransferLeaderSkipCounter.Inc()
+			continue
+		}
+		op, err := operator.CreateTransferLeaderOperator(ScatterGroupType, cluster, region, source.GetID(), target.GetID(), []uint64{}, operator.OpLeader)
+		if err != nil {
+			scatterGroupCreateOperatorFailCounter.Inc()
+			log.Debug("fail to create scatter group operator", zap.String("group", group), errs.ZapError(err))
+			continue
+		}
+		op.Counters = append(op.Counters, scatterGroupNewLeaderOperatorCounter)
+		op.SetPriorityLevel(constant.Low)
+		return op
+	}
+	scatterGroupNoRegionCounter.Inc()
+	return nil
+}
+
+// pickStores picks the store with the most count as the source and the store
+// with the fewest count as the target. It returns nil if the difference is not
+// more than one, which means the group is already balanced.
+func (s *scatterGroupScheduler) pickStores(cluster schedule.Cluster, counts map[uint64]int, stateFilter filter.Filter) (source, target *core.StoreInfo) {
+	stores := cluster.GetStores()
+	targets := filter.NewCandidates(stores).FilterTarget(cluster.GetOpts(), nil, nil, stateFilter).Stores
+	if len(targets) < 2 {
+		scatterGroupNotEnoughStoreCounter.Inc()
+		return nil, nil
+	}
+	for _, store := range targets {
+		if target == nil || counts[store.GetID()] < counts[target.GetID()] {
+			target = store
+		}
+	}
+	for _, store := range filter.NewCandidates(stores).FilterSource(cluster.GetOpts(), nil, nil, stateFilter).Stores {
+		if source == nil || counts[store.GetID()] > counts[source.GetID()] {
+			source = store
+		}
+	}
+	if source == nil || counts[source.GetID()]-counts[target.GetID()] <= 1 {
+		return nil, nil
+	}
+	return source, target
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestScatterGroup(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	sl, err := schedule.CreateScheduler(ScatterGroupType, oc, nil, storage.NewStorageWithMemoryBackend(), schedule.ConfigSliceDecoder(ScatterGroupType, nil))
+	re.NoError(err)
+	re.True(sl.IsScheduleAllowed(tc))
+	ops, _ := sl.Schedule(tc, false)
+	re.Empty(ops)
+
+	for i := uint64(1); i <= 3; i++ {
+		tc.AddRegionStore(i, 0)
+	}
+	tc.AddLeaderRegion(1, 1, 2, 3)
+	tc.AddLeaderRegion(2, 2, 3, 1)
+	tc.AddLeaderRegion(3, 3, 1, 2)
+	tc.AddLeaderRegion(4, 1, 2, 3)
+	manager := tc.GetScatterGroupManager()
+	manager.AddRegions("g", 1, 2, 3, 4, 5)
+	// The group is evenly distributed, and the missing region is removed.
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+	re.Equal([]uint64{1, 2, 3, 4}, manager.GetGroup("g").RegionIDs)
+
+	// A new store is added, the peers of the group are moved to it.
+	tc.AddRegionStore(4, 0)
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(ScatterGroupType, ops[0].Desc())
+	re.Equal(operator.OpRegion, ops[0].Kind()&operator.OpRegion)
+	re.Equal(uint64(4), ops[0].Step(0).(operator.AddLearner).ToStore)
+
+	// The leaders of the group are balanced.
+	tc.AddLeaderRegion(1, 1, 2, 4)
+	tc.AddLeaderRegion(2, 1, 3, 4)
+	tc.AddLeaderRegion(3, 1, 2, 3)
+	tc.AddLeaderRegion(4, 2, 3, 4)
+	ops, _ = sl.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(operator.OpLeader, ops[0].Kind()&operator.OpLeader)
+	re.Equal(uint64(1), ops[0].Step(0).(operator.TransferLeader).FromStore)
+}
@@ -0,0 +1,417 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"go.uber.org/zap"
+)
+
+const (
+	// StoreHealthName is store health scheduler name.
+	StoreHealthName = "store-health-scheduler"
+	// StoreHealthType is store health scheduler type.
+	StoreHealthType = "store-health"
+
+	// defaultStoreSuspectDuration is how long a suspect store keeps showing unhealthy signals before being quarantined.
+	defaultStoreSuspectDuration = time.Minute
+	// defaultStoreRecoverDuration is how long an unhealthy store needs to keep healthy before
+	// moving to the next state, i.e., from quarantined to recovering, and from recovering to healthy.
+	defaultStoreRecoverDuration = 5 * time.Minute
+	// storeRecoverSteps is the number of the steps for a recovering store to take the leaders back,
+	// its leader weight is scaled from 1/(steps+1) up to steps/(steps+1) step by step.
+	storeRecoverSteps = 4
+	// storeQuarantineSignalCount is the number of the signals to quarantine a store without waiting.
+	storeQuarantineSignalCount = 2
+	// storeHeartbeatJitterRatio is the ratio of the heartbeat interval to the median of all stores
+	// to regard the heartbeat as jittered.
+	storeHeartbeatJitterRatio = 2
+)
+
+// The signals used to detect the unhealthy store.
+const (
+	// healthSignalSlowScore means the slow score reported by the store is high.
+	healthSignalSlowScore = "slow-score"
+	// healthSignalSlowTrend means the latency of the store is increasing while its throughput is decreasing.
+	healthSignalSlowTrend = "slow-trend"
+	// healthSignalDiskStall means the store reports busy, which is caused by the stalled disk IO in most cases.
+	healthSignalDiskStall = "disk-stall"
+	// healthSignalHeartbeatJitter means the heartbeat interval of the store is much longer than the others.
+	healthSignalHeartbeatJitter = "heartbeat-jitter"
+)
+
+// WithLabelValues is a heavy operation, define variable to avoid call it every time.
+var storeHealthCounter = schedulerCounter.WithLabelValues(StoreHealthName, "schedule")
+
+type storeHealthSchedulerConfig struct {
+	storage endpoint.ConfigStorage
+	// QuarantinedStores are the stores whose leaders are being evicted, which are
+	// persisted to keep them quarantined after the scheduler is restarted.
+	QuarantinedStores []uint64 `json:"quarantined-stores"`
+
+	suspectDuration time.Duration
+	recoverDuration time.Duration
+}
+
+func (conf *storeHealthSchedulerConfig) Persist() error {
+	name := conf.getSchedulerName()
+	data, err := schedule.EncodeConfig(conf)
+	failpoint.Inject("persistFail", func() {
+		err = errors.New("fail to persist")
+	})
+	if err != nil {
+		return err
+	}
+	return conf.storage.SaveScheduleConfig(name, data)
+}
+
+func (conf *storeHealthSchedulerConfig) getSchedulerName() string {
+	return StoreHealthName
+}
+
+func (conf *storeHealthSchedulerConfig) getStores() []uint64 {
+	return conf.QuarantinedStores
+}
+
+func (conf *storeHealthSchedulerConfig) getKeyRangesByID(id uint64) []core.KeyRange {
+	if !conf.isQuarantined(id) {
+		return nil
+	}
+	return []core.KeyRange{core.NewKeyRange("", "")}
+}
+
+func (conf *storeHealthSchedulerConfig) isQuarantined(id uint64) bool {
+	for _, storeID := range conf.QuarantinedStores {
+		if storeID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (conf *storeHealthSchedulerConfig) addAndPersist(id uint64) error {
+	conf.QuarantinedStores = append(conf.QuarantinedStores, id)
+	return conf.Persist()
+}
+
+func (conf *storeHealthSchedulerConfig) removeAndPersist(id uint64) error {
+	stores := make([]uint64, 0, len(conf.QuarantinedStores))
+	for _, storeID := range conf.QuarantinedStores {
+		if storeID != id {
+			stores = append(stores, storeID)
+		}
+	}
+	if len(stores) == len(conf.QuarantinedStores) {
+		return nil
+	}
+	conf.QuarantinedStores = stores
+	return conf.Persist()
+}
+
+// storeHealth is the health state machine of a store:
+//
+//	healthy -> suspect:         any signal is detected
+//	suspect -> healthy:         no signal is detected
+//	suspect -> quarantined:     signals are detected for the suspect duration, or multiple signals are detected
+//	quarantined -> recovering:  no signal is detected for the recover duration
+//	recovering -> quarantined:  any signal is detected
+//	recovering -> healthy:      no signal is detected for the recover duration
+//
+// The recovering store takes the leaders back gradually, its leader ratio steps up
+// evenly during the recover duration.
+type storeHealth struct {
+	state   core.StoreHealthState
+	signals []string
+	// leaderRatio is the ratio applied to the leader weight of the store.
+	leaderRatio float64
+	// since is the time when the store enters the current state.
+	since time.Time
+	// lastUnhealthy is the last time when any signal is detected.
+	lastUnhealthy time.Time
+}
+
+type storeHealthScheduler struct {
+	*BaseScheduler
+	conf   *storeHealthSchedulerConfig
+	stores map[uint64]*storeHealth
+}
+
+func (s *storeHealthScheduler) GetName() string {
+	return StoreHealthName
+}
+
+func (s *storeHealthScheduler) GetType() string {
+	return StoreHealthType
+}
+
+func (s *storeHealthScheduler) EncodeConfig() ([]byte, error) {
+	return schedule.EncodeConfig(s.conf)
+}
+
+func (s *storeHealthScheduler) Prepare(cluster schedule.Cluster) error {
+	now := time.Now()
+	for _, storeID := range s.conf.getStores() {
+		s.stores[storeID] = &storeHealth{state: core.StoreQuarantined, leaderRatio: 1, since: now, lastUnhealthy: now}
+		s.publish(cluster, storeID, s.stores[storeID])
+	}
+	return nil
+}
+
+func (s *storeHealthScheduler) Cleanup(cluster schedule.Cluster) {
+	for storeID := range s.stores {
+		s.reset(cluster, storeID)
+	}
+}
+
+func (s *storeHealthScheduler) IsScheduleAllowed(cluster schedule.Cluster) bool {
+	if len(s.conf.getStores()) != 0 {
+		allowed := s.OpController.OperatorCount(operator.OpLeader) < cluster.GetOpts().GetLeaderScheduleLimit()
+		if !allowed {
+			operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpLeader.String()).Inc()
+		}
+		return allowed
+	}
+	return true
+}
+
+func (s *storeHealthScheduler) Schedule(cluster schedule.Cluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	storeHealthCounter.Inc()
+	now := time.Now()
+
+	var activeStores []*core.StoreInfo
+	for _, store := range cluster.GetStores() {
+		if store.IsRemoved() || !(store.IsPreparing() || store.IsServing()) {
+			// The store is not serving anymore, which is handled by other mechanisms.
+			if _, ok := s.stores[store.GetID()]; ok {
+				s.reset(cluster, store.GetID())
+			}
+			continue
+		}
+		activeStores = append(activeStores, store)
+	}
+	for storeID := range s.stores {
+		if cluster.GetStore(storeID) == nil {
+			s.reset(cluster, storeID)
+		}
+	}
+
+	medianInterval := getMedianHeartbeatInterval(activeStores, now)
+	signals := make(map[uint64][]string, len(activeStores))
+	unhealthyCount := 0
+	for _, store := range activeStores {
+		signals[store.GetID()] = collectStoreHealthSignals(store, medianInterval, now)
+		if len(signals[store.GetID()]) > 0 {
+			unhealthyCount++
+		}
+	}
+	// If most of the stores are unhealthy, it's more likely a cluster-wide problem, evicting
+	// leaders from one of them makes things worse, so don't quarantine any store in this case.
+	canQuarantine := unhealthyCount*2 < len(activeStores)
+	for _, store := range activeStores {
+		s.transit(cluster, store.GetID(), signals[store.GetID()], canQuarantine, now)
+	}
+
+	if len(s.conf.getStores()) == 0 {
+		return nil, nil
+	}
+	return scheduleEvictLeaderBatch(s.GetName(), s.GetType(), cluster, s.conf, EvictLeaderBatchSize), nil
+}
+
+func (s *storeHealthScheduler) transit(cluster schedule.Cluster, storeID uint64, signals []string, canQuarantine bool, now time.Time) {
+	h, ok := s.stores[storeID]
+	if !ok {
+		if len(signals) == 0 {
+			return
+		}
+		h = &storeHealth{state: core.StoreHealthy, leaderRatio: 1, since: now}
+		s.stores[storeID] = h
+	}
+	unhealthy := len(signals) > 0
+	if unhealthy {
+		h.lastUnhealthy = now
+	}
+
+	state := h.state
+	switch h.state {
+	case core.StoreHealthy:
+		if unhealthy {
+			state = core.StoreSuspect
+		}
+	case core.StoreSuspect:
+		if !unhealthy {
+			state = core.StoreHealthy
+		} else if canQuarantine && len(s.conf.getStores()) == 0 &&
+			(len(signals) >= storeQuarantineSignalCount || now.Sub(h.since) >= s.conf.suspectDuration) {
+			// Only quarantine one store at the same time to limit the impact.
+			state = core.StoreQuarantined
+		}
+	case core.StoreQuarantined:
+		if now.Sub(h.lastUnhealthy) >= s.conf.recoverDuration {
+			state = core.StoreRecovering
+		}
+	case core.StoreRecovering:
+		if unhealthy {
+			state = core.StoreQuarantined
+		} else if now.Sub(h.since) >= s.conf.recoverDuration {
+			state = core.StoreHealthy
+		}
+	}
+
+	if state == core.StoreQuarantined && h.state != core.StoreQuarantined {
+		if err := s.conf.addAndPersist(storeID); err != nil {
+			log.Info("store-health-scheduler persist config failed", zap.Uint64("store-id", storeID), zap.Error(err))
+			return
+		}
+	} else if state != core.StoreQuarantined && h.state == core.StoreQuarantined {
+		if err := s.conf.removeAndPersist(storeID); err != nil {
+			log.Info("store-health-scheduler persist config failed", zap.Uint64("store-id", storeID), zap.Error(err))
+			return
+		}
+	}
+
+	leaderRatio := 1.0
+	if state == core.StoreRecovering {
+		elapsed := time.Duration(0)
+		if h.state == core.StoreRecovering {
+			elapsed = now.Sub(h.since)
+		}
+		leaderRatio = getRecoverLeaderRatio(elapsed, s.conf.recoverDuration)
+	}
+
+	changed := state != h.state || !equalSignals(signals, h.signals) || leaderRatio != h.leaderRatio
+	if state != h.state {
+		log.Info("store health state changed",
+			zap.Uint64("store-id", storeID),
+			zap.Stringer("from", h.state),
+			zap.Stringer("to", state),
+			zap.Strings("signals", signals))
+		storeHealthTransitionCounter.WithLabelValues(strconv.FormatUint(storeID, 10), h.state.String(), state.String()).Inc()
+		h.state = state
+		h.since = now
+	}
+	h.signals = signals
+	h.leaderRatio = leaderRatio
+	if changed {
+		s.publish(cluster, storeID, h)
+	}
+	if h.state == core.StoreHealthy {
+		delete(s.stores, storeID)
+	}
+}
+
+// reset makes the store healthy and stops tracking it.
+func (s *storeHealthScheduler) reset(cluster schedule.Cluster, storeID uint64) {
+	if err := s.conf.removeAndPersist(storeID); err != nil {
+		log.Info("store-health-scheduler persist config failed", zap.Uint64("store-id", storeID), zap.Error(err))
+	}
+	s.publish(cluster, storeID, &storeHealth{state: core.StoreHealthy, leaderRatio: 1})
+	delete(s.stores, storeID)
+}
+
+func (s *storeHealthScheduler) publish(cluster schedule.Cluster, storeID uint64, h *storeHealth) {
+	cluster.SetStoreHealthState(storeID, h.state, h.signals, h.leaderRatio)
+	storeLabel := strconv.FormatUint(storeID, 10)
+	for _, state := range []core.StoreHealthState{core.StoreHealthy, core.StoreSuspect, core.StoreQuarantined, core.StoreRecovering} {
+		value := 0.0
+		if state == h.state {
+			value = 1
+		}
+		storeHealthStateGauge.WithLabelValues(storeLabel, state.String()).Set(value)
+	}
+}
+
+// getRecoverLeaderRatio returns the leader ratio of a store which has been recovering for the elapsed time.
+func getRecoverLeaderRatio(elapsed, recoverDuration time.Duration) float64 {
+	step := storeRecoverSteps - 1
+	if recoverDuration > 0 && elapsed < recoverDuration {
+		step = int(elapsed * storeRecoverSteps / recoverDuration)
+	}
+	return float64(step+1) / float64(storeRecoverSteps+1)
+}
+
+func collectStoreHealthSignals(store *core.StoreInfo, medianInterval time.Duration, now time.Time) []string {
+	var signals []string
+	if store.IsSlow() {
+		signals = append(signals, healthSignalSlowScore)
+	}
+	if slowTrend := store.GetSlowTrend(); slowTrend != nil && slowTrend.CauseRate > alterEpsilon && slowTrend.ResultRate < -alterEpsilon {
+		signals = append(signals, healthSignalSlowTrend)
+	}
+	if store.IsBusy() {
+		signals = append(signals, healthSignalDiskStall)
+	}
+	if medianInterval > 0 && getHeartbeatInterval(store, now) > medianInterval*storeHeartbeatJitterRatio {
+		signals = append(signals, healthSignalHeartbeatJitter)
+	}
+	return signals
+}
+
+// getHeartbeatInterval returns the heartbeat interval of the store, which is the longer one of
+// the last reported interval and the time elapsed since the last heartbeat.
+func getHeartbeatInterval(store *core.StoreInfo, now time.Time) time.Duration {
+	var interval time.Duration
+	if reported := store.GetStoreStats().GetInterval(); reported != nil && reported.GetEndTimestamp() > reported.GetStartTimestamp() {
+		interval = time.Duration(reported.GetEndTimestamp()-reported.GetStartTimestamp()) * time.Second
+	}
+	if lastHeartbeat := store.GetLastHeartbeatTS(); !lastHeartbeat.IsZero() && now.Sub(lastHeartbeat) > interval {
+		interval = now.Sub(lastHeartbeat)
+	}
+	return interval
+}
+
+func getMedianHeartbeatInterval(stores []*core.StoreInfo, now time.Time) time.Duration {
+	if len(stores) == 0 {
+		return 0
+	}
+	intervals := make([]time.Duration, 0, len(stores))
+	for _, store := range stores {
+		intervals = append(intervals, getHeartbeatInterval(store, now))
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	return intervals[len(intervals)/2]
+}
+
+func equalSignals(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newStoreHealthScheduler creates a scheduler that detects the unhealthy stores by multiple signals,
+// quarantines them by evicting their leaders and stopping adding peers to them, and restores them gradually.
+func newStoreHealthScheduler(opController *schedule.OperatorController, conf *storeHealthSchedulerConfig) schedule.Scheduler {
+	return &storeHealthScheduler{
+		BaseScheduler: NewBaseScheduler(opController),
+		conf:          conf,
+		stores:        make(map[uint64]*storeHealth),
+	}
+}
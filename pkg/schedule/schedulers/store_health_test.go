@@ -0,0 +1,207 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/testutil"
+)
+
+type storeHealthTestSuite struct {
+	suite.Suite
+	cancel context.CancelFunc
+	tc     *mockcluster.Cluster
+	sh     schedule.Scheduler
+	bs     schedule.Scheduler
+	oc     *schedule.OperatorController
+}
+
+func TestStoreHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(storeHealthTestSuite))
+}
+
+func (suite *storeHealthTestSuite) SetupTest() {
+	suite.cancel, _, suite.tc, suite.oc = prepareSchedulersTest()
+
+	suite.tc.AddLeaderStore(1, 0)
+	suite.tc.AddLeaderStore(2, 0)
+	suite.tc.AddLeaderStore(3, 0)
+	suite.tc.AddLeaderRegion(1, 1, 2)
+	suite.tc.AddLeaderRegion(2, 2, 1)
+	suite.tc.UpdateLeaderCount(2, 16)
+
+	storage := storage.NewStorageWithMemoryBackend()
+	var err error
+	suite.sh, err = schedule.CreateScheduler(StoreHealthType, suite.oc, nil, storage, schedule.ConfigSliceDecoder(StoreHealthType, []string{}))
+	suite.NoError(err)
+	suite.bs, err = schedule.CreateScheduler(BalanceLeaderType, suite.oc, nil, storage, schedule.ConfigSliceDecoder(BalanceLeaderType, []string{}))
+	suite.NoError(err)
+}
+
+func (suite *storeHealthTestSuite) TearDownTest() {
+	suite.cancel()
+}
+
+func (suite *storeHealthTestSuite) setStoreStats(storeID uint64, slowScore uint64, isBusy bool) {
+	store := suite.tc.GetStore(storeID)
+	stats := store.CloneStoreStats()
+	stats.SlowScore = slowScore
+	stats.IsBusy = isBusy
+	suite.tc.PutStore(store.Clone(core.SetStoreStats(stats)))
+}
+
+func (suite *storeHealthTestSuite) checkState(storeID uint64, state core.StoreHealthState) {
+	suite.Equal(state, suite.tc.GetStore(storeID).GetHealthState())
+}
+
+func (suite *storeHealthTestSuite) TestStoreHealthLifecycle() {
+	sh := suite.sh.(*storeHealthScheduler)
+
+	// Multiple signals quarantine the store without waiting for the suspect duration.
+	suite.setStoreStats(1, 100, true)
+	ops, _ := suite.sh.Schedule(suite.tc, false)
+	suite.Empty(ops)
+	suite.checkState(1, core.StoreSuspect)
+	suite.Equal([]string{healthSignalSlowScore, healthSignalDiskStall}, suite.tc.GetStore(1).GetHealthSignals())
+	suite.checkState(2, core.StoreHealthy)
+
+	ops, _ = suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreQuarantined)
+	suite.Equal([]uint64{1}, sh.conf.QuarantinedStores)
+	testutil.CheckMultiTargetTransferLeader(suite.Require(), ops[0], operator.OpLeader, 1, []uint64{2})
+	suite.Equal(StoreHealthType, ops[0].Desc())
+	// Cannot balance leaders to the quarantined store.
+	ops, _ = suite.bs.Schedule(suite.tc, false)
+	suite.Empty(ops)
+
+	// Keep quarantined until no signal is detected for the recover duration.
+	suite.setStoreStats(1, 1, false)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreQuarantined)
+	sh.conf.recoverDuration = time.Hour
+	sh.stores[1].lastUnhealthy = time.Now().Add(-time.Hour)
+	ops, _ = suite.sh.Schedule(suite.tc, false)
+	suite.Empty(ops)
+	suite.checkState(1, core.StoreRecovering)
+	suite.Empty(sh.conf.QuarantinedStores)
+	suite.Equal(0.2, suite.tc.GetStore(1).GetHealthLeaderRatio())
+	// The recovering store takes the leaders back gradually.
+	suite.tc.UpdateLeaderCount(1, 2)
+	ops, _ = suite.bs.Schedule(suite.tc, false)
+	suite.Empty(ops)
+	sh.stores[1].since = time.Now().Add(-45 * time.Minute)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreRecovering)
+	suite.Equal(0.8, suite.tc.GetStore(1).GetHealthLeaderRatio())
+	ops, _ = suite.bs.Schedule(suite.tc, false)
+	testutil.CheckTransferLeader(suite.Require(), ops[0], operator.OpLeader, 2, 1)
+
+	// Any signal quarantines the recovering store again.
+	sh.conf.recoverDuration = 0
+	suite.setStoreStats(1, 1, true)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreQuarantined)
+	suite.setStoreStats(1, 1, false)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreRecovering)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreHealthy)
+	suite.Empty(suite.tc.GetStore(1).GetHealthSignals())
+	suite.Equal(1.0, suite.tc.GetStore(1).GetHealthLeaderRatio())
+	ops, _ = suite.bs.Schedule(suite.tc, false)
+	testutil.CheckTransferLeader(suite.Require(), ops[0], operator.OpLeader, 2, 1)
+}
+
+func (suite *storeHealthTestSuite) TestSuspectDuration() {
+	sh := suite.sh.(*storeHealthScheduler)
+
+	suite.setStoreStats(1, 1, true)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreSuspect)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreSuspect)
+	// Back to healthy once the signal disappears.
+	suite.setStoreStats(1, 1, false)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreHealthy)
+
+	suite.setStoreStats(1, 1, true)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreSuspect)
+	sh.stores[1].since = time.Now().Add(-sh.conf.suspectDuration)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreQuarantined)
+
+	// Only one store can be quarantined at the same time.
+	suite.setStoreStats(2, 100, true)
+	suite.sh.Schedule(suite.tc, false)
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(2, core.StoreSuspect)
+
+	// Clean up the states after the scheduler is removed.
+	suite.sh.Cleanup(suite.tc)
+	suite.checkState(1, core.StoreHealthy)
+	suite.checkState(2, core.StoreHealthy)
+	suite.Empty(sh.conf.QuarantinedStores)
+}
+
+func (suite *storeHealthTestSuite) TestClusterWideProblem() {
+	for _, storeID := range []uint64{1, 2, 3} {
+		suite.setStoreStats(storeID, 100, true)
+	}
+	for i := 0; i < 3; i++ {
+		ops, _ := suite.sh.Schedule(suite.tc, false)
+		suite.Empty(ops)
+	}
+	for _, storeID := range []uint64{1, 2, 3} {
+		suite.checkState(storeID, core.StoreSuspect)
+	}
+}
+
+func (suite *storeHealthTestSuite) TestHeartbeatJitter() {
+	now := time.Now()
+	for _, storeID := range []uint64{1, 2, 3} {
+		interval := uint64(10)
+		if storeID == 1 {
+			interval = 30
+		}
+		store := suite.tc.GetStore(storeID)
+		stats := store.CloneStoreStats()
+		stats.Interval = &pdpb.TimeInterval{StartTimestamp: uint64(now.Unix()) - interval, EndTimestamp: uint64(now.Unix())}
+		suite.tc.PutStore(store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(now)))
+	}
+	suite.sh.Schedule(suite.tc, false)
+	suite.checkState(1, core.StoreSuspect)
+	suite.Equal([]string{healthSignalHeartbeatJitter}, suite.tc.GetStore(1).GetHealthSignals())
+	suite.checkState(2, core.StoreHealthy)
+	suite.checkState(3, core.StoreHealthy)
+}
+
+func (suite *storeHealthTestSuite) TestPrepare() {
+	sh := suite.sh.(*storeHealthScheduler)
+	suite.NoError(sh.conf.addAndPersist(1))
+	suite.NoError(suite.sh.Prepare(suite.tc))
+	suite.checkState(1, core.StoreQuarantined)
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+// AlertStorage defines the storage operations on the state of the alerted conditions.
+type AlertStorage interface {
+	LoadAlertState() (string, error)
+	SaveAlertState(state string) error
+}
+
+var _ AlertStorage = (*StorageEndpoint)(nil)
+
+// LoadAlertState loads the state of the alerted conditions from storage.
+func (se *StorageEndpoint) LoadAlertState() (string, error) {
+	return se.Load(alertStatePath)
+}
+
+// SaveAlertState stores the state of the alerted conditions to storage.
+func (se *StorageEndpoint) SaveAlertState(state string) error {
+	return se.Save(alertStatePath, state)
+}
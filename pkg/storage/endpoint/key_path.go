@@ -16,9 +16,12 @@ package endpoint
 
 import (
 	"fmt"
+	"net/url"
 	"path"
 	"strconv"
 	"strings"
+
+	"github.com/tikv/pd/client/keypath"
 )
 
 const (
@@ -30,6 +33,12 @@ const (
 	rulesPath                  = "rules"
 	ruleGroupPath              = "rule_group"
 	regionLabelPath            = "region_label"
+	scatterGroupPath           = "scatter_group"
+	storeDrainPath             = "store_drain"
+	alertStatePath             = "alert_state"
+	rbacPath                   = "rbac"
+	rbacRolePath               = rbacPath + "/role"
+	rbacBindingPath            = rbacPath + "/binding"
 	replicationPath            = "replication_mode"
 	customScheduleConfigPath   = "scheduler_config"
 	gcWorkerServiceSafePointID = "gc_worker"
@@ -50,6 +59,9 @@ const (
 	microserviceKey = "microservice"
 	tsoServiceKey   = "tso"
 	timestampKey    = "timestamp"
+	// keyspace group membership has prefix `tso/keyspace_groups`
+	tsoKeyspaceGroupPrefix = "tso/keyspace_groups"
+	keyspaceGroupFenceKey  = "fence"
 
 	// we use uint64 to represent ID, the max length of uint64 is 20.
 	keyLen = 20
@@ -132,6 +144,23 @@ func regionLabelKeyPath(ruleKey string) string {
 	return path.Join(regionLabelPath, ruleKey)
 }
 
+func scatterGroupKeyPath(name string) string {
+	return path.Join(scatterGroupPath, name)
+}
+
+func storeDrainKeyPath(storeID uint64) string {
+	return path.Join(storeDrainPath, fmt.Sprintf("%020d", storeID))
+}
+
+func rbacRoleKeyPath(name string) string {
+	return path.Join(rbacRolePath, name)
+}
+
+// rbacBindingKeyPath escapes the subject since it may contain "/", e.g. the URI SAN.
+func rbacBindingKeyPath(subject string) string {
+	return path.Join(rbacBindingPath, url.PathEscape(subject))
+}
+
 func replicationModePath(mode string) string {
 	return path.Join(replicationPath, mode)
 }
@@ -189,6 +218,18 @@ func KeyspaceGCSafePointSuffix() string {
 	return "/" + keyspaceGCSafePointSuffix
 }
 
+// ServiceMiddlewarePath returns the path of the service middleware config.
+// Path: service_middleware
+func ServiceMiddlewarePath() string {
+	return serviceMiddlewarePath
+}
+
+// RBACPrefix returns the prefix of the RBAC roles and bindings.
+// Prefix: rbac/
+func RBACPrefix() string {
+	return rbacPath + "/"
+}
+
 // KeyspaceMetaPrefix returns the prefix of keyspaces' metadata.
 // Prefix: keyspaces/meta/
 func KeyspaceMetaPrefix() string {
@@ -214,6 +255,43 @@ func KeyspaceIDAlloc() string {
 	return path.Join(keyspacePrefix, keyspaceAllocID)
 }
 
+// KeyspaceGroupIDPrefix returns the prefix of keyspace group id.
+// Path: tso/keyspace_groups/membership/
+func KeyspaceGroupIDPrefix() string {
+	return keypath.KeyspaceGroupMembershipPrefix()
+}
+
+// KeyspaceGroupIDPath returns the path to the membership of the given keyspace group.
+// Path: tso/keyspace_groups/membership/{id}
+func KeyspaceGroupIDPath(id uint32) string {
+	return path.Join(keypath.KeyspaceGroupMembershipPrefix(), encodeKeyspaceGroupID(id))
+}
+
+// ParseKeyspaceGroupIDPath parses the keyspace group ID from the path returned by KeyspaceGroupIDPath.
+func ParseKeyspaceGroupIDPath(key string) (uint32, error) {
+	id, err := strconv.ParseUint(path.Base(key), 10, 32)
+	return uint32(id), err
+}
+
+// KeyspaceGroupFencePath returns the path to the membership revision which has been applied by
+// the primary of the given keyspace group.
+// Path: tso/keyspace_groups/fence/{id}
+func KeyspaceGroupFencePath(id uint32) string {
+	return path.Join(tsoKeyspaceGroupPrefix, keyspaceGroupFenceKey, encodeKeyspaceGroupID(id))
+}
+
+// KeyspaceGroupTSOPath returns the root path under which the given keyspace group persists its timestamp.
+// Path: /ms/{cluster_id}/tso/{group_id}
+func KeyspaceGroupTSOPath(clusterID uint64, id uint32) string {
+	return path.Join("/", microserviceKey, strconv.FormatUint(clusterID, 10), tsoServiceKey, encodeKeyspaceGroupID(id))
+}
+
+// encodeKeyspaceGroupID from uint32 to string.
+// It's a 5 digits integer with leading zeros.
+func encodeKeyspaceGroupID(groupID uint32) string {
+	return fmt.Sprintf("%05d", groupID)
+}
+
 // encodeKeyspaceID from uint32 to string.
 // It adds extra padding to make encoded ID ordered.
 // Encoded ID can be decoded directly with strconv.ParseUint.
@@ -0,0 +1,57 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+// RBACStorage defines the storage operations on the roles and the role bindings.
+type RBACStorage interface {
+	LoadRBACRoles(f func(k, v string)) error
+	SaveRBACRole(name string, role interface{}) error
+	DeleteRBACRole(name string) error
+	LoadRBACBindings(f func(k, v string)) error
+	SaveRBACBinding(subject string, binding interface{}) error
+	DeleteRBACBinding(subject string) error
+}
+
+var _ RBACStorage = (*StorageEndpoint)(nil)
+
+// LoadRBACRoles loads all custom roles from storage.
+func (se *StorageEndpoint) LoadRBACRoles(f func(k, v string)) error {
+	return se.loadRangeByPrefix(rbacRolePath+"/", f)
+}
+
+// SaveRBACRole stores a custom role to storage.
+func (se *StorageEndpoint) SaveRBACRole(name string, role interface{}) error {
+	return se.saveJSON(rbacRoleKeyPath(name), role)
+}
+
+// DeleteRBACRole removes a custom role from storage.
+func (se *StorageEndpoint) DeleteRBACRole(name string) error {
+	return se.Remove(rbacRoleKeyPath(name))
+}
+
+// LoadRBACBindings loads all role bindings from storage.
+func (se *StorageEndpoint) LoadRBACBindings(f func(k, v string)) error {
+	return se.loadRangeByPrefix(rbacBindingPath+"/", f)
+}
+
+// SaveRBACBinding stores a role binding of the subject to storage.
+func (se *StorageEndpoint) SaveRBACBinding(subject string, binding interface{}) error {
+	return se.saveJSON(rbacBindingKeyPath(subject), binding)
+}
+
+// DeleteRBACBinding removes a role binding of the subject from storage.
+func (se *StorageEndpoint) DeleteRBACBinding(subject string) error {
+	return se.Remove(rbacBindingKeyPath(subject))
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+// ScatterGroupStorage defines the storage operations on the scatter group.
+type ScatterGroupStorage interface {
+	LoadScatterGroups(f func(k, v string)) error
+	SaveScatterGroup(name string, group interface{}) error
+	DeleteScatterGroup(name string) error
+}
+
+var _ ScatterGroupStorage = (*StorageEndpoint)(nil)
+
+// LoadScatterGroups loads all scatter groups from storage.
+func (se *StorageEndpoint) LoadScatterGroups(f func(k, v string)) error {
+	return se.loadRangeByPrefix(scatterGroupPath+"/", f)
+}
+
+// SaveScatterGroup stores a scatter group to storage.
+func (se *StorageEndpoint) SaveScatterGroup(name string, group interface{}) error {
+	return se.saveJSON(scatterGroupKeyPath(name), group)
+}
+
+// DeleteScatterGroup removes a scatter group from storage.
+func (se *StorageEndpoint) DeleteScatterGroup(name string) error {
+	return se.Remove(scatterGroupKeyPath(name))
+}
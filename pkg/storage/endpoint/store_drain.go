@@ -0,0 +1,39 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+// StoreDrainStorage defines the storage operations on the store drain jobs.
+type StoreDrainStorage interface {
+	LoadStoreDrainJobs(f func(k, v string)) error
+	SaveStoreDrainJob(storeID uint64, job interface{}) error
+	DeleteStoreDrainJob(storeID uint64) error
+}
+
+var _ StoreDrainStorage = (*StorageEndpoint)(nil)
+
+// LoadStoreDrainJobs loads all store drain jobs from storage.
+func (se *StorageEndpoint) LoadStoreDrainJobs(f func(k, v string)) error {
+	return se.loadRangeByPrefix(storeDrainPath+"/", f)
+}
+
+// SaveStoreDrainJob stores a store drain job to storage.
+func (se *StorageEndpoint) SaveStoreDrainJob(storeID uint64, job interface{}) error {
+	return se.saveJSON(storeDrainKeyPath(storeID), job)
+}
+
+// DeleteStoreDrainJob removes a store drain job from storage.
+func (se *StorageEndpoint) DeleteStoreDrainJob(storeID uint64) error {
+	return se.Remove(storeDrainKeyPath(storeID))
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"go.etcd.io/etcd/clientv3"
+)
+
+// KeyspaceGroup is the keyspace group, which serves the TSO of a set of keyspaces
+// with an independent allocator and primary election.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type KeyspaceGroup struct {
+	ID uint32 `json:"id"`
+	// Keyspaces are the IDs of the keyspaces which belong to the keyspace group.
+	Keyspaces []uint32 `json:"keyspaces"`
+	// Transfers are the keyspaces moved into the keyspace group which are not served yet.
+	// The TSO service serves them only after the source keyspace groups have stopped serving
+	// them and the allocator of this keyspace group has been advanced past the timestamps
+	// persisted by the source keyspace groups, then the transfers are cleared.
+	Transfers []*KeyspaceTransfer `json:"transfers,omitempty"`
+}
+
+// KeyspaceTransfer records the keyspaces moved from the source keyspace group.
+type KeyspaceTransfer struct {
+	Source    uint32   `json:"source"`
+	Keyspaces []uint32 `json:"keyspaces"`
+}
+
+// KeyspaceGroupStorage is the interface for keyspace group storage.
+type KeyspaceGroupStorage interface {
+	LoadKeyspaceGroup(txn kv.Txn, id uint32) (*KeyspaceGroup, error)
+	LoadKeyspaceGroups() ([]*KeyspaceGroup, error)
+	SaveKeyspaceGroup(txn kv.Txn, kg *KeyspaceGroup) error
+	DeleteKeyspaceGroup(txn kv.Txn, id uint32) error
+	RunInTxn(ctx context.Context, f func(txn kv.Txn) error) error
+}
+
+var _ KeyspaceGroupStorage = (*StorageEndpoint)(nil)
+
+// LoadKeyspaceGroup loads the keyspace group by id.
+// It returns nil if the keyspace group does not exist.
+func (se *StorageEndpoint) LoadKeyspaceGroup(txn kv.Txn, id uint32) (*KeyspaceGroup, error) {
+	value, err := txn.Load(KeyspaceGroupIDPath(id))
+	if err != nil || value == "" {
+		return nil, err
+	}
+	kg := &KeyspaceGroup{}
+	if err := json.Unmarshal([]byte(value), kg); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return kg, nil
+}
+
+// LoadKeyspaceGroups loads all keyspace groups ordered by their IDs.
+func (se *StorageEndpoint) LoadKeyspaceGroups() ([]*KeyspaceGroup, error) {
+	prefix := KeyspaceGroupIDPrefix()
+	keys, values, err := se.LoadRange(prefix, clientv3.GetPrefixRangeEnd(prefix), 0)
+	if err != nil {
+		return nil, err
+	}
+	kgs := make([]*KeyspaceGroup, 0, len(keys))
+	for _, value := range values {
+		kg := &KeyspaceGroup{}
+		if err := json.Unmarshal([]byte(value), kg); err != nil {
+			return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+		}
+		kgs = append(kgs, kg)
+	}
+	return kgs, nil
+}
+
+// SaveKeyspaceGroup adds a save keyspace group operation to the target transaction.
+func (se *StorageEndpoint) SaveKeyspaceGroup(txn kv.Txn, kg *KeyspaceGroup) error {
+	value, err := json.Marshal(kg)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByArgs()
+	}
+	return txn.Save(KeyspaceGroupIDPath(kg.ID), string(value))
+}
+
+// DeleteKeyspaceGroup adds a delete keyspace group operation to the target transaction.
+func (se *StorageEndpoint) DeleteKeyspaceGroup(txn kv.Txn, id uint32) error {
+	return txn.Remove(KeyspaceGroupIDPath(id))
+}
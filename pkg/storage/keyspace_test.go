@@ -147,3 +147,48 @@ func TestEncodeSpaceID(t *testing.T) {
 	re.Equal("keyspaces/meta/00000011", endpoint.KeyspaceMetaPath(11))
 	re.Equal("keyspaces/meta/00000010", endpoint.KeyspaceMetaPath(10))
 }
+
+func TestSaveLoadKeyspaceGroup(t *testing.T) {
+	re := require.New(t)
+	storage := NewStorageWithMemoryBackend()
+	kgs := []*endpoint.KeyspaceGroup{
+		{ID: 0, Keyspaces: []uint32{0}},
+		{ID: 2, Keyspaces: []uint32{3, 4}},
+		{ID: 1, Keyspaces: []uint32{1, 2}},
+	}
+	err := storage.RunInTxn(context.TODO(), func(txn kv.Txn) error {
+		for _, kg := range kgs {
+			re.NoError(storage.SaveKeyspaceGroup(txn, kg))
+		}
+		return nil
+	})
+	re.NoError(err)
+	err = storage.RunInTxn(context.TODO(), func(txn kv.Txn) error {
+		for _, kg := range kgs {
+			loaded, err := storage.LoadKeyspaceGroup(txn, kg.ID)
+			re.NoError(err)
+			re.Equal(kg, loaded)
+		}
+		return nil
+	})
+	re.NoError(err)
+	// Keyspace groups are loaded in the order of their IDs.
+	loaded, err := storage.LoadKeyspaceGroups()
+	re.NoError(err)
+	re.Equal([]*endpoint.KeyspaceGroup{kgs[0], kgs[2], kgs[1]}, loaded)
+
+	err = storage.RunInTxn(context.TODO(), func(txn kv.Txn) error {
+		return storage.DeleteKeyspaceGroup(txn, 1)
+	})
+	re.NoError(err)
+	err = storage.RunInTxn(context.TODO(), func(txn kv.Txn) error {
+		kg, err := storage.LoadKeyspaceGroup(txn, 1)
+		re.NoError(err)
+		re.Nil(kg)
+		return nil
+	})
+	re.NoError(err)
+	loaded, err = storage.LoadKeyspaceGroups()
+	re.NoError(err)
+	re.Len(loaded, 2)
+}
@@ -36,6 +36,10 @@ type Storage interface {
 	endpoint.ConfigStorage
 	endpoint.MetaStorage
 	endpoint.RuleStorage
+	endpoint.ScatterGroupStorage
+	endpoint.StoreDrainStorage
+	endpoint.AlertStorage
+	endpoint.RBACStorage
 	endpoint.ReplicationStatusStorage
 	endpoint.GCSafePointStorage
 	endpoint.MinResolvedTSStorage
@@ -44,6 +48,7 @@ type Storage interface {
 	endpoint.KeyspaceStorage
 	endpoint.ResourceGroupStorage
 	endpoint.TSOStorage
+	endpoint.KeyspaceGroupStorage
 }
 
 // NewStorageWithMemoryBackend creates a new storage with memory backend.
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/election"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/storage/kv"
@@ -172,6 +173,10 @@ type AllocatorManager struct {
 	updatePhysicalInterval time.Duration
 	maxResetTSGap          func() time.Duration
 	securityConfig         *grpcutil.TLSConfig
+	// clock provides the physical time for all the TSO allocators.
+	clock *boundedClock
+	// eventHub is used to alert the fallback of the system time.
+	eventHub *event.Hub
 	// for gRPC use
 	localAllocatorConn struct {
 		syncutil.RWMutex
@@ -189,6 +194,8 @@ func NewAllocatorManager(
 	updatePhysicalInterval time.Duration,
 	tlsConfig *grpcutil.TLSConfig,
 	maxResetTSGap func() time.Duration,
+	timeSource TimeSource,
+	maxClockUncertainty time.Duration,
 ) *AllocatorManager {
 	allocatorManager := &AllocatorManager{
 		enableLocalTSO:         enableLocalTSO,
@@ -199,6 +206,7 @@ func NewAllocatorManager(
 		updatePhysicalInterval: updatePhysicalInterval,
 		maxResetTSGap:          maxResetTSGap,
 		securityConfig:         tlsConfig,
+		clock:                  newBoundedClock(timeSource, maxClockUncertainty),
 	}
 	allocatorManager.mu.allocatorGroups = make(map[string]*allocatorGroup)
 	allocatorManager.mu.clusterDCLocations = make(map[string]*DCLocationInfo)
@@ -206,6 +214,12 @@ func NewAllocatorManager(
 	return allocatorManager
 }
 
+// GetClockUncertainty returns the latest uncertainty bound of the TSO physical time.
+// It returns false if the clock uncertainty check isn't enabled.
+func (am *AllocatorManager) GetClockUncertainty() (time.Duration, bool) {
+	return am.clock.getUncertainty(), am.clock.maxUncertainty > 0
+}
+
 // SetLocalTSOConfig receives the zone label of this PD server and write it into etcd as dc-location
 // to make the whole cluster know the DC-level topology for later Local TSO Allocator campaign.
 func (am *AllocatorManager) SetLocalTSOConfig(dcLocation string) error {
@@ -362,6 +376,12 @@ func CalSuffixBits(maxSuffix int32) int {
 	return int(math.Ceil(math.Log2(float64(maxSuffix + 1))))
 }
 
+// SetEventHub sets the hub to alert the fallback of the system time, it must be called before
+// setting up the allocators.
+func (am *AllocatorManager) SetEventHub(hub *event.Hub) {
+	am.eventHub = hub
+}
+
 // SetUpAllocator is used to set up an allocator, which will initialize the allocator and put it into allocator daemon.
 // One TSO Allocator should only be set once, and may be initialized and reset multiple times depending on the election.
 func (am *AllocatorManager) SetUpAllocator(parentCtx context.Context, dcLocation string, leadership *election.Leadership) {
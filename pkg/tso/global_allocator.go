@@ -87,6 +87,8 @@ func NewGlobalTSOAllocator(
 			saveInterval:           am.saveInterval,
 			updatePhysicalInterval: am.updatePhysicalInterval,
 			maxResetTSGap:          am.maxResetTSGap,
+			clock:                  am.clock,
+			eventHub:               am.eventHub,
 			dcLocation:             GlobalDCLocation,
 			tsoMux:                 &tsoObject{},
 		},
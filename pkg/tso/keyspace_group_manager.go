@@ -0,0 +1,679 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mcs/utils"
+	"github.com/tikv/pd/pkg/member"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/memberutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+	"go.etcd.io/etcd/clientv3"
+	"go.uber.org/zap"
+)
+
+const (
+	// keyspaceGroupRewatchInterval is the interval to watch the keyspace group membership
+	// again after the watcher is canceled.
+	keyspaceGroupRewatchInterval = time.Second
+	// keyspaceGroupTransferInterval is the interval for the primary to fence itself and
+	// to take over the keyspaces transferred into its keyspace group.
+	keyspaceGroupTransferInterval = 100 * time.Millisecond
+)
+
+// keyspaceGroupAllocator is the TSO allocator of a keyspace group, which has its own
+// primary election and timestamp path.
+type keyspaceGroupAllocator struct {
+	id          uint32
+	participant *member.Participant
+	am          *AllocatorManager
+	ctx         context.Context
+	cancel      context.CancelFunc
+	// fencedRevision is the membership revision which has been persisted as the fence of
+	// the keyspace group by this server during its current primary term.
+	fencedRevision atomic.Int64
+	// epoch is increased every time a keyspace is moved out of the keyspace group,
+	// it's protected by the lock of the KeyspaceGroupManager.
+	epoch uint64
+}
+
+// keyspaceGroupMembership is the membership of a keyspace group loaded from etcd.
+type keyspaceGroupMembership struct {
+	*endpoint.KeyspaceGroup
+	// revision is the etcd mod revision of the membership.
+	revision int64
+}
+
+// KeyspaceGroupManager manages the TSO allocators of all keyspace groups. Each keyspace
+// group is served by an independent AllocatorManager with its own etcd timestamp path
+// and primary election, so that the load or failover of one keyspace group won't affect
+// the others.
+type KeyspaceGroupManager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	clusterID  uint64
+	etcdClient *clientv3.Client
+	// legacySvcRootPath is the root path of the PD service, e.g. /pd/{cluster_id}.
+	// The default keyspace group still stores its timestamp under it for backward
+	// compatibility, and the keyspace group membership is also stored under it.
+	legacySvcRootPath string
+	// legacySvcStorage is the storage rooted at legacySvcRootPath.
+	legacySvcStorage *endpoint.StorageEndpoint
+	cfg              ServiceConfig
+	// timeSource provides the physical time for the allocators of all the keyspace groups.
+	timeSource TimeSource
+	// primaryCallbacks will be called after the server becomes the primary of the default keyspace group.
+	primaryCallbacks []func(context.Context)
+
+	mu struct {
+		syncutil.RWMutex
+		// groups is the map from keyspace group ID to its allocator.
+		groups map[uint32]*keyspaceGroupAllocator
+		// memberships is the map from keyspace group ID to its membership.
+		memberships map[uint32]*keyspaceGroupMembership
+		// keyspaceLookupTable is the map from keyspace ID to the keyspace group ID it belongs to.
+		keyspaceLookupTable map[uint32]uint32
+		// transferringKeyspaces is the map from the ID of the keyspace which has been moved
+		// but not taken over yet to the keyspace group ID it is moved into.
+		transferringKeyspaces map[uint32]uint32
+		// revision is the etcd revision of the applied membership.
+		revision int64
+	}
+}
+
+// NewKeyspaceGroupManager creates a new keyspace group manager.
+func NewKeyspaceGroupManager(
+	ctx context.Context,
+	clusterID uint64,
+	etcdClient *clientv3.Client,
+	legacySvcRootPath string,
+	cfg ServiceConfig,
+	primaryCallbacks []func(context.Context),
+) *KeyspaceGroupManager {
+	ctx, cancel := context.WithCancel(ctx)
+	kgm := &KeyspaceGroupManager{
+		ctx:               ctx,
+		cancel:            cancel,
+		clusterID:         clusterID,
+		etcdClient:        etcdClient,
+		legacySvcRootPath: legacySvcRootPath,
+		legacySvcStorage:  endpoint.NewStorageEndpoint(kv.NewEtcdKVBase(etcdClient, legacySvcRootPath), nil),
+		cfg:               cfg,
+		primaryCallbacks:  primaryCallbacks,
+	}
+	kgm.mu.groups = make(map[uint32]*keyspaceGroupAllocator)
+	kgm.mu.memberships = make(map[uint32]*keyspaceGroupMembership)
+	kgm.mu.keyspaceLookupTable = make(map[uint32]uint32)
+	kgm.mu.transferringKeyspaces = make(map[uint32]uint32)
+	return kgm
+}
+
+// Initialize sets up the allocators of the keyspace groups and starts the background loops.
+// The default keyspace group is always served even if its membership hasn't been persisted yet.
+func (kgm *KeyspaceGroupManager) Initialize() error {
+	timeSource, err := NewTimeSource(kgm.ctx, kgm.cfg.GetTSOTimeSource())
+	if err != nil {
+		return err
+	}
+	kgm.timeSource = timeSource
+	if err = kgm.loadKeyspaceGroups(); err != nil {
+		return err
+	}
+	kgm.wg.Add(1)
+	go kgm.watchKeyspaceGroups()
+	return nil
+}
+
+// Close stops all the allocators and waits for the background loops to exit.
+func (kgm *KeyspaceGroupManager) Close() {
+	kgm.cancel()
+	kgm.wg.Wait()
+	log.Info("keyspace group manager is closed")
+}
+
+// watchKeyspaceGroups watches the keyspace group membership, sets up the allocators of
+// the new keyspace groups and stops the allocators of the removed ones.
+func (kgm *KeyspaceGroupManager) watchKeyspaceGroups() {
+	defer logutil.LogPanic()
+	defer kgm.wg.Done()
+
+	for {
+		watchCtx, watchCancel := context.WithCancel(kgm.ctx)
+		watchChan := kgm.etcdClient.Watch(watchCtx, kgm.membershipPrefix(), clientv3.WithPrefix())
+		// Reload after watching to not miss the changes before the watch starts.
+		if err := kgm.loadKeyspaceGroups(); err != nil {
+			log.Warn("failed to load keyspace groups", errs.ZapError(err))
+		}
+	watchLoop:
+		for {
+			select {
+			case resp, ok := <-watchChan:
+				if !ok || resp.Err() != nil {
+					log.Warn("keyspace group watcher is canceled, the watcher will watch again", errs.ZapError(resp.Err()))
+					break watchLoop
+				}
+				if err := kgm.loadKeyspaceGroups(); err != nil {
+					log.Warn("failed to load keyspace groups", errs.ZapError(err))
+				}
+			case <-kgm.ctx.Done():
+				watchCancel()
+				log.Info("exit keyspace group watch loop")
+				return
+			}
+		}
+		watchCancel()
+		select {
+		case <-time.After(keyspaceGroupRewatchInterval):
+		case <-kgm.ctx.Done():
+			log.Info("exit keyspace group watch loop")
+			return
+		}
+	}
+}
+
+// membershipPrefix returns the etcd prefix of the keyspace group membership.
+func (kgm *KeyspaceGroupManager) membershipPrefix() string {
+	return kgm.legacySvcRootPath + "/" + endpoint.KeyspaceGroupIDPrefix()
+}
+
+// loadKeyspaceGroups loads the keyspace group membership from etcd and applies it.
+func (kgm *KeyspaceGroupManager) loadKeyspaceGroups() error {
+	resp, err := etcdutil.EtcdKVGet(kgm.etcdClient, kgm.membershipPrefix(), clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	memberships := make(map[uint32]*keyspaceGroupMembership, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		kg := &endpoint.KeyspaceGroup{}
+		if err := json.Unmarshal(item.Value, kg); err != nil {
+			return errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+		}
+		memberships[kg.ID] = &keyspaceGroupMembership{KeyspaceGroup: kg, revision: item.ModRevision}
+	}
+	kgm.updateKeyspaceGroups(memberships, resp.Header.GetRevision())
+	return nil
+}
+
+// updateKeyspaceGroups applies the membership loaded at the given revision. The membership
+// loaded earlier than the applied one is ignored.
+func (kgm *KeyspaceGroupManager) updateKeyspaceGroups(memberships map[uint32]*keyspaceGroupMembership, revision int64) {
+	kgm.mu.Lock()
+	defer kgm.mu.Unlock()
+	if revision < kgm.mu.revision {
+		return
+	}
+	lookupTable := make(map[uint32]uint32)
+	latest := map[uint32]struct{}{utils.DefaultKeyspaceGroupID: {}}
+	for id, membership := range memberships {
+		if id >= utils.MaxKeyspaceGroupCount {
+			log.Warn("skip the keyspace group with illegal id", zap.Uint32("keyspace-group-id", id))
+			delete(memberships, id)
+			continue
+		}
+		latest[id] = struct{}{}
+		for _, keyspaceID := range membership.Keyspaces {
+			lookupTable[keyspaceID] = id
+		}
+	}
+	transferringKeyspaces := make(map[uint32]uint32)
+	for id, membership := range memberships {
+		for _, transfer := range membership.Transfers {
+			for _, keyspaceID := range transfer.Keyspaces {
+				groupID, ok := lookupTable[keyspaceID]
+				if !ok {
+					groupID = utils.DefaultKeyspaceGroupID
+				}
+				// The keyspace may have been moved out again.
+				if groupID == id {
+					transferringKeyspaces[keyspaceID] = id
+				}
+			}
+		}
+	}
+	for id := range latest {
+		if _, ok := kgm.mu.groups[id]; !ok {
+			kgm.mu.groups[id] = kgm.setUpKeyspaceGroup(id)
+		}
+	}
+	// Advance the epoch of the keyspace groups which keyspaces are moved out of,
+	// so that the in-flight allocations of them could be discarded.
+	movedOut := make(map[uint32]struct{})
+	for keyspaceID, id := range lookupTable {
+		if prevID := kgm.getKeyspaceGroupIDLocked(keyspaceID); prevID != id {
+			movedOut[prevID] = struct{}{}
+		}
+	}
+	for keyspaceID, prevID := range kgm.mu.keyspaceLookupTable {
+		if _, ok := lookupTable[keyspaceID]; !ok && prevID != utils.DefaultKeyspaceGroupID {
+			movedOut[prevID] = struct{}{}
+		}
+	}
+	for id := range movedOut {
+		if kga, ok := kgm.mu.groups[id]; ok {
+			kga.epoch++
+		}
+	}
+	for id, kga := range kgm.mu.groups {
+		if _, ok := latest[id]; !ok {
+			log.Info("keyspace group is removed, stop its allocator", zap.Uint32("keyspace-group-id", id))
+			kga.cancel()
+			delete(kgm.mu.groups, id)
+		}
+	}
+	kgm.mu.memberships = memberships
+	kgm.mu.keyspaceLookupTable = lookupTable
+	kgm.mu.transferringKeyspaces = transferringKeyspaces
+	kgm.mu.revision = revision
+}
+
+// keyspaceGroupStorage returns the root path and the storage under which the given
+// keyspace group persists its timestamp.
+func (kgm *KeyspaceGroupManager) keyspaceGroupStorage(id uint32) (string, *endpoint.StorageEndpoint) {
+	if id == utils.DefaultKeyspaceGroupID {
+		return kgm.legacySvcRootPath, kgm.legacySvcStorage
+	}
+	rootPath := endpoint.KeyspaceGroupTSOPath(kgm.clusterID, id)
+	return rootPath, endpoint.NewStorageEndpoint(kv.NewEtcdKVBase(kgm.etcdClient, rootPath), nil)
+}
+
+// keyspaceGroupPrimaryPath returns the primary election root path of the given keyspace group.
+func keyspaceGroupPrimaryPath(id uint32) string {
+	return path.Join(utils.TSOPrimaryPrefix, fmt.Sprintf("%05d", id))
+}
+
+// setUpKeyspaceGroup creates the allocator of the given keyspace group and starts its
+// primary election and allocator daemon. It should be called with kgm.mu held.
+func (kgm *KeyspaceGroupManager) setUpKeyspaceGroup(id uint32) *keyspaceGroupAllocator {
+	// The participant name is the listen address, which is used by the client to
+	// connect to the primary.
+	name := kgm.cfg.GetListenAddr()
+	participant := member.NewParticipant(kgm.etcdClient, memberutil.GenerateUniqueID(name))
+	participant.InitInfo(name, keyspaceGroupPrimaryPath(id), "primary", "keyspace group primary election", name)
+
+	rootPath, storage := kgm.keyspaceGroupStorage(id)
+	am := NewAllocatorManager(
+		participant, rootPath, storage, kgm.cfg.IsLocalTSOEnabled(), kgm.cfg.GetTSOSaveInterval(),
+		kgm.cfg.GetTSOUpdatePhysicalInterval(), kgm.cfg.GetTLSConfig(), kgm.cfg.GetMaxResetTSGap,
+		kgm.timeSource, kgm.cfg.GetTSOMaxClockUncertainty())
+	ctx, cancel := context.WithCancel(kgm.ctx)
+	// Set up the Global TSO Allocator here, it will be initialized once the participant campaigns the primary successfully.
+	am.SetUpAllocator(ctx, GlobalDCLocation, participant.GetLeadership())
+	kga := &keyspaceGroupAllocator{
+		id:          id,
+		participant: participant,
+		am:          am,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	log.Info("set up keyspace group allocator",
+		zap.Uint32("keyspace-group-id", id),
+		zap.String("root-path", rootPath),
+		zap.String("participant-name", name))
+	kgm.wg.Add(2)
+	go kgm.primaryElectionLoop(kga)
+	go kgm.allocatorLoop(kga)
+	return kga
+}
+
+// allocatorLoop is used to run the TSO Allocator updating daemon of the keyspace group.
+func (kgm *KeyspaceGroupManager) allocatorLoop(kga *keyspaceGroupAllocator) {
+	defer logutil.LogPanic()
+	defer kgm.wg.Done()
+
+	kga.am.AllocatorDaemon(kga.ctx)
+	log.Info("exit allocator loop", zap.Uint32("keyspace-group-id", kga.id))
+}
+
+func (kgm *KeyspaceGroupManager) primaryElectionLoop(kga *keyspaceGroupAllocator) {
+	defer logutil.LogPanic()
+	defer kgm.wg.Done()
+
+	for {
+		select {
+		case <-kga.ctx.Done():
+			log.Info("exit tso primary election loop", zap.Uint32("keyspace-group-id", kga.id))
+			return
+		default:
+		}
+
+		primary, rev, checkAgain := kga.participant.CheckLeader()
+		if checkAgain {
+			continue
+		}
+		if primary != nil {
+			log.Info("start to watch the primary/leader",
+				zap.Uint32("keyspace-group-id", kga.id),
+				zap.Stringer("tso-primary", primary))
+			// WatchLeader will keep looping and never return unless the primary/leader has changed.
+			kga.participant.WatchLeader(kga.ctx, primary, rev)
+			log.Info("the tso primary/leader has changed, try to re-campaign a primary/leader",
+				zap.Uint32("keyspace-group-id", kga.id))
+		}
+
+		kgm.campaignLeader(kga)
+	}
+}
+
+func (kgm *KeyspaceGroupManager) campaignLeader(kga *keyspaceGroupAllocator) {
+	log.Info("start to campaign the primary/leader",
+		zap.Uint32("keyspace-group-id", kga.id),
+		zap.String("campaign-tso-primary-name", kga.participant.Member().Name))
+	if err := kga.participant.CampaignLeader(kgm.cfg.GetLeaderLease()); err != nil {
+		if err.Error() == errs.ErrEtcdTxnConflict.Error() {
+			log.Info("campaign tso primary/leader meets error due to txn conflict, another tso server may campaign successfully",
+				zap.Uint32("keyspace-group-id", kga.id),
+				zap.String("campaign-tso-primary-name", kga.participant.Member().Name))
+		} else {
+			log.Error("campaign tso primary/leader meets error due to etcd error",
+				zap.Uint32("keyspace-group-id", kga.id),
+				zap.String("campaign-tso-primary-name", kga.participant.Member().Name),
+				errs.ZapError(err))
+		}
+		return
+	}
+
+	// Start keepalive the leadership and enable TSO service.
+	// TSO service is strictly enabled/disabled by the leader lease for 2 reasons:
+	//   1. lease based approach is not affected by thread pause, slow runtime schedule, etc.
+	//   2. load region could be slow. Based on lease we can recover TSO service faster.
+	ctx, cancel := context.WithCancel(kga.ctx)
+	var resetLeaderOnce sync.Once
+	defer resetLeaderOnce.Do(func() {
+		cancel()
+		kga.participant.ResetLeader()
+	})
+
+	// maintain the the leadership, after this, TSO can be service.
+	kga.participant.KeepLeader(ctx)
+	log.Info("campaign tso primary ok",
+		zap.Uint32("keyspace-group-id", kga.id),
+		zap.String("campaign-tso-primary-name", kga.participant.Member().Name))
+
+	// Reload the membership before serving, so that the new primary won't serve the keyspaces
+	// which have been moved out while the keyspace group had no primary.
+	if err := kgm.loadKeyspaceGroups(); err != nil {
+		log.Error("failed to load keyspace groups", zap.Uint32("keyspace-group-id", kga.id), errs.ZapError(err))
+		return
+	}
+	kga.fencedRevision.Store(0)
+
+	allocator, err := kga.am.GetAllocator(GlobalDCLocation)
+	if err != nil {
+		log.Error("failed to get the global tso allocator", zap.Uint32("keyspace-group-id", kga.id), errs.ZapError(err))
+		return
+	}
+	log.Info("initializing the global tso allocator", zap.Uint32("keyspace-group-id", kga.id))
+	if err := allocator.Initialize(0); err != nil {
+		log.Error("failed to initialize the global tso allocator", zap.Uint32("keyspace-group-id", kga.id), errs.ZapError(err))
+		return
+	}
+	defer func() {
+		kga.am.ResetAllocatorGroup(GlobalDCLocation)
+	}()
+
+	if kga.id == utils.DefaultKeyspaceGroupID {
+		log.Info("triggering the primary callback functions")
+		for _, cb := range kgm.primaryCallbacks {
+			cb(ctx)
+		}
+	}
+
+	kga.participant.EnableLeader()
+	log.Info("tso primary is ready to serve",
+		zap.Uint32("keyspace-group-id", kga.id),
+		zap.String("tso-primary-name", kga.participant.Member().Name))
+
+	leaderTicker := time.NewTicker(utils.LeaderTickInterval)
+	defer leaderTicker.Stop()
+	transferTicker := time.NewTicker(keyspaceGroupTransferInterval)
+	defer transferTicker.Stop()
+
+	for {
+		select {
+		case <-leaderTicker.C:
+			if !kga.participant.IsLeader() {
+				log.Info("no longer a primary/leader because lease has expired, the tso primary/leader will step down",
+					zap.Uint32("keyspace-group-id", kga.id))
+				return
+			}
+		case <-transferTicker.C:
+			kgm.fenceKeyspaceGroup(kga)
+			kgm.takeOverKeyspaces(kga, allocator)
+		case <-ctx.Done():
+			// Server is closed or the keyspace group is removed and it should return nil.
+			log.Info("exit leader campaign", zap.Uint32("keyspace-group-id", kga.id))
+			return
+		}
+	}
+}
+
+// fenceKeyspaceGroup persists the applied membership revision as the fence of the keyspace
+// group if there are keyspaces moved out of it, which means the primary has stopped serving
+// the moved keyspaces and the keyspace groups they are moved into could take them over.
+func (kgm *KeyspaceGroupManager) fenceKeyspaceGroup(kga *keyspaceGroupAllocator) {
+	kgm.mu.RLock()
+	revision, required := kgm.mu.revision, int64(0)
+	for _, membership := range kgm.mu.memberships {
+		for _, transfer := range membership.Transfers {
+			if transfer.Source == kga.id && membership.revision > required {
+				required = membership.revision
+			}
+		}
+	}
+	kgm.mu.RUnlock()
+	if required <= kga.fencedRevision.Load() {
+		return
+	}
+	fencePath := path.Join(kgm.legacySvcRootPath, endpoint.KeyspaceGroupFencePath(kga.id))
+	resp, err := kga.participant.GetLeadership().LeaderTxn().
+		Then(clientv3.OpPut(fencePath, strconv.FormatInt(revision, 10))).
+		Commit()
+	if err != nil {
+		log.Warn("failed to fence the keyspace group", zap.Uint32("keyspace-group-id", kga.id), errs.ZapError(err))
+		return
+	}
+	if resp.Succeeded {
+		kga.fencedRevision.Store(revision)
+	}
+}
+
+// isKeyspaceGroupFenced checks whether the primary of the given keyspace group has applied
+// the membership of the given revision. The keyspace group without a primary is also fenced,
+// because the new primary will reload the membership before serving.
+func (kgm *KeyspaceGroupManager) isKeyspaceGroupFenced(id uint32, revision int64) (bool, error) {
+	value, err := etcdutil.GetValue(kgm.etcdClient, path.Join(kgm.legacySvcRootPath, endpoint.KeyspaceGroupFencePath(id)))
+	if err != nil {
+		return false, err
+	}
+	if value != nil {
+		fenced, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return false, errs.ErrStrconvParseInt.Wrap(err).GenWithStackByCause()
+		}
+		if fenced >= revision {
+			return true, nil
+		}
+	}
+	primary, err := etcdutil.GetValue(kgm.etcdClient, path.Join(keyspaceGroupPrimaryPath(id), "primary"))
+	if err != nil {
+		return false, err
+	}
+	return primary == nil, nil
+}
+
+// takeOverKeyspaces serves the keyspaces transferred into the keyspace group. It waits for the
+// source keyspace groups to be fenced, advances the allocator past the timestamps persisted by
+// them, and then clears the transfers from the membership.
+func (kgm *KeyspaceGroupManager) takeOverKeyspaces(kga *keyspaceGroupAllocator, allocator Allocator) {
+	kgm.mu.RLock()
+	membership := kgm.mu.memberships[kga.id]
+	kgm.mu.RUnlock()
+	if membership == nil || len(membership.Transfers) == 0 {
+		return
+	}
+	for _, transfer := range membership.Transfers {
+		fenced, err := kgm.isKeyspaceGroupFenced(transfer.Source, membership.revision)
+		if err != nil {
+			log.Warn("failed to check the fence of the keyspace group",
+				zap.Uint32("keyspace-group-id", kga.id), zap.Uint32("source-keyspace-group-id", transfer.Source), errs.ZapError(err))
+			return
+		}
+		if !fenced {
+			return
+		}
+	}
+	for _, transfer := range membership.Transfers {
+		_, storage := kgm.keyspaceGroupStorage(transfer.Source)
+		last, err := storage.LoadTimestamp("")
+		if err != nil {
+			log.Warn("failed to load the timestamp of the keyspace group",
+				zap.Uint32("keyspace-group-id", kga.id), zap.Uint32("source-keyspace-group-id", transfer.Source), errs.ZapError(err))
+			return
+		}
+		if last == typeutil.ZeroTime {
+			continue
+		}
+		// The timestamps allocated by the source keyspace group are always less than its persisted one.
+		if err := allocator.SetTSO(tsoutil.GenerateTS(tsoutil.GenerateTimestamp(last, 0)), true, true); err != nil {
+			log.Warn("failed to advance the timestamp past the source keyspace group",
+				zap.Uint32("keyspace-group-id", kga.id), zap.Uint32("source-keyspace-group-id", transfer.Source), errs.ZapError(err))
+			return
+		}
+	}
+	kg := *membership.KeyspaceGroup
+	kg.Transfers = nil
+	value, err := json.Marshal(&kg)
+	if err != nil {
+		log.Warn("failed to marshal the keyspace group", zap.Uint32("keyspace-group-id", kga.id), errs.ZapError(err))
+		return
+	}
+	key := path.Join(kgm.legacySvcRootPath, endpoint.KeyspaceGroupIDPath(kga.id))
+	resp, err := kga.participant.GetLeadership().LeaderTxn(clientv3.Compare(clientv3.ModRevision(key), "=", membership.revision)).
+		Then(clientv3.OpPut(key, string(value))).
+		Commit()
+	if err != nil {
+		log.Warn("failed to clear the transfers of the keyspace group", zap.Uint32("keyspace-group-id", kga.id), errs.ZapError(err))
+		return
+	}
+	if resp.Succeeded {
+		log.Info("keyspaces are taken over by the keyspace group",
+			zap.Uint32("keyspace-group-id", kga.id), zap.Int("transfers", len(membership.Transfers)))
+	}
+}
+
+// getKeyspaceGroupAllocator returns the allocator of the given keyspace group.
+func (kgm *KeyspaceGroupManager) getKeyspaceGroupAllocator(id uint32) (*keyspaceGroupAllocator, error) {
+	kgm.mu.RLock()
+	defer kgm.mu.RUnlock()
+	kga, ok := kgm.mu.groups[id]
+	if !ok {
+		return nil, errs.ErrGetAllocator.FastGenByArgs(fmt.Sprintf("keyspace group %d is not served", id))
+	}
+	return kga, nil
+}
+
+// GetAllocatorManager returns the AllocatorManager of the given keyspace group.
+func (kgm *KeyspaceGroupManager) GetAllocatorManager(id uint32) (*AllocatorManager, error) {
+	kga, err := kgm.getKeyspaceGroupAllocator(id)
+	if err != nil {
+		return nil, err
+	}
+	return kga.am, nil
+}
+
+// GetElectionMember returns the election member of the given keyspace group.
+func (kgm *KeyspaceGroupManager) GetElectionMember(id uint32) (*member.Participant, error) {
+	kga, err := kgm.getKeyspaceGroupAllocator(id)
+	if err != nil {
+		return nil, err
+	}
+	return kga.participant, nil
+}
+
+// GetKeyspaceGroupID returns the ID of the keyspace group to which the given keyspace belongs.
+// The keyspaces which don't belong to any keyspace group are served by the default keyspace group.
+func (kgm *KeyspaceGroupManager) GetKeyspaceGroupID(keyspaceID uint32) uint32 {
+	kgm.mu.RLock()
+	defer kgm.mu.RUnlock()
+	return kgm.getKeyspaceGroupIDLocked(keyspaceID)
+}
+
+func (kgm *KeyspaceGroupManager) getKeyspaceGroupIDLocked(keyspaceID uint32) uint32 {
+	if id, ok := kgm.mu.keyspaceLookupTable[keyspaceID]; ok {
+		return id
+	}
+	return utils.DefaultKeyspaceGroupID
+}
+
+// HandleTSORequest forwards TSO allocation requests to the allocator of the keyspace group
+// to which the given keyspace belongs. It also returns the ID of the keyspace group which
+// actually serves the request, so the caller could tell the client to update its routing.
+// The lock isn't held during the allocation. Instead, the epoch of the keyspace group is
+// checked again after the allocation, and the timestamp is discarded if the keyspace has
+// been moved out in the meantime, so that no timestamp allocated by the previous keyspace
+// group will be returned once the membership change is applied and fenced.
+func (kgm *KeyspaceGroupManager) HandleTSORequest(keyspaceID uint32, dcLocation string, count uint32) (pdpb.Timestamp, uint32, error) {
+	kga, id, epoch, err := kgm.getAllocatorForKeyspace(keyspaceID)
+	if err != nil {
+		return pdpb.Timestamp{}, id, err
+	}
+	ts, err := kga.am.HandleTSORequest(dcLocation, count)
+	if err != nil {
+		return ts, id, err
+	}
+	current, currentID, currentEpoch, err := kgm.getAllocatorForKeyspace(keyspaceID)
+	if err != nil {
+		return pdpb.Timestamp{}, currentID, err
+	}
+	if current != kga || currentEpoch != epoch {
+		return pdpb.Timestamp{}, currentID, errs.ErrKeyspaceTransfer.FastGenByArgs(keyspaceID, currentID)
+	}
+	return ts, id, nil
+}
+
+// getAllocatorForKeyspace returns the allocator of the keyspace group to which the given
+// keyspace belongs, along with the keyspace group ID and its current epoch.
+func (kgm *KeyspaceGroupManager) getAllocatorForKeyspace(keyspaceID uint32) (*keyspaceGroupAllocator, uint32, uint64, error) {
+	kgm.mu.RLock()
+	defer kgm.mu.RUnlock()
+	id := kgm.getKeyspaceGroupIDLocked(keyspaceID)
+	if _, ok := kgm.mu.transferringKeyspaces[keyspaceID]; ok {
+		return nil, id, 0, errs.ErrKeyspaceTransfer.FastGenByArgs(keyspaceID, id)
+	}
+	kga, ok := kgm.mu.groups[id]
+	if !ok {
+		return nil, id, 0, errs.ErrGetAllocator.FastGenByArgs(fmt.Sprintf("keyspace group %d is not served", id))
+	}
+	return kga, id, kga.epoch, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/mcs/utils"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+)
+
+func TestKeyspaceGroupEpoch(t *testing.T) {
+	re := require.New(t)
+	kgm := &KeyspaceGroupManager{}
+	kgm.mu.groups = map[uint32]*keyspaceGroupAllocator{
+		utils.DefaultKeyspaceGroupID: {id: utils.DefaultKeyspaceGroupID},
+		1:                            {id: 1},
+	}
+	kgm.mu.memberships = make(map[uint32]*keyspaceGroupMembership)
+	kgm.mu.keyspaceLookupTable = make(map[uint32]uint32)
+	membership := func(id uint32, revision int64, keyspaces ...uint32) map[uint32]*keyspaceGroupMembership {
+		return map[uint32]*keyspaceGroupMembership{
+			id: {KeyspaceGroup: &endpoint.KeyspaceGroup{ID: id, Keyspaces: keyspaces}, revision: revision},
+		}
+	}
+	epochs := func() []uint64 {
+		return []uint64{kgm.mu.groups[utils.DefaultKeyspaceGroupID].epoch, kgm.mu.groups[1].epoch}
+	}
+
+	// The keyspace moved into the keyspace group 1 advances the epoch of the default one.
+	kgm.updateKeyspaceGroups(membership(1, 1, 10), 1)
+	re.Equal([]uint64{1, 0}, epochs())
+	kga, id, epoch, err := kgm.getAllocatorForKeyspace(10)
+	re.NoError(err)
+	re.Equal(uint32(1), id)
+	re.Same(kgm.mu.groups[1], kga)
+	re.Equal(uint64(0), epoch)
+
+	// Only the epoch of the keyspace group which the keyspace is moved out of is advanced.
+	kgm.updateKeyspaceGroups(membership(1, 2, 10, 11), 2)
+	re.Equal([]uint64{2, 0}, epochs())
+
+	// The keyspace moved back to the default keyspace group advances the epoch of the keyspace group 1.
+	kgm.updateKeyspaceGroups(membership(1, 3, 11), 3)
+	re.Equal([]uint64{2, 1}, epochs())
+	_, id, _, err = kgm.getAllocatorForKeyspace(10)
+	re.NoError(err)
+	re.Equal(utils.DefaultKeyspaceGroupID, id)
+
+	// The membership loaded earlier than the applied one is ignored.
+	kgm.updateKeyspaceGroups(membership(1, 1, 10), 1)
+	re.Equal([]uint64{2, 1}, epochs())
+}
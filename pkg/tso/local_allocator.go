@@ -65,6 +65,8 @@ func NewLocalTSOAllocator(
 			saveInterval:           am.saveInterval,
 			updatePhysicalInterval: am.updatePhysicalInterval,
 			maxResetTSGap:          am.maxResetTSGap,
+			clock:                  am.clock,
+			eventHub:               am.eventHub,
 			dcLocation:             dcLocation,
 			tsoMux:                 &tsoObject{},
 		},
@@ -54,6 +54,14 @@ var (
 			Name:      "role",
 			Help:      "Indicate the PD server role info, whether it's a TSO allocator.",
 		}, []string{dcLabel})
+
+	tsoClockUncertainty = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "tso",
+			Name:      "clock_uncertainty_seconds",
+			Help:      "The latest uncertainty bound of the TSO physical time source.",
+		})
 )
 
 func init() {
@@ -61,4 +69,5 @@ func init() {
 	prometheus.MustRegister(tsoGauge)
 	prometheus.MustRegister(tsoGap)
 	prometheus.MustRegister(tsoAllocatorRole)
+	prometheus.MustRegister(tsoClockUncertainty)
 }
@@ -0,0 +1,230 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// LocalTimeSource is the time source which uses the local wall clock.
+	LocalTimeSource = "local"
+	// ntpScheme is the URL scheme of the time source which samples an NTP server, e.g. ntp://pool.ntp.org.
+	ntpScheme = "ntp"
+
+	ntpDefaultPort    = "123"
+	ntpSampleInterval = time.Second
+	ntpQueryTimeout   = time.Second
+	ntpPacketSize     = 48
+	ntpEpochOffset    = 2208988800
+	ntpModeClient     = 3
+	ntpModeServer     = 4
+	ntpVersion        = 4
+	ntpMaxDriftRate   = 15e-6 // 15 PPM, the max frequency tolerance assumed by NTP.
+	ntpMaxUncertainty = time.Duration(math.MaxInt64)
+)
+
+// TimeSource is the source of the TSO physical time.
+type TimeSource interface {
+	// Now returns the current time and its uncertainty bound, which means
+	// the true time is within [now-uncertainty, now+uncertainty].
+	Now() (now time.Time, uncertainty time.Duration)
+}
+
+// NewTimeSource creates the TimeSource of the given config, which is either empty or "local"
+// for the local wall clock, or "ntp://host[:port]" to follow the time of an NTP server.
+// The NTP server is sampled in the background until the context is canceled.
+func NewTimeSource(ctx context.Context, source string) (TimeSource, error) {
+	if source == "" || source == LocalTimeSource {
+		return localClock{}, nil
+	}
+	u, err := url.Parse(source)
+	if err != nil || u.Scheme != ntpScheme || u.Host == "" {
+		return nil, errors.Errorf("invalid TSO time source %q, it should be %q or %s://host[:port]", source, LocalTimeSource, ntpScheme)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), ntpDefaultPort)
+	}
+	c := &ntpClock{addr: addr}
+	go c.sampleLoop(ctx)
+	return c, nil
+}
+
+// localClock is the default TimeSource, which uses the local wall clock without any uncertainty bound.
+type localClock struct{}
+
+// Now implements the TimeSource interface.
+func (localClock) Now() (time.Time, time.Duration) {
+	return time.Now(), 0
+}
+
+// ntpSample is the clock offset to the NTP server measured at a local time.
+type ntpSample struct {
+	at          time.Time
+	offset      time.Duration
+	uncertainty time.Duration
+}
+
+// ntpClock is the TimeSource which follows the time of an NTP server. The offset to the server is
+// sampled periodically, and the uncertainty grows with the max drift rate since the last sample.
+// The uncertainty is unbounded until the first sample succeeds.
+type ntpClock struct {
+	addr string
+	mu   struct {
+		syncutil.RWMutex
+		sample *ntpSample
+	}
+}
+
+// Now implements the TimeSource interface.
+func (c *ntpClock) Now() (time.Time, time.Duration) {
+	now := time.Now()
+	c.mu.RLock()
+	sample := c.mu.sample
+	c.mu.RUnlock()
+	if sample == nil {
+		return now, ntpMaxUncertainty
+	}
+	drift := time.Duration(float64(now.Sub(sample.at)) * ntpMaxDriftRate)
+	return now.Add(sample.offset), sample.uncertainty + drift
+}
+
+func (c *ntpClock) sampleLoop(ctx context.Context) {
+	defer logutil.LogPanic()
+
+	ticker := time.NewTicker(ntpSampleInterval)
+	defer ticker.Stop()
+	for {
+		sample, err := c.query()
+		if err != nil {
+			log.Warn("failed to sample the NTP server", zap.String("addr", c.addr), errs.ZapError(err))
+		} else {
+			c.mu.Lock()
+			c.mu.sample = sample
+			c.mu.Unlock()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// query measures the clock offset to the NTP server with an SNTP request, see RFC 4330.
+func (c *ntpClock) query() (*ntpSample, error) {
+	conn, err := net.DialTimeout("udp", c.addr, ntpQueryTimeout)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(ntpQueryTimeout)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	req := make([]byte, ntpPacketSize)
+	req[0] = ntpVersion<<3 | ntpModeClient
+	sent := time.Now()
+	origin := toNTPTime(sent)
+	binary.BigEndian.PutUint64(req[40:], origin)
+	if _, err := conn.Write(req); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	received := time.Now()
+	if n < ntpPacketSize || resp[0]&0x7 != ntpModeServer || resp[1] == 0 ||
+		binary.BigEndian.Uint64(resp[24:]) != origin {
+		return nil, errors.Errorf("invalid NTP response from %s", c.addr)
+	}
+
+	rootDelay := fromNTPShort(binary.BigEndian.Uint32(resp[4:]))
+	rootDispersion := fromNTPShort(binary.BigEndian.Uint32(resp[8:]))
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(resp[32:]))
+	serverSent := fromNTPTime(binary.BigEndian.Uint64(resp[40:]))
+	// The round trip time is measured by the monotonic clock.
+	delay := received.Sub(sent) - serverSent.Sub(serverReceived)
+	if delay < 0 {
+		delay = 0
+	}
+	return &ntpSample{
+		at:          received,
+		offset:      (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2,
+		uncertainty: delay/2 + rootDelay/2 + rootDispersion,
+	}, nil
+}
+
+func toNTPTime(t time.Time) uint64 {
+	secs := uint64(t.Unix() + ntpEpochOffset)
+	frac := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return secs<<32 | frac
+}
+
+func fromNTPTime(v uint64) time.Time {
+	secs := int64(v>>32) - ntpEpochOffset
+	nanos := (int64(v&0xffffffff) * int64(time.Second)) >> 32
+	return time.Unix(secs, nanos)
+}
+
+func fromNTPShort(v uint32) time.Duration {
+	return time.Duration((int64(v) * int64(time.Second)) >> 16)
+}
+
+// boundedClock provides the TSO physical time from the TimeSource and checks its uncertainty.
+type boundedClock struct {
+	source TimeSource
+	// maxUncertainty is the max clock uncertainty allowed to advance the TSO physical time.
+	// Zero means no limit.
+	maxUncertainty time.Duration
+	// uncertainty is the latest uncertainty bound returned by the source, stored as nanoseconds.
+	uncertainty atomic.Int64
+}
+
+func newBoundedClock(source TimeSource, maxUncertainty time.Duration) *boundedClock {
+	if source == nil {
+		source = localClock{}
+	}
+	return &boundedClock{source: source, maxUncertainty: maxUncertainty}
+}
+
+// now returns the current time from the source, and whether its uncertainty is within the bound.
+func (c *boundedClock) now() (time.Time, bool) {
+	now, uncertainty := c.source.Now()
+	c.uncertainty.Store(int64(uncertainty))
+	tsoClockUncertainty.Set(uncertainty.Seconds())
+	return now, c.maxUncertainty <= 0 || uncertainty <= c.maxUncertainty
+}
+
+// getUncertainty returns the latest uncertainty bound of the clock.
+func (c *boundedClock) getUncertainty() time.Duration {
+	return time.Duration(c.uncertainty.Load())
+}
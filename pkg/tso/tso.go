@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/election"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 	"github.com/tikv/pd/pkg/utils/tsoutil"
@@ -69,6 +70,13 @@ type timestampOracle struct {
 	saveInterval           time.Duration
 	updatePhysicalInterval time.Duration
 	maxResetTSGap          func() time.Duration
+	// clock provides the physical time and checks its uncertainty.
+	clock *boundedClock
+	// eventHub is used to alert the fallback of the system time.
+	eventHub *event.Hub
+	// timeFallback is whether the system time is behind the physical time of the TSO, it's used to
+	// alert only once for a fallback.
+	timeFallback atomic.Bool
 	// tso info stored in the memory
 	tsoMux *tsoObject
 	// last timestamp window stored in etcd
@@ -158,7 +166,14 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 		return err
 	}
 
-	next := time.Now()
+	next, withinBound := t.clock.now()
+	if !withinBound {
+		tsoCounter.WithLabelValues("err_clock_uncertainty_sync", t.dcLocation).Inc()
+		log.Error("clock uncertainty exceeds the threshold",
+			zap.Duration("uncertainty", t.clock.getUncertainty()), zap.Duration("max-uncertainty", t.clock.maxUncertainty),
+			errs.ZapError(errs.ErrIncorrectSystemTime))
+		return errs.ErrIncorrectSystemTime.FastGenByArgs()
+	}
 	failpoint.Inject("fallBackSync", func() {
 		next = next.Add(time.Hour)
 	})
@@ -169,6 +184,8 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	// the timestamp allocation will start from the saved etcd timestamp temporarily.
 	if typeutil.SubRealTimeByWallClock(next, last) < UpdateTimestampGuard {
 		log.Error("system time may be incorrect", zap.Time("last", last), zap.Time("next", next), errs.ZapError(errs.ErrIncorrectSystemTime))
+		t.timeFallback.Store(true)
+		t.alertTimeFallback(last, next)
 		next = last.Add(UpdateTimestampGuard)
 	}
 
@@ -186,6 +203,13 @@ func (t *timestampOracle) SyncTimestamp(leadership *election.Leadership) error {
 	return nil
 }
 
+func (t *timestampOracle) alertTimeFallback(prevPhysical, now time.Time) {
+	t.eventHub.Publish(event.TypeAlert, event.AlertTSOFallback, t.dcLocation, map[string]interface{}{
+		"prev-physical": prevPhysical,
+		"system-time":   now,
+	})
+}
+
 // isInitialized is used to check whether the timestampOracle is initialized.
 // There are two situations we have an uninitialized timestampOracle:
 // 1. When the SyncTimestamp has not been called yet.
@@ -273,7 +297,7 @@ func (t *timestampOracle) UpdateTimestamp(leadership *election.Leadership) error
 	tsoGauge.WithLabelValues("tso", t.dcLocation).Set(float64(prevPhysical.UnixNano() / int64(time.Millisecond)))
 	tsoGap.WithLabelValues(t.dcLocation).Set(float64(time.Since(prevPhysical).Milliseconds()))
 
-	now := time.Now()
+	now, withinBound := t.clock.now()
 	failpoint.Inject("fallBackUpdate", func() {
 		now = now.Add(time.Hour)
 	})
@@ -291,9 +315,23 @@ func (t *timestampOracle) UpdateTimestamp(leadership *election.Leadership) error
 
 	if jetLag < 0 {
 		tsoCounter.WithLabelValues("system_time_slow", t.dcLocation).Inc()
+		if t.timeFallback.CompareAndSwap(false, true) {
+			t.alertTimeFallback(prevPhysical, now)
+		}
+	} else {
+		t.timeFallback.Store(false)
 	}
 
 	var next time.Time
+	// If the clock uncertainty exceeds the threshold, the physical time is not safe to be advanced.
+	// The allocation will be slowed down and finally rejected once the logical time is used up.
+	if !withinBound {
+		log.Warn("clock uncertainty exceeds the threshold, stop advancing the physical time",
+			zap.Duration("uncertainty", t.clock.getUncertainty()), zap.Duration("max-uncertainty", t.clock.maxUncertainty),
+			zap.Time("prev-physical", prevPhysical), zap.Int64("prev-logical", prevLogical))
+		tsoCounter.WithLabelValues("clock_uncertainty_exceeded", t.dcLocation).Inc()
+		return nil
+	}
 	// If the system time is greater, it will be synchronized with the system time.
 	if jetLag > UpdateTimestampGuard {
 		next = now
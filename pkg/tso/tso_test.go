@@ -0,0 +1,160 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+type mockTimeSource struct {
+	now         time.Time
+	uncertainty time.Duration
+}
+
+func (m *mockTimeSource) Now() (time.Time, time.Duration) {
+	return m.now, m.uncertainty
+}
+
+func TestClockUncertainty(t *testing.T) {
+	re := require.New(t)
+	source := &mockTimeSource{now: time.Now()}
+	oracle := &timestampOracle{
+		storage:                storage.NewStorageWithMemoryBackend(),
+		saveInterval:           3 * time.Second,
+		updatePhysicalInterval: 50 * time.Millisecond,
+		maxResetTSGap:          func() time.Duration { return time.Hour },
+		clock:                  newBoundedClock(source, 100*time.Millisecond),
+		dcLocation:             GlobalDCLocation,
+		tsoMux:                 &tsoObject{},
+	}
+
+	// The TSO can not be initialized with an uncertain clock.
+	source.uncertainty = time.Second
+	re.Error(oracle.SyncTimestamp(nil))
+	re.False(oracle.isInitialized())
+	source.uncertainty = 10 * time.Millisecond
+	re.NoError(oracle.SyncTimestamp(nil))
+	physical, _ := oracle.getTSO()
+	re.True(physical.Equal(source.now))
+	re.Equal(10*time.Millisecond, oracle.clock.getUncertainty())
+
+	// The physical time follows the time source within the uncertainty bound.
+	source.now = source.now.Add(100 * time.Millisecond)
+	re.NoError(oracle.UpdateTimestamp(nil))
+	physical, _ = oracle.getTSO()
+	re.True(physical.Equal(source.now))
+
+	// The physical time stops advancing once the uncertainty exceeds the threshold,
+	// even if the logical time is going to be used up.
+	last := source.now
+	source.now = source.now.Add(100 * time.Millisecond)
+	source.uncertainty = time.Second
+	oracle.tsoMux.logical = maxLogical - 1
+	re.NoError(oracle.UpdateTimestamp(nil))
+	physical, _ = oracle.getTSO()
+	re.True(physical.Equal(last))
+	re.Equal(time.Second, oracle.clock.getUncertainty())
+
+	// Recover after the uncertainty becomes small again.
+	source.uncertainty = 0
+	re.NoError(oracle.UpdateTimestamp(nil))
+	physical, logical := oracle.getTSO()
+	re.True(physical.Equal(source.now))
+	re.Zero(logical)
+}
+
+func TestLocalClock(t *testing.T) {
+	re := require.New(t)
+	clock := newBoundedClock(nil, 0)
+	now, withinBound := clock.now()
+	re.True(withinBound)
+	re.WithinDuration(time.Now(), now, time.Second)
+	re.Zero(clock.getUncertainty())
+}
+
+func TestNewTimeSource(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, source := range []string{"", LocalTimeSource} {
+		clock, err := NewTimeSource(ctx, source)
+		re.NoError(err)
+		re.Equal(localClock{}, clock)
+	}
+	for _, source := range []string{"remote", "http://127.0.0.1:123", "ntp://"} {
+		_, err := NewTimeSource(ctx, source)
+		re.Error(err, source)
+	}
+	clock, err := NewTimeSource(ctx, "ntp://127.0.0.1")
+	re.NoError(err)
+	re.Equal("127.0.0.1:123", clock.(*ntpClock).addr)
+}
+
+// serveNTP serves the SNTP requests with the time ahead of the local clock by the offset.
+func serveNTP(conn net.PacketConn, offset time.Duration, rootDispersion time.Duration) {
+	buf := make([]byte, ntpPacketSize)
+	for {
+		_, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		now := toNTPTime(time.Now().Add(offset))
+		resp := make([]byte, ntpPacketSize)
+		resp[0] = ntpVersion<<3 | ntpModeServer
+		resp[1] = 1
+		binary.BigEndian.PutUint32(resp[8:], uint32(rootDispersion*(1<<16)/time.Second))
+		copy(resp[24:32], buf[40:48])
+		binary.BigEndian.PutUint64(resp[32:], now)
+		binary.BigEndian.PutUint64(resp[40:], now)
+		_, _ = conn.WriteTo(resp, addr)
+	}
+}
+
+func TestNTPClock(t *testing.T) {
+	re := require.New(t)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	re.NoError(err)
+	defer conn.Close()
+	go serveNTP(conn, time.Hour, 20*time.Millisecond)
+
+	clock := &ntpClock{addr: conn.LocalAddr().String()}
+	// The uncertainty is unbounded before the first sample.
+	now, uncertainty := clock.Now()
+	re.WithinDuration(time.Now(), now, time.Second)
+	re.Equal(ntpMaxUncertainty, uncertainty)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go clock.sampleLoop(ctx)
+	re.Eventually(func() bool {
+		_, uncertainty = clock.Now()
+		return uncertainty != ntpMaxUncertainty
+	}, 5*time.Second, 10*time.Millisecond)
+	now, uncertainty = clock.Now()
+	re.WithinDuration(time.Now().Add(time.Hour), now, 100*time.Millisecond)
+	re.GreaterOrEqual(uncertainty, 20*time.Millisecond)
+	re.Less(uncertainty, 100*time.Millisecond)
+
+	// The query fails if the server is unreachable.
+	_, err = (&ntpClock{addr: "127.0.0.1:1"}).query()
+	re.Error(err)
+}
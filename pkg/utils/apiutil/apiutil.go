@@ -17,6 +17,7 @@ package apiutil
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -36,6 +37,7 @@ import (
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/unrolled/render"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
@@ -132,6 +134,16 @@ func GetComponentNameOnHTTP(r *http.Request) string {
 	return componentName
 }
 
+// GetComponentNameOnGRPC returns component name from the gRPC metadata
+func GetComponentNameOnGRPC(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if names := md.Get(componentSignatureKey); len(names) > 0 && len(names[0]) > 0 {
+			return names[0]
+		}
+	}
+	return componentAnonymousValue
+}
+
 // ComponentSignatureRoundTripper is used to add component signature in HTTP header
 type ComponentSignatureRoundTripper struct {
 	proxied   http.RoundTripper
@@ -422,12 +434,17 @@ func (p *customReverseProxies) ServeHTTP(w http.ResponseWriter, r *http.Request)
 
 		copyHeader(w.Header(), resp.Header)
 		w.WriteHeader(resp.StatusCode)
-		for {
-			if _, err = io.CopyN(w, reader, chunkSize); err != nil {
-				if err == io.EOF {
-					err = nil
+		if flusher, ok := w.(http.Flusher); ok && strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+			// The event stream never ends, so each event needs to be flushed as soon as it's received.
+			err = copyAndFlush(w, flusher, reader)
+		} else {
+			for {
+				if _, err = io.CopyN(w, reader, chunkSize); err != nil {
+					if err == io.EOF {
+						err = nil
+					}
+					break
 				}
-				break
 			}
 		}
 		if err != nil {
@@ -440,6 +457,25 @@ func (p *customReverseProxies) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	http.Error(w, ErrRedirectFailed, http.StatusInternalServerError)
 }
 
+func copyAndFlush(w io.Writer, flusher http.Flusher, reader io.Reader) error {
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			flusher.Flush()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func copyHeader(dst, src http.Header) {
 	for k, vv := range src {
 		values := dst[k]
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/log"
 	bs "github.com/tikv/pd/pkg/basicserver"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"go.uber.org/zap"
 )
@@ -51,6 +52,7 @@ func ServiceRedirector() gin.HandlerFunc {
 		}
 
 		c.Request.Header.Set(ServiceRedirectorHeader, svr.Name())
+		rbac.ForwardSubjects(c.Request, svr.Name())
 
 		primary := svr.GetPrimary()
 		if primary == nil {
@@ -74,3 +76,17 @@ func ServiceRedirector() gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// AccessChecker is a middleware to check whether the client is allowed to access the service, if
+// the server which the service is installed in supports the access control.
+func AccessChecker(service string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ac, ok := c.MustGet("service").(bs.AccessController); ok {
+			if err := ac.CheckHTTPPermission(c.Request, service); err != nil {
+				c.AbortWithStatusJSON(http.StatusForbidden, err.Error())
+				return
+			}
+		}
+		c.Next()
+	}
+}
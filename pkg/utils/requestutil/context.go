@@ -16,6 +16,7 @@ package requestutil
 
 import (
 	"context"
+	"time"
 )
 
 // The key type is unexported to prevent collisions
@@ -26,6 +27,8 @@ const (
 	requestInfoKey key = iota
 	// endTimeKey is the context key for the end time.
 	endTimeKey
+	// responseInfoKey is the context key for the response info.
+	responseInfoKey
 )
 
 // WithRequestInfo returns a copy of parent in which the request info value is set
@@ -49,3 +52,20 @@ func EndTimeFrom(ctx context.Context) (int64, bool) {
 	info, ok := ctx.Value(endTimeKey).(int64)
 	return info, ok
 }
+
+// ResponseInfo is the information of the response, which is set after the handler.
+type ResponseInfo struct {
+	StatusCode int
+	Latency    time.Duration
+}
+
+// WithResponseInfo returns a copy of parent in which the response info value is set
+func WithResponseInfo(parent context.Context, responseInfo ResponseInfo) context.Context {
+	return context.WithValue(parent, responseInfoKey, responseInfo)
+}
+
+// ResponseInfoFrom returns the value of the response info key on the ctx
+func ResponseInfoFrom(ctx context.Context) (ResponseInfo, bool) {
+	info, ok := ctx.Value(responseInfoKey).(ResponseInfo)
+	return info, ok
+}
@@ -65,3 +65,16 @@ func TestEndTime(t *testing.T) {
 	re.True(ok)
 	re.Equal(timeNow, result)
 }
+
+func TestResponseInfo(t *testing.T) {
+	t.Parallel()
+	re := require.New(t)
+	ctx := context.Background()
+	_, ok := ResponseInfoFrom(ctx)
+	re.False(ok)
+	ctx = WithResponseInfo(ctx, ResponseInfo{StatusCode: http.StatusOK, Latency: time.Second})
+	result, ok := ResponseInfoFrom(ctx)
+	re.True(ok)
+	re.Equal(http.StatusOK, result.StatusCode)
+	re.Equal(time.Second, result.Latency)
+}
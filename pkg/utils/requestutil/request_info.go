@@ -16,16 +16,21 @@ package requestutil
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/tikv/pd/pkg/utils/apiutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 )
 
-// RequestInfo holds service information from http.Request
+// RequestInfo holds service information from http.Request or the gRPC request
 type RequestInfo struct {
 	ServiceLabel   string
 	Method         string
@@ -55,6 +60,26 @@ func GetRequestInfo(r *http.Request) RequestInfo {
 	}
 }
 
+// GetGRPCRequestInfo returns request info needed from the gRPC request, the service label
+// is the full method name without the leading slash like "pdpb.PD/PutStore".
+func GetGRPCRequestInfo(ctx context.Context, req fmt.Stringer) RequestInfo {
+	method, _ := grpc.Method(ctx)
+	method = strings.TrimPrefix(method, "/")
+	info := RequestInfo{
+		ServiceLabel:   method,
+		Method:         "gRPC:" + method,
+		Component:      apiutil.GetComponentNameOnGRPC(ctx),
+		BodyParam:      req.String(),
+		StartTimeStamp: time.Now().Unix(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if ip, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			info.IP = ip
+		}
+	}
+	return info
+}
+
 func getURLParam(r *http.Request) string {
 	buf, err := json.Marshal(r.URL.Query())
 	if err != nil {
@@ -70,8 +70,17 @@ func (h *adminHandler) DeleteAllRegionCache(w http.ResponseWriter, r *http.Reque
 	h.rd.JSON(w, http.StatusOK, "All regions are removed from server cache.")
 }
 
-// Intentionally no swagger mark as it is supposed to be only used in
-// server-to-server. For security reason, it only accepts JSON formatted data.
+// It is supposed to be only used in server-to-server. For security reason, it
+// only accepts JSON formatted data.
+// @Tags     admin
+// @Summary  Save a file in the data directory of the PD server.
+// @Param    file_name  path  string  true  "The name of the file"
+// @Param    body       body  object  true  "The content of the file in JSON format"
+// @Produce  plain
+// @Success  200  {string}  string  "The file is saved."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /admin/persist-file/{file_name} [post]
 func (h *adminHandler) SavePersistFile(w http.ResponseWriter, r *http.Request) {
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -91,6 +100,12 @@ func (h *adminHandler) SavePersistFile(w http.ResponseWriter, r *http.Request) {
 	h.rd.Text(w, http.StatusOK, "")
 }
 
+// @Tags     admin
+// @Summary  Mark the cluster as recovering from a snapshot.
+// @Produce  plain
+// @Success  200  {string}  string  "The cluster is marked."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /admin/cluster/markers/snapshot-recovering [post]
 func (h *adminHandler) MarkSnapshotRecovering(w http.ResponseWriter, r *http.Request) {
 	if err := h.svr.MarkSnapshotRecovering(); err != nil {
 		_ = h.rd.Text(w, http.StatusInternalServerError, err.Error())
@@ -99,18 +114,31 @@ func (h *adminHandler) MarkSnapshotRecovering(w http.ResponseWriter, r *http.Req
 	_ = h.rd.Text(w, http.StatusOK, "")
 }
 
+type snapshotRecoveringStatus struct {
+	Marked bool `json:"marked"`
+}
+
+// @Tags     admin
+// @Summary  Get whether the cluster is recovering from a snapshot.
+// @Produce  json
+// @Success  200  {object}  snapshotRecoveringStatus
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /admin/cluster/markers/snapshot-recovering [get]
 func (h *adminHandler) IsSnapshotRecovering(w http.ResponseWriter, r *http.Request) {
 	marked, err := h.svr.IsSnapshotRecovering(r.Context())
 	if err != nil {
 		_ = h.rd.Text(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	type resStruct struct {
-		Marked bool `json:"marked"`
-	}
-	_ = h.rd.JSON(w, http.StatusOK, &resStruct{Marked: marked})
+	_ = h.rd.JSON(w, http.StatusOK, &snapshotRecoveringStatus{Marked: marked})
 }
 
+// @Tags     admin
+// @Summary  Unmark the cluster as recovering from a snapshot.
+// @Produce  plain
+// @Success  200  {string}  string  "The cluster is unmarked."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /admin/cluster/markers/snapshot-recovering [delete]
 func (h *adminHandler) UnmarkSnapshotRecovering(w http.ResponseWriter, r *http.Request) {
 	if err := h.svr.UnmarkSnapshotRecovering(r.Context()); err != nil {
 		_ = h.rd.Text(w, http.StatusInternalServerError, err.Error())
@@ -121,6 +149,17 @@ func (h *adminHandler) UnmarkSnapshotRecovering(w http.ResponseWriter, r *http.R
 
 // RecoverAllocID recover base alloc id
 // body should be in {"id": "123"} format
+// @Tags     admin
+// @Summary  Recover the base of the allocated IDs when the cluster is recovering from a snapshot.
+// @Accept   json
+// @Param    body  body  object  true  "The base ID in the format {"id": "123"}"
+// @Produce  plain
+// @Success  200  {string}  string  "The base ID is recovered."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  403  {string}  string  "The cluster isn't recovering from a snapshot."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Failure  503  {string}  string  "The leader of PD isn't elected."
+// @Router   /admin/base-alloc-id [post]
 func (h *adminHandler) RecoverAllocID(w http.ResponseWriter, r *http.Request) {
 	var input map[string]interface{}
 	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
@@ -0,0 +1,140 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/audit"
+	"github.com/tikv/pd/pkg/utils/apiutil/serverapi"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/cluster"
+	"github.com/unrolled/render"
+)
+
+type auditHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newAuditHandler(svr *server.Server, rd *render.Render) *auditHandler {
+	return &auditHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// @Tags     admin
+// @Summary  Query the audit records of the configuration changes and admin operations. The records are kept by each server, so the leader collects the records from all the members, and only the records of the server itself are returned if the header PD-Allow-follower-handle is set.
+// @Param    start_time  query  integer  false  "Start time in unix seconds"
+// @Param    end_time    query  integer  false  "End time in unix seconds"
+// @Param    ip          query  string   false  "IP of the caller"
+// @Param    component   query  string   false  "Component of the caller"
+// @Param    service     query  string   false  "Service label of the route"
+// @Param    limit       query  integer  false  "Max number of the records, default 1000"
+// @Produce  json
+// @Success  200  {array}   audit.Record
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /admin/audit [get]
+func (h *auditHandler) GetAuditRecords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := &audit.RecordFilter{
+		IP:        query.Get("ip"),
+		Component: query.Get("component"),
+		Service:   query.Get("service"),
+	}
+	for name, t := range map[string]*time.Time{"start_time": &filter.StartTime, "end_time": &filter.EndTime} {
+		if v := query.Get(name); v != "" {
+			sec, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				h.rd.JSON(w, http.StatusBadRequest, "invalid "+name)
+				return
+			}
+			*t = time.Unix(sec, 0)
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = limit
+	}
+	records, err := h.svr.GetDurableAuditBackend().Query(filter)
+	if err == nil && len(r.Header.Get(serverapi.PDAllowFollowerHandle)) == 0 {
+		records, err = h.collectMemberRecords(r, records, filter.Limit)
+	}
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, records)
+}
+
+// collectMemberRecords queries the records of the other members with the same filter, and merges
+// them with the local records in time order.
+func (h *auditHandler) collectMemberRecords(r *http.Request, records []*audit.Record, limit int) ([]*audit.Record, error) {
+	members, err := cluster.GetMembers(h.svr.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.GetMemberId() == h.svr.GetMember().ID() || len(member.GetClientUrls()) == 0 {
+			continue
+		}
+		memberRecords, err := h.queryMemberRecords(r, member.GetClientUrls()[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query the audit records of member %s", member.GetName())
+		}
+		records = append(records, memberRecords...)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	if limit <= 0 {
+		limit = audit.DefaultQueryLimit
+	}
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+func (h *auditHandler) queryMemberRecords(r *http.Request, clientURL string) ([]*audit.Record, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, clientURL+r.URL.RequestURI(), http.NoBody)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set(serverapi.PDAllowFollowerHandle, "true")
+	h.svr.GetRBACManager().ForwardClientSubjects(r, req, h.svr.Name())
+	resp, err := h.svr.GetHTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", resp.Status)
+	}
+	var records []*audit.Record
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return records, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/audit"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+type auditTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	suite.Run(t, new(auditTestSuite))
+}
+
+func (suite *auditTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1", addr, apiPrefix)
+
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *auditTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+func (suite *auditTestSuite) TestAuditRecords() {
+	re := suite.Require()
+	start := time.Now().Add(-time.Second).Unix()
+	postData, err := json.Marshal(map[string]interface{}{"max-replicas": 5})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/config", postData, tu.StatusOK(re)))
+	postData, err = json.Marshal(map[string]interface{}{"max-replicas": -1})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/config", postData, tu.Status(re, http.StatusBadRequest)))
+	// the read-only requests aren't recorded.
+	suite.NoError(tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/config", nil, tu.StatusOK(re)))
+
+	var records []*audit.Record
+	url := fmt.Sprintf("%s/admin/audit?start_time=%d&service=SetConfig", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Len(records, 2)
+	suite.Equal(http.MethodPost, records[0].Method)
+	suite.Equal(http.StatusOK, records[0].StatusCode)
+	suite.NotEmpty(records[0].BodyDigest)
+	suite.Equal(http.StatusBadRequest, records[1].StatusCode)
+
+	url = fmt.Sprintf("%s/admin/audit?start_time=%d&limit=1", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Len(records, 1)
+	url = fmt.Sprintf("%s/admin/audit?end_time=%d", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Empty(records)
+	url = fmt.Sprintf("%s/admin/audit?start_time=%d&component=unknown", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Empty(records)
+	suite.NoError(tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/admin/audit?limit=x", nil, tu.Status(re, http.StatusBadRequest)))
+}
+
+func (suite *auditTestSuite) TestGRPCAuditRecords() {
+	re := suite.Require()
+	start := time.Now().Add(-time.Second).Unix()
+	grpcPDClient := tu.MustNewGrpcClient(re, suite.svr.GetAddr())
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "component", "tidb")
+	_, err := grpcPDClient.UpdateServiceGCSafePoint(ctx, &pdpb.UpdateServiceGCSafePointRequest{
+		Header:    &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()},
+		ServiceId: []byte("audit-test"),
+		TTL:       60,
+		SafePoint: 1,
+	})
+	suite.NoError(err)
+	// the read-only methods aren't recorded.
+	_, err = grpcPDClient.GetGCSafePoint(ctx, &pdpb.GetGCSafePointRequest{
+		Header: &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()},
+	})
+	suite.NoError(err)
+
+	var records []*audit.Record
+	url := fmt.Sprintf("%s/admin/audit?start_time=%d&component=tidb", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Len(records, 1)
+	suite.Equal("pdpb.PD/UpdateServiceGCSafePoint", records[0].Service)
+	suite.Equal("gRPC", records[0].Method)
+	suite.Equal(int(codes.OK), records[0].StatusCode)
+
+	// the error in the response header is converted to the gRPC code.
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "component", "tidb-scatter")
+	resp, err := grpcPDClient.ScatterRegion(ctx, &pdpb.ScatterRegionRequest{
+		Header:   &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()},
+		RegionId: 10000,
+	})
+	suite.NoError(err)
+	suite.Equal(pdpb.ErrorType_REGION_NOT_FOUND, resp.GetHeader().GetError().GetType())
+	url = fmt.Sprintf("%s/admin/audit?start_time=%d&component=tidb-scatter", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Len(records, 1)
+	suite.Equal(int(codes.NotFound), records[0].StatusCode)
+
+	// the audit backend labels of the gRPC method can be overridden.
+	postData, err := json.Marshal(map[string]interface{}{
+		"audit.grpc-backend-labels": map[string][]string{"pdpb.PD/UpdateServiceGCSafePoint": {}},
+	})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/service-middleware/config", postData, tu.StatusOK(re)))
+	defer func() {
+		cfg := suite.svr.GetAuditConfig()
+		cfg.GRPCBackendLabels = nil
+		suite.NoError(suite.svr.SetAuditConfig(*cfg))
+	}()
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "component", "tidb-disabled")
+	_, err = grpcPDClient.UpdateServiceGCSafePoint(ctx, &pdpb.UpdateServiceGCSafePointRequest{
+		Header:    &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()},
+		ServiceId: []byte("audit-test"),
+		TTL:       60,
+		SafePoint: 1,
+	})
+	suite.NoError(err)
+	url = fmt.Sprintf("%s/admin/audit?start_time=%d&component=tidb-disabled", suite.urlPrefix, start)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &records))
+	suite.Empty(records)
+	postData, err = json.Marshal(map[string]interface{}{
+		"audit.grpc-backend-labels": map[string][]string{"pdpb.PD/PutStore": {"unknown"}},
+	})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/service-middleware/config", postData, tu.Status(re, http.StatusBadRequest)))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/errcode"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/schedule/maintenance"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/jsonutil"
 	"github.com/tikv/pd/pkg/utils/logutil"
@@ -283,10 +284,31 @@ func (h *confHandler) GetScheduleConfig(w http.ResponseWriter, r *http.Request)
 	h.rd.JSON(w, http.StatusOK, cfg)
 }
 
+// @Tags     config
+// @Summary  Get the maintenance status of the schedulers and checkers restricted by the maintenance windows or blackout periods.
+// @Produce  json
+// @Success  200  {array}  maintenance.Status
+// @Router   /config/maintenance-windows [get]
+func (h *confHandler) GetMaintenanceWindowStatus(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, getMaintenanceStatus(h.svr))
+}
+
+func getMaintenanceStatus(svr *server.Server) []*maintenance.Status {
+	opts := svr.GetPersistOptions()
+	windows, blackouts := opts.GetMaintenanceWindows(), opts.GetBlackoutPeriods()
+	now := time.Now()
+	targets := maintenance.GetTargets(windows, blackouts)
+	status := make([]*maintenance.Status, 0, len(targets))
+	for _, target := range targets {
+		status = append(status, maintenance.GetStatus(windows, blackouts, target, now))
+	}
+	return status
+}
+
 // @Tags     config
 // @Summary  Update a schedule config item.
 // @Accept   json
-// @Param    body  body  object  string  "json params"
+// @Param    body  body  object  true    "json params"
 // @Produce  json
 // @Success  200  {string}  string  "The config is updated."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -347,7 +369,7 @@ func (h *confHandler) GetReplicationConfig(w http.ResponseWriter, r *http.Reques
 // @Tags     config
 // @Summary  Update a replication config item.
 // @Accept   json
-// @Param    body  body  object  string  "json params"
+// @Param    body  body  object  true    "json params"
 // @Produce  json
 // @Success  200  {string}  string  "The config is updated."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -379,7 +401,7 @@ func (h *confHandler) GetLabelPropertyConfig(w http.ResponseWriter, r *http.Requ
 // @Tags     config
 // @Summary  Update label property config item.
 // @Accept   json
-// @Param    body  body  object  string  "json params"
+// @Param    body  body  object  true    "json params"
 // @Produce  json
 // @Success  200  {string}  string  "The config is updated."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
@@ -419,7 +441,7 @@ func (h *confHandler) GetClusterVersion(w http.ResponseWriter, r *http.Request)
 // @Tags     config
 // @Summary  Update cluster version.
 // @Accept   json
-// @Param    body  body  object  string  "json params"
+// @Param    body  body  object  true    "json params"
 // @Produce  json
 // @Success  200  {string}  string  "The cluster version is updated."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
@@ -456,7 +478,7 @@ func (h *confHandler) GetReplicationModeConfig(w http.ResponseWriter, r *http.Re
 // @Tags     config
 // @Summary  Set replication mode config.
 // @Accept   json
-// @Param    body  body  object  string  "json params"
+// @Param    body  body  object  true    "json params"
 // @Produce  json
 // @Success  200  {string}  string  "The replication mode config is updated."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
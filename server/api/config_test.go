@@ -17,10 +17,13 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/schedule/maintenance"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/pkg/versioninfo"
@@ -176,6 +179,54 @@ func (suite *configTestSuite) TestConfigSchedule() {
 	sc1 := &config.ScheduleConfig{}
 	suite.NoError(tu.ReadGetJSON(re, testDialClient, addr, sc1))
 	suite.Equal(*sc1, *sc)
+
+	postData, err = json.Marshal(map[string]interface{}{"cluster-movement-limit": "10GiB", "zone-movement-limit": "2GiB"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, addr, sc1))
+	suite.Equal(typeutil.ByteSize(10*units.GiB), sc1.ClusterMovementLimit)
+	suite.Equal(typeutil.ByteSize(2*units.GiB), sc1.ZoneMovementLimit)
+	suite.Equal(uint64(2*1024), suite.svr.GetPersistOptions().GetZoneMovementLimit())
+}
+
+func (suite *configTestSuite) TestConfigMaintenanceWindows() {
+	re := suite.Require()
+	addr := fmt.Sprintf("%s/config/schedule", suite.urlPrefix)
+	now := time.Now().UTC()
+	windows := []maintenance.Window{{Targets: []string{"balance-region", "merge"}, Start: "00:00", End: "00:01"}}
+	if now.Hour() == 0 && now.Minute() == 0 {
+		windows[0].Start, windows[0].End = "00:01", "00:02"
+	}
+	blackouts := []maintenance.Blackout{{Targets: []string{"balance-leader"}, Start: now.Add(-time.Hour), End: now.Add(time.Hour)}}
+	postData, err := json.Marshal(map[string]interface{}{"maintenance-windows": windows, "blackout-periods": blackouts})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	sc := &config.ScheduleConfig{}
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, addr, sc))
+	suite.Equal(windows, sc.MaintenanceWindows)
+	suite.Len(sc.BlackoutPeriods, 1)
+
+	var status []*maintenance.Status
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, addr[:len(addr)-len("schedule")]+"maintenance-windows", &status))
+	suite.Len(status, 3)
+	for _, s := range status {
+		suite.False(s.Allowed)
+		suite.NotNil(s.NextTransition)
+	}
+	suite.Equal("balance-leader", status[0].Target)
+	suite.Contains(status[0].ActiveWindow, "blackout")
+	suite.False(suite.svr.GetPersistOptions().IsInMaintenanceWindow("merge"))
+	suite.True(suite.svr.GetPersistOptions().IsInMaintenanceWindow("split"))
+
+	// The repair checkers can't be restricted.
+	postData, err = json.Marshal(map[string]interface{}{"maintenance-windows": []maintenance.Window{{Targets: []string{"replica"}, Start: "00:00", End: "06:00"}}})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.Status(re, http.StatusInternalServerError)))
+
+	postData, err = json.Marshal(map[string]interface{}{"maintenance-windows": []maintenance.Window{}, "blackout-periods": []maintenance.Blackout{}})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	suite.True(suite.svr.GetPersistOptions().IsInMaintenanceWindow("merge"))
 }
 
 func (suite *configTestSuite) TestConfigReplication() {
@@ -36,6 +36,14 @@ func newDiagnosticHandler(svr *server.Server, rd *render.Render) *diagnosticHand
 	}
 }
 
+// @Tags     scheduler
+// @Summary  Get the diagnostic result of a scheduler.
+// @Param    name  path  string  true  "The name of the scheduler"
+// @Produce  json
+// @Success  200  {object}  cluster.DiagnosticResult
+// @Failure  400  {string}  string  "The scheduler can't be diagnosed."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/diagnostic/{name} [get]
 func (h *diagnosticHandler) GetDiagnosticResult(w http.ResponseWriter, r *http.Request) {
 	name := mux.Vars(r)["name"]
 	if _, ok := cluster.DiagnosableSummaryFunc[name]; !ok {
@@ -0,0 +1,134 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
+	"github.com/tikv/pd/pkg/slice"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+// eventKeepaliveInterval is the interval to send the comments to keep the idle stream alive.
+const eventKeepaliveInterval = 15 * time.Second
+
+// eventHandler serves the events as the server-sent events. The same events are also served by the
+// gRPC service server.EventServer.
+type eventHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newEventHandler(svr *server.Server, rd *render.Render) *eventHandler {
+	return &eventHandler{
+		svr: svr,
+		rd:  rd,
+	}
+}
+
+// @Tags     event
+// @Summary  Subscribe the state changes of the cluster as the server-sent events.
+// @Param    types         query   string  false  "Comma separated event types: store, operator, scheduler, leader, placement-rule, label-rule, keyspace, alert"
+// @Param    actions       query   string  false  "Comma separated event actions, such as offline, create, timeout"
+// @Param    subject       query   string  false  "Subject of the events, such as the store ID or the scheduler name"
+// @Param    resume_token  query   string  false  "Resume after the event with the ID, the Last-Event-ID header is preferred"
+// @Produce  text/event-stream
+// @Success  200  {object}  event.Event
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  410  {string}  string  "The events after the resume token are not available."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /events [get]
+func (h *eventHandler) SubscribeEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := event.Filter{Subject: query.Get("subject")}
+	for _, typ := range splitQueryList(query.Get("types")) {
+		if !slice.Contains(event.Types, event.Type(typ)) {
+			h.rd.JSON(w, http.StatusBadRequest, fmt.Sprintf("unknown event type %s", typ))
+			return
+		}
+		filter.Types = append(filter.Types, event.Type(typ))
+	}
+	filter.Actions = splitQueryList(query.Get("actions"))
+	token := r.Header.Get("Last-Event-ID")
+	if token == "" {
+		token = query.Get("resume_token")
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.rd.JSON(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	hub := h.svr.GetEventHub()
+	sub, err := hub.Subscribe(filter, token)
+	if err != nil {
+		if errs.ErrEventResumeToken.Equal(err) {
+			h.rd.JSON(w, http.StatusGone, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer hub.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventKeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-sub.Events():
+			if !ok {
+				// The subscriber is too slow, it can reconnect with the ID of the last received event.
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func splitQueryList(v string) []string {
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
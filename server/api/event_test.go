@@ -0,0 +1,202 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/event"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+type eventTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func TestEventTestSuite(t *testing.T) {
+	suite.Run(t, new(eventTestSuite))
+}
+
+func (suite *eventTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1", addr, apiPrefix)
+
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *eventTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+// subscribe returns the response of the event stream and the function to read the next event.
+func (suite *eventTestSuite) subscribe(ctx context.Context, query, lastEventID string) (*http.Response, func() *event.Event) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, suite.urlPrefix+"/events"+query, nil)
+	suite.NoError(err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := testDialClient.Do(req)
+	suite.NoError(err)
+	reader := bufio.NewReader(resp.Body)
+	return resp, func() *event.Event {
+		var id, data string
+		for {
+			line, err := reader.ReadString('\n')
+			suite.NoError(err)
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "" && data != "":
+				e := &event.Event{}
+				suite.NoError(json.Unmarshal([]byte(data), e))
+				suite.Equal(id, e.ID)
+				return e
+			}
+		}
+	}
+}
+
+func (suite *eventTestSuite) TestSubscribeEvents() {
+	re := suite.Require()
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, next := suite.subscribe(ctx, "?types=scheduler,keyspace&subject=shuffle-leader-scheduler", "")
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	schedulersURL := suite.urlPrefix + "/schedulers"
+	input, err := json.Marshal(map[string]interface{}{"name": "shuffle-leader-scheduler"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, schedulersURL, input, tu.StatusOK(re)))
+	for _, delay := range []int{100, 0} {
+		input, err = json.Marshal(map[string]interface{}{"delay": delay})
+		suite.NoError(err)
+		suite.NoError(tu.CheckPostJSON(testDialClient, schedulersURL+"/shuffle-leader-scheduler", input, tu.StatusOK(re)))
+	}
+	_, err = apiutil.DoDelete(testDialClient, schedulersURL+"/shuffle-leader-scheduler")
+	suite.NoError(err)
+
+	var events []*event.Event
+	for _, action := range []string{"add", "pause", "resume", "remove"} {
+		e := next()
+		suite.Equal(event.TypeScheduler, e.Type)
+		suite.Equal(action, e.Action)
+		suite.Equal("shuffle-leader-scheduler", e.Subject)
+		events = append(events, e)
+	}
+	cancel()
+	resp.Body.Close()
+
+	// resume the subscription after the paused event.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	resp, next = suite.subscribe(ctx, "?types=scheduler&actions=resume,remove", events[1].ID)
+	defer resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal(events[2].ID, next().ID)
+	suite.Equal(events[3].ID, next().ID)
+
+	suite.NoError(tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/events?resume_token=unknown-1", nil, tu.Status(re, http.StatusGone)))
+	suite.NoError(tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/events?types=unknown", nil, tu.Status(re, http.StatusBadRequest)))
+}
+
+func (suite *eventTestSuite) TestSubscribeAlerts() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp, next := suite.subscribe(ctx, "?types=alert&actions="+event.AlertStoreDown, "")
+	defer resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	hub := suite.svr.GetEventHub()
+	hub.Publish(event.TypeAlert, event.AlertTSOFallback, "global", nil)
+	hub.Publish(event.TypeAlert, event.AlertStoreDown, "1", map[string]interface{}{"address": "127.0.0.1:20160"})
+	e := next()
+	suite.Equal(event.TypeAlert, e.Type)
+	suite.Equal(event.AlertStoreDown, e.Action)
+	suite.Equal("1", e.Subject)
+	suite.Equal("127.0.0.1:20160", e.Detail["address"])
+}
+
+func (suite *eventTestSuite) TestSubscribeEventsByGRPC() {
+	re := suite.Require()
+	conn, err := grpc.Dial(strings.TrimPrefix(suite.svr.GetAddr(), "http://"), grpc.WithInsecure())
+	re.NoError(err)
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clusterID := suite.svr.ClusterID()
+
+	stream, err := server.SubscribeEvents(ctx, conn, &server.SubscribeEventsRequest{
+		ClusterID: clusterID,
+		Types:     []event.Type{event.TypeAlert},
+		Subject:   "2",
+	})
+	re.NoError(err)
+	hub := suite.svr.GetEventHub()
+	hub.Publish(event.TypeAlert, event.AlertStoreDown, "1", nil)
+	hub.Publish(event.TypeAlert, event.AlertStoreDown, "2", nil)
+	hub.Publish(event.TypeAlert, event.AlertSlowStoreEvicted, "2", nil)
+	first, err := stream.Recv()
+	re.NoError(err)
+	suite.Equal(event.AlertStoreDown, first.Action)
+	suite.Equal("2", first.Subject)
+	second, err := stream.Recv()
+	re.NoError(err)
+	suite.Equal(event.AlertSlowStoreEvicted, second.Action)
+
+	// resume the subscription after the first event.
+	stream, err = server.SubscribeEvents(ctx, conn, &server.SubscribeEventsRequest{
+		ClusterID:   clusterID,
+		Subject:     "2",
+		ResumeToken: first.ID,
+	})
+	re.NoError(err)
+	e, err := stream.Recv()
+	re.NoError(err)
+	suite.Equal(second.ID, e.ID)
+
+	for req, code := range map[*server.SubscribeEventsRequest]codes.Code{
+		{ClusterID: clusterID, ResumeToken: "unknown-1"}:       codes.OutOfRange,
+		{ClusterID: clusterID, Types: []event.Type{"unknown"}}: codes.InvalidArgument,
+		{ClusterID: clusterID + 1}:                             codes.FailedPrecondition,
+	} {
+		stream, err = server.SubscribeEvents(ctx, conn, req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		suite.Equal(code, grpcstatus.Code(err))
+	}
+}
@@ -295,11 +295,11 @@ func (h *leaderHandler) ResignLeader(w http.ResponseWriter, r *http.Request) {
 
 // @Tags     leader
 // @Summary  Transfer etcd leadership to the specific PD server.
-// @Param    nextLeader  path  string  true  "PD server that transfer leader to"
+// @Param    next_leader  path  string  true  "PD server that transfer leader to"
 // @Produce  json
 // @Success  200  {string}  string  "The transfer command is submitted."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
-// @Router   /leader/transfer/{nextLeader} [post]
+// @Router   /leader/transfer/{next_leader} [post]
 func (h *leaderHandler) TransferLeader(w http.ResponseWriter, r *http.Request) {
 	err := h.svr.GetMember().ResignEtcdLeader(h.svr.Context(), h.svr.Name(), mux.Vars(r)["next_leader"])
 	if err != nil {
@@ -32,6 +32,32 @@ func newQueryMetric(s *server.Server) *queryMetric {
 	return &queryMetric{s: s}
 }
 
+// The range query is served by QueryMetric too.
+// @Tags     metric
+// @Summary  Query the metrics in a range of time from the metric storage, which is compatible with the Prometheus API.
+// @ID       QueryMetricRange
+// @ID       QueryMetricRangeByPost
+// @Param    query  query  string  true   "The Prometheus expression"
+// @Param    start  query  string  true   "The start timestamp"
+// @Param    end    query  string  true   "The end timestamp"
+// @Param    step   query  string  true   "The resolution step"
+// @Produce  json
+// @Success  200  {object}  object  "The response of the metric storage."
+// @Failure  500  {string}  string  "The metric storage is unavailable."
+// @Router   /metric/query_range [get]
+// @Router   /metric/query_range [post]
+
+// @Tags     metric
+// @Summary  Query the metrics at an instant from the metric storage, which is compatible with the Prometheus API.
+// @ID       QueryMetric
+// @ID       QueryMetricByPost
+// @Param    query  query  string  true   "The Prometheus expression"
+// @Param    time   query  string  false  "The evaluation timestamp"
+// @Produce  json
+// @Success  200  {object}  object  "The response of the metric storage."
+// @Failure  500  {string}  string  "The metric storage is unavailable."
+// @Router   /metric/query [get]
+// @Router   /metric/query [post]
 func (h *queryMetric) QueryMetric(w http.ResponseWriter, r *http.Request) {
 	metricAddr := h.s.GetConfig().PDServerCfg.MetricStorage
 	if metricAddr == "" {
@@ -17,11 +17,13 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/tikv/pd/pkg/audit"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/utils/requestutil"
 	"github.com/tikv/pd/server"
 	"github.com/tikv/pd/server/cluster"
@@ -38,7 +40,7 @@ type serviceMiddlewareBuilder struct {
 func newServiceMiddlewareBuilder(s *server.Server) *serviceMiddlewareBuilder {
 	return &serviceMiddlewareBuilder{
 		svr:      s,
-		handlers: []negroni.Handler{newRequestInfoMiddleware(s), newAuditMiddleware(s), newRateLimitMiddleware(s)},
+		handlers: []negroni.Handler{newRequestInfoMiddleware(s), newAuditMiddleware(s), newRBACMiddleware(s), newRateLimitMiddleware(s)},
 	}
 }
 
@@ -56,7 +58,8 @@ func newRequestInfoMiddleware(s *server.Server) negroni.Handler {
 }
 
 func (rm *requestInfoMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	if !rm.svr.GetServiceMiddlewarePersistOptions().IsAuditEnabled() && !rm.svr.GetServiceMiddlewarePersistOptions().IsRateLimitEnabled() {
+	opts := rm.svr.GetServiceMiddlewarePersistOptions()
+	if !opts.IsAuditEnabled() && !opts.IsRateLimitEnabled() && !opts.IsRBACEnabled() && !opts.IsFairQueuingEnabled() {
 		next(w, r)
 		return
 	}
@@ -147,15 +150,47 @@ func (s *auditMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next
 		backend.ProcessHTTPRequest(r)
 	}
 
+	start := time.Now()
 	next(w, r)
 
 	endTime := time.Now().Unix()
 	r = r.WithContext(requestutil.WithEndTime(r.Context(), endTime))
+	responseInfo := requestutil.ResponseInfo{Latency: time.Since(start)}
+	if rw, ok := w.(negroni.ResponseWriter); ok {
+		responseInfo.StatusCode = rw.Status()
+	}
+	r = r.WithContext(requestutil.WithResponseInfo(r.Context(), responseInfo))
 	for _, backend := range afterNextBackends {
 		backend.ProcessHTTPRequest(r)
 	}
 }
 
+type rbacMiddleware struct {
+	svr *server.Server
+}
+
+func newRBACMiddleware(s *server.Server) negroni.Handler {
+	return &rbacMiddleware{svr: s}
+}
+
+// ServeHTTP is used to implememt negroni.Handler for rbacMiddleware
+func (s *rbacMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if !s.svr.GetServiceMiddlewarePersistOptions().IsRBACEnabled() {
+		next(w, r)
+		return
+	}
+	requestInfo, ok := requestutil.RequestInfoFrom(r.Context())
+	if !ok {
+		requestInfo = requestutil.GetRequestInfo(r)
+	}
+
+	if err := s.svr.CheckHTTPPermission(r, requestInfo.ServiceLabel); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	next(w, r)
+}
+
 type rateLimitMiddleware struct {
 	svr *server.Server
 }
@@ -166,7 +201,8 @@ func newRateLimitMiddleware(s *server.Server) negroni.Handler {
 
 // ServeHTTP is used to implememt negroni.Handler for rateLimitMiddleware
 func (s *rateLimitMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	if !s.svr.GetServiceMiddlewarePersistOptions().IsRateLimitEnabled() {
+	opts := s.svr.GetServiceMiddlewarePersistOptions()
+	if !opts.IsRateLimitEnabled() && !opts.IsFairQueuingEnabled() {
 		next(w, r)
 		return
 	}
@@ -177,10 +213,39 @@ func (s *rateLimitMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request,
 
 	// There is no need to check whether rateLimiter is nil. CreateServer ensures that it is created
 	rateLimiter := s.svr.GetServiceRateLimiter()
-	if rateLimiter.Allow(requestInfo.ServiceLabel) {
+	if opts.IsRateLimitEnabled() {
+		if !rateLimiter.Allow(requestInfo.ServiceLabel) {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
 		defer rateLimiter.Release(requestInfo.ServiceLabel)
-		next(w, r)
-	} else {
-		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
 	}
+	// The services in the allow list aren't queued, so the rate limit config can always be updated.
+	// The streaming services aren't queued either, since they hold the concurrency as long as the
+	// connection is alive and their latency misleads the adaptive limit.
+	if opts.IsFairQueuingEnabled() && !rateLimiter.IsInAllowList(requestInfo.ServiceLabel) &&
+		!s.svr.IsStreamingService(requestInfo.ServiceLabel) {
+		release, ok := s.svr.GetFairLimiter().Acquire(r.Context(), requestInfo.ServiceLabel, s.callerIdentity(r, requestInfo))
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+	}
+	next(w, r)
+}
+
+// callerIdentity returns the identity of the caller for the fair queuing, which is the first CN or
+// SAN subject of the client certificate, the client IP or the component name in order. The component
+// name is set by the client itself, so it's only used when the others are unknown.
+func (s *rateLimitMiddleware) callerIdentity(r *http.Request, requestInfo requestutil.RequestInfo) string {
+	for _, subject := range s.svr.GetRBACManager().ClientSubjectsFromHTTP(r) {
+		if strings.HasPrefix(subject, rbac.KindCN+":") || strings.HasPrefix(subject, rbac.KindSAN+":") {
+			return subject
+		}
+	}
+	if requestInfo.IP != "" {
+		return "ip:" + requestInfo.IP
+	}
+	return "component:" + requestInfo.Component
 }
@@ -16,9 +16,12 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/keyspace"
 	"github.com/unrolled/render"
 )
 
@@ -57,3 +60,36 @@ func (h *minResolvedTSHandler) GetMinResolvedTS(w http.ResponseWriter, r *http.R
 		IsRealTime:      persistInterval.Duration != 0,
 	})
 }
+
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type safeReadTS struct {
+	SafeReadTS uint64 `json:"safe_read_ts"`
+}
+
+// @Tags     min_resolved_ts
+// @Summary  Get the safe read ts of a key range or a keyspace, which is the newest ts that a stale read on it is guaranteed to be consistent.
+// @Param    start_key    query  string   false  "Start key of the range, in the same format as the region boundaries, i.e., memcomparable-encoded for the TxnKV keys"
+// @Param    end_key      query  string   false  "End key of the range, in the same format as the region boundaries, i.e., memcomparable-encoded for the TxnKV keys"
+// @Param    keyspace_id  query  integer  false  "ID of the keyspace, the key range will be ignored if it's set"
+// @Produce  json
+// @Success  200  {object}  safeReadTS
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /min-resolved-ts/safe-read-ts [get]
+func (h *minResolvedTSHandler) GetSafeReadTS(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	query := r.URL.Query()
+	var ranges []core.KeyRange
+	if keyspaceIDStr := query.Get("keyspace_id"); keyspaceIDStr != "" {
+		keyspaceID, err := strconv.ParseUint(keyspaceIDStr, 10, 32)
+		if err != nil {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		ranges = keyspace.GetKeyspaceKeyRanges(uint32(keyspaceID))
+	} else {
+		ranges = []core.KeyRange{core.NewKeyRange(query.Get("start_key"), query.Get("end_key"))}
+	}
+	h.rd.JSON(w, http.StatusOK, safeReadTS{
+		SafeReadTS: rc.GetMinResolvedTSByKeyRanges(ranges),
+	})
+}
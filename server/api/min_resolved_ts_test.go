@@ -16,6 +16,7 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -115,6 +116,33 @@ func (suite *minResolvedTSTestSuite) TestMinResolvedTS() {
 	})
 }
 
+func (suite *minResolvedTSTestSuite) TestSafeReadTS() {
+	re := suite.Require()
+	rc := suite.svr.GetRaftCluster()
+	ts := uint64(300)
+	re.NoError(rc.SetMinResolvedTS(1, ts))
+	getSafeReadTS := func(query string) uint64 {
+		res, err := testDialClient.Get(suite.url + "/safe-read-ts?" + query)
+		re.NoError(err)
+		defer res.Body.Close()
+		re.Equal(http.StatusOK, res.StatusCode)
+		resp := &safeReadTS{}
+		re.NoError(apiutil.ReadJSON(res.Body, resp))
+		return resp.SafeReadTS
+	}
+	// The range is covered by the regions on store 1.
+	re.Equal(ts, getSafeReadTS("start_key=a&end_key=c"))
+	re.Equal(ts, getSafeReadTS("start_key=b&end_key=bb"))
+	// Fall back to the cluster-level min resolved ts if the range is not covered.
+	re.Equal(rc.GetMinResolvedTS(), getSafeReadTS("start_key=a&end_key=d"))
+	re.Equal(rc.GetMinResolvedTS(), getSafeReadTS("keyspace_id=1"))
+	// Invalid keyspace id.
+	res, err := testDialClient.Get(suite.url + "/safe-read-ts?keyspace_id=abc")
+	re.NoError(err)
+	defer res.Body.Close()
+	re.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
 func (suite *minResolvedTSTestSuite) setMinResolvedTSPersistenceInterval(duration typeutil.Duration) {
 	cfg := suite.svr.GetRaftCluster().GetPDServerConfig().Clone()
 	cfg.MinResolvedTSPersistenceInterval = duration
@@ -0,0 +1,117 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/openapi"
+)
+
+func TestOpenAPI(t *testing.T) {
+	re := require.New(t)
+	doc, err := openapi.Generate(openapi.Options{Dir: "../..", Packages: []string{"./server/api", "./pkg/tso"}})
+	re.NoError(err)
+
+	// The generated files must be up to date.
+	spec, err := openapi.Marshal(doc)
+	re.NoError(err)
+	expected, err := os.ReadFile("../../docs/openapi/v1.json")
+	re.NoError(err)
+	re.Equal(string(expected), string(spec), "run `make openapi-spec` to update it")
+	client, err := openapi.GenerateClient(doc, "apiclient")
+	re.NoError(err)
+	expected, err = os.ReadFile("../../pkg/apiclient/client_gen.go")
+	re.NoError(err)
+	re.Equal(string(expected), string(client), "run `make openapi-spec` to update it")
+
+	svr, cleanup := mustNewServer(re)
+	defer cleanup()
+	router := createRouter(apiPrefix, svr)
+	basePath := doc.Servers[0].URL
+
+	// Every route must be documented.
+	var undocumented []string
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || route.GetName() == "" || !strings.HasPrefix(path, basePath) {
+			return nil
+		}
+		path = strings.TrimPrefix(path, basePath)
+		// The failpoint API is only used in the tests.
+		if path == "/fail" {
+			return nil
+		}
+		pathRegexp, err := route.GetPathRegexp()
+		re.NoError(err)
+		if !strings.HasSuffix(pathRegexp, "$") {
+			// The routes with the path prefix document the sub paths.
+			for p := range doc.Paths {
+				if strings.HasPrefix(p, path+"/") {
+					return nil
+				}
+			}
+			undocumented = append(undocumented, path+"/*")
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// The route accepts any method.
+			if len(doc.Paths[path]) == 0 {
+				undocumented = append(undocumented, path)
+			}
+			return nil
+		}
+		for _, method := range methods {
+			if doc.Paths[path][strings.ToLower(method)] == nil {
+				undocumented = append(undocumented, path+" ["+method+"]")
+			}
+		}
+		return nil
+	})
+	re.NoError(err)
+	sort.Strings(undocumented)
+	re.Empty(undocumented, "the routes aren't documented")
+
+	// Every operation must be served.
+	pathParam := regexp.MustCompile(`\{[^}]+\}`)
+	var unserved []string
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			req := httptest.NewRequest(strings.ToUpper(method), basePath+pathParam.ReplaceAllString(path, "1"), http.NoBody)
+			query := req.URL.Query()
+			for _, param := range op.Parameters {
+				if param.In == openapi.InQuery && param.Required {
+					query.Set(param.Name, "1")
+				}
+			}
+			req.URL.RawQuery = query.Encode()
+			var match mux.RouteMatch
+			if !router.Match(req, &match) || match.MatchErr != nil {
+				unserved = append(unserved, path+" ["+method+"]")
+			}
+		}
+	}
+	sort.Strings(unserved)
+	re.Empty(unserved, "the documented operations aren't served")
+}
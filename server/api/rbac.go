@@ -0,0 +1,184 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/rbac"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+type rbacHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newRBACHandler(s *server.Server, rd *render.Render) *rbacHandler {
+	return &rbacHandler{
+		svr: s,
+		rd:  rd,
+	}
+}
+
+// @Tags     rbac
+// @Summary  List all the built-in and custom roles.
+// @Produce  json
+// @Success  200  {array}  rbac.Role
+// @Router   /rbac/roles [get]
+func (h *rbacHandler) GetRBACRoles(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.svr.GetRBACManager().GetRoles())
+}
+
+// @Tags     rbac
+// @Summary  Get the role by name.
+// @Param    name  path  string  true  "Role name"
+// @Produce  json
+// @Success  200  {object}  rbac.Role
+// @Failure  404  {string}  string  "The role does not exist."
+// @Router   /rbac/roles/{name} [get]
+func (h *rbacHandler) GetRBACRole(w http.ResponseWriter, r *http.Request) {
+	role, err := h.svr.GetRBACManager().GetRole(mux.Vars(r)["name"])
+	if err != nil {
+		h.rd.JSON(w, http.StatusNotFound, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, role)
+}
+
+// @Tags     rbac
+// @Summary  Create or update a custom role.
+// @Accept   json
+// @Param    role  body  rbac.Role  true  "The role"
+// @Produce  json
+// @Success  200  {string}  string  "The role is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /rbac/roles [post]
+func (h *rbacHandler) SetRBACRole(w http.ResponseWriter, r *http.Request) {
+	var role rbac.Role
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &role); err != nil {
+		return
+	}
+	if err := role.Validate(); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.svr.GetRBACManager().SetRole(&role); err != nil {
+		if errs.ErrRoleBuiltIn.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The role is updated.")
+}
+
+// @Tags     rbac
+// @Summary  Delete a custom role which isn't bound to any subject.
+// @Param    name  path  string  true  "Role name"
+// @Produce  json
+// @Success  200  {string}  string  "The role is deleted."
+// @Failure  400  {string}  string  "The role is built-in or still in use."
+// @Failure  404  {string}  string  "The role does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /rbac/roles/{name} [delete]
+func (h *rbacHandler) DeleteRBACRole(w http.ResponseWriter, r *http.Request) {
+	if err := h.svr.GetRBACManager().DeleteRole(mux.Vars(r)["name"]); err != nil {
+		switch {
+		case errs.ErrRoleNotFound.Equal(err):
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		case errs.ErrRoleBuiltIn.Equal(err), errs.ErrRoleInUse.Equal(err):
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		default:
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The role is deleted.")
+}
+
+// @Tags     rbac
+// @Summary  List all the role bindings. The values of the token bindings are the hashes of the tokens.
+// @Produce  json
+// @Success  200  {array}  rbac.Binding
+// @Router   /rbac/bindings [get]
+func (h *rbacHandler) GetRBACBindings(w http.ResponseWriter, r *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.svr.GetRBACManager().GetBindings())
+}
+
+// @Tags     rbac
+// @Summary  Bind the roles to a certificate CN, a certificate SAN or a bearer token. The existing binding of the subject is replaced.
+// @Accept   json
+// @Param    binding  body  rbac.Binding  true  "The role binding"
+// @Produce  json
+// @Success  200  {string}  string  "The role binding is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /rbac/bindings [post]
+func (h *rbacHandler) SetRBACBinding(w http.ResponseWriter, r *http.Request) {
+	var binding rbac.Binding
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &binding); err != nil {
+		return
+	}
+	if err := binding.Validate(); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.svr.GetRBACManager().SetBinding(&binding); err != nil {
+		if errs.ErrRoleNotFound.Equal(err) || errs.ErrLastAdminBinding.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The role binding is updated.")
+}
+
+// @Tags     rbac
+// @Summary  Delete the role binding of a subject.
+// @Param    kind   query  string  true  "The kind of the subject: cn, san or token"
+// @Param    value  query  string  true  "The value of the subject, the token or its hash for the token binding"
+// @Produce  json
+// @Success  200  {string}  string  "The role binding is deleted."
+// @Failure  400  {string}  string  "The input is invalid or the binding is the last admin."
+// @Failure  404  {string}  string  "The role binding does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /rbac/bindings [delete]
+func (h *rbacHandler) DeleteRBACBinding(w http.ResponseWriter, r *http.Request) {
+	kind, value := r.URL.Query().Get("kind"), r.URL.Query().Get("value")
+	if kind == "" || value == "" {
+		h.rd.JSON(w, http.StatusBadRequest, "kind and value are required")
+		return
+	}
+	if err := h.svr.GetRBACManager().DeleteBinding(kind, value); err != nil {
+		switch {
+		case errs.ErrBindingNotFound.Equal(err):
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		case errs.ErrLastAdminBinding.Equal(err):
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		default:
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The role binding is deleted.")
+}
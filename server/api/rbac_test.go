@@ -0,0 +1,133 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/rbac"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/config"
+)
+
+type rbacTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func TestRBACTestSuite(t *testing.T) {
+	suite.Run(t, new(rbacTestSuite))
+}
+
+func (suite *rbacTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1", addr, apiPrefix)
+
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *rbacTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+func (suite *rbacTestSuite) doWithToken(method, url, token string) int {
+	req, err := http.NewRequest(method, url, http.NoBody)
+	suite.NoError(err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := testDialClient.Do(req)
+	suite.NoError(err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (suite *rbacTestSuite) TestRBAC() {
+	re := suite.Require()
+	configURL := fmt.Sprintf("%s/service-middleware/config", suite.urlPrefix)
+	rolesURL := fmt.Sprintf("%s/rbac/roles", suite.urlPrefix)
+	bindingsURL := fmt.Sprintf("%s/rbac/bindings", suite.urlPrefix)
+
+	var roles []*rbac.Role
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, rolesURL, &roles))
+	suite.Len(roles, 3)
+
+	// RBAC can't be enabled without any admin.
+	postData, err := json.Marshal(map[string]interface{}{"rbac.enable-rbac": "true"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, configURL, postData, tu.Status(re, http.StatusBadRequest)))
+
+	role := &rbac.Role{Name: "store-reader", Permissions: []*rbac.Permission{
+		{Actions: []string{rbac.ActionRead}, Services: []string{"GetStore*"}},
+	}}
+	postData, err = json.Marshal(role)
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, rolesURL, postData, tu.StatusOK(re)))
+	postData, err = json.Marshal(&rbac.Role{Name: rbac.RoleAdmin, Permissions: role.Permissions})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, rolesURL, postData, tu.Status(re, http.StatusBadRequest)))
+	var got rbac.Role
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, rolesURL+"/store-reader", &got))
+	suite.Equal(role, &got)
+
+	for token, roleName := range map[string]string{"admin-token": rbac.RoleAdmin, "viewer-token": rbac.RoleViewer, "reader-token": "store-reader"} {
+		postData, err = json.Marshal(&rbac.Binding{Kind: rbac.KindToken, Value: token, Roles: []string{roleName}})
+		suite.NoError(err)
+		suite.NoError(tu.CheckPostJSON(testDialClient, bindingsURL, postData, tu.StatusOK(re)))
+	}
+	var bindings []*rbac.Binding
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, bindingsURL, &bindings))
+	suite.Len(bindings, 3)
+	suite.Equal(http.StatusBadRequest, suite.doWithToken(http.MethodDelete, rolesURL+"/store-reader", ""))
+
+	postData, err = json.Marshal(map[string]interface{}{"rbac.enable-rbac": "true"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, configURL, postData, tu.StatusOK(re)))
+	suite.True(suite.svr.GetServiceMiddlewareConfig().EnableRBAC)
+
+	storesURL := fmt.Sprintf("%s/stores", suite.urlPrefix)
+	storeStateURL := fmt.Sprintf("%s/store/1/state?state=Up", suite.urlPrefix)
+	suite.Equal(http.StatusForbidden, suite.doWithToken(http.MethodGet, storesURL, ""))
+	suite.Equal(http.StatusForbidden, suite.doWithToken(http.MethodGet, storesURL, "unknown-token"))
+	suite.Equal(http.StatusOK, suite.doWithToken(http.MethodGet, storesURL, "reader-token"))
+	suite.Equal(http.StatusForbidden, suite.doWithToken(http.MethodGet, rolesURL, "reader-token"))
+	suite.Equal(http.StatusOK, suite.doWithToken(http.MethodGet, rolesURL, "viewer-token"))
+	suite.Equal(http.StatusForbidden, suite.doWithToken(http.MethodPost, storeStateURL, "viewer-token"))
+	suite.Equal(http.StatusOK, suite.doWithToken(http.MethodPost, storeStateURL, "admin-token"))
+
+	// only the admin can manage the bindings.
+	deleteURL := bindingsURL + "?kind=token&value=reader-token"
+	suite.Equal(http.StatusForbidden, suite.doWithToken(http.MethodDelete, deleteURL, "viewer-token"))
+	suite.Equal(http.StatusOK, suite.doWithToken(http.MethodDelete, deleteURL, "admin-token"))
+	suite.Equal(http.StatusNotFound, suite.doWithToken(http.MethodDelete, deleteURL, "admin-token"))
+	suite.Equal(http.StatusForbidden, suite.doWithToken(http.MethodGet, storesURL, "reader-token"))
+	suite.Equal(http.StatusOK, suite.doWithToken(http.MethodDelete, rolesURL+"/store-reader", "admin-token"))
+	// the last admin binding can't be removed while RBAC is enabled.
+	suite.Equal(http.StatusBadRequest, suite.doWithToken(http.MethodDelete, bindingsURL+"?kind=token&value=admin-token", "admin-token"))
+
+	suite.NoError(suite.svr.SetRBACConfig(config.RBACConfig{EnableRBAC: false}))
+	suite.Equal(http.StatusOK, suite.doWithToken(http.MethodGet, storesURL, ""))
+}
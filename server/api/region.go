@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/replication_modepb"
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/regionquery"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/utils/apiutil"
@@ -192,6 +193,11 @@ func (r *RegionInfo) Adjust() {
 type RegionsInfo struct {
 	Count   int          `json:"count"`
 	Regions []RegionInfo `json:"regions"`
+	// NextKey and NextID are the cursor of the next page if there are more regions. NextKey
+	// is set if the regions are listed in the order of the start key, and NextID is set if
+	// they are listed in the order of the ID.
+	NextKey string `json:"next_key,omitempty"`
+	NextID  uint64 `json:"next_id,omitempty"`
 }
 
 // Adjust is only used in testing, in order to compare the data from json deserialization.
@@ -324,14 +330,44 @@ func convertToAPIRegions(regions []*core.RegionInfo) *RegionsInfo {
 
 // @Tags     region
 // @Summary  List all regions in the cluster.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Router   /regions [get]
 func (h *regionsHandler) GetRegions(w http.ResponseWriter, r *http.Request) {
 	rc := getCluster(r)
-	regions := rc.GetRegions()
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	opts, err := parseRegionListOptions(rc, r.URL.Query())
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	regions, next := opts.scanRegions(rc)
+	opts.writeRegions(w, regions, next)
+}
+
+// @Tags     region
+// @Summary  List the regions which match the query, like `size > 96MiB and store in (1, 4) order by size desc limit 10`.
+// @Param    q       query  string  false  "The query of the regions, all the regions are listed if it's empty"
+// @Param    fields  query  array   false  "Fields of the regions to return"
+// @Produce  json
+// @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /regions/query [get]
+func (h *regionsHandler) QueryRegions(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	q, err := regionquery.Parse(r.URL.Query().Get("q"))
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	fields, err := parseRegionFields(r.URL.Query())
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts := &regionListOptions{fields: fields}
+	opts.writeRegions(w, q.Run(rc, rc.GetRegionLabeler()), nil)
 }
 
 // @Tags     region
@@ -378,7 +414,8 @@ func (h *regionsHandler) GetRegionCount(w http.ResponseWriter, r *http.Request)
 
 // @Tags     region
 // @Summary  List all regions of a specific store.
-// @Param    id  path  integer  true  "Store Id"
+// @Param    id               path   integer  true   "Store Id"
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
 // @Failure  400  {string}  string  "The input is invalid."
@@ -392,15 +429,15 @@ func (h *regionsHandler) GetStoreRegions(w http.ResponseWriter, r *http.Request)
 		h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	regions := rc.GetStoreRegions(uint64(id))
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, rc.GetStoreRegions(uint64(id)))
 }
 
 // @Tags     region
 // @Summary  List all regions that miss peer.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/miss-peer [get]
 func (h *regionsHandler) GetMissPeerRegions(w http.ResponseWriter, r *http.Request) {
@@ -410,14 +447,15 @@ func (h *regionsHandler) GetMissPeerRegions(w http.ResponseWriter, r *http.Reque
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that has extra peer.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/extra-peer [get]
 func (h *regionsHandler) GetExtraPeerRegions(w http.ResponseWriter, r *http.Request) {
@@ -427,14 +465,15 @@ func (h *regionsHandler) GetExtraPeerRegions(w http.ResponseWriter, r *http.Requ
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that has pending peer.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/pending-peer [get]
 func (h *regionsHandler) GetPendingPeerRegions(w http.ResponseWriter, r *http.Request) {
@@ -444,14 +483,15 @@ func (h *regionsHandler) GetPendingPeerRegions(w http.ResponseWriter, r *http.Re
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that has down peer.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/down-peer [get]
 func (h *regionsHandler) GetDownPeerRegions(w http.ResponseWriter, r *http.Request) {
@@ -461,14 +501,15 @@ func (h *regionsHandler) GetDownPeerRegions(w http.ResponseWriter, r *http.Reque
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that has learner peer.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/learner-peer [get]
 func (h *regionsHandler) GetLearnerPeerRegions(w http.ResponseWriter, r *http.Request) {
@@ -478,14 +519,15 @@ func (h *regionsHandler) GetLearnerPeerRegions(w http.ResponseWriter, r *http.Re
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that has offline peer.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/offline-peer [get]
 func (h *regionsHandler) GetOfflinePeerRegions(w http.ResponseWriter, r *http.Request) {
@@ -495,14 +537,15 @@ func (h *regionsHandler) GetOfflinePeerRegions(w http.ResponseWriter, r *http.Re
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that are oversized.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/oversized-region [get]
 func (h *regionsHandler) GetOverSizedRegions(w http.ResponseWriter, r *http.Request) {
@@ -512,14 +555,15 @@ func (h *regionsHandler) GetOverSizedRegions(w http.ResponseWriter, r *http.Requ
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all regions that are undersized.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/undersized-region [get]
 func (h *regionsHandler) GetUndersizedRegions(w http.ResponseWriter, r *http.Request) {
@@ -529,14 +573,15 @@ func (h *regionsHandler) GetUndersizedRegions(w http.ResponseWriter, r *http.Req
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
 }
 
 // @Tags     region
 // @Summary  List all empty regions.
+// @Params   regionList
 // @Produce  json
 // @Success  200  {object}  RegionsInfo
+// @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /regions/check/empty-region [get]
 func (h *regionsHandler) GetEmptyRegions(w http.ResponseWriter, r *http.Request) {
@@ -546,8 +591,19 @@ func (h *regionsHandler) GetEmptyRegions(w http.ResponseWriter, r *http.Request)
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	regionsInfo := convertToAPIRegions(regions)
-	h.rd.JSON(w, http.StatusOK, regionsInfo)
+	h.listRegions(w, r, regions)
+}
+
+// listRegions writes the regions with the pagination, filters and field selection in the
+// query of the request.
+func (h *regionsHandler) listRegions(w http.ResponseWriter, r *http.Request, regions []*core.RegionInfo) {
+	opts, err := parseRegionListOptions(getCluster(r), r.URL.Query())
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	regions, next := opts.selectRegions(regions)
+	opts.writeRegions(w, regions, next)
 }
 
 type histItem struct {
@@ -0,0 +1,432 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/server/cluster"
+	"go.uber.org/zap"
+)
+
+// The states of the peers which can be used to filter the regions.
+const (
+	peerStatePending = "pending"
+	peerStateDown    = "down"
+	peerStateLearner = "learner"
+	peerStateWitness = "witness"
+)
+
+// regionFields is the JSON fields of RegionInfo which can be selected.
+var regionFields = func() map[string]struct{} {
+	fields := make(map[string]struct{})
+	typ := reflect.TypeOf(RegionInfo{})
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = struct{}{}
+		}
+	}
+	return fields
+}()
+
+// The query parameters of the region listing APIs.
+//
+// @ParamSet  regionList
+// @Param    start_key        query  string   false  "Hex encoded key to list the regions from in the order of the start key"
+// @Param    start_id         query  integer  false  "Region ID to list the regions from in the order of the ID, each page scans all the regions, so start_key is cheaper for a large cluster"
+// @Param    limit            query  integer  false  "Max count of the regions in a page"
+// @Param    fields           query  array    false  "Fields of the regions to return"
+// @Param    store_id         query  array    false  "List the regions which have a peer on any of the stores"
+// @Param    label            query  array    false  "List the regions which have all the labels in the form of key=value"
+// @Param    peer_state       query  array    false  "List the regions which have a peer in any of the states: pending, down, learner, witness"
+// @Param    min_size         query  integer  false  "Min approximate size in MiB"
+// @Param    max_size         query  integer  false  "Max approximate size in MiB"
+// @Param    min_keys         query  integer  false  "Min approximate keys"
+// @Param    max_keys         query  integer  false  "Max approximate keys"
+// @Param    min_write_bytes  query  integer  false  "Min written bytes"
+// @Param    max_write_bytes  query  integer  false  "Max written bytes"
+// @Param    min_read_bytes   query  integer  false  "Min read bytes"
+// @Param    max_read_bytes   query  integer  false  "Max read bytes"
+
+// regionListOptions is the pagination, filters and field selection of listing regions.
+// The regions are listed in the order of the start key if start_key is given or only
+// limit is given, in the order of the ID if start_id is given, and all the regions are
+// listed without a specific order if none of them is given.
+type regionListOptions struct {
+	paginated bool
+	byID      bool
+	startKey  []byte
+	startID   uint64
+	limit     int
+	fields    []string
+	filters   []func(region *core.RegionInfo) bool
+}
+
+func parseRegionListOptions(rc *cluster.RaftCluster, query url.Values) (*regionListOptions, error) {
+	o := &regionListOptions{}
+	if startKey, ok := query["start_key"]; ok {
+		key, err := hex.DecodeString(startKey[0])
+		if err != nil {
+			return nil, errors.Errorf("invalid start_key %s", startKey[0])
+		}
+		o.paginated, o.startKey = true, key
+	}
+	if startID := query.Get("start_id"); startID != "" {
+		if o.paginated {
+			return nil, errors.New("start_key and start_id can't be used together")
+		}
+		id, err := strconv.ParseUint(startID, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid start_id %s", startID)
+		}
+		o.paginated, o.byID, o.startID = true, true, id
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		o.limit, err = strconv.Atoi(limit)
+		if err != nil || o.limit <= 0 {
+			return nil, errors.Errorf("invalid limit %s", limit)
+		}
+		if o.limit > maxRegionLimit {
+			o.limit = maxRegionLimit
+		}
+		o.paginated = true
+	}
+	var err error
+	if o.fields, err = parseRegionFields(query); err != nil {
+		return nil, err
+	}
+
+	// The region matches if it has a peer on any of the stores.
+	if values := splitQueryValues(query["store_id"]); len(values) > 0 {
+		storeIDs := make([]uint64, 0, len(values))
+		for _, v := range values {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid store_id %s", v)
+			}
+			storeIDs = append(storeIDs, id)
+		}
+		o.filters = append(o.filters, func(region *core.RegionInfo) bool {
+			for _, id := range storeIDs {
+				if region.GetStorePeer(id) != nil {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	// The region matches if it has all the labels.
+	for _, label := range query["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return nil, errors.Errorf("invalid label %s, it should be key=value", label)
+		}
+		labeler := rc.GetRegionLabeler()
+		o.filters = append(o.filters, func(region *core.RegionInfo) bool {
+			return labeler.GetRegionLabel(region, key) == value
+		})
+	}
+	// The region matches if it has a peer in any of the states.
+	if states := splitQueryValues(query["peer_state"]); len(states) > 0 {
+		for _, state := range states {
+			switch state {
+			case peerStatePending, peerStateDown, peerStateLearner, peerStateWitness:
+			default:
+				return nil, errors.Errorf("unknown peer_state %s", state)
+			}
+		}
+		o.filters = append(o.filters, func(region *core.RegionInfo) bool {
+			for _, state := range states {
+				if hasPeerInState(region, state) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	ranges := []struct {
+		min, max string
+		get      func(region *core.RegionInfo) uint64
+	}{
+		{"min_size", "max_size", func(region *core.RegionInfo) uint64 { return uint64(region.GetApproximateSize()) }},
+		{"min_keys", "max_keys", func(region *core.RegionInfo) uint64 { return uint64(region.GetApproximateKeys()) }},
+		{"min_write_bytes", "max_write_bytes", (*core.RegionInfo).GetBytesWritten},
+		{"min_read_bytes", "max_read_bytes", (*core.RegionInfo).GetBytesRead},
+	}
+	for _, r := range ranges {
+		get := r.get
+		if v := query.Get(r.min); v != "" {
+			min, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid %s %s", r.min, v)
+			}
+			o.filters = append(o.filters, func(region *core.RegionInfo) bool { return get(region) >= min })
+		}
+		if v := query.Get(r.max); v != "" {
+			max, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid %s %s", r.max, v)
+			}
+			o.filters = append(o.filters, func(region *core.RegionInfo) bool { return get(region) <= max })
+		}
+	}
+	return o, nil
+}
+
+// parseRegionFields parses the fields of the regions to return.
+func parseRegionFields(query url.Values) ([]string, error) {
+	var fields []string
+	for _, field := range splitQueryValues(query["fields"]) {
+		if _, ok := regionFields[field]; !ok {
+			return nil, errors.Errorf("unknown field %s", field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// splitQueryValues supports both the repeated and the comma separated query values.
+func splitQueryValues(values []string) []string {
+	var res []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+	return res
+}
+
+func hasPeerInState(region *core.RegionInfo, state string) bool {
+	switch state {
+	case peerStatePending:
+		return len(region.GetPendingPeers()) > 0
+	case peerStateDown:
+		return len(region.GetDownPeers()) > 0
+	case peerStateLearner:
+		return len(region.GetLearners()) > 0
+	case peerStateWitness:
+		return len(region.GetWitnesses()) > 0
+	}
+	return false
+}
+
+func (o *regionListOptions) match(region *core.RegionInfo) bool {
+	for _, f := range o.filters {
+		if !f(region) {
+			return false
+		}
+	}
+	return true
+}
+
+// collect collects the matched regions from the scan, and returns the first matched region
+// which exceeds the limit as the cursor of the next page.
+func (o *regionListOptions) collect(scan func(iterator func(region *core.RegionInfo) bool)) (regions []*core.RegionInfo, next *core.RegionInfo) {
+	regions = make([]*core.RegionInfo, 0)
+	scan(func(region *core.RegionInfo) bool {
+		if !o.match(region) {
+			return true
+		}
+		if o.limit > 0 && len(regions) >= o.limit {
+			next = region
+			return false
+		}
+		regions = append(regions, region)
+		return true
+	})
+	return regions, next
+}
+
+// selectRegions applies the options to the regions, the regions may be sorted in place.
+func (o *regionListOptions) selectRegions(regions []*core.RegionInfo) ([]*core.RegionInfo, *core.RegionInfo) {
+	if o.paginated && o.byID {
+		return o.selectRegionsByID(regions)
+	}
+	if o.paginated {
+		sort.Slice(regions, func(i, j int) bool {
+			return bytes.Compare(regions[i].GetStartKey(), regions[j].GetStartKey()) < 0
+		})
+	}
+	return o.collect(func(iterator func(region *core.RegionInfo) bool) {
+		for _, region := range regions {
+			if o.paginated && o.beforeCursor(region) {
+				continue
+			}
+			if !iterator(region) {
+				return
+			}
+		}
+	})
+}
+
+// selectRegionsByID selects a page of the regions in the order of the ID. There is no index
+// of the region IDs, so it still scans all the regions, but only keeps the first limit+1
+// matched ones in a heap rather than sorting all of them.
+func (o *regionListOptions) selectRegionsByID(regions []*core.RegionInfo) ([]*core.RegionInfo, *core.RegionInfo) {
+	h := make(regionIDHeap, 0)
+	for _, region := range regions {
+		if o.beforeCursor(region) || !o.match(region) {
+			continue
+		}
+		switch {
+		case o.limit <= 0 || len(h) <= o.limit:
+			heap.Push(&h, region)
+		case region.GetID() < h[0].GetID():
+			h[0] = region
+			heap.Fix(&h, 0)
+		}
+	}
+	selected := []*core.RegionInfo(h)
+	sort.Slice(selected, func(i, j int) bool { return selected[i].GetID() < selected[j].GetID() })
+	if o.limit > 0 && len(selected) > o.limit {
+		return selected[:o.limit], selected[o.limit]
+	}
+	return selected, nil
+}
+
+// regionIDHeap is a max-heap of the regions by the ID.
+type regionIDHeap []*core.RegionInfo
+
+func (h regionIDHeap) Len() int           { return len(h) }
+func (h regionIDHeap) Less(i, j int) bool { return h[i].GetID() > h[j].GetID() }
+func (h regionIDHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *regionIDHeap) Push(x interface{}) {
+	*h = append(*h, x.(*core.RegionInfo))
+}
+
+func (h *regionIDHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	region := old[n-1]
+	*h = old[:n-1]
+	return region
+}
+
+// scanRegions lists the regions of the cluster. It doesn't need to sort all the regions if
+// they are listed in the order of the start key.
+func (o *regionListOptions) scanRegions(rc *cluster.RaftCluster) ([]*core.RegionInfo, *core.RegionInfo) {
+	if !o.paginated || o.byID {
+		return o.selectRegions(rc.GetRegions())
+	}
+	return o.collect(func(iterator func(region *core.RegionInfo) bool) {
+		rc.ScanRegionsWithIterator(o.startKey, iterator)
+	})
+}
+
+// beforeCursor returns true if the region is before the cursor. The region containing the
+// start key isn't before the cursor.
+func (o *regionListOptions) beforeCursor(region *core.RegionInfo) bool {
+	if o.byID {
+		return region.GetID() < o.startID
+	}
+	endKey := region.GetEndKey()
+	return len(endKey) > 0 && bytes.Compare(endKey, o.startKey) <= 0
+}
+
+// writeRegions encodes the regions as RegionsInfo one by one, so that the whole response
+// isn't built in memory.
+func (o *regionListOptions) writeRegions(w http.ResponseWriter, regions []*core.RegionInfo, next *core.RegionInfo) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	bw := bufio.NewWriter(w)
+	if err := o.encodeRegions(bw, regions, next); err != nil {
+		log.Warn("failed to write regions", zap.Error(err))
+		return
+	}
+	if err := bw.Flush(); err != nil {
+		log.Warn("failed to write regions", zap.Error(err))
+	}
+}
+
+func (o *regionListOptions) encodeRegions(bw *bufio.Writer, regions []*core.RegionInfo, next *core.RegionInfo) error {
+	bw.WriteString(`{"count":`)
+	bw.WriteString(strconv.Itoa(len(regions)))
+	bw.WriteString(`,"regions":[`)
+	var info RegionInfo
+	for i, region := range regions {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		info = RegionInfo{}
+		data, err := json.Marshal(InitRegion(region, &info))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if len(o.fields) > 0 {
+			if data, err = selectFields(data, o.fields); err != nil {
+				return err
+			}
+		}
+		if _, err = bw.Write(data); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	bw.WriteByte(']')
+	if next != nil {
+		if o.byID {
+			bw.WriteString(`,"next_id":`)
+			bw.WriteString(strconv.FormatUint(next.GetID(), 10))
+		} else {
+			bw.WriteString(`,"next_key":"`)
+			bw.WriteString(core.HexRegionKeyStr(next.GetStartKey()))
+			bw.WriteByte('"')
+		}
+	}
+	_, err := bw.WriteString("}")
+	return errors.WithStack(err)
+}
+
+// selectFields returns the JSON object only with the fields. The omitted empty fields are
+// still omitted.
+func selectFields(data []byte, fields []string) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, field := range fields {
+		v, ok := obj[field]
+		if !ok {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Quote(field))
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -420,6 +420,160 @@ func (suite *regionTestSuite) TestTopN() {
 	}
 }
 
+type listRegionsTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func TestListRegionsTestSuite(t *testing.T) {
+	suite.Run(t, new(listRegionsTestSuite))
+}
+
+func (suite *listRegionsTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1", addr, apiPrefix)
+
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *listRegionsTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+func (suite *listRegionsTestSuite) TestListRegions() {
+	re := suite.Require()
+	var rs []*core.RegionInfo
+	for i := 0; i < 5; i++ {
+		r := core.NewTestRegionInfo(uint64(100+i), 10, []byte(fmt.Sprintf("k%d", i)), []byte(fmt.Sprintf("k%d", i+1)),
+			core.SetApproximateSize(int64(10*(i+1))),
+			core.SetWrittenBytes(uint64(i)*units.MiB))
+		if i == 4 {
+			pendingPeer := &metapb.Peer{Id: 1004, StoreId: 11}
+			r = r.Clone(core.WithAddPeer(pendingPeer), core.WithPendingPeers([]*metapb.Peer{pendingPeer}))
+		}
+		mustRegionHeartbeat(re, suite.svr, r)
+		rs = append(rs, r)
+	}
+	checkRegionIDs := func(query string, expected []uint64) *RegionsInfo {
+		regions := &RegionsInfo{}
+		suite.NoError(tu.ReadGetJSON(re, testDialClient, fmt.Sprintf("%s/regions?%s", suite.urlPrefix, query), regions))
+		ids := make([]uint64, 0, len(regions.Regions))
+		for _, r := range regions.Regions {
+			ids = append(ids, r.ID)
+		}
+		suite.Equal(expected, ids)
+		suite.Equal(len(expected), regions.Count)
+		return regions
+	}
+
+	// Paginate by the start key.
+	regions := checkRegionIDs("store_id=10&limit=2", []uint64{100, 101})
+	suite.Equal(core.HexRegionKeyStr(rs[2].GetStartKey()), regions.NextKey)
+	regions = checkRegionIDs("store_id=10&limit=2&start_key="+regions.NextKey, []uint64{102, 103})
+	suite.Equal(core.HexRegionKeyStr(rs[4].GetStartKey()), regions.NextKey)
+	regions = checkRegionIDs("store_id=10&limit=2&start_key="+regions.NextKey, []uint64{104})
+	suite.Empty(regions.NextKey)
+	// Paginate by the region ID.
+	regions = checkRegionIDs("store_id=10&limit=2&start_id=102", []uint64{102, 103})
+	suite.Equal(uint64(104), regions.NextID)
+	suite.Empty(regions.NextKey)
+	// Filter the regions.
+	checkRegionIDs("store_id=10&start_id=0&min_size=20&max_size=40", []uint64{101, 102, 103})
+	checkRegionIDs("store_id=10&start_id=0&min_write_bytes=3145728", []uint64{103, 104})
+	checkRegionIDs("store_id=11&peer_state=pending,down", []uint64{104})
+
+	// Select the fields.
+	var selected struct {
+		Regions []map[string]interface{} `json:"regions"`
+	}
+	url := fmt.Sprintf("%s/regions/store/10?limit=1&fields=id,approximate_size", suite.urlPrefix)
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &selected))
+	suite.Equal([]map[string]interface{}{{"id": float64(100), "approximate_size": float64(10)}}, selected.Regions)
+
+	// Invalid options.
+	for _, query := range []string{"fields=unknown", "start_key=zz", "start_key=6b&start_id=1", "limit=-1", "peer_state=unknown", "label=env"} {
+		url = fmt.Sprintf("%s/regions/store/10?%s", suite.urlPrefix, query)
+		suite.NoError(tu.CheckGetJSON(testDialClient, url, nil, tu.Status(re, http.StatusBadRequest)))
+	}
+}
+
+func (suite *listRegionsTestSuite) TestQueryRegions() {
+	re := suite.Require()
+	for i := 0; i < 3; i++ {
+		r := core.NewTestRegionInfo(uint64(200+i), 20, []byte(fmt.Sprintf("q%d", i)), []byte(fmt.Sprintf("q%d", i+1)),
+			core.SetApproximateSize(int64(64*(i+1))))
+		mustRegionHeartbeat(re, suite.svr, r)
+	}
+	checkRegionIDs := func(query string, expected []uint64) {
+		regions := &RegionsInfo{}
+		url := fmt.Sprintf("%s/regions/query?q=%s", suite.urlPrefix, url.QueryEscape(query))
+		suite.NoError(tu.ReadGetJSON(re, testDialClient, url, regions))
+		ids := make([]uint64, 0, len(regions.Regions))
+		for _, r := range regions.Regions {
+			ids = append(ids, r.ID)
+		}
+		suite.Equal(expected, ids, query)
+		suite.Equal(len(expected), regions.Count, query)
+	}
+	checkRegionIDs("store = 20", []uint64{200, 201, 202})
+	checkRegionIDs("store = 20 and size > 96MiB", []uint64{201, 202})
+	checkRegionIDs("leader.store = 20 order by size desc limit 2", []uint64{202, 201})
+	checkRegionIDs("id = 200 or start_key = '7131'", []uint64{200, 201})
+
+	// Invalid queries.
+	for _, query := range []string{"q=" + url.QueryEscape("size >"), "q=" + url.QueryEscape("unknown = 1"), "fields=unknown"} {
+		url := fmt.Sprintf("%s/regions/query?%s", suite.urlPrefix, query)
+		suite.NoError(tu.CheckGetJSON(testDialClient, url, nil, tu.Status(re, http.StatusBadRequest)))
+	}
+
+	// Select the fields.
+	var selected struct {
+		Regions []map[string]interface{} `json:"regions"`
+	}
+	url := fmt.Sprintf("%s/regions/query?q=%s&fields=id", suite.urlPrefix, url.QueryEscape("id = 201"))
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, url, &selected))
+	suite.Equal([]map[string]interface{}{{"id": float64(201)}}, selected.Regions)
+}
+
+func TestSelectRegionsByID(t *testing.T) {
+	re := require.New(t)
+	var regions []*core.RegionInfo
+	for _, id := range []uint64{7, 3, 9, 1, 5, 8, 2} {
+		regions = append(regions, core.NewTestRegionInfo(id, id%2, []byte{byte(id)}, []byte{byte(id + 1)}))
+	}
+	selectIDs := func(o *regionListOptions) ([]uint64, uint64) {
+		selected, next := o.selectRegionsByID(regions)
+		ids := make([]uint64, 0, len(selected))
+		for _, region := range selected {
+			ids = append(ids, region.GetID())
+		}
+		if next == nil {
+			return ids, 0
+		}
+		return ids, next.GetID()
+	}
+	ids, next := selectIDs(&regionListOptions{paginated: true, byID: true, startID: 2, limit: 3})
+	re.Equal([]uint64{2, 3, 5}, ids)
+	re.Equal(uint64(7), next)
+	ids, next = selectIDs(&regionListOptions{paginated: true, byID: true, startID: next, limit: 3})
+	re.Equal([]uint64{7, 8, 9}, ids)
+	re.Zero(next)
+	ids, next = selectIDs(&regionListOptions{paginated: true, byID: true})
+	re.Equal([]uint64{1, 2, 3, 5, 7, 8, 9}, ids)
+	re.Zero(next)
+	// Only the matched regions are counted in the limit.
+	leaderOnStore1 := func(region *core.RegionInfo) bool { return region.GetLeader().GetStoreId() == 1 }
+	ids, next = selectIDs(&regionListOptions{paginated: true, byID: true, limit: 2, filters: []func(*core.RegionInfo) bool{leaderOnStore1}})
+	re.Equal([]uint64{1, 3}, ids)
+	re.Equal(uint64(5), next)
+}
+
 type getRegionTestSuite struct {
 	suite.Suite
 	svr       *server.Server
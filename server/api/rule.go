@@ -70,7 +70,7 @@ func (h *ruleHandler) GetAllRules(w http.ResponseWriter, r *http.Request) {
 // @Failure  400    {string}  string            "The input is invalid."
 // @Failure  412    {string}  string            "Placement rules feature is disabled."
 // @Failure  500    {string}  string            "PD server failed to proceed the request."
-// @Router   /config/rules [get]
+// @Router   /config/rules [post]
 func (h *ruleHandler) SetAllRules(w http.ResponseWriter, r *http.Request) {
 	cluster := getCluster(r)
 	if !cluster.GetOpts().IsPlacementRulesEnabled() {
@@ -119,7 +119,7 @@ func (h *ruleHandler) GetRuleByGroup(w http.ResponseWriter, r *http.Request) {
 
 // @Tags     rule
 // @Summary  List all rules of cluster by region.
-// @Param    id  path  integer  true  "Region Id"
+// @Param    region  path  integer  true  "Region Id"
 // @Produce  json
 // @Success  200  {array}   placement.Rule
 // @Failure  400  {string}  string  "The input is invalid."
@@ -137,7 +137,7 @@ func (h *ruleHandler) GetRulesByRegion(w http.ResponseWriter, r *http.Request) {
 
 // @Tags     rule
 // @Summary  List rules and matched peers related to the given region.
-// @Param    id  path  integer  true  "Region Id"
+// @Param    region  path  integer  true  "Region Id"
 // @Produce  json
 // @Success  200  {object}  placement.RegionFit
 // @Failure  400  {string}  string  "The input is invalid."
@@ -447,7 +447,9 @@ func (h *ruleHandler) GetPlacementRules(w http.ResponseWriter, r *http.Request)
 
 // @Tags     rule
 // @Summary  Update all rules and groups configuration.
-// @Param    partial  query  bool  false  "if partially update rules"  default(false)
+// @Accept   json
+// @Param    partial  query  bool                     false  "if partially update rules"  default(false)
+// @Param    body     body   []placement.GroupBundle  true   "Parameters of groups and rules"
 // @Produce  json
 // @Success  200  {string}  string  "Update rules and groups successfully."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -495,14 +497,14 @@ func (h *ruleHandler) GetPlacementRuleByGroup(w http.ResponseWriter, r *http.Req
 }
 
 // @Tags     rule
-// @Summary  Get group config and all rules belong to the group.
+// @Summary  Delete group config and all rules belong to the group.
 // @Param    group   path   string  true   "The name or name pattern of group"
 // @Param    regexp  query  bool    false  "Use regular expression"  default(false)
 // @Produce  plain
 // @Success  200  {string}  string  "Delete group and rules successfully."
 // @Failure  400  {string}  string  "Bad request."
 // @Failure  412  {string}  string  "Placement rules feature is disabled."
-// @Router   /config/placement-rule [delete]
+// @Router   /config/placement-rule/{group} [delete]
 func (h *ruleHandler) DeletePlacementRuleByGroup(w http.ResponseWriter, r *http.Request) {
 	cluster := getCluster(r)
 	if !cluster.GetOpts().IsPlacementRulesEnabled() {
@@ -525,6 +527,8 @@ func (h *ruleHandler) DeletePlacementRuleByGroup(w http.ResponseWriter, r *http.
 
 // @Tags     rule
 // @Summary  Update group and all rules belong to it.
+// @Param    group  path  string                 true  "The name of group"
+// @Param    body   body  placement.GroupBundle  true  "Parameters of group and rules"
 // @Produce  json
 // @Success  200  {string}  string  "Update group and rules successfully."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -0,0 +1,90 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
+	"github.com/tikv/pd/server"
+	"github.com/unrolled/render"
+)
+
+type scatterGroupHandler struct {
+	svr *server.Server
+	rd  *render.Render
+}
+
+func newScatterGroupHandler(s *server.Server, rd *render.Render) *scatterGroupHandler {
+	return &scatterGroupHandler{
+		svr: s,
+		rd:  rd,
+	}
+}
+
+// @Tags     region
+// @Summary  List all scatter groups and their distribution over the stores.
+// @Produce  json
+// @Success  200  {array}  scattergroup.Distribution
+// @Router   /regions/scatter-groups [get]
+func (h *scatterGroupHandler) GetScatterGroups(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	groups := rc.GetScatterGroupManager().GetGroups()
+	dists := make([]*scattergroup.Distribution, 0, len(groups))
+	for _, group := range groups {
+		dists = append(dists, scattergroup.GetDistribution(rc, group))
+	}
+	h.rd.JSON(w, http.StatusOK, dists)
+}
+
+// @Tags     region
+// @Summary  Get the distribution of a scatter group over the stores.
+// @Param    name  path  string  true  "The name of the scatter group"
+// @Produce  json
+// @Success  200  {object}  scattergroup.Distribution
+// @Failure  404  {string}  string  "The scatter group does not exist."
+// @Router   /regions/scatter-groups/{name} [get]
+func (h *scatterGroupHandler) GetScatterGroup(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	group := rc.GetScatterGroupManager().GetGroup(mux.Vars(r)["name"])
+	if group == nil {
+		h.rd.JSON(w, http.StatusNotFound, "The scatter group does not exist.")
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, scattergroup.GetDistribution(rc, group))
+}
+
+// @Tags     region
+// @Summary  Delete a scatter group, the regions of the group are no longer kept evenly distributed.
+// @Param    name  path  string  true  "The name of the scatter group"
+// @Produce  json
+// @Success  200  {string}  string  "Delete scatter group successfully."
+// @Failure  404  {string}  string  "The scatter group does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/scatter-groups/{name} [delete]
+func (h *scatterGroupHandler) DeleteScatterGroup(w http.ResponseWriter, r *http.Request) {
+	manager := getCluster(r).GetScatterGroupManager()
+	name := mux.Vars(r)["name"]
+	if manager.GetGroup(name) == nil {
+		h.rd.JSON(w, http.StatusNotFound, "The scatter group does not exist.")
+		return
+	}
+	if err := manager.DeleteGroup(name); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Delete scatter group successfully.")
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+)
+
+type scatterGroupTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   cleanUpFunc
+	urlPrefix string
+}
+
+func TestScatterGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(scatterGroupTestSuite))
+}
+
+func (suite *scatterGroupTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1/regions/scatter-groups", addr, apiPrefix)
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *scatterGroupTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+func (suite *scatterGroupTestSuite) TestScatterGroups() {
+	re := suite.Require()
+	mustRegionHeartbeat(re, suite.svr, core.NewTestRegionInfo(100, 1, []byte("a"), []byte("b")))
+	mustRegionHeartbeat(re, suite.svr, core.NewTestRegionInfo(101, 1, []byte("b"), []byte("c")))
+	manager := suite.svr.GetRaftCluster().GetScatterGroupManager()
+	manager.AddRegions("g1", 100, 101, 102)
+	manager.AddRegions("g2", 101)
+
+	var dists []*scattergroup.Distribution
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, suite.urlPrefix, &dists))
+	suite.Len(dists, 2)
+	suite.Equal("g1", dists[0].Name)
+	suite.Equal(2, dists[0].RegionCount)
+	suite.Equal(uint64(2), dists[0].Leaders[1])
+	suite.Equal("g2", dists[1].Name)
+
+	var dist scattergroup.Distribution
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"/g2", &dist))
+	suite.Equal(1, dist.RegionCount)
+	suite.NoError(tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/g3", nil, tu.Status(re, http.StatusNotFound)))
+
+	code, err := apiutil.DoDelete(testDialClient, suite.urlPrefix+"/g2")
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, code)
+	suite.Nil(manager.GetGroup("g2"))
+	code, err = apiutil.DoDelete(testDialClient, suite.urlPrefix+"/g2")
+	suite.NoError(err)
+	suite.Equal(http.StatusNotFound, code)
+}
@@ -122,6 +122,8 @@ func (h *schedulerHandler) GetSchedulers(w http.ResponseWriter, r *http.Request)
 			}
 		}
 		h.r.JSON(w, http.StatusOK, disabledSchedulers)
+	case "windowed":
+		h.r.JSON(w, http.StatusOK, getMaintenanceStatus(h.svr))
 	default:
 		h.r.JSON(w, http.StatusOK, schedulers)
 	}
@@ -175,11 +177,25 @@ func (h *schedulerHandler) CreateScheduler(w http.ResponseWriter, r *http.Reques
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case schedulers.StoreHealthName:
+		if err := h.AddStoreHealthScheduler(); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	case schedulers.BalanceRegionName:
 		if err := h.AddBalanceRegionScheduler(); err != nil {
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case schedulers.BalanceTableName:
+		var args []string
+		if granularity, ok := input["granularity"].(string); ok {
+			args = append(args, granularity)
+		}
+		if err := h.AddBalanceTableScheduler(args...); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	case schedulers.LabelName:
 		if err := h.AddLabelScheduler(); err != nil {
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
@@ -249,6 +265,16 @@ func (h *schedulerHandler) CreateScheduler(w http.ResponseWriter, r *http.Reques
 			h.r.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+	case schedulers.LoadSplitName:
+		if err := h.AddLoadSplitScheduler(); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case schedulers.ScatterGroupName:
+		if err := h.AddScatterGroupScheduler(); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	case schedulers.GrantHotRegionName:
 		leaderID, ok := input["store-leader-id"].(string)
 		if !ok {
@@ -372,6 +398,68 @@ func newSchedulerConfigHandler(svr *server.Server, rd *render.Render) *scheduler
 	}
 }
 
+// The requests are served by the schedulers, and the configs vary with the schedulers.
+// @Tags     scheduler
+// @Summary  List the config of a scheduler.
+// @ID       ListSchedulerConfig
+// @Param    name  path  string  true  "The name of the scheduler"
+// @Produce  json
+// @Success  200  {object}  object  "The config of the scheduler."
+// @Failure  404  {string}  string  "The scheduler does not exist."
+// @Failure  406  {string}  string  "The cluster is not bootstrapped."
+// @Router   /scheduler-config/{name}/list [get]
+
+// @Tags     scheduler
+// @Summary  Update the config of a scheduler.
+// @ID       SetSchedulerConfig
+// @Accept   json
+// @Param    name  path  string  true  "The name of the scheduler"
+// @Param    body  body  object  true  "The config items to update"
+// @Produce  plain
+// @Success  200  {string}  string  "The config is updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scheduler does not exist."
+// @Failure  406  {string}  string  "The cluster is not bootstrapped."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /scheduler-config/{name}/config [post]
+
+// @Tags     scheduler
+// @Summary  Delete a store from the config of a scheduler, such as evict-leader-scheduler and grant-leader-scheduler.
+// @ID       DeleteSchedulerConfigStore
+// @Param    name      path  string   true  "The name of the scheduler"
+// @Param    store_id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {string}  string  "The store is deleted from the config."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scheduler or the store does not exist."
+// @Failure  406  {string}  string  "The cluster is not bootstrapped."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /scheduler-config/{name}/delete/{store_id} [delete]
+
+// @Tags     scheduler
+// @Summary  Get the roles of the peers which the shuffle region scheduler shuffles.
+// @ID       GetSchedulerRoles
+// @Param    name  path  string  true  "The name of the scheduler"
+// @Produce  json
+// @Success  200  {array}   string  "The roles of the scheduler."
+// @Failure  404  {string}  string  "The scheduler does not exist."
+// @Failure  406  {string}  string  "The cluster is not bootstrapped."
+// @Router   /scheduler-config/{name}/roles [get]
+
+// @Tags     scheduler
+// @Summary  Set the roles of the peers which the shuffle region scheduler shuffles.
+// @ID       SetSchedulerRoles
+// @Accept   json
+// @Param    name  path  string    true  "The name of the scheduler"
+// @Param    body  body  []string  true  "The roles of the scheduler, can include leader, follower and learner"
+// @Produce  plain
+// @Success  200  {string}  string  "The roles are updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scheduler does not exist."
+// @Failure  406  {string}  string  "The cluster is not bootstrapped."
+// @Router   /scheduler-config/{name}/roles [post]
+
+// GetSchedulerConfig serves the config API of the schedulers.
 func (h *schedulerConfigHandler) GetSchedulerConfig(w http.ResponseWriter, r *http.Request) {
 	handler := h.svr.GetHandler()
 	sh, err := handler.GetSchedulerConfigHandler()
@@ -111,6 +111,8 @@ func (h *serviceMiddlewareHandler) updateServiceMiddlewareConfig(cfg *config.Ser
 		return h.updateAudit(cfg, kp[len(kp)-1], value)
 	case "rate-limit":
 		return h.svr.UpdateRateLimit(&cfg.RateLimitConfig, kp[len(kp)-1], value)
+	case "rbac":
+		return h.updateRBAC(cfg, kp[len(kp)-1], value)
 	}
 	return errors.Errorf("config prefix %s not found", kp[0])
 }
@@ -131,9 +133,25 @@ func (h *serviceMiddlewareHandler) updateAudit(config *config.ServiceMiddlewareC
 	return err
 }
 
+func (h *serviceMiddlewareHandler) updateRBAC(config *config.ServiceMiddlewareConfig, key string, value interface{}) error {
+	updated, found, err := jsonutil.AddKeyValue(&config.RBACConfig, key, value)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.Errorf("config item %s not found", key)
+	}
+
+	if updated {
+		err = h.svr.SetRBACConfig(config.RBACConfig)
+	}
+	return err
+}
+
 // @Tags     service_middleware
 // @Summary  update ratelimit config
-// @Param    body  body  object  string  "json params"
+// @Param    body  body  object  true    "json params"
 // @Produce  json
 // @Success  200  {string}  string
 // @Failure  400  {string}  string  "The input is invalid."
@@ -182,14 +200,68 @@ func (h *serviceMiddlewareHandler) SetRatelimitConfig(w http.ResponseWriter, r *
 		return
 	}
 	cfg := h.svr.GetRateLimitConfig().LimiterConfig[serviceLabel]
-	// update concurrency limiter
-	concurrencyUpdatedFlag := "Concurrency limiter is not changed."
+	if !updateDimensionConfig(input, &cfg) {
+		h.rd.JSON(w, http.StatusOK, "No changed.")
+		return
+	}
+	status := h.svr.UpdateServiceRateLimiter(serviceLabel, ratelimit.UpdateDimensionConfig(&cfg))
+	concurrencyUpdatedFlag, qpsRateUpdatedFlag := rateLimitUpdatedFlags(status)
+	if err := h.svr.UpdateRateLimitConfig("limiter-config", serviceLabel, cfg); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	result := rateLimitResult{concurrencyUpdatedFlag, qpsRateUpdatedFlag, h.svr.GetServiceMiddlewareConfig().RateLimitConfig.LimiterConfig}
+	h.rd.JSON(w, http.StatusOK, result)
+}
+
+// @Tags     service_middleware
+// @Summary  update the rate limit config of a gRPC method
+// @Param    body  body  object  true    "json params"
+// @Produce  json
+// @Success  200  {string}  string
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /service-middleware/config/grpc-rate-limit [POST]
+func (h *serviceMiddlewareHandler) SetGRPCRateLimitConfig(w http.ResponseWriter, r *http.Request) {
+	var input map[string]interface{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	method, ok := input["label"].(string)
+	if !ok || len(method) == 0 {
+		h.rd.JSON(w, http.StatusBadRequest, "The label is empty.")
+		return
+	}
+	// The method name without the service is regarded as the method of PD service, like "GetRegion".
+	if !strings.Contains(method, "/") {
+		method = "pdpb.PD/" + method
+	}
+	if !server.IsGRPCRateLimitMethod(method) {
+		h.rd.JSON(w, http.StatusBadRequest, "There is no label matched.")
+		return
+	}
+	cfg := h.svr.GetRateLimitConfig().GRPCLimiterConfig[method]
+	if !updateDimensionConfig(input, &cfg) {
+		h.rd.JSON(w, http.StatusOK, "No changed.")
+		return
+	}
+	status := h.svr.UpdateGRPCServiceRateLimiter(method, ratelimit.UpdateDimensionConfig(&cfg))
+	concurrencyUpdatedFlag, qpsRateUpdatedFlag := rateLimitUpdatedFlags(status)
+	if err := h.svr.UpdateGRPCRateLimitConfig(method, cfg); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	result := rateLimitResult{concurrencyUpdatedFlag, qpsRateUpdatedFlag, h.svr.GetServiceMiddlewareConfig().RateLimitConfig.GRPCLimiterConfig}
+	h.rd.JSON(w, http.StatusOK, result)
+}
+
+// updateDimensionConfig updates the config by the "concurrency" and "qps" of the input,
+// it returns false if neither of them is set.
+func updateDimensionConfig(input map[string]interface{}, cfg *ratelimit.DimensionConfig) bool {
 	concurrencyFloat, okc := input["concurrency"].(float64)
 	if okc {
 		cfg.ConcurrencyLimit = uint64(concurrencyFloat)
 	}
-	// update qps rate limiter
-	qpsRateUpdatedFlag := "QPS rate limiter is not changed."
 	qps, okq := input["qps"].(float64)
 	if okq {
 		brust := 0
@@ -201,30 +273,25 @@ func (h *serviceMiddlewareHandler) SetRatelimitConfig(w http.ResponseWriter, r *
 		cfg.QPS = qps
 		cfg.QPSBurst = brust
 	}
-	if !okc && !okq {
-		h.rd.JSON(w, http.StatusOK, "No changed.")
-	} else {
-		status := h.svr.UpdateServiceRateLimiter(serviceLabel, ratelimit.UpdateDimensionConfig(&cfg))
-		switch {
-		case status&ratelimit.QPSChanged != 0:
-			qpsRateUpdatedFlag = "QPS rate limiter is changed."
-		case status&ratelimit.QPSDeleted != 0:
-			qpsRateUpdatedFlag = "QPS rate limiter is deleted."
-		}
-		switch {
-		case status&ratelimit.ConcurrencyChanged != 0:
-			concurrencyUpdatedFlag = "Concurrency limiter is changed."
-		case status&ratelimit.ConcurrencyDeleted != 0:
-			concurrencyUpdatedFlag = "Concurrency limiter is deleted."
-		}
-		err := h.svr.UpdateRateLimitConfig("limiter-config", serviceLabel, cfg)
-		if err != nil {
-			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
-		} else {
-			result := rateLimitResult{concurrencyUpdatedFlag, qpsRateUpdatedFlag, h.svr.GetServiceMiddlewareConfig().RateLimitConfig.LimiterConfig}
-			h.rd.JSON(w, http.StatusOK, result)
-		}
+	return okc || okq
+}
+
+func rateLimitUpdatedFlags(status ratelimit.UpdateStatus) (concurrencyUpdatedFlag, qpsRateUpdatedFlag string) {
+	concurrencyUpdatedFlag = "Concurrency limiter is not changed."
+	qpsRateUpdatedFlag = "QPS rate limiter is not changed."
+	switch {
+	case status&ratelimit.QPSChanged != 0:
+		qpsRateUpdatedFlag = "QPS rate limiter is changed."
+	case status&ratelimit.QPSDeleted != 0:
+		qpsRateUpdatedFlag = "QPS rate limiter is deleted."
+	}
+	switch {
+	case status&ratelimit.ConcurrencyChanged != 0:
+		concurrencyUpdatedFlag = "Concurrency limiter is changed."
+	case status&ratelimit.ConcurrencyDeleted != 0:
+		concurrencyUpdatedFlag = "Concurrency limiter is deleted."
 	}
+	return concurrencyUpdatedFlag, qpsRateUpdatedFlag
 }
 
 type rateLimitResult struct {
@@ -15,17 +15,28 @@
 package api
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/stretchr/testify/suite"
 	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/rbac"
+	"github.com/tikv/pd/pkg/utils/grpcutil"
+	"github.com/tikv/pd/pkg/utils/requestutil"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server"
 	"github.com/tikv/pd/server/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 type auditMiddlewareTestSuite struct {
@@ -288,6 +299,150 @@ func (suite *rateLimitConfigTestSuite) TestUpdateRateLimitConfig() {
 	suite.NoError(err)
 }
 
+func (suite *rateLimitConfigTestSuite) TestUpdateGRPCRateLimitConfig() {
+	re := suite.Require()
+	urlPrefix := fmt.Sprintf("%s/service-middleware/config/grpc-rate-limit", suite.urlPrefix)
+	for label, expected := range map[string]string{
+		"":                "\"The label is empty.\"\n",
+		"Unknown":         "\"There is no label matched.\"\n",
+		"pdpb.PD/Tso":     "\"There is no label matched.\"\n",
+		"RegionHeartbeat": "\"There is no label matched.\"\n",
+	} {
+		jsonBody, err := json.Marshal(map[string]interface{}{"label": label, "qps": 1})
+		suite.NoError(err)
+		suite.NoError(tu.CheckPostJSON(testDialClient, urlPrefix, jsonBody, tu.Status(re, http.StatusBadRequest), tu.StringEqual(re, expected)))
+	}
+	jsonBody, err := json.Marshal(map[string]interface{}{"label": "GetAllStores"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, urlPrefix, jsonBody, tu.StatusOK(re), tu.StringEqual(re, "\"No changed.\"\n")))
+	// the burst of the QPS less than 1 is 1.
+	jsonBody, err = json.Marshal(map[string]interface{}{"label": "GetAllStores", "qps": 0.001})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, urlPrefix, jsonBody, tu.StatusOK(re), tu.StringContain(re, "QPS rate limiter is changed.")))
+	suite.Equal(0.001, suite.svr.GetRateLimitConfig().GRPCLimiterConfig["pdpb.PD/GetAllStores"].QPS)
+
+	grpcPDClient := tu.MustNewGrpcClient(re, suite.svr.GetAddr())
+	getAllStores := func(component string) error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "component", component)
+		_, err := grpcPDClient.GetAllStores(ctx, &pdpb.GetAllStoresRequest{Header: &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()}})
+		return err
+	}
+	// it isn't limited before the gRPC rate limit is enabled.
+	for i := 0; i < 3; i++ {
+		suite.NoError(getAllStores("tidb"))
+	}
+	addr := fmt.Sprintf("%s/service-middleware/config", suite.urlPrefix)
+	postData, err := json.Marshal(map[string]interface{}{"rate-limit.enable-grpc-rate-limit": "true"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	suite.NoError(getAllStores("tidb"))
+	err = getAllStores("tidb")
+	suite.Equal(codes.ResourceExhausted, grpcstatus.Code(err))
+	// the other methods aren't limited.
+	_, err = grpcPDClient.GetClusterConfig(context.Background(), &pdpb.GetClusterConfigRequest{Header: &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()}})
+	suite.NoError(err)
+
+	// the component name in the metadata can't bypass the limit.
+	postData, err = json.Marshal(map[string]interface{}{"rate-limit.grpc-allow-subjects": []string{"token:" + rbac.HashToken("tikv-token")}})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	suite.Equal(codes.ResourceExhausted, grpcstatus.Code(getAllStores("tikv")))
+	// the subjects in the allow list aren't limited.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer tikv-token")
+	_, err = grpcPDClient.GetAllStores(ctx, &pdpb.GetAllStoresRequest{Header: &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()}})
+	suite.NoError(err)
+	// the request forwarded by the member has been limited by the member.
+	ctx = metadata.AppendToOutgoingContext(context.Background(), grpcutil.ForwardMetadataKey, "")
+	_, err = grpcPDClient.GetAllStores(ctx, &pdpb.GetAllStoresRequest{Header: &pdpb.RequestHeader{ClusterId: suite.svr.ClusterID()}})
+	suite.NoError(err)
+	suite.Equal(codes.ResourceExhausted, grpcstatus.Code(getAllStores("tidb")))
+	// the components in the allow list aren't limited, whose names are asserted by the clients.
+	postData, err = json.Marshal(map[string]interface{}{"rate-limit.grpc-allow-components": []string{"tikv"}})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	suite.NoError(getAllStores("tikv"))
+	suite.Equal(codes.ResourceExhausted, grpcstatus.Code(getAllStores("tidb")))
+
+	postData, err = json.Marshal(map[string]interface{}{"rate-limit.enable-grpc-rate-limit": "false"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	suite.NoError(getAllStores("tidb"))
+}
+
+func (suite *rateLimitConfigTestSuite) TestFairQueuing() {
+	re := suite.Require()
+	addr := fmt.Sprintf("%s/service-middleware/config", suite.urlPrefix)
+	postData, err := json.Marshal(map[string]interface{}{
+		"enable-fair-queuing": "true",
+		"fair-queuing":        map[string]interface{}{"min-concurrency": 1, "max-concurrency": 1, "max-queue-length": 0},
+	})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	sc := &config.ServiceMiddlewareConfig{}
+	suite.NoError(tu.ReadGetJSON(re, testDialClient, addr, sc))
+	suite.True(sc.EnableFairQueuing)
+	suite.Equal(uint64(1), sc.FairQueuing.MaxConcurrency)
+	suite.Equal(ratelimit.NewFairQueuingConfig().QueueTimeout, sc.FairQueuing.QueueTimeout)
+
+	getStores := func(component string) int {
+		req, err := http.NewRequest(http.MethodGet, suite.urlPrefix+"/stores", http.NoBody)
+		suite.NoError(err)
+		req.Header.Set("component", component)
+		resp, err := testDialClient.Do(req)
+		suite.NoError(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+	suite.Equal(http.StatusOK, getStores("tidb"))
+	// the concurrency is used up by another caller.
+	release, ok := suite.svr.GetFairLimiter().Acquire(context.Background(), "GetStores", "component:dashboard")
+	suite.True(ok)
+	suite.Equal(http.StatusTooManyRequests, getStores("tidb"))
+	// the event stream isn't queued.
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, suite.urlPrefix+"/events", http.NoBody)
+	suite.NoError(err)
+	resp, err := testDialClient.Do(req)
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	cancel()
+	resp.Body.Close()
+	// the config can still be updated.
+	postData, err = json.Marshal(map[string]interface{}{"fair-queuing": map[string]interface{}{"max-queue-length": 10}})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+	done := make(chan int)
+	go func() {
+		done <- getStores("tidb")
+	}()
+	suite.Eventually(func() bool {
+		_, _, waiting := suite.svr.GetFairLimiter().GetStatus()
+		return waiting == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	release()
+	suite.Equal(http.StatusOK, <-done)
+
+	postData, err = json.Marshal(map[string]interface{}{"enable-fair-queuing": "false"})
+	suite.NoError(err)
+	suite.NoError(tu.CheckPostJSON(testDialClient, addr, postData, tu.StatusOK(re)))
+}
+
+func (suite *rateLimitConfigTestSuite) TestCallerIdentity() {
+	m := &rateLimitMiddleware{svr: suite.svr}
+	req, err := http.NewRequest(http.MethodGet, suite.urlPrefix+"/stores", http.NoBody)
+	suite.NoError(err)
+	req.Header.Set("component", "tidb")
+	info := requestutil.GetRequestInfo(req)
+	// the component name is the last resort.
+	suite.Equal("component:tidb", m.callerIdentity(req, info))
+	info.IP = "10.0.0.1"
+	suite.Equal("ip:10.0.0.1", m.callerIdentity(req, info))
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{DNSNames: []string{"tidb.local"}}}}
+	suite.Equal("san:tidb.local", m.callerIdentity(req, info))
+	req.TLS.PeerCertificates[0].Subject.CommonName = "tidb"
+	suite.Equal("cn:tidb", m.callerIdentity(req, info))
+}
+
 func (suite *rateLimitConfigTestSuite) TestConfigRateLimitSwitch() {
 	addr := fmt.Sprintf("%s/service-middleware/config", suite.urlPrefix)
 	sc := &config.ServiceMiddlewareConfig{}
@@ -81,6 +81,8 @@ type StoreStatus struct {
 	SendingSnapCount   uint32             `json:"sending_snap_count,omitempty"`
 	ReceivingSnapCount uint32             `json:"receiving_snap_count,omitempty"`
 	IsBusy             bool               `json:"is_busy,omitempty"`
+	HealthState        string             `json:"health_state,omitempty"`
+	HealthSignals      []string           `json:"health_signals,omitempty"`
 	StartTS            *time.Time         `json:"start_ts,omitempty"`
 	LastHeartbeatTS    *time.Time         `json:"last_heartbeat_ts,omitempty"`
 	Uptime             *typeutil.Duration `json:"uptime,omitempty"`
@@ -126,9 +128,14 @@ func newStoreInfo(opt *config.ScheduleConfig, store *core.StoreInfo) *StoreInfo
 			SendingSnapCount:   store.GetSendingSnapCount(),
 			ReceivingSnapCount: store.GetReceivingSnapCount(),
 			IsBusy:             store.IsBusy(),
+			HealthSignals:      store.GetHealthSignals(),
 		},
 	}
 
+	if state := store.GetHealthState(); state != core.StoreHealthy {
+		s.Status.HealthState = state.String()
+	}
+
 	if store.GetStoreStats() != nil {
 		startTS := store.GetStartTime()
 		s.Status.StartTS = &startTS
@@ -200,7 +207,7 @@ func (h *storeHandler) GetStore(w http.ResponseWriter, r *http.Request) {
 // @Tags     store
 // @Summary  Take down a store from the cluster.
 // @Param    id     path   integer  true  "Store Id"
-// @Param    force  query  string   true  "force"  Enums(true, false)
+// @Param    force  query  string   false  "Set the store as Offline even if the regions can't be moved out"  Enums(true, false)
 // @Produce  json
 // @Success  200  {string}  string  "The store is set as Offline."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -283,8 +290,9 @@ func (h *storeHandler) responseStoreErr(w http.ResponseWriter, err error, storeI
 // FIXME: details of input json body params
 // @Tags     store
 // @Summary  Set the store's label.
-// @Param    id    path  integer  true  "Store Id"
-// @Param    body  body  object   true  "Labels in json format"
+// @Param    id     path   integer  true   "Store Id"
+// @Param    force  query  string   false  "Overwrite all the labels of the store instead of updating them"  Enums(true)
+// @Param    body   body   object   true   "Labels in json format"
 // @Produce  json
 // @Success  200  {string}  string  "The store's label is updated."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -329,9 +337,9 @@ func (h *storeHandler) SetStoreLabel(w http.ResponseWriter, r *http.Request) {
 // @Tags     store
 // @Summary  delete the store's label.
 // @Param    id    path  integer  true  "Store Id"
-// @Param    body  body  object   true  "Labels in json format"
+// @Param    body  body  string   true  "The key of the label"
 // @Produce  json
-// @Success  200  {string}  string  "The store's label is updated."
+// @Success  200  {string}  string  "The store's label is deleted."
 // @Failure  400  {string}  string  "The input is invalid."
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /store/{id}/label [delete]
@@ -635,7 +643,8 @@ func (h *storesHandler) GetAllStoresLimit(w http.ResponseWriter, r *http.Request
 // @Tags     store
 // @Summary  Set limit scene in the cluster.
 // @Accept   json
-// @Param    body  body  storelimit.Scene  true  "Store limit scene"
+// @Param    type  query  string  false  "The type of the store limit"  Enums(add-peer, remove-peer)
+// @Param    body  body   object  true   "The fields of storelimit.Scene to update"
 // @Produce  json
 // @Success  200  {string}  string  "Set store limit scene successfully."
 // @Failure  400  {string}  string  "The input is invalid."
@@ -0,0 +1,121 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errcode"
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server/cluster"
+)
+
+// @Tags     store
+// @Summary  Drain a store gracefully. The leaders are transferred out first, then the peers are moved out, and the store becomes tombstone at last.
+// @Param    id    path  integer                    true  "Store Id"
+// @Param    body  body  cluster.StoreDrainOptions  false  "The target stores, the target labels and the rate limit in regions per minute"
+// @Produce  json
+// @Success  200  {string}  string  "The store is being drained."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The store does not exist."
+// @Failure  410  {string}  string  "The store has already been removed."
+// @Router   /store/{id}/drain [post]
+func (h *storeHandler) DrainStore(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	var opts cluster.StoreDrainOptions
+	if r.ContentLength != 0 {
+		if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &opts); err != nil {
+			return
+		}
+	}
+	if opts.RateLimit < 0 {
+		h.rd.JSON(w, http.StatusBadRequest, "rate-limit should not be negative")
+		return
+	}
+	if err := rc.DrainStore(storeID, opts); err != nil {
+		h.responseStoreErr(w, err, storeID)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, fmt.Sprintf("The store %d is being drained.", storeID))
+}
+
+// @Tags     store
+// @Summary  Get the progress of draining the store.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {object}  cluster.StoreDrainProgress
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The store is not being drained."
+// @Router   /store/{id}/drain [get]
+func (h *storeHandler) GetStoreDrain(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	progress, err := rc.GetStoreDrainProgress(storeID)
+	if err != nil {
+		h.rd.JSON(w, http.StatusNotFound, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, progress)
+}
+
+// @Tags     store
+// @Summary  Cancel draining the store, and the store becomes up again if it's not tombstone.
+// @Param    id  path  integer  true  "Store Id"
+// @Produce  json
+// @Success  200  {string}  string  "The store drain is canceled."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The store is not being drained."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /store/{id}/drain [delete]
+func (h *storeHandler) CancelStoreDrain(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	storeID, errParse := apiutil.ParseUint64VarsField(mux.Vars(r), "id")
+	if errParse != nil {
+		apiutil.ErrorResp(h.rd, w, errcode.NewInvalidInputErr(errParse))
+		return
+	}
+	if err := rc.CancelStoreDrain(storeID); err != nil {
+		if errors.ErrorEqual(err, errs.ErrStoreDrainNotFound.FastGenByArgs(storeID)) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, fmt.Sprintf("The drain of store %d is canceled.", storeID))
+}
+
+// @Tags     stores
+// @Summary  Get the progress of all the store drains.
+// @Produce  json
+// @Success  200  {array}  cluster.StoreDrainProgress
+// @Router   /stores/drain [get]
+func (h *storesHandler) GetStoreDrains(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	h.rd.JSON(w, http.StatusOK, rc.GetStoreDrainProgresses())
+}
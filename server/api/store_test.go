@@ -33,6 +33,7 @@ import (
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/cluster"
 	"github.com/tikv/pd/server/config"
 )
 
@@ -357,6 +358,50 @@ func (suite *storeTestSuite) TestStoreSetState() {
 	suite.SetupSuite()
 }
 
+func (suite *storeTestSuite) TestStoreDrain() {
+	re := suite.Require()
+	// prepare enough online stores to store replica.
+	for id := 1111; id <= 1115; id++ {
+		mustPutStore(re, suite.svr, uint64(id), metapb.StoreState_Up, metapb.NodeState_Serving, nil)
+	}
+	url := fmt.Sprintf("%s/store/1/drain", suite.urlPrefix)
+	err := tu.CheckPostJSON(testDialClient, url, []byte(`{"rate-limit":-1}`), tu.StatusNotOK(re))
+	suite.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, url, []byte(`{"target-stores":[10086]}`), tu.StatusNotOK(re))
+	suite.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/store/10086/drain", nil, tu.Status(re, http.StatusNotFound))
+	suite.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, url, []byte(`{"target-stores":[1111,1112],"rate-limit":10}`), tu.StatusOK(re))
+	suite.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, url, nil, tu.StatusNotOK(re), tu.StringContain(re, "already being drained"))
+	suite.NoError(err)
+
+	info := StoreInfo{}
+	err = tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"/store/1", &info)
+	suite.NoError(err)
+	suite.Equal(metapb.StoreState_Offline, info.Store.State)
+	progress := &cluster.StoreDrainProgress{}
+	err = tu.ReadGetJSON(re, testDialClient, url, progress)
+	suite.NoError(err)
+	suite.Equal(uint64(1), progress.StoreID)
+	suite.Equal([]uint64{1111, 1112}, progress.Options.TargetStores)
+	suite.Equal(10.0, progress.Options.RateLimit)
+	var progresses []*cluster.StoreDrainProgress
+	err = tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"/stores/drain", &progresses)
+	suite.NoError(err)
+	suite.Len(progresses, 1)
+
+	suite.Equal(http.StatusOK, suite.requestStatusBody(testDialClient, http.MethodDelete, url))
+	suite.Equal(http.StatusNotFound, suite.requestStatusBody(testDialClient, http.MethodDelete, url))
+	suite.Equal(http.StatusNotFound, suite.requestStatusBody(testDialClient, http.MethodGet, url))
+	info = StoreInfo{}
+	err = tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"/store/1", &info)
+	suite.NoError(err)
+	suite.Equal(metapb.StoreState_Up, info.Store.State)
+	suite.cleanup()
+	suite.SetupSuite()
+}
+
 func (suite *storeTestSuite) TestUrlStoreFilter() {
 	testCases := []struct {
 		u    string
@@ -70,7 +70,54 @@ func (h *unsafeOperationHandler) RemoveFailedStores(w http.ResponseWriter, r *ht
 		timeout = uint64(rawTimeout)
 	}
 
-	if err := rc.GetUnsafeRecoveryController().RemoveFailedStores(stores, timeout, autoDetect); err != nil {
+	removeFailedStores := rc.GetUnsafeRecoveryController().RemoveFailedStores
+	if dryRun, exists := input["dry-run"].(bool); exists && dryRun {
+		removeFailedStores = rc.GetUnsafeRecoveryController().DryRunRemoveFailedStores
+	}
+	if err := removeFailedStores(stores, timeout, autoDetect); err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Request has been accepted.")
+}
+
+// @Tags     unsafe
+// @Summary  Get the recovery plan generated by the last dry run of failed stores removal.
+// @Produce  json
+// @Success  200  {object}  cluster.UnsafeRecoveryPlan
+// @Failure  404  {string}  string  "No dry run plan."
+// @Router   /admin/unsafe/remove-failed-stores/plan [GET]
+func (h *unsafeOperationHandler) GetFailedStoresRemovalPlan(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	plan := rc.GetUnsafeRecoveryController().GetDryRunPlan()
+	if plan == nil {
+		h.rd.JSON(w, http.StatusNotFound, "No dry run plan.")
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, plan)
+}
+
+// @Tags     unsafe
+// @Summary  Approve the recovery plan generated by the last dry run to remove the failed stores.
+// @Accept   json
+// @Param    body  body  object  true  "The ID of the plan in the format {"id": "..."}"
+// @Produce  json
+// Success 200 {string} string "Request has been accepted."
+// Failure 400 {string} string "The input is invalid."
+// Failure 500 {string} string "PD server failed to proceed the request."
+// @Router   /admin/unsafe/remove-failed-stores/approve [POST]
+func (h *unsafeOperationHandler) ApproveFailedStoresRemovalPlan(w http.ResponseWriter, r *http.Request) {
+	rc := getCluster(r)
+	var input map[string]interface{}
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	planID, ok := input["id"].(string)
+	if !ok || len(planID) == 0 {
+		h.rd.JSON(w, http.StatusBadRequest, "The plan ID is required")
+		return
+	}
+	if err := rc.GetUnsafeRecoveryController().ApproveDryRunPlan(planID); err != nil {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
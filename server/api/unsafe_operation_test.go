@@ -17,6 +17,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
@@ -99,3 +100,31 @@ func (suite *unsafeOperationTestSuite) TestRemoveFailedStoresAutoDetect() {
 	err = tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/remove-failed-stores", data, tu.StatusOK(re))
 	suite.NoError(err)
 }
+
+func (suite *unsafeOperationTestSuite) TestRemoveFailedStoresDryRun() {
+	re := suite.Require()
+
+	err := tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/remove-failed-stores/plan", nil, tu.Status(re, http.StatusNotFound))
+	suite.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/remove-failed-stores/approve", []byte(`{}`), tu.Status(re, http.StatusBadRequest),
+		tu.StringEqual(re, "\"The plan ID is required\"\n"))
+	suite.NoError(err)
+	err = tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/remove-failed-stores/approve", []byte(`{"id": "abc"}`), tu.StatusNotOK(re),
+		tu.StringEqual(re, "\"[PD:unsaferecovery:ErrUnsafeRecoveryInvalidInput]invalid input no dry run plan to approve\"\n"))
+	suite.NoError(err)
+
+	input := map[string]interface{}{"stores": []uint64{1}, "dry-run": true}
+	data, _ := json.Marshal(input)
+	err = tu.CheckPostJSON(testDialClient, suite.urlPrefix+"/remove-failed-stores", data, tu.StatusOK(re))
+	suite.NoError(err)
+	// the failed store is not marked as tombstone in the dry run
+	suite.False(suite.svr.GetRaftCluster().GetStore(1).IsRemoved())
+
+	var output []cluster.StageOutput
+	err = tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"/remove-failed-stores/show", &output)
+	suite.NoError(err)
+	suite.Equal("Unsafe recovery dry run enters collect report stage", output[0].Info)
+	// the plan is not ready before collecting all the reports
+	err = tu.CheckGetJSON(testDialClient, suite.urlPrefix+"/remove-failed-stores/plan", nil, tu.Status(re, http.StatusNotFound))
+	suite.NoError(err)
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/apiv2/middlewares"
+	"github.com/tikv/pd/server/keyspace"
+)
+
+// RegisterTSOKeyspaceGroup registers keyspace group handlers to the server.
+func RegisterTSOKeyspaceGroup(r *gin.RouterGroup) {
+	router := r.Group("tso/keyspace-groups")
+	router.Use(middlewares.BootstrapChecker())
+	router.POST("", CreateKeyspaceGroups)
+	router.GET("", GetKeyspaceGroups)
+	router.GET("/:id", GetKeyspaceGroupByID)
+	router.DELETE("/:id", DeleteKeyspaceGroupByID)
+}
+
+// CreateKeyspaceGroupParams defines the params for creating keyspace groups.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type CreateKeyspaceGroupParams struct {
+	KeyspaceGroups []*endpoint.KeyspaceGroup `json:"keyspace-groups"`
+}
+
+// CreateKeyspaceGroups creates keyspace groups.
+//
+//	@Tags		keyspace-groups
+//	@Summary	Create keyspace groups.
+//	@Param		body	body	CreateKeyspaceGroupParams	true	"Create keyspace groups parameters"
+//	@Produce	json
+//	@Success	200	{string}	string	"Create keyspace groups successfully."
+//	@Failure	400	{string}	string	"The input is invalid or the keyspace does not exist."
+//	@Failure	500	{string}	string	"PD server failed to proceed the request."
+//	@Router		/tso/keyspace-groups [post]
+func CreateKeyspaceGroups(c *gin.Context) {
+	svr := c.MustGet("server").(*server.Server)
+	manager := svr.GetKeyspaceGroupManager()
+	createParams := &CreateKeyspaceGroupParams{}
+	err := c.BindJSON(createParams)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errs.ErrBindJSON.Wrap(err).GenWithStackByCause())
+		return
+	}
+	err = manager.CreateKeyspaceGroups(createParams.KeyspaceGroups)
+	if errors.Cause(err) == keyspace.ErrKeyspaceNotFound {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// GetKeyspaceGroups gets all keyspace groups.
+//
+//	@Tags		keyspace-groups
+//	@Summary	List keyspace groups.
+//	@Produce	json
+//	@Success	200	{object}	[]endpoint.KeyspaceGroup
+//	@Failure	500	{string}	string	"PD server failed to proceed the request."
+//	@Router		/tso/keyspace-groups [get]
+func GetKeyspaceGroups(c *gin.Context) {
+	svr := c.MustGet("server").(*server.Server)
+	manager := svr.GetKeyspaceGroupManager()
+	keyspaceGroups, err := manager.GetKeyspaceGroups()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, keyspaceGroups)
+}
+
+// GetKeyspaceGroupByID gets keyspace group by id.
+//
+//	@Tags		keyspace-groups
+//	@Summary	Get keyspace group info.
+//	@Param		id	path	string	true	"Keyspace Group ID"
+//	@Produce	json
+//	@Success	200	{object}	endpoint.KeyspaceGroup
+//	@Failure	400	{string}	string	"The input is invalid."
+//	@Failure	404	{string}	string	"The keyspace group does not exist."
+//	@Failure	500	{string}	string	"PD server failed to proceed the request."
+//	@Router		/tso/keyspace-groups/{id} [get]
+func GetKeyspaceGroupByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid keyspace group id")
+		return
+	}
+	svr := c.MustGet("server").(*server.Server)
+	manager := svr.GetKeyspaceGroupManager()
+	kg, err := manager.GetKeyspaceGroupByID(uint32(id))
+	if err == keyspace.ErrKeyspaceGroupNotFound {
+		c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, kg)
+}
+
+// DeleteKeyspaceGroupByID deletes keyspace group by id.
+//
+//	@Tags		keyspace-groups
+//	@Summary	Delete keyspace group.
+//	@Param		id	path	string	true	"Keyspace Group ID"
+//	@Produce	json
+//	@Success	200	{string}	string	"Delete keyspace group successfully."
+//	@Failure	400	{string}	string	"The input is invalid."
+//	@Failure	404	{string}	string	"The keyspace group does not exist."
+//	@Failure	500	{string}	string	"PD server failed to proceed the request."
+//	@Router		/tso/keyspace-groups/{id} [delete]
+func DeleteKeyspaceGroupByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "invalid keyspace group id")
+		return
+	}
+	svr := c.MustGet("server").(*server.Server)
+	manager := svr.GetKeyspaceGroupManager()
+	err = manager.DeleteKeyspaceGroupByID(uint32(id))
+	if err == keyspace.ErrKeyspaceGroupNotFound {
+		c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tikv/pd/server"
+)
+
+// RBACChecker is a middleware to check whether the client is allowed to access the API if RBAC is
+// enabled. The service of the API is the name of its handler, like LoadKeyspace.
+func RBACChecker() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svr := c.MustGet("server").(*server.Server)
+		service := c.HandlerName()
+		if i := strings.LastIndex(service, "."); i >= 0 {
+			service = service[i+1:]
+		}
+		if err := svr.CheckHTTPPermission(c.Request, service); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, err.Error())
+			return
+		}
+		c.Next()
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/apiutil/serverapi"
 	"github.com/tikv/pd/server"
@@ -46,6 +47,7 @@ func Redirector() gin.HandlerFunc {
 		}
 
 		c.Request.Header.Set(serverapi.PDRedirectorHeader, svr.Name())
+		rbac.ForwardSubjects(c.Request, svr.Name())
 
 		leader := svr.GetMember().GetLeader()
 		if leader == nil {
@@ -61,7 +61,9 @@ func NewV2Handler(_ context.Context, svr *server.Server) (http.Handler, apiutil.
 		c.Next()
 	})
 	router.Use(middlewares.Redirector())
+	router.Use(middlewares.RBACChecker())
 	root := router.Group(apiV2Prefix)
 	handlers.RegisterKeyspace(root)
+	handlers.RegisterTSOKeyspaceGroup(root)
 	return router, group, nil
 }
@@ -15,6 +15,7 @@
 package cluster
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -34,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/gctuner"
 	"github.com/tikv/pd/pkg/id"
 	"github.com/tikv/pd/pkg/memory"
@@ -44,6 +46,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
 	"github.com/tikv/pd/pkg/schedule/schedulers"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/statistics"
@@ -78,6 +81,9 @@ var (
 // regionLabelGCInterval is the interval to run region-label's GC work.
 const regionLabelGCInterval = time.Hour
 
+// scatterGroupFlushInterval is the interval to persist the changes of the scatter groups.
+const scatterGroupFlushInterval = time.Second
+
 const (
 	// nodeStateCheckJobInterval is the interval to run node state check job.
 	nodeStateCheckJobInterval = 10 * time.Second
@@ -107,6 +113,7 @@ type Server interface {
 	GetBasicCluster() *core.BasicCluster
 	GetMembers() ([]*pdpb.Member, error)
 	ReplicateFileToMember(ctx context.Context, member *pdpb.Member, name string, data []byte) error
+	GetEventHub() *event.Hub
 }
 
 // RaftCluster is used for cluster config management.
@@ -151,11 +158,17 @@ type RaftCluster struct {
 	slowStat                 *statistics.SlowStat
 	ruleManager              *placement.RuleManager
 	regionLabeler            *labeler.RegionLabeler
+	scatterGroupManager      *scattergroup.Manager
+	storeDrainController     *storeDrainController
 	replicationMode          *replication.ModeManager
 	unsafeRecoveryController *unsafeRecoveryController
 	progressManager          *progress.Manager
 	regionSyncer             *syncer.RegionSyncer
 	changedRegions           chan *core.RegionInfo
+	eventHub                 *event.Hub
+	alertChecker             *alertChecker
+	// disconnectedStores is only accessed by the node state check job.
+	disconnectedStores map[uint64]struct{}
 }
 
 // Status saves some state information.
@@ -257,6 +270,7 @@ func (c *RaftCluster) Start(s Server) error {
 	defer c.Unlock()
 
 	c.InitCluster(s.GetAllocator(), s.GetPersistOptions(), s.GetStorage(), s.GetBasicCluster())
+	c.eventHub = s.GetEventHub()
 	cluster, err := c.LoadClusterInfo()
 	if err != nil {
 		return err
@@ -266,6 +280,7 @@ func (c *RaftCluster) Start(s Server) error {
 	}
 
 	c.ruleManager = placement.NewRuleManager(c.storage, c, c.GetOpts())
+	c.ruleManager.SetEventHub(c.eventHub)
 	if c.opt.IsPlacementRulesEnabled() {
 		err = c.ruleManager.Initialize(c.opt.GetMaxReplicas(), c.opt.GetLocationLabels())
 		if err != nil {
@@ -277,11 +292,24 @@ func (c *RaftCluster) Start(s Server) error {
 	if err != nil {
 		return err
 	}
+	c.regionLabeler.SetEventHub(c.eventHub)
+
+	c.scatterGroupManager, err = scattergroup.NewManager(c.ctx, c.storage, scatterGroupFlushInterval)
+	if err != nil {
+		return err
+	}
+
+	c.storeDrainController, err = newStoreDrainController(c)
+	if err != nil {
+		return err
+	}
 
 	c.replicationMode, err = replication.NewReplicationModeManager(s.GetConfig().ReplicationMode, c.storage, cluster, s)
 	if err != nil {
 		return err
 	}
+	c.replicationMode.SetEventHub(c.eventHub)
+	c.alertChecker = newAlertChecker(s.GetConfig().Notification.GCSafePointStallThreshold.Duration)
 	c.storeConfigManager = config.NewStoreConfigManager(c.httpClient)
 	c.coordinator = newCoordinator(c.ctx, cluster, s.GetHBStreams())
 	c.regionStats = statistics.NewRegionStatistics(c.opt, c.ruleManager, c.storeConfigManager)
@@ -291,7 +319,7 @@ func (c *RaftCluster) Start(s Server) error {
 		log.Error("load external timestamp meets error", zap.Error(err))
 	}
 
-	c.wg.Add(10)
+	c.wg.Add(11)
 	go c.runCoordinator()
 	go c.runMetricsCollectionJob()
 	go c.runNodeStateCheckJob()
@@ -302,6 +330,7 @@ func (c *RaftCluster) Start(s Server) error {
 	go c.runSyncConfig()
 	go c.runUpdateStoreStats()
 	go c.startGCTuner()
+	go c.runAlertCheckJob()
 
 	c.running.Store(true)
 	return nil
@@ -722,6 +751,11 @@ func (c *RaftCluster) GetRegionLabeler() *labeler.RegionLabeler {
 	return c.regionLabeler
 }
 
+// GetScatterGroupManager returns the scatter group manager.
+func (c *RaftCluster) GetScatterGroupManager() *scattergroup.Manager {
+	return c.scatterGroupManager
+}
+
 // GetStorage returns the storage.
 func (c *RaftCluster) GetStorage() storage.Storage {
 	c.RLock()
@@ -1067,6 +1101,12 @@ func (c *RaftCluster) ScanRegions(startKey, endKey []byte, limit int) []*core.Re
 	return c.core.ScanRange(startKey, endKey, limit)
 }
 
+// ScanRegionsWithIterator scans regions from the one containing or behind the start key,
+// until the iterator returns false.
+func (c *RaftCluster) ScanRegionsWithIterator(startKey []byte, iterator func(region *core.RegionInfo) bool) {
+	c.core.ScanRangeWithIterator(startKey, iterator)
+}
+
 // GetRegion searches for a region by ID.
 func (c *RaftCluster) GetRegion(regionID uint64) *core.RegionInfo {
 	return c.core.GetRegion(regionID)
@@ -1501,13 +1541,29 @@ func (c *RaftCluster) ResumeLeaderTransfer(storeID uint64) {
 // SlowStoreEvicted marks a store as a slow store and prevents transferring
 // leader to the store
 func (c *RaftCluster) SlowStoreEvicted(storeID uint64) error {
-	return c.core.SlowStoreEvicted(storeID)
+	if err := c.core.SlowStoreEvicted(storeID); err != nil {
+		return err
+	}
+	c.alertSlowStoreEvicted(storeID, "slow-store")
+	return nil
 }
 
 // SlowTrendEvicted marks a store as a slow store by trend and prevents transferring
 // leader to the store
 func (c *RaftCluster) SlowTrendEvicted(storeID uint64) error {
-	return c.core.SlowTrendEvicted(storeID)
+	if err := c.core.SlowTrendEvicted(storeID); err != nil {
+		return err
+	}
+	c.alertSlowStoreEvicted(storeID, "slow-trend")
+	return nil
+}
+
+func (c *RaftCluster) alertSlowStoreEvicted(storeID uint64, detectedBy string) {
+	detail := map[string]interface{}{"detected-by": detectedBy}
+	if store := c.GetStore(storeID); store != nil {
+		detail["address"] = store.GetAddress()
+	}
+	c.eventHub.Publish(event.TypeAlert, event.AlertSlowStoreEvicted, strconv.FormatUint(storeID, 10), detail)
 }
 
 // SlowTrendRecovered cleans the evicted by slow trend state of a store.
@@ -1520,6 +1576,11 @@ func (c *RaftCluster) SlowStoreRecovered(storeID uint64) {
 	c.core.SlowStoreRecovered(storeID)
 }
 
+// SetStoreHealthState sets the health state, the unhealthy signals and the leader ratio of a store.
+func (c *RaftCluster) SetStoreHealthState(storeID uint64, state core.StoreHealthState, signals []string, leaderRatio float64) {
+	c.core.SetStoreHealthState(storeID, state, signals, leaderRatio)
+}
+
 // NeedAwakenAllRegionsInStore checks whether we should do AwakenRegions operation.
 func (c *RaftCluster) NeedAwakenAllRegionsInStore(storeID uint64) (needAwaken bool, slowStoreIDs []uint64) {
 	store := c.GetStore(storeID)
@@ -1654,18 +1715,60 @@ func (c *RaftCluster) putStoreLocked(store *core.StoreInfo) error {
 			return err
 		}
 	}
+	old := c.core.GetStore(store.GetID())
 	c.core.PutStore(store)
+	if old == nil || old.GetNodeState() != store.GetNodeState() {
+		c.eventHub.Publish(event.TypeStore, storeStateAction(store.GetNodeState()), strconv.FormatUint(store.GetID(), 10),
+			map[string]interface{}{"address": store.GetAddress()})
+	}
 	c.hotStat.GetOrCreateRollingStoreStats(store.GetID())
 	c.slowStat.ObserveSlowStoreStatus(store.GetID(), store.IsSlow())
 	return nil
 }
 
+// storeStateAction returns the action of the store event when the store changes to the state.
+func storeStateAction(state metapb.NodeState) string {
+	switch state {
+	case metapb.NodeState_Serving:
+		return "up"
+	case metapb.NodeState_Removing:
+		return "offline"
+	case metapb.NodeState_Removed:
+		return "tombstone"
+	default:
+		return strings.ToLower(state.String())
+	}
+}
+
+// checkDisconnected publishes the event when the store is disconnected or connected again.
+func (c *RaftCluster) checkDisconnected(store *core.StoreInfo) {
+	if c.disconnectedStores == nil {
+		c.disconnectedStores = make(map[uint64]struct{})
+	}
+	storeID := store.GetID()
+	_, ok := c.disconnectedStores[storeID]
+	disconnected := !store.IsRemoved() && store.IsDisconnected()
+	if ok == disconnected {
+		return
+	}
+	action := "connected"
+	if disconnected {
+		action = "disconnected"
+		c.disconnectedStores[storeID] = struct{}{}
+	} else {
+		delete(c.disconnectedStores, storeID)
+	}
+	c.eventHub.Publish(event.TypeStore, action, strconv.FormatUint(storeID, 10),
+		map[string]interface{}{"address": store.GetAddress()})
+}
+
 func (c *RaftCluster) checkStores() {
 	var offlineStores []*metapb.Store
 	var upStoreCount int
 	stores := c.GetStores()
 
 	for _, store := range stores {
+		c.checkDisconnected(store)
 		// the store has already been tombstone
 		if store.IsRemoved() {
 			if store.DownTime() > gcTombstoneInterval {
@@ -2446,6 +2549,66 @@ func (c *RaftCluster) GetMinResolvedTS() uint64 {
 	return c.minResolvedTS
 }
 
+// GetMinResolvedTSByKeyRanges returns the min resolved ts of the stores holding any peer of the regions in the given
+// key ranges, which is the newest ts that a stale read on these ranges is guaranteed to be consistent no matter it's
+// served by the leader, a follower or a learner. It falls back to the cluster-level min resolved ts if the ranges are
+// not fully covered by the known regions, or any of these stores hasn't reported its min resolved ts yet.
+// The keys of the ranges must be in the same format as the region boundaries reported by TiKV, i.e., they're
+// the memcomparable-encoded keys rather than the raw user keys if the data is written by TxnKV or TiDB.
+func (c *RaftCluster) GetMinResolvedTSByKeyRanges(ranges []core.KeyRange) uint64 {
+	fallback := func() uint64 {
+		if ts := c.GetMinResolvedTS(); ts != math.MaxUint64 {
+			return ts
+		}
+		return 0
+	}
+	storeIDs := make(map[uint64]struct{})
+	for _, r := range ranges {
+		regions := c.ScanRegions(r.StartKey, r.EndKey, -1)
+		if !isKeyRangeCovered(regions, r.StartKey, r.EndKey) {
+			return fallback()
+		}
+		for _, region := range regions {
+			// The resolved ts of a region is advanced by its leader, so it's not safe to read without one.
+			if region.GetLeader() == nil {
+				return fallback()
+			}
+			// The stale read may be served by any peer, so all of their stores are taken into account.
+			for _, peer := range region.GetPeers() {
+				storeIDs[peer.GetStoreId()] = struct{}{}
+			}
+		}
+	}
+	minResolvedTS := uint64(math.MaxUint64)
+	for storeID := range storeIDs {
+		store := c.GetStore(storeID)
+		if store == nil || store.IsRemoved() || store.GetMinResolvedTS() == 0 {
+			return fallback()
+		}
+		if store.GetMinResolvedTS() < minResolvedTS {
+			minResolvedTS = store.GetMinResolvedTS()
+		}
+	}
+	if minResolvedTS == math.MaxUint64 {
+		return fallback()
+	}
+	return minResolvedTS
+}
+
+// isKeyRangeCovered checks whether the sorted regions cover the key range [startKey, endKey) without holes.
+func isKeyRangeCovered(regions []*core.RegionInfo, startKey, endKey []byte) bool {
+	if len(regions) == 0 || bytes.Compare(regions[0].GetStartKey(), startKey) > 0 {
+		return false
+	}
+	for i := 1; i < len(regions); i++ {
+		if !bytes.Equal(regions[i-1].GetEndKey(), regions[i].GetStartKey()) {
+			return false
+		}
+	}
+	lastEndKey := regions[len(regions)-1].GetEndKey()
+	return len(lastEndKey) == 0 || (len(endKey) != 0 && bytes.Compare(lastEndKey, endKey) >= 0)
+}
+
 // GetExternalTS returns the external timestamp.
 func (c *RaftCluster) GetExternalTS() uint64 {
 	c.RLock()
@@ -0,0 +1,238 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+)
+
+const (
+	// alertCheckJobInterval is the interval to check the critical conditions of the cluster.
+	alertCheckJobInterval = time.Minute
+	// maxQuorumLostAlerts is the max number of the regions alerted in a check, the remaining ones
+	// are alerted in the next checks, to avoid flooding the webhooks.
+	maxQuorumLostAlerts = 100
+	// maxPersistedQuorumLostRegions is the max number of the quorum lost regions persisted, to keep
+	// the alert state far below the request size limit of etcd. The regions beyond it may be alerted
+	// again by the new leader, at most maxQuorumLostAlerts in a check.
+	maxPersistedQuorumLostRegions = 10000
+)
+
+// alertChecker keeps the alerted conditions, so that a condition is only alerted once until it's
+// recovered. The conditions are persisted, so they aren't alerted again by the new leader. It's
+// only accessed by the alert check job.
+type alertChecker struct {
+	alertState
+	// saved is the last persisted state.
+	saved string
+	// gcSafePointStallThreshold is the max lag of a service GC safe point before it's alerted.
+	gcSafePointStallThreshold time.Duration
+}
+
+// alertState is the persisted state of the alerted conditions.
+type alertState struct {
+	DownStores        map[uint64]struct{} `json:"down-stores"`
+	QuorumLostRegions map[uint64]struct{} `json:"quorum-lost-regions"`
+	StalledServices   map[string]struct{} `json:"stalled-services"`
+}
+
+// persisted returns the state to persist, which keeps at most maxRegions quorum lost regions with
+// the smallest IDs, so the persisted state is stable between the checks.
+func (s alertState) persisted(maxRegions int) alertState {
+	if len(s.QuorumLostRegions) <= maxRegions {
+		return s
+	}
+	regionIDs := make([]uint64, 0, len(s.QuorumLostRegions))
+	for id := range s.QuorumLostRegions {
+		regionIDs = append(regionIDs, id)
+	}
+	sort.Slice(regionIDs, func(i, j int) bool { return regionIDs[i] < regionIDs[j] })
+	s.QuorumLostRegions = make(map[uint64]struct{}, maxRegions)
+	for _, id := range regionIDs[:maxRegions] {
+		s.QuorumLostRegions[id] = struct{}{}
+	}
+	return s
+}
+
+func newAlertChecker(gcSafePointStallThreshold time.Duration) *alertChecker {
+	return &alertChecker{
+		alertState: alertState{
+			DownStores:        make(map[uint64]struct{}),
+			QuorumLostRegions: make(map[uint64]struct{}),
+			StalledServices:   make(map[string]struct{}),
+		},
+		gcSafePointStallThreshold: gcSafePointStallThreshold,
+	}
+}
+
+// loadAlertState seeds the alerted conditions with the ones alerted by the previous leader.
+func (c *RaftCluster) loadAlertState() {
+	value, err := c.storage.LoadAlertState()
+	if err != nil || value == "" {
+		if err != nil {
+			log.Warn("failed to load the alert state", errs.ZapError(err))
+		}
+		return
+	}
+	if err := json.Unmarshal([]byte(value), &c.alertChecker.alertState); err != nil {
+		log.Warn("failed to unmarshal the alert state", errs.ZapError(errs.ErrJSONUnmarshal.Wrap(err)))
+		return
+	}
+	c.alertChecker.saved = value
+}
+
+// saveAlertState persists the alerted conditions if they are changed.
+func (c *RaftCluster) saveAlertState() {
+	value, err := json.Marshal(c.alertChecker.alertState.persisted(maxPersistedQuorumLostRegions))
+	if err != nil || string(value) == c.alertChecker.saved {
+		return
+	}
+	if err := c.storage.SaveAlertState(string(value)); err != nil {
+		log.Warn("failed to save the alert state", errs.ZapError(err))
+		return
+	}
+	c.alertChecker.saved = string(value)
+}
+
+func (c *RaftCluster) runAlertCheckJob() {
+	defer logutil.LogPanic()
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(alertCheckJobInterval)
+	failpoint.Inject("highFrequencyClusterJobs", func() {
+		ticker = time.NewTicker(2 * time.Second)
+	})
+	defer ticker.Stop()
+
+	c.loadAlertState()
+	for {
+		select {
+		case <-c.ctx.Done():
+			log.Info("alert check job has been stopped")
+			return
+		case <-ticker.C:
+			c.checkAlerts()
+		}
+	}
+}
+
+func (c *RaftCluster) checkAlerts() {
+	c.checkStoreDown()
+	c.checkRegionQuorum()
+	c.checkGCSafePoints()
+	c.saveAlertState()
+}
+
+// checkStoreDown alerts the stores which are down longer than max-store-down-time.
+func (c *RaftCluster) checkStoreDown() {
+	maxDownTime := c.opt.GetMaxStoreDownTime()
+	downStores := make(map[uint64]struct{})
+	for _, store := range c.GetStores() {
+		if store.IsRemoved() || store.DownTime() <= maxDownTime {
+			continue
+		}
+		downStores[store.GetID()] = struct{}{}
+		if _, ok := c.alertChecker.DownStores[store.GetID()]; !ok {
+			c.eventHub.Publish(event.TypeAlert, event.AlertStoreDown, strconv.FormatUint(store.GetID(), 10), map[string]interface{}{
+				"address":   store.GetAddress(),
+				"down-time": store.DownTime().String(),
+			})
+		}
+	}
+	c.alertChecker.DownStores = downStores
+}
+
+// checkRegionQuorum alerts the regions which lose the majority of the voters. A voter is regarded as
+// lost if its store is down longer than max-store-down-time, removed or not found. The disconnected
+// stores aren't regarded as lost, since they may be restarting.
+func (c *RaftCluster) checkRegionQuorum() {
+	maxDownTime := c.opt.GetMaxStoreDownTime()
+	lostStores := make(map[uint64]struct{})
+	for _, store := range c.GetStores() {
+		if store.IsRemoved() || store.DownTime() > maxDownTime {
+			lostStores[store.GetID()] = struct{}{}
+		}
+	}
+	quorumLostRegions := make(map[uint64]struct{})
+	alerted := 0
+	for storeID := range lostStores {
+		for _, region := range c.GetStoreRegions(storeID) {
+			if _, ok := quorumLostRegions[region.GetID()]; ok {
+				continue
+			}
+			voters := region.GetVoters()
+			alive := 0
+			for _, voter := range voters {
+				if _, ok := lostStores[voter.GetStoreId()]; !ok && c.GetStore(voter.GetStoreId()) != nil {
+					alive++
+				}
+			}
+			if alive > len(voters)/2 {
+				continue
+			}
+			if _, ok := c.alertChecker.QuorumLostRegions[region.GetID()]; !ok {
+				if alerted >= maxQuorumLostAlerts {
+					continue
+				}
+				alerted++
+				c.eventHub.Publish(event.TypeAlert, event.AlertRegionQuorumLost, strconv.FormatUint(region.GetID(), 10), map[string]interface{}{
+					"voters":       len(voters),
+					"alive-voters": alive,
+				})
+			}
+			quorumLostRegions[region.GetID()] = struct{}{}
+		}
+	}
+	c.alertChecker.QuorumLostRegions = quorumLostRegions
+}
+
+// checkGCSafePoints alerts the services whose GC safe points fall behind the threshold.
+func (c *RaftCluster) checkGCSafePoints() {
+	ssps, err := c.storage.LoadAllServiceGCSafePoints()
+	if err != nil {
+		log.Warn("failed to load the service GC safe points", errs.ZapError(err))
+		return
+	}
+	now := time.Now()
+	stalledServices := make(map[string]struct{})
+	for _, ssp := range ssps {
+		if ssp.ExpiredAt < now.Unix() {
+			continue
+		}
+		physical, _ := tsoutil.ParseTS(ssp.SafePoint)
+		lag := now.Sub(physical)
+		if lag <= c.alertChecker.gcSafePointStallThreshold {
+			continue
+		}
+		stalledServices[ssp.ServiceID] = struct{}{}
+		if _, ok := c.alertChecker.StalledServices[ssp.ServiceID]; !ok {
+			c.eventHub.Publish(event.TypeAlert, event.AlertGCSafePointStalled, ssp.ServiceID, map[string]interface{}{
+				"safe-point": ssp.SafePoint,
+				"lag":        lag.Round(time.Second).String(),
+			})
+		}
+	}
+	c.alertChecker.StalledServices = stalledServices
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/event"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+)
+
+func receiveAlerts(sub *event.Subscriber) []*event.Event {
+	var alerts []*event.Event
+	for {
+		select {
+		case e := <-sub.Events():
+			alerts = append(alerts, e)
+		default:
+			return alerts
+		}
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, opt, err := newTestScheduleConfig()
+	re.NoError(err)
+	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, storage.NewStorageWithMemoryBackend(), core.NewBasicCluster())
+	cluster.eventHub = event.NewHub(event.DefaultBacklogSize)
+	cluster.alertChecker = newAlertChecker(time.Hour)
+	sub, err := cluster.eventHub.Subscribe(event.Filter{Types: []event.Type{event.TypeAlert}}, "")
+	re.NoError(err)
+
+	// store 2 is disconnected, and store 3 and 4 are down longer than max-store-down-time.
+	lastHeartbeats := []time.Time{time.Now(), time.Now().Add(-time.Minute), time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)}
+	for i, store := range newTestStores(4, "2.0.0") {
+		re.NoError(cluster.putStoreLocked(store.Clone(core.SetLastHeartbeatTS(lastHeartbeats[i]))))
+	}
+	// region 2 loses the majority of the voters, and the disconnected store of region 1 isn't lost.
+	for id, storeIDs := range map[uint64][]uint64{1: {1, 2, 3}, 2: {1, 3, 4}} {
+		peers := make([]*metapb.Peer, 0, len(storeIDs))
+		for _, storeID := range storeIDs {
+			peers = append(peers, &metapb.Peer{Id: id*10 + storeID, StoreId: storeID})
+		}
+		region := core.NewRegionInfo(&metapb.Region{Id: id, Peers: peers, StartKey: []byte{byte(id)}, EndKey: []byte{byte(id + 1)}}, peers[0])
+		re.NoError(cluster.putRegion(region))
+	}
+	// the GC safe point of br falls behind the threshold.
+	for serviceID, lag := range map[string]time.Duration{"br": 2 * time.Hour, "cdc": time.Minute} {
+		safePoint := tsoutil.ComposeTS(time.Now().Add(-lag).UnixMilli(), 0)
+		re.NoError(cluster.storage.SaveServiceGCSafePoint(&endpoint.ServiceSafePoint{ServiceID: serviceID, ExpiredAt: math.MaxInt64, SafePoint: safePoint}))
+	}
+
+	cluster.checkAlerts()
+	alerts := receiveAlerts(sub)
+	re.Len(alerts, 4)
+	re.Equal(event.AlertStoreDown, alerts[0].Action)
+	re.Equal(event.AlertStoreDown, alerts[1].Action)
+	re.ElementsMatch([]string{"3", "4"}, []string{alerts[0].Subject, alerts[1].Subject})
+	re.Equal(event.AlertRegionQuorumLost, alerts[2].Action)
+	re.Equal("2", alerts[2].Subject)
+	re.Equal(event.AlertGCSafePointStalled, alerts[3].Action)
+	re.Equal("br", alerts[3].Subject)
+	// the conditions are alerted only once.
+	cluster.checkAlerts()
+	re.Empty(receiveAlerts(sub))
+	// the conditions alerted by the previous leader aren't alerted again.
+	cluster.alertChecker = newAlertChecker(time.Hour)
+	cluster.loadAlertState()
+	cluster.checkAlerts()
+	re.Empty(receiveAlerts(sub))
+
+	// the condition is alerted again after it's recovered.
+	store := cluster.GetStore(3)
+	re.NoError(cluster.putStoreLocked(store.Clone(core.SetLastHeartbeatTS(time.Now()))))
+	cluster.checkAlerts()
+	re.Empty(receiveAlerts(sub))
+	re.NoError(cluster.putStoreLocked(store))
+	cluster.checkAlerts()
+	alerts = receiveAlerts(sub)
+	re.Len(alerts, 2)
+	re.Equal(event.AlertStoreDown, alerts[0].Action)
+	re.Equal(event.AlertRegionQuorumLost, alerts[1].Action)
+
+	re.NoError(cluster.SlowStoreEvicted(4))
+	alerts = receiveAlerts(sub)
+	re.Len(alerts, 1)
+	re.Equal(event.AlertSlowStoreEvicted, alerts[0].Action)
+	re.Equal("4", alerts[0].Subject)
+}
+
+func TestPersistedAlertState(t *testing.T) {
+	re := require.New(t)
+	state := newAlertChecker(time.Hour).alertState
+	for id := uint64(1); id <= 5; id++ {
+		state.QuorumLostRegions[id] = struct{}{}
+	}
+	re.Len(state.persisted(5).QuorumLostRegions, 5)
+	persisted := state.persisted(3)
+	re.Equal(map[uint64]struct{}{1: {}, 2: {}, 3: {}}, persisted.QuorumLostRegions)
+	// the alerted state isn't changed.
+	re.Len(state.QuorumLostRegions, 5)
+}
@@ -304,7 +304,7 @@ func TestSetOfflineWithReplica(t *testing.T) {
 
 func addEvictLeaderScheduler(cluster *RaftCluster, storeID uint64) (evictScheduler schedule.Scheduler, err error) {
 	args := []string{fmt.Sprintf("%d", storeID)}
-	evictScheduler, err = schedule.CreateScheduler(schedulers.EvictLeaderType, cluster.GetOperatorController(), cluster.storage, schedule.ConfigSliceDecoder(schedulers.EvictLeaderType, args))
+	evictScheduler, err = schedule.CreateScheduler(schedulers.EvictLeaderType, cluster.GetOperatorController(), nil, cluster.storage, schedule.ConfigSliceDecoder(schedulers.EvictLeaderType, args))
 	if err != nil {
 		return
 	}
@@ -1839,6 +1839,68 @@ type testCluster struct {
 	*RaftCluster
 }
 
+func TestGetMinResolvedTSByKeyRanges(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, opt, err := newTestScheduleConfig()
+	re.NoError(err)
+	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, storage.NewStorageWithMemoryBackend(), core.NewBasicCluster())
+
+	// Store 1~5 with min resolved ts 100, 200, 300, 150 and 50, store 5 holds no region.
+	minResolvedTSs := []uint64{100, 200, 300, 150, 50}
+	for i, store := range newTestStores(5, "2.0.0") {
+		re.NoError(cluster.putStoreLocked(store.Clone(core.SetLeaderCount(1), core.SetMinResolvedTS(minResolvedTSs[i]))))
+	}
+	// Region [a, b) on store 1, 2; [b, c) on store 2, 3; [c, d) on store 3, 4. The leaders are on store 1, 2, 3.
+	keys := []string{"a", "b", "c", "d"}
+	for i := 0; i < 3; i++ {
+		peers := []*metapb.Peer{
+			{Id: uint64(10*i + 1), StoreId: uint64(i + 1)},
+			{Id: uint64(10*i + 2), StoreId: uint64(i + 2)},
+		}
+		region := core.NewRegionInfo(&metapb.Region{
+			Id:          uint64(i + 1),
+			Peers:       peers,
+			StartKey:    []byte(keys[i]),
+			EndKey:      []byte(keys[i+1]),
+			RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+		}, peers[0])
+		re.NoError(cluster.putRegion(region))
+	}
+	clusterMinResolvedTS, _ := cluster.checkAndUpdateMinResolvedTS()
+	re.Equal(uint64(50), clusterMinResolvedTS)
+
+	testCases := []struct {
+		startKey, endKey string
+		expect           uint64
+	}{
+		{"a", "b", 100},
+		{"b", "c", 200},
+		{"b", "bb", 200},
+		{"a", "c", 100},
+		{"b", "d", 150},
+		// The follower on store 4 is taken into account.
+		{"c", "d", 150},
+		// Not covered by the known regions.
+		{"", "b", clusterMinResolvedTS},
+		{"a", "", clusterMinResolvedTS},
+		{"c", "e", clusterMinResolvedTS},
+	}
+	for _, testCase := range testCases {
+		re.Equal(testCase.expect, cluster.GetMinResolvedTSByKeyRanges(
+			[]core.KeyRange{core.NewKeyRange(testCase.startKey, testCase.endKey)}),
+			"start: %s, end: %s", testCase.startKey, testCase.endKey)
+	}
+	// Multiple ranges.
+	re.Equal(uint64(100), cluster.GetMinResolvedTSByKeyRanges(
+		[]core.KeyRange{core.NewKeyRange("a", "b"), core.NewKeyRange("b", "c")}))
+	// Fall back if any store holding the peers hasn't reported the min resolved ts.
+	re.NoError(cluster.SetMinResolvedTS(2, 0))
+	re.Equal(uint64(50), cluster.GetMinResolvedTSByKeyRanges([]core.KeyRange{core.NewKeyRange("b", "c")}))
+}
+
 func newTestScheduleConfig() (*config.ScheduleConfig, *config.PersistOptions, error) {
 	schedulers.Register()
 	cfg := config.NewConfig()
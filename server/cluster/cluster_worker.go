@@ -216,6 +216,7 @@ func (c *RaftCluster) HandleReportSplit(request *pdpb.ReportSplitRequest) (*pdpb
 	log.Info("region split, generate new region",
 		zap.Uint64("region-id", originRegion.GetId()),
 		logutil.ZapRedactStringer("region-meta", core.RegionToHexMeta(left)))
+	c.inheritScatterGroups(originRegion.GetId(), left.GetId())
 	return &pdpb.ReportSplitResponse{}, nil
 }
 
@@ -238,9 +239,23 @@ func (c *RaftCluster) HandleBatchReportSplit(request *pdpb.ReportBatchSplitReque
 		zap.Uint64("region-id", originRegion.GetId()),
 		zap.Stringer("origin", hrm),
 		zap.Int("total", last))
+	newRegionIDs := make([]uint64, 0, last)
+	for _, region := range regions[:last] {
+		newRegionIDs = append(newRegionIDs, region.GetId())
+	}
+	c.inheritScatterGroups(originRegion.GetId(), newRegionIDs...)
 	return &pdpb.ReportBatchSplitResponse{}, nil
 }
 
+// inheritScatterGroups adds the regions split from the origin region into the
+// scatter groups of the origin region, so that they're kept evenly distributed too.
+func (c *RaftCluster) inheritScatterGroups(originRegionID uint64, newRegionIDs ...uint64) {
+	if c.scatterGroupManager == nil {
+		return
+	}
+	c.scatterGroupManager.InheritRegions(originRegionID, newRegionIDs...)
+}
+
 // HandleReportBuckets processes buckets reports from client
 func (c *RaftCluster) HandleReportBuckets(b *metapb.Buckets) error {
 	if err := c.processReportBuckets(b); err != nil {
@@ -17,12 +17,14 @@ package cluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/schedule/scattergroup"
 	"github.com/tikv/pd/pkg/storage"
 )
 
@@ -50,6 +52,9 @@ func TestReportBatchSplit(t *testing.T) {
 	_, opt, err := newTestScheduleConfig()
 	re.NoError(err)
 	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, storage.NewStorageWithMemoryBackend(), core.NewBasicCluster())
+	cluster.scatterGroupManager, err = scattergroup.NewManager(ctx, storage.NewStorageWithMemoryBackend(), time.Hour)
+	re.NoError(err)
+	cluster.scatterGroupManager.AddRegions("g", 3)
 	regions := []*metapb.Region{
 		{Id: 1, StartKey: []byte(""), EndKey: []byte("a")},
 		{Id: 2, StartKey: []byte("a"), EndKey: []byte("b")},
@@ -58,4 +63,6 @@ func TestReportBatchSplit(t *testing.T) {
 	}
 	_, err = cluster.HandleBatchReportSplit(&pdpb.ReportBatchSplitRequest{Regions: regions})
 	re.NoError(err)
+	// The new regions inherit the scatter group of the origin region.
+	re.Equal([]uint64{1, 2, 3}, cluster.scatterGroupManager.GetGroup("g").RegionIDs)
 }
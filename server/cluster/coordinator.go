@@ -29,6 +29,7 @@ import (
 	"github.com/tikv/pd/pkg/cache"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/schedule"
 	"github.com/tikv/pd/pkg/schedule/checker"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
@@ -87,6 +88,7 @@ type coordinator struct {
 func newCoordinator(ctx context.Context, cluster *RaftCluster, hbStreams *hbstream.HeartbeatStreams) *coordinator {
 	ctx, cancel := context.WithCancel(ctx)
 	opController := schedule.NewOperatorController(ctx, cluster, hbStreams)
+	opController.SetEventHub(cluster.eventHub)
 	schedulers := make(map[string]*scheduleController)
 	return &coordinator{
 		ctx:               ctx,
@@ -302,6 +304,26 @@ func (c *coordinator) drivePushOperator() {
 	}
 }
 
+// driveStoreDrain is used to drive the drains of the stores.
+func (c *coordinator) driveStoreDrain() {
+	defer logutil.LogPanic()
+
+	defer c.wg.Done()
+	ticker := time.NewTicker(storeDrainInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			log.Info("drive store drain has been stopped")
+			return
+		case <-ticker.C:
+			if d := c.cluster.storeDrainController; d != nil {
+				d.tick(c.opController)
+			}
+		}
+	}
+}
+
 func (c *coordinator) runUntilStop() {
 	c.run()
 	<-c.ctx.Done()
@@ -372,7 +394,7 @@ func (c *coordinator) run() {
 			log.Info("skip create scheduler with independent configuration", zap.String("scheduler-name", name), zap.String("scheduler-type", cfg.Type), zap.Strings("scheduler-args", cfg.Args))
 			continue
 		}
-		s, err := schedule.CreateScheduler(cfg.Type, c.opController, c.cluster.storage, schedule.ConfigJSONDecoder([]byte(data)))
+		s, err := schedule.CreateScheduler(cfg.Type, c.opController, c.regionScatterer, c.cluster.storage, schedule.ConfigJSONDecoder([]byte(data)))
 		if err != nil {
 			log.Error("can not create scheduler with independent configuration", zap.String("scheduler-name", name), zap.Strings("scheduler-args", cfg.Args), errs.ZapError(err))
 			continue
@@ -393,7 +415,7 @@ func (c *coordinator) run() {
 			continue
 		}
 
-		s, err := schedule.CreateScheduler(schedulerCfg.Type, c.opController, c.cluster.storage, schedule.ConfigSliceDecoder(schedulerCfg.Type, schedulerCfg.Args))
+		s, err := schedule.CreateScheduler(schedulerCfg.Type, c.opController, c.regionScatterer, c.cluster.storage, schedule.ConfigSliceDecoder(schedulerCfg.Type, schedulerCfg.Args))
 		if err != nil {
 			log.Error("can not create scheduler", zap.String("scheduler-type", schedulerCfg.Type), zap.Strings("scheduler-args", schedulerCfg.Args), errs.ZapError(err))
 			continue
@@ -416,12 +438,13 @@ func (c *coordinator) run() {
 		log.Error("cannot persist schedule config", errs.ZapError(err))
 	}
 
-	c.wg.Add(3)
+	c.wg.Add(4)
 	// Starts to patrol regions.
 	go c.patrolRegions()
 	// Checks suspect key ranges
 	go c.checkSuspectRanges()
 	go c.drivePushOperator()
+	go c.driveStoreDrain()
 }
 
 // LoadPlugin load user plugin
@@ -442,7 +465,7 @@ func (c *coordinator) LoadPlugin(pluginPath string, ch chan string) {
 	}
 	schedulerArgs := SchedulerArgs.(func() []string)
 	// create and add user scheduler
-	s, err := schedule.CreateScheduler(schedulerType(), c.opController, c.cluster.storage, schedule.ConfigSliceDecoder(schedulerType(), schedulerArgs()))
+	s, err := schedule.CreateScheduler(schedulerType(), c.opController, c.regionScatterer, c.cluster.storage, schedule.ConfigSliceDecoder(schedulerType(), schedulerArgs()))
 	if err != nil {
 		log.Error("can not create scheduler", zap.String("scheduler-type", schedulerType()), errs.ZapError(err))
 		return
@@ -639,6 +662,7 @@ func (c *coordinator) addScheduler(scheduler schedule.Scheduler, args ...string)
 	go c.runScheduler(s)
 	c.schedulers[s.GetName()] = s
 	c.cluster.opt.AddSchedulerCfg(s.GetType(), args)
+	c.cluster.eventHub.Publish(event.TypeScheduler, "add", s.GetName(), map[string]interface{}{"args": args})
 	return nil
 }
 
@@ -672,6 +696,7 @@ func (c *coordinator) removeScheduler(name string) error {
 	s.Stop()
 	schedulerStatusGauge.DeleteLabelValues(name, "allow")
 	delete(c.schedulers, name)
+	c.cluster.eventHub.Publish(event.TypeScheduler, "remove", name, nil)
 
 	return nil
 }
@@ -681,7 +706,7 @@ func (c *coordinator) removeOptScheduler(o *config.PersistOptions, name string)
 	for i, schedulerCfg := range v.Schedulers {
 		// To create a temporary scheduler is just used to get scheduler's name
 		decoder := schedule.ConfigSliceDecoder(schedulerCfg.Type, schedulerCfg.Args)
-		tmp, err := schedule.CreateScheduler(schedulerCfg.Type, schedule.NewOperatorController(c.ctx, nil, nil), storage.NewStorageWithMemoryBackend(), decoder)
+		tmp, err := schedule.CreateScheduler(schedulerCfg.Type, schedule.NewOperatorController(c.ctx, nil, nil), nil, storage.NewStorageWithMemoryBackend(), decoder)
 		if err != nil {
 			return err
 		}
@@ -726,6 +751,11 @@ func (c *coordinator) pauseOrResumeScheduler(name string, t int64) error {
 		}
 		atomic.StoreInt64(&sc.delayAt, delayAt)
 		atomic.StoreInt64(&sc.delayUntil, delayUntil)
+		if t > 0 {
+			c.cluster.eventHub.Publish(event.TypeScheduler, "pause", sc.GetName(), map[string]interface{}{"delay-until": delayUntil})
+		} else {
+			c.cluster.eventHub.Publish(event.TypeScheduler, "resume", sc.GetName(), nil)
+		}
 	}
 	return err
 }
@@ -928,7 +958,8 @@ func (s *scheduleController) AllowSchedule(diagnosable bool) bool {
 		}
 		return false
 	}
-	if s.IsPaused() || s.cluster.GetUnsafeRecoveryController().IsRunning() {
+	if s.IsPaused() || s.cluster.GetUnsafeRecoveryController().IsRunning() ||
+		!s.cluster.GetOpts().IsInMaintenanceWindow(s.Scheduler.GetType()) {
 		if diagnosable {
 			s.diagnosticRecorder.setResultFromStatus(paused)
 		}
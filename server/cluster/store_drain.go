@@ -0,0 +1,517 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/schedule"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"go.uber.org/zap"
+)
+
+const (
+	storeDrainInterval    = 5 * time.Second
+	storeDrainBatchSize   = 32
+	maxDrainBlockedRegion = 16
+	drainStoreLeaderDesc  = "drain-store-leader"
+	drainStoreRegionDesc  = "drain-store-region"
+	// throttledStatusClass is the class of the plan status codes of the soft limitations.
+	throttledStatusClass = 2
+)
+
+// The phases of the store drain.
+const (
+	// DrainPhaseLeader means the leaders of the store are being transferred out.
+	DrainPhaseLeader = "leader"
+	// DrainPhasePeer means the peers of the store are being moved out.
+	DrainPhasePeer = "peer"
+	// DrainPhaseFinished means the store is drained and becomes tombstone.
+	DrainPhaseFinished = "finished"
+)
+
+// StoreDrainOptions are the options of draining a store.
+type StoreDrainOptions struct {
+	// TargetStores are the stores which the peers are moved to, empty means all the stores.
+	TargetStores []uint64 `json:"target-stores,omitempty"`
+	// TargetLabels are the labels which the target stores must match.
+	TargetLabels map[string]string `json:"target-labels,omitempty"`
+	// RateLimit is the max number of the regions moved out per minute, 0 means no limit.
+	RateLimit float64 `json:"rate-limit,omitempty"`
+}
+
+// DrainBlockedRegion is a region whose peer can't be moved out of the draining store.
+type DrainBlockedRegion struct {
+	RegionID uint64 `json:"region-id"`
+	Reason   string `json:"reason"`
+}
+
+// StoreDrainProgress is the progress of draining a store.
+type StoreDrainProgress struct {
+	StoreID          uint64            `json:"store-id"`
+	Phase            string            `json:"phase"`
+	Options          StoreDrainOptions `json:"options"`
+	StartTime        time.Time         `json:"start-time"`
+	FinishTime       *time.Time        `json:"finish-time,omitempty"`
+	LeadersRemaining int               `json:"leaders-remaining"`
+	RegionsRemaining int               `json:"regions-remaining"`
+	BytesRemaining   uint64            `json:"bytes-remaining"`
+	// Progress is the ratio of the moved region size, which is in [0, 1].
+	Progress float64 `json:"progress"`
+	// ETA is the estimated time to finish the drain, which is estimated by the moving speed so far.
+	ETA            *time.Time            `json:"eta,omitempty"`
+	BlockedRegions []*DrainBlockedRegion `json:"blocked-regions,omitempty"`
+}
+
+type storeDrainJob struct {
+	StoreID uint64 `json:"store-id"`
+	StoreDrainOptions
+	StartTime       time.Time `json:"start-time"`
+	InitRegionCount int       `json:"init-region-count"`
+	InitRegionSize  int64     `json:"init-region-size"`
+
+	phase      string
+	finishTime time.Time
+	limiter    *ratelimit.RateLimiter
+	blocked    []*DrainBlockedRegion
+	// fallback is the regions whose peers can't be moved out by the drain,
+	// which are left to the checkers to repair.
+	fallback map[uint64]struct{}
+}
+
+func (j *storeDrainJob) init() {
+	j.phase = DrainPhaseLeader
+	j.fallback = make(map[uint64]struct{})
+	if j.RateLimit > 0 {
+		// The burst is the number of the regions of one minute.
+		burst := int(j.RateLimit)
+		if burst < 1 {
+			burst = 1
+		}
+		j.limiter = ratelimit.NewRateLimiter(j.RateLimit/60, burst)
+	}
+}
+
+func (j *storeDrainJob) isTarget(store *core.StoreInfo) bool {
+	if len(j.TargetStores) > 0 {
+		found := false
+		for _, id := range j.TargetStores {
+			if id == store.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for k, v := range j.TargetLabels {
+		if store.GetLabelValue(k) != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *storeDrainJob) addBlocked(regionID uint64, reason string) {
+	if len(j.blocked) < maxDrainBlockedRegion {
+		j.blocked = append(j.blocked, &DrainBlockedRegion{RegionID: regionID, Reason: reason})
+	}
+}
+
+// storeDrainController drains the stores gracefully. It transfers the leaders out first and then
+// moves the peers to the selected stores under the rate limit. The draining store is set offline
+// during the drain, so it becomes tombstone automatically once it's empty.
+type storeDrainController struct {
+	syncutil.RWMutex
+	cluster *RaftCluster
+	jobs    map[uint64]*storeDrainJob
+}
+
+func newStoreDrainController(c *RaftCluster) (*storeDrainController, error) {
+	d := &storeDrainController{
+		cluster: c,
+		jobs:    make(map[uint64]*storeDrainJob),
+	}
+	if err := c.storage.LoadStoreDrainJobs(func(k, v string) {
+		job := &storeDrainJob{}
+		if err := json.Unmarshal([]byte(v), job); err != nil {
+			log.Error("failed to unmarshal store drain job", zap.String("key", k), errs.ZapError(errs.ErrJSONUnmarshal, err))
+			return
+		}
+		job.init()
+		d.jobs[job.StoreID] = job
+	}); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DrainStore starts to drain the store. The store is set offline at first.
+func (c *RaftCluster) DrainStore(storeID uint64, opts StoreDrainOptions) error {
+	d := c.storeDrainController
+	d.RLock()
+	_, ok := d.jobs[storeID]
+	d.RUnlock()
+	if ok {
+		return errs.ErrStoreDrainExisted.FastGenByArgs(storeID)
+	}
+	for _, id := range opts.TargetStores {
+		if store := c.GetStore(id); store == nil || !store.IsUp() || id == storeID {
+			return errs.ErrInvalidStoreID.FastGenByArgs(id)
+		}
+	}
+	if c.GetStore(storeID) == nil {
+		return errs.ErrStoreNotFound.FastGenByArgs(storeID)
+	}
+	if err := c.RemoveStore(storeID, false); err != nil {
+		return err
+	}
+
+	_, regionCount, _, _, _, regionSize := c.core.GetStoreStats(storeID)
+	job := &storeDrainJob{
+		StoreID:           storeID,
+		StoreDrainOptions: opts,
+		StartTime:         time.Now(),
+		InitRegionCount:   regionCount,
+		InitRegionSize:    regionSize,
+	}
+	job.init()
+	if err := c.storage.SaveStoreDrainJob(storeID, job); err != nil {
+		return err
+	}
+	d.Lock()
+	d.jobs[storeID] = job
+	d.Unlock()
+	log.Info("store drain started", zap.Uint64("store-id", storeID), zap.Reflect("options", opts))
+	return nil
+}
+
+// CancelStoreDrain cancels draining the store, and the store becomes up again if it's not tombstone.
+// The record of a finished drain is removed as well.
+func (c *RaftCluster) CancelStoreDrain(storeID uint64) error {
+	d := c.storeDrainController
+	d.Lock()
+	job, ok := d.jobs[storeID]
+	if !ok {
+		d.Unlock()
+		return errs.ErrStoreDrainNotFound.FastGenByArgs(storeID)
+	}
+	if err := c.storage.DeleteStoreDrainJob(storeID); err != nil {
+		d.Unlock()
+		return err
+	}
+	delete(d.jobs, storeID)
+	d.Unlock()
+	if job.phase == DrainPhaseFinished {
+		return nil
+	}
+	log.Info("store drain canceled", zap.Uint64("store-id", storeID))
+	return c.UpStore(storeID)
+}
+
+// IsStoreDraining returns whether the store is being drained. The peers of the draining
+// store are moved out by the drain instead of the checkers.
+func (c *RaftCluster) IsStoreDraining(storeID uint64) bool {
+	d := c.storeDrainController
+	if d == nil {
+		return false
+	}
+	d.RLock()
+	defer d.RUnlock()
+	job, ok := d.jobs[storeID]
+	return ok && job.phase != DrainPhaseFinished
+}
+
+// IsStoreDrainBlocked returns whether the peer of the region can't be moved out of the draining
+// store by the drain, which is left to the checkers to repair.
+func (c *RaftCluster) IsStoreDrainBlocked(storeID, regionID uint64) bool {
+	d := c.storeDrainController
+	if d == nil {
+		return false
+	}
+	d.RLock()
+	defer d.RUnlock()
+	job, ok := d.jobs[storeID]
+	if !ok {
+		return false
+	}
+	_, blocked := job.fallback[regionID]
+	return blocked
+}
+
+// GetStoreDrainProgress returns the progress of draining the store.
+func (c *RaftCluster) GetStoreDrainProgress(storeID uint64) (*StoreDrainProgress, error) {
+	d := c.storeDrainController
+	d.RLock()
+	defer d.RUnlock()
+	job, ok := d.jobs[storeID]
+	if !ok {
+		return nil, errs.ErrStoreDrainNotFound.FastGenByArgs(storeID)
+	}
+	return d.getProgressLocked(job), nil
+}
+
+// GetStoreDrainProgresses returns the progress of all the drains.
+func (c *RaftCluster) GetStoreDrainProgresses() []*StoreDrainProgress {
+	d := c.storeDrainController
+	d.RLock()
+	defer d.RUnlock()
+	progresses := make([]*StoreDrainProgress, 0, len(d.jobs))
+	for _, job := range d.jobs {
+		progresses = append(progresses, d.getProgressLocked(job))
+	}
+	sort.Slice(progresses, func(i, j int) bool { return progresses[i].StoreID < progresses[j].StoreID })
+	return progresses
+}
+
+func (d *storeDrainController) getProgressLocked(job *storeDrainJob) *StoreDrainProgress {
+	p := &StoreDrainProgress{
+		StoreID:        job.StoreID,
+		Phase:          job.phase,
+		Options:        job.StoreDrainOptions,
+		StartTime:      job.StartTime,
+		BlockedRegions: job.blocked,
+	}
+	if job.phase == DrainPhaseFinished {
+		finishTime := job.finishTime
+		p.FinishTime = &finishTime
+		p.Progress = 1
+		return p
+	}
+	leaderCount, regionCount, _, _, _, regionSize := d.cluster.core.GetStoreStats(job.StoreID)
+	p.LeadersRemaining, p.RegionsRemaining = leaderCount, regionCount
+	p.BytesRemaining = uint64(regionSize) * units.MiB
+	if job.InitRegionSize <= 0 {
+		if regionCount == 0 {
+			p.Progress = 1
+		}
+		return p
+	}
+	moved := job.InitRegionSize - regionSize
+	if moved <= 0 {
+		return p
+	}
+	p.Progress = float64(moved) / float64(job.InitRegionSize)
+	if p.Progress > 1 {
+		p.Progress = 1
+	}
+	elapsed := time.Since(job.StartTime)
+	eta := time.Now().Add(time.Duration(float64(elapsed) * float64(regionSize) / float64(moved)))
+	p.ETA = &eta
+	return p
+}
+
+// tick drives all the drains.
+func (d *storeDrainController) tick(oc *schedule.OperatorController) {
+	d.Lock()
+	defer d.Unlock()
+	for storeID, job := range d.jobs {
+		if job.phase == DrainPhaseFinished {
+			continue
+		}
+		store := d.cluster.GetStore(storeID)
+		if store == nil || store.IsRemoved() {
+			job.phase, job.finishTime, job.blocked = DrainPhaseFinished, time.Now(), nil
+			job.fallback = make(map[uint64]struct{})
+			if err := d.cluster.storage.DeleteStoreDrainJob(storeID); err != nil {
+				log.Error("failed to delete store drain job", zap.Uint64("store-id", storeID), errs.ZapError(err))
+			}
+			log.Info("store drain finished", zap.Uint64("store-id", storeID), zap.Duration("takes", time.Since(job.StartTime)))
+			continue
+		}
+		if store.IsUp() {
+			// The store is brought up in other ways, e.g. `store cancel-delete`.
+			if err := d.cluster.storage.DeleteStoreDrainJob(storeID); err != nil {
+				log.Error("failed to delete store drain job", zap.Uint64("store-id", storeID), errs.ZapError(err))
+				continue
+			}
+			delete(d.jobs, storeID)
+			log.Info("store drain stopped since the store is up", zap.Uint64("store-id", storeID))
+			continue
+		}
+		d.drain(job, store, oc)
+	}
+}
+
+func (d *storeDrainController) drain(job *storeDrainJob, store *core.StoreInfo, oc *schedule.OperatorController) {
+	job.blocked = nil
+	regions := d.cluster.GetStoreRegions(store.GetID())
+	if d.cluster.core.GetStoreLeaderCount(store.GetID()) > 0 {
+		job.phase = DrainPhaseLeader
+		if d.drainLeaders(job, store, regions, oc) {
+			return
+		}
+	}
+	job.phase = DrainPhasePeer
+	d.drainPeers(job, store, regions, oc)
+}
+
+// drainLeaders transfers the leaders out of the store. It returns false if no leader can be
+// transferred, then the peers are moved with the leaders.
+func (d *storeDrainController) drainLeaders(job *storeDrainJob, store *core.StoreInfo, regions []*core.RegionInfo, oc *schedule.OperatorController) bool {
+	c, opts := d.cluster, d.cluster.GetOpts()
+	inProgress := false
+	created := 0
+	for _, region := range regions {
+		if region.GetLeader().GetStoreId() != store.GetID() {
+			continue
+		}
+		if oc.GetOperator(region.GetID()) != nil {
+			inProgress = true
+			continue
+		}
+		if created >= storeDrainBatchSize {
+			break
+		}
+		filters := []filter.Filter{&filter.StoreStateFilter{ActionScope: drainStoreLeaderDesc, TransferLeader: true}}
+		if f := filter.NewPlacementLeaderSafeguard(drainStoreLeaderDesc, opts, c.GetBasicCluster(), c.GetRuleManager(), region, store, false); f != nil {
+			filters = append(filters, f)
+		}
+		var target *core.StoreInfo
+		reasons := make(map[string]int)
+		for _, s := range c.GetFollowerStores(region) {
+			if reason, _ := filterReason(opts, s, filters); reason != "" {
+				reasons[reason]++
+				continue
+			}
+			if target == nil || s.GetLeaderCount() < target.GetLeaderCount() {
+				target = s
+			}
+		}
+		if target == nil {
+			job.addBlocked(region.GetID(), "no target store for the leader: "+formatReasons(reasons))
+			continue
+		}
+		op, err := operator.CreateTransferLeaderOperator(drainStoreLeaderDesc, c, region, store.GetID(), target.GetID(), []uint64{}, operator.OpLeader)
+		if err != nil {
+			job.addBlocked(region.GetID(), err.Error())
+			continue
+		}
+		op.SetPriorityClass(constant.RepairClass)
+		if oc.AddOperator(op) {
+			created++
+			inProgress = true
+		}
+	}
+	return inProgress
+}
+
+// drainPeers moves the peers out of the store under the rate limit. The peers which can't be moved
+// out by the drain are left to the checkers, which don't limit the target stores.
+func (d *storeDrainController) drainPeers(job *storeDrainJob, store *core.StoreInfo, regions []*core.RegionInfo, oc *schedule.OperatorController) {
+	c, opts := d.cluster, d.cluster.GetOpts()
+	stores := c.GetStores()
+	created := 0
+	for _, region := range regions {
+		if created >= storeDrainBatchSize || (job.limiter != nil && !job.limiter.Available(1)) {
+			return
+		}
+		if oc.GetOperator(region.GetID()) != nil {
+			continue
+		}
+		oldPeer := region.GetStorePeer(store.GetID())
+		if oldPeer == nil {
+			continue
+		}
+		filters := []filter.Filter{
+			filter.NewExcludedFilter(drainStoreRegionDesc, nil, region.GetStoreIDs()),
+			&filter.StoreStateFilter{ActionScope: drainStoreRegionDesc, MoveRegion: true},
+			filter.NewPlacementSafeguard(drainStoreRegionDesc, opts, c.GetBasicCluster(), c.GetRuleManager(), region, store, nil),
+		}
+		var target *core.StoreInfo
+		reasons := make(map[string]int)
+		throttled := false
+		for _, s := range stores {
+			if s.GetID() == store.GetID() {
+				continue
+			}
+			if !job.isTarget(s) {
+				reasons["not-target"]++
+				continue
+			}
+			if reason, isThrottled := filterReason(opts, s, filters); reason != "" {
+				reasons[reason]++
+				throttled = throttled || isThrottled
+				continue
+			}
+			if target == nil || regionScore(opts, s) < regionScore(opts, target) {
+				target = s
+			}
+		}
+		if target == nil {
+			job.addBlocked(region.GetID(), "no target store: "+formatReasons(reasons))
+			if !throttled {
+				job.fallback[region.GetID()] = struct{}{}
+			}
+			continue
+		}
+		newPeer := &metapb.Peer{StoreId: target.GetID(), Role: oldPeer.GetRole(), IsWitness: oldPeer.GetIsWitness()}
+		op, err := operator.CreateMovePeerOperator(drainStoreRegionDesc, c, region, operator.OpRegion, store.GetID(), newPeer)
+		if err != nil {
+			job.addBlocked(region.GetID(), err.Error())
+			job.fallback[region.GetID()] = struct{}{}
+			continue
+		}
+		delete(job.fallback, region.GetID())
+		op.SetPriorityClass(constant.RepairClass)
+		if !oc.AddOperator(op) {
+			job.addBlocked(region.GetID(), "rejected by the operator controller")
+			continue
+		}
+		created++
+		if job.limiter != nil {
+			job.limiter.AllowN(1)
+		}
+	}
+}
+
+// filterReason returns why the store is filtered out, and whether it's throttled, which
+// means the store may be selected later. It returns an empty reason if the store is selected.
+func filterReason(opts sc.Config, store *core.StoreInfo, filters []filter.Filter) (string, bool) {
+	for _, f := range filters {
+		if status := f.Target(opts, store); !status.IsOK() {
+			return fmt.Sprintf("%s(%s)", f.Type(), status), int(status.StatusCode)/100 == throttledStatusClass
+		}
+	}
+	return "", false
+}
+
+func regionScore(opts sc.Config, store *core.StoreInfo) float64 {
+	return store.RegionScore(opts.GetRegionScoreFormulaVersion(), opts.GetHighSpaceRatio(), opts.GetLowSpaceRatio(), 0)
+}
+
+func formatReasons(reasons map[string]int) string {
+	items := make([]string, 0, len(reasons))
+	for reason, count := range reasons {
+		items = append(items, fmt.Sprintf("%s x%d", reason, count))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+)
+
+func prepareStoreDrain(re *require.Assertions) (*testCluster, *coordinator, func()) {
+	tc, co, cleanup := prepare(nil, nil, nil, re)
+	var err error
+	tc.storeDrainController, err = newStoreDrainController(tc.RaftCluster)
+	re.NoError(err)
+	for i := uint64(1); i <= 4; i++ {
+		re.NoError(tc.addRegionStore(i, 3))
+	}
+	for i := uint64(1); i <= 3; i++ {
+		re.NoError(tc.addLeaderRegion(i, 1, 2, 3))
+	}
+	return tc, co, cleanup
+}
+
+func TestStoreDrain(t *testing.T) {
+	re := require.New(t)
+	tc, co, cleanup := prepareStoreDrain(re)
+	defer cleanup()
+	oc := co.opController
+	d := tc.storeDrainController
+
+	re.Error(tc.DrainStore(1, StoreDrainOptions{TargetStores: []uint64{1}}))
+	re.Error(tc.DrainStore(1, StoreDrainOptions{TargetStores: []uint64{5}}))
+	re.NoError(tc.DrainStore(1, StoreDrainOptions{TargetStores: []uint64{4}}))
+	re.Error(tc.DrainStore(1, StoreDrainOptions{}))
+	re.True(tc.GetStore(1).IsRemoving())
+	re.True(tc.IsStoreDraining(1))
+	// The checkers leave the draining store to the drain.
+	re.Empty(co.checkers.CheckRegion(tc.GetRegion(1)))
+
+	// The leaders are transferred out first.
+	d.tick(oc)
+	ops := oc.GetOperators()
+	re.Len(ops, 3)
+	for _, op := range ops {
+		re.Equal(drainStoreLeaderDesc, op.Desc())
+		re.NotZero(op.Kind() & operator.OpLeader)
+	}
+	progress, err := tc.GetStoreDrainProgress(1)
+	re.NoError(err)
+	re.Equal(DrainPhaseLeader, progress.Phase)
+	re.Equal(3, progress.LeadersRemaining)
+	re.Equal(3, progress.RegionsRemaining)
+	re.Zero(progress.Progress)
+	re.Nil(progress.ETA)
+
+	for _, op := range ops {
+		oc.RemoveOperator(op)
+		region := tc.GetRegion(op.RegionID())
+		re.NoError(tc.putRegion(region.Clone(core.WithLeader(region.GetStorePeer(2)))))
+	}
+
+	// Then the peers are moved to the target stores.
+	d.tick(oc)
+	ops = oc.GetOperators()
+	re.Len(ops, 1)
+	re.Equal(drainStoreRegionDesc, ops[0].Desc())
+	re.Contains(ops[0].String(), "mv peer: store [1] to [4]")
+	progress, err = tc.GetStoreDrainProgress(1)
+	re.NoError(err)
+	re.Equal(DrainPhasePeer, progress.Phase)
+	re.Zero(progress.LeadersRemaining)
+	// The other regions are blocked by the store limit of the target store.
+	re.Len(progress.BlockedRegions, 2)
+	re.Contains(progress.BlockedRegions[0].Reason, "StoreAddPeerThrottled")
+	// They are still left to the drain since the store limit is temporary.
+	re.False(tc.IsStoreDrainBlocked(1, progress.BlockedRegions[0].RegionID))
+	re.Empty(co.checkers.CheckRegion(tc.GetRegion(progress.BlockedRegions[0].RegionID)))
+
+	// Simulate one region is moved out.
+	region := tc.GetRegion(1)
+	re.NoError(tc.putRegion(region.Clone(core.WithRemoveStorePeer(1), core.WithAddPeer(region.GetStorePeer(2)))))
+	progress, err = tc.GetStoreDrainProgress(1)
+	re.NoError(err)
+	re.Equal(2, progress.RegionsRemaining)
+	re.InDelta(1.0/3, progress.Progress, 0.01)
+	re.NotNil(progress.ETA)
+
+	// The store is finished when it becomes tombstone.
+	re.NoError(tc.BuryStore(1, true))
+	d.tick(oc)
+	progress, err = tc.GetStoreDrainProgress(1)
+	re.NoError(err)
+	re.Equal(DrainPhaseFinished, progress.Phase)
+	re.Equal(1.0, progress.Progress)
+	re.NotNil(progress.FinishTime)
+	re.False(tc.IsStoreDraining(1))
+	d, err = newStoreDrainController(tc.RaftCluster)
+	re.NoError(err)
+	re.Empty(d.jobs)
+	re.NoError(tc.CancelStoreDrain(1))
+	re.Empty(tc.GetStoreDrainProgresses())
+}
+
+func TestStoreDrainBlockedAndCancel(t *testing.T) {
+	re := require.New(t)
+	tc, co, cleanup := prepareStoreDrain(re)
+	defer cleanup()
+	oc := co.opController
+
+	for i := uint64(1); i <= 3; i++ {
+		region := tc.GetRegion(i)
+		re.NoError(tc.putRegion(region.Clone(core.WithLeader(region.GetStorePeer(2)))))
+	}
+	re.NoError(tc.DrainStore(1, StoreDrainOptions{TargetLabels: map[string]string{"zone": "z1"}}))
+	tc.storeDrainController.tick(oc)
+	re.Empty(oc.GetOperators())
+	progress, err := tc.GetStoreDrainProgress(1)
+	re.NoError(err)
+	re.Equal(DrainPhasePeer, progress.Phase)
+	re.Len(progress.BlockedRegions, 3)
+	re.Contains(progress.BlockedRegions[0].Reason, "not-target")
+	// The regions which can't be moved out by the drain are repaired by the checkers.
+	re.True(tc.IsStoreDrainBlocked(1, 1))
+	ops := co.checkers.CheckRegion(tc.GetRegion(1))
+	re.Len(ops, 1)
+	re.Contains(ops[0].String(), "mv peer: store [1] to [4]")
+
+	// The job is persisted.
+	d, err := newStoreDrainController(tc.RaftCluster)
+	re.NoError(err)
+	re.Len(d.jobs, 1)
+	re.Equal(map[string]string{"zone": "z1"}, d.jobs[1].TargetLabels)
+
+	re.NoError(tc.CancelStoreDrain(1))
+	re.True(tc.GetStore(1).IsUp())
+	re.False(tc.IsStoreDraining(1))
+	re.Error(tc.CancelStoreDrain(1))
+	_, err = tc.GetStoreDrainProgress(1)
+	re.Error(err)
+
+	// The drain is limited by the rate.
+	re.NoError(tc.DrainStore(1, StoreDrainOptions{RateLimit: 1}))
+	tc.storeDrainController.tick(oc)
+	re.Len(oc.GetOperators(), 1)
+}
+
+func TestStoreDrainLeaderBlocked(t *testing.T) {
+	re := require.New(t)
+	tc, co, cleanup := prepareStoreDrain(re)
+	defer cleanup()
+
+	re.NoError(tc.PauseLeaderTransfer(2))
+	re.NoError(tc.PauseLeaderTransfer(3))
+	re.NoError(tc.DrainStore(1, StoreDrainOptions{}))
+	tc.storeDrainController.tick(co.opController)
+	progress, err := tc.GetStoreDrainProgress(1)
+	re.NoError(err)
+	re.NotEmpty(progress.BlockedRegions)
+	re.Contains(progress.BlockedRegions[0].Reason, "no target store for the leader")
+	// The peers are moved with the leaders.
+	re.Equal(DrainPhasePeer, progress.Phase)
+}
@@ -16,6 +16,8 @@ package cluster
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -23,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
@@ -111,6 +114,16 @@ type unsafeRecoveryController struct {
 	failedStores map[uint64]struct{}
 	timeout      time.Time
 	autoDetect   bool
+	// dryRun means the recovery only collects reports and generates the plan without dispatching it,
+	// the generated plan is kept in dryRunPlan until it's approved or another recovery starts.
+	dryRun        bool
+	timeoutSecond uint64
+	dryRunPlan    *UnsafeRecoveryPlan
+	// approvedPlan is the dry run plan being executed, whose stages are dispatched one by one
+	// instead of being generated from the reports, and approvedStage is the index of the next
+	// stage to dispatch.
+	approvedPlan  *UnsafeRecoveryPlan
+	approvedStage int
 
 	// collected reports from store, if not reported yet, it would be nil
 	storeReports      map[uint64]*pdpb.StoreReport
@@ -123,6 +136,7 @@ type unsafeRecoveryController struct {
 	output              []StageOutput
 	affectedTableIDs    map[int64]struct{}
 	affectedMetaRegions map[uint64]struct{}
+	affectedRegions     map[uint64]struct{}
 	err                 error
 }
 
@@ -134,6 +148,48 @@ type StageOutput struct {
 	Details []string            `json:"details,omitempty"`
 }
 
+// UnsafeRecoveryPlan is the complete recovery plan generated by the dry run, which can be
+// reviewed before being approved to execute.
+type UnsafeRecoveryPlan struct {
+	// ID is the digest of the plan, which must be given to approve the plan.
+	ID           string   `json:"id"`
+	Time         string   `json:"time"`
+	FailedStores []uint64 `json:"failed_stores,omitempty"`
+	AutoDetect   bool     `json:"auto_detect,omitempty"`
+	Timeout      uint64   `json:"timeout"`
+	// Stages are the simulated stages with the actions to be dispatched to each store.
+	Stages              []StageOutput `json:"stages"`
+	AffectedRegions     []uint64      `json:"affected_regions"`
+	AffectedMetaRegions []uint64      `json:"affected_meta_regions"`
+	AffectedTableIDs    []int64       `json:"affected_table_ids"`
+
+	// reportsDigest is the digest of the regions reported by the alive stores, which the plan
+	// is generated from.
+	reportsDigest string
+	// stagePlans are the recovery plans to be dispatched to the stores in each stage.
+	stagePlans []unsafeRecoveryStagePlan
+}
+
+// unsafeRecoveryStagePlan is the recovery plans of the stores in a stage.
+type unsafeRecoveryStagePlan struct {
+	stage unsafeRecoveryStage
+	plans map[uint64]*pdpb.RecoveryPlan
+}
+
+// digest returns the SHA-256 digest of the content of the plan, the time is included
+// so that the plans of different dry runs are distinguished.
+func (p *UnsafeRecoveryPlan) digest() string {
+	plan := *p
+	plan.ID = ""
+	data, err := json.Marshal(&plan)
+	if err != nil {
+		// It never happens since the plan only consists of the plain types.
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 func newUnsafeRecoveryController(cluster *RaftCluster) *unsafeRecoveryController {
 	u := &unsafeRecoveryController{
 		cluster: cluster,
@@ -153,6 +209,11 @@ func (u *unsafeRecoveryController) reset() {
 	u.output = make([]StageOutput, 0)
 	u.affectedTableIDs = make(map[int64]struct{}, 0)
 	u.affectedMetaRegions = make(map[uint64]struct{}, 0)
+	u.affectedRegions = make(map[uint64]struct{}, 0)
+	u.dryRun = false
+	u.dryRunPlan = nil
+	u.approvedPlan = nil
+	u.approvedStage = 0
 	u.err = nil
 }
 
@@ -172,7 +233,53 @@ func (u *unsafeRecoveryController) isRunningLocked() bool {
 func (u *unsafeRecoveryController) RemoveFailedStores(failedStores map[uint64]struct{}, timeout uint64, autoDetect bool) error {
 	u.Lock()
 	defer u.Unlock()
+	return u.removeFailedStoresLocked(failedStores, timeout, autoDetect, false, nil)
+}
+
+// DryRunRemoveFailedStores collects the reports from the alive stores and generates the complete
+// recovery plan for removing the failed stores, without marking the failed stores as tombstone or
+// dispatching any plan. The generated plan can be obtained by GetDryRunPlan and executed by ApproveDryRunPlan.
+func (u *unsafeRecoveryController) DryRunRemoveFailedStores(failedStores map[uint64]struct{}, timeout uint64, autoDetect bool) error {
+	u.Lock()
+	defer u.Unlock()
+	return u.removeFailedStoresLocked(failedStores, timeout, autoDetect, true, nil)
+}
+
+// GetDryRunPlan returns the recovery plan generated by the last dry run, nil if there is no such plan.
+func (u *unsafeRecoveryController) GetDryRunPlan() *UnsafeRecoveryPlan {
+	u.RLock()
+	defer u.RUnlock()
+	return u.dryRunPlan
+}
+
+// ApproveDryRunPlan starts the recovery to execute the plan generated by the last dry run. The ID of the
+// reviewed plan must be given, which is rejected if another dry run has replaced the plan. The recovery
+// collects the reports again and fails if the regions have changed since the dry run, otherwise the failed
+// stores are marked as tombstone and the stages of the plan are dispatched as they are. The progress can be
+// checked by Show.
+func (u *unsafeRecoveryController) ApproveDryRunPlan(planID string) error {
+	u.Lock()
+	defer u.Unlock()
+
+	if u.isRunningLocked() {
+		return errs.ErrUnsafeRecoveryIsRunning.FastGenByArgs()
+	}
+	plan := u.dryRunPlan
+	if plan == nil {
+		return errs.ErrUnsafeRecoveryInvalidInput.FastGenByArgs("no dry run plan to approve")
+	}
+	if planID != plan.ID {
+		return errs.ErrUnsafeRecoveryInvalidInput.FastGenByArgs(fmt.Sprintf("plan %q doesn't match the dry run plan %q", planID, plan.ID))
+	}
+	failedStores := make(map[uint64]struct{}, len(plan.FailedStores))
+	for _, store := range plan.FailedStores {
+		failedStores[store] = struct{}{}
+	}
+	return u.removeFailedStoresLocked(failedStores, plan.Timeout, plan.AutoDetect, false, plan)
+}
 
+func (u *unsafeRecoveryController) removeFailedStoresLocked(failedStores map[uint64]struct{}, timeout uint64, autoDetect, dryRun bool,
+	approvedPlan *UnsafeRecoveryPlan) error {
 	if u.isRunningLocked() {
 		return errs.ErrUnsafeRecoveryIsRunning.FastGenByArgs()
 	}
@@ -191,9 +298,9 @@ func (u *unsafeRecoveryController) RemoveFailedStores(failedStores map[uint64]st
 				return errs.ErrUnsafeRecoveryInvalidInput.FastGenByArgs(fmt.Sprintf("store %v is up and connected", failedStore))
 			}
 		}
-		for failedStore := range failedStores {
-			err := u.cluster.BuryStore(failedStore, true)
-			if err != nil && !errors.ErrorEqual(err, errs.ErrStoreNotFound.FastGenByArgs(failedStore)) {
+		// for the dry run, the failed stores are marked as tombstone after the plan is approved and verified
+		if !dryRun && approvedPlan == nil {
+			if err := u.buryFailedStores(failedStores); err != nil {
 				return err
 			}
 		}
@@ -211,12 +318,36 @@ func (u *unsafeRecoveryController) RemoveFailedStores(failedStores map[uint64]st
 	}
 
 	u.timeout = time.Now().Add(time.Duration(timeout) * time.Second)
+	u.timeoutSecond = timeout
 	u.failedStores = failedStores
 	u.autoDetect = autoDetect
+	u.dryRun = dryRun
+	if approvedPlan != nil {
+		u.approvedPlan = approvedPlan
+		for _, region := range approvedPlan.AffectedRegions {
+			u.affectedRegions[region] = struct{}{}
+		}
+		for _, region := range approvedPlan.AffectedMetaRegions {
+			u.affectedMetaRegions[region] = struct{}{}
+		}
+		for _, table := range approvedPlan.AffectedTableIDs {
+			u.affectedTableIDs[table] = struct{}{}
+		}
+	}
 	u.changeStage(collectReport)
 	return nil
 }
 
+func (u *unsafeRecoveryController) buryFailedStores(failedStores map[uint64]struct{}) error {
+	for failedStore := range failedStores {
+		err := u.cluster.BuryStore(failedStore, true)
+		if err != nil && !errors.ErrorEqual(err, errs.ErrStoreNotFound.FastGenByArgs(failedStore)) {
+			return err
+		}
+	}
+	return nil
+}
+
 // Show returns the current status of ongoing unsafe recover operation.
 func (u *unsafeRecoveryController) Show() []StageOutput {
 	u.Lock()
@@ -278,6 +409,11 @@ func (u *unsafeRecoveryController) HandleErr(err error) bool {
 	if u.err == nil {
 		u.err = err
 	}
+	if u.dryRun {
+		// No plan is dispatched in the dry run, so there is no force leader to exit.
+		u.changeStage(failed)
+		return true
+	}
 	if u.stage == exitForceLeader {
 		// We already tried to exit force leader, and it still failed.
 		// We turn into failed stage directly. TiKV will step down force leader
@@ -317,12 +453,18 @@ func (u *unsafeRecoveryController) HandleStoreHeartbeat(heartbeat *pdpb.StoreHea
 			return false, err
 		}
 
+		if allCollected && u.approvedPlan != nil {
+			return u.generateApprovedPlan()
+		}
 		if allCollected {
 			newestRegionTree, peersMap, err := u.buildUpFromReports()
 			if err != nil {
 				return false, err
 			}
 
+			if u.dryRun {
+				return u.generateDryRunPlan(newestRegionTree, peersMap)
+			}
 			return u.generatePlan(newestRegionTree, peersMap)
 		}
 		return false, nil
@@ -414,6 +556,74 @@ func (u *unsafeRecoveryController) generatePlan(newestRegionTree *regionTree, pe
 	return false, err
 }
 
+// generateApprovedPlan takes the plan of the next stage of the approved plan. Before the first stage,
+// the regions in the reports are verified to be the same as the ones the plan is generated from, and
+// the failed stores are marked as tombstone.
+func (u *unsafeRecoveryController) generateApprovedPlan() (bool, error) {
+	// clean up previous plan
+	u.storePlanExpires = make(map[uint64]time.Time)
+	u.storeRecoveryPlans = make(map[uint64]*pdpb.RecoveryPlan)
+
+	plan := u.approvedPlan
+	if u.stage == collectReport {
+		if u.getReportsDigest() != plan.reportsDigest {
+			// No plan has been dispatched, so there is no force leader to exit.
+			u.err = errors.Errorf("the regions have changed since plan %s is generated, please dry run again", plan.ID)
+			u.changeStage(failed)
+			return true, nil
+		}
+		if !u.autoDetect {
+			if err := u.buryFailedStores(u.failedStores); err != nil {
+				return false, err
+			}
+		}
+	}
+	// Stop dispatching the plan once an error occurs.
+	if u.err == nil && u.approvedStage < len(plan.stagePlans) {
+		stagePlan := plan.stagePlans[u.approvedStage]
+		u.approvedStage++
+		for storeID, storePlan := range stagePlan.plans {
+			u.storeRecoveryPlans[storeID] = proto.Clone(storePlan).(*pdpb.RecoveryPlan)
+		}
+		u.changeStage(stagePlan.stage)
+		return false, nil
+	}
+	if u.generateExitForceLeaderPlan() {
+		u.changeStage(exitForceLeader)
+		return false, nil
+	}
+	if u.err != nil {
+		u.changeStage(failed)
+	} else {
+		u.changeStage(finished)
+	}
+	return true, nil
+}
+
+// getReportsDigest returns the SHA-256 digest of the regions reported by the alive stores.
+func (u *unsafeRecoveryController) getReportsDigest() string {
+	storeIDs := make([]uint64, 0, len(u.storeReports))
+	for storeID := range u.storeReports {
+		storeIDs = append(storeIDs, storeID)
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+	h := sha256.New()
+	for _, storeID := range storeIDs {
+		regions := make([]string, 0, len(u.storeReports[storeID].GetPeerReports()))
+		for _, report := range u.storeReports[storeID].GetPeerReports() {
+			data, err := report.GetRegionState().GetRegion().Marshal()
+			if err != nil {
+				// It never happens since the region has been unmarshalled from the report.
+				panic(err)
+			}
+			regions = append(regions, hex.EncodeToString(data))
+		}
+		sort.Strings(regions)
+		fmt.Fprintf(h, "store %d: %s\n", storeID, strings.Join(regions, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // It dispatches recovery plan if any.
 func (u *unsafeRecoveryController) dispatchPlan(heartbeat *pdpb.StoreHeartbeatRequest, resp *pdpb.StoreHeartbeatResponse) {
 	storeID := heartbeat.Stats.StoreId
@@ -484,6 +694,11 @@ func (u *unsafeRecoveryController) changeStage(stage unsafeRecoveryStage) {
 	case collectReport:
 		// TODO: clean up existing operators
 		output.Info = "Unsafe recovery enters collect report stage"
+		if u.dryRun {
+			output.Info = "Unsafe recovery dry run enters collect report stage"
+		} else if u.approvedPlan != nil {
+			output.Info = fmt.Sprintf("Unsafe recovery enters collect report stage to execute plan %s", u.approvedPlan.ID)
+		}
 		if u.autoDetect {
 			output.Details = append(output.Details, "auto detect mode with no specified failed stores")
 		} else {
@@ -525,6 +740,9 @@ func (u *unsafeRecoveryController) changeStage(stage unsafeRecoveryStage) {
 			u.cluster.DropCacheAllRegion()
 		}
 		output.Info = "Unsafe recovery finished"
+		if u.dryRun {
+			output.Info = "Unsafe recovery dry run finished, the plan is waiting for approval"
+		}
 		output.Details = u.getAffectedTableDigest()
 		u.storePlanExpires = make(map[uint64]time.Time)
 		u.storeRecoveryPlans = make(map[uint64]*pdpb.RecoveryPlan)
@@ -650,6 +868,7 @@ func (u *unsafeRecoveryController) getAffectedTableDigest() []string {
 }
 
 func (u *unsafeRecoveryController) recordAffectedRegion(region *metapb.Region) {
+	u.affectedRegions[region.GetId()] = struct{}{}
 	isMeta, tableID := codec.Key(region.StartKey).MetaOrTable()
 	if isMeta {
 		u.affectedMetaRegions[region.GetId()] = struct{}{}
@@ -1214,6 +1433,112 @@ func (u *unsafeRecoveryController) generateCreateEmptyRegionPlan(newestRegionTre
 	return hasPlan, nil
 }
 
+// generateDryRunPlan simulates the whole recovery process based on the collected reports, assuming
+// every stage executes as planned, and keeps the complete plan for approval.
+func (u *unsafeRecoveryController) generateDryRunPlan(newestRegionTree *regionTree, peersMap map[uint64][]*regionItem) (bool, error) {
+	plan := &UnsafeRecoveryPlan{
+		Time:       time.Now().Format("2006-01-02 15:04:05.000"),
+		AutoDetect: u.autoDetect,
+		Timeout:    u.timeoutSecond,
+
+		reportsDigest: u.getReportsDigest(),
+	}
+	for store := range u.failedStores {
+		plan.FailedStores = append(plan.FailedStores, store)
+	}
+	sort.Slice(plan.FailedStores, func(i, j int) bool { return plan.FailedStores[i] < plan.FailedStores[j] })
+
+	simulate := func(stage unsafeRecoveryStage, info string, generate func() (bool, error), digest func() map[string][]string) error {
+		u.storeRecoveryPlans = make(map[uint64]*pdpb.RecoveryPlan)
+		hasPlan, err := generate()
+		if err != nil || !hasPlan {
+			return err
+		}
+		plan.Stages = append(plan.Stages, StageOutput{Info: info, Actions: digest()})
+		plan.stagePlans = append(plan.stagePlans, unsafeRecoveryStagePlan{stage: stage, plans: u.storeRecoveryPlans})
+		return nil
+	}
+	// applyTombstones removes the tombstoned peers as if the plan was executed.
+	applyTombstones := func() {
+		for storeID, storePlan := range u.storeRecoveryPlans {
+			for _, regionID := range storePlan.GetTombstones() {
+				peers := peersMap[regionID][:0]
+				for _, peer := range peersMap[regionID] {
+					if peer.storeID != storeID {
+						peers = append(peers, peer)
+					}
+				}
+				peersMap[regionID] = peers
+			}
+		}
+	}
+	// applyForceLeaders marks the peers as force leader as if the plan was executed.
+	applyForceLeaders := func() {
+		for storeID, storePlan := range u.storeRecoveryPlans {
+			for _, regionID := range storePlan.GetForceLeader().GetEnterForceLeaders() {
+				for _, peer := range peersMap[regionID] {
+					if peer.storeID == storeID {
+						// copy the report to keep the collected one unchanged
+						report := *peer.report
+						report.IsForceLeader = true
+						peer.report = &report
+					}
+				}
+			}
+		}
+	}
+
+	err := simulate(tombstoneTiFlashLearner, "tombstone TiFlash learner", func() (bool, error) {
+		return u.generateTombstoneTiFlashLearnerPlan(newestRegionTree, peersMap)
+	}, u.getTombstoneTiFlashLearnerDigest)
+	if err == nil {
+		applyTombstones()
+		err = simulate(forceLeaderForCommitMerge, "force leader for commit merge", func() (bool, error) {
+			return u.generateForceLeaderPlan(newestRegionTree, peersMap, true)
+		}, u.getForceLeaderPlanDigest)
+	}
+	if err == nil {
+		applyForceLeaders()
+		err = simulate(forceLeader, "force leader", func() (bool, error) {
+			return u.generateForceLeaderPlan(newestRegionTree, peersMap, false)
+		}, u.getForceLeaderPlanDigest)
+	}
+	if err == nil {
+		applyForceLeaders()
+		err = simulate(demoteFailedVoter, "demote failed voter", func() (bool, error) {
+			return u.generateDemoteFailedVoterPlan(newestRegionTree, peersMap), nil
+		}, u.getDemoteFailedVoterPlanDigest)
+	}
+	if err == nil {
+		err = simulate(createEmptyRegion, "create empty region", func() (bool, error) {
+			return u.generateCreateEmptyRegionPlan(newestRegionTree, peersMap)
+		}, u.getCreateEmptyRegionPlanDigest)
+	}
+	u.storeRecoveryPlans = make(map[uint64]*pdpb.RecoveryPlan)
+	if err != nil {
+		// Return as done to skip dispatching any plan.
+		u.HandleErr(err)
+		return true, nil
+	}
+
+	for region := range u.affectedRegions {
+		plan.AffectedRegions = append(plan.AffectedRegions, region)
+	}
+	sort.Slice(plan.AffectedRegions, func(i, j int) bool { return plan.AffectedRegions[i] < plan.AffectedRegions[j] })
+	for region := range u.affectedMetaRegions {
+		plan.AffectedMetaRegions = append(plan.AffectedMetaRegions, region)
+	}
+	sort.Slice(plan.AffectedMetaRegions, func(i, j int) bool { return plan.AffectedMetaRegions[i] < plan.AffectedMetaRegions[j] })
+	for table := range u.affectedTableIDs {
+		plan.AffectedTableIDs = append(plan.AffectedTableIDs, table)
+	}
+	sort.Slice(plan.AffectedTableIDs, func(i, j int) bool { return plan.AffectedTableIDs[i] < plan.AffectedTableIDs[j] })
+	plan.ID = plan.digest()
+	u.dryRunPlan = plan
+	u.changeStage(finished)
+	return true, nil
+}
+
 func (u *unsafeRecoveryController) generateExitForceLeaderPlan() bool {
 	hasPlan := false
 	for storeID, storeReport := range u.storeReports {
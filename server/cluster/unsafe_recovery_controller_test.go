@@ -16,6 +16,7 @@ package cluster
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -264,6 +265,190 @@ func TestFinished(t *testing.T) {
 	re.Equal(finished, recoveryController.GetStage())
 }
 
+func TestDryRun(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, opt, _ := newTestScheduleConfig()
+	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, storage.NewStorageWithMemoryBackend(), core.NewBasicCluster())
+	cluster.coordinator = newCoordinator(ctx, cluster, hbstream.NewTestHeartbeatStreams(ctx, cluster.meta.GetId(), cluster, true))
+	cluster.coordinator.run()
+	for _, store := range newTestStores(3, "6.0.0") {
+		re.NoError(cluster.PutStore(store.GetMeta()))
+	}
+	recoveryController := newUnsafeRecoveryController(cluster)
+	re.Error(recoveryController.ApproveDryRunPlan(""))
+	re.NoError(recoveryController.DryRunRemoveFailedStores(map[uint64]struct{}{
+		2: {},
+		3: {},
+	}, 60, false))
+	// the failed stores are not marked as tombstone in the dry run
+	re.False(cluster.GetStore(2).IsRemoved())
+	re.False(cluster.GetStore(3).IsRemoved())
+
+	reports := map[uint64]*pdpb.StoreReport{
+		1: {PeerReports: []*pdpb.PeerReport{
+			{
+				RaftState: &raft_serverpb.RaftLocalState{LastIndex: 10, HardState: &eraftpb.HardState{Term: 1, Commit: 10}},
+				RegionState: &raft_serverpb.RegionLocalState{
+					Region: &metapb.Region{
+						Id:          1001,
+						StartKey:    []byte(""),
+						EndKey:      []byte("c"),
+						RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+						Peers: []*metapb.Peer{
+							{Id: 11, StoreId: 1}, {Id: 21, StoreId: 2}, {Id: 31, StoreId: 3}}}}},
+		}},
+	}
+	re.Equal(collectReport, recoveryController.GetStage())
+	for storeID := range reports {
+		req := newStoreHeartbeat(storeID, nil)
+		resp := &pdpb.StoreHeartbeatResponse{}
+		recoveryController.HandleStoreHeartbeat(req, resp)
+		// require peer report by empty plan
+		re.NotNil(resp.RecoveryPlan)
+		re.Nil(resp.RecoveryPlan.ForceLeader)
+		applyRecoveryPlan(re, storeID, reports, resp)
+	}
+	for storeID, report := range reports {
+		req := newStoreHeartbeat(storeID, report)
+		resp := &pdpb.StoreHeartbeatResponse{}
+		recoveryController.HandleStoreHeartbeat(req, resp)
+		// no plan is dispatched in the dry run
+		re.Nil(resp.RecoveryPlan)
+	}
+	re.Equal(finished, recoveryController.GetStage())
+
+	plan := recoveryController.GetDryRunPlan()
+	re.NotNil(plan)
+	re.Equal([]uint64{2, 3}, plan.FailedStores)
+	re.Equal(uint64(60), plan.Timeout)
+	re.Len(plan.Stages, 3)
+	re.Equal("force leader", plan.Stages[0].Info)
+	re.Equal([]string{"force leader on regions: 1001"}, plan.Stages[0].Actions["store 1"])
+	re.Equal("demote failed voter", plan.Stages[1].Info)
+	re.Len(plan.Stages[1].Actions["store 1"], 1)
+	re.Contains(plan.Stages[1].Actions["store 1"][0], "region 1001 demotes peers")
+	re.Equal("create empty region", plan.Stages[2].Info)
+	re.Len(plan.Stages[2].Actions["store 1"], 1)
+	// the newly created empty region is affected as well
+	re.Len(plan.AffectedRegions, 2)
+	re.Contains(plan.AffectedRegions, uint64(1001))
+	// the reports are not modified by the dry run
+	re.False(reports[1].PeerReports[0].IsForceLeader)
+
+	re.Len(plan.ID, 64)
+	re.Equal(plan.ID, plan.digest())
+
+	// the plan which doesn't match the dry run plan is rejected
+	re.Error(recoveryController.ApproveDryRunPlan(""))
+	re.Error(recoveryController.ApproveDryRunPlan(strings.Repeat("0", 64)))
+	re.False(cluster.GetStore(2).IsRemoved())
+	// approve the plan to start the recovery
+	re.NoError(recoveryController.ApproveDryRunPlan(plan.ID))
+	re.Equal(collectReport, recoveryController.GetStage())
+	re.Nil(recoveryController.GetDryRunPlan())
+	re.Error(recoveryController.ApproveDryRunPlan(plan.ID))
+	// the failed stores are marked as tombstone after the reports are verified
+	re.False(cluster.GetStore(2).IsRemoved())
+	for storeID := range reports {
+		req := newStoreHeartbeat(storeID, nil)
+		resp := &pdpb.StoreHeartbeatResponse{}
+		recoveryController.HandleStoreHeartbeat(req, resp)
+		applyRecoveryPlan(re, storeID, reports, resp)
+	}
+	// the plan of each stage is dispatched as it is in the dry run plan
+	for _, stage := range []unsafeRecoveryStage{forceLeader, demoteFailedVoter, createEmptyRegion} {
+		for storeID, report := range reports {
+			req := newStoreHeartbeat(storeID, report)
+			resp := &pdpb.StoreHeartbeatResponse{}
+			recoveryController.HandleStoreHeartbeat(req, resp)
+			re.NotNil(resp.RecoveryPlan)
+			switch stage {
+			case forceLeader:
+				re.Equal([]uint64{1001}, resp.RecoveryPlan.GetForceLeader().GetEnterForceLeaders())
+			case demoteFailedVoter:
+				re.Len(resp.RecoveryPlan.GetDemotes(), 1)
+			case createEmptyRegion:
+				re.Len(resp.RecoveryPlan.GetCreates(), 1)
+				re.Contains(plan.AffectedRegions, resp.RecoveryPlan.GetCreates()[0].GetId())
+			}
+			applyRecoveryPlan(re, storeID, reports, resp)
+		}
+		re.Equal(stage, recoveryController.GetStage())
+		re.True(cluster.GetStore(2).IsRemoved())
+		re.True(cluster.GetStore(3).IsRemoved())
+	}
+	for storeID, report := range reports {
+		req := newStoreHeartbeat(storeID, report)
+		resp := &pdpb.StoreHeartbeatResponse{}
+		recoveryController.HandleStoreHeartbeat(req, resp)
+		re.Nil(resp.RecoveryPlan)
+	}
+	re.Equal(finished, recoveryController.GetStage())
+}
+
+func TestDryRunPlanChanged(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, opt, _ := newTestScheduleConfig()
+	cluster := newTestRaftCluster(ctx, mockid.NewIDAllocator(), opt, storage.NewStorageWithMemoryBackend(), core.NewBasicCluster())
+	cluster.coordinator = newCoordinator(ctx, cluster, hbstream.NewTestHeartbeatStreams(ctx, cluster.meta.GetId(), cluster, true))
+	cluster.coordinator.run()
+	for _, store := range newTestStores(3, "6.0.0") {
+		re.NoError(cluster.PutStore(store.GetMeta()))
+	}
+	recoveryController := newUnsafeRecoveryController(cluster)
+	reports := map[uint64]*pdpb.StoreReport{
+		1: {PeerReports: []*pdpb.PeerReport{
+			{
+				RaftState: &raft_serverpb.RaftLocalState{LastIndex: 10, HardState: &eraftpb.HardState{Term: 1, Commit: 10}},
+				RegionState: &raft_serverpb.RegionLocalState{
+					Region: &metapb.Region{
+						Id:          1001,
+						StartKey:    []byte(""),
+						EndKey:      []byte("c"),
+						RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+						Peers: []*metapb.Peer{
+							{Id: 11, StoreId: 1}, {Id: 21, StoreId: 2}, {Id: 31, StoreId: 3}}}}},
+		}},
+	}
+	collectReports := func() {
+		for storeID := range reports {
+			req := newStoreHeartbeat(storeID, nil)
+			resp := &pdpb.StoreHeartbeatResponse{}
+			recoveryController.HandleStoreHeartbeat(req, resp)
+			applyRecoveryPlan(re, storeID, reports, resp)
+		}
+		for storeID, report := range reports {
+			req := newStoreHeartbeat(storeID, report)
+			resp := &pdpb.StoreHeartbeatResponse{}
+			recoveryController.HandleStoreHeartbeat(req, resp)
+			re.Nil(resp.RecoveryPlan)
+		}
+	}
+	re.NoError(recoveryController.DryRunRemoveFailedStores(map[uint64]struct{}{
+		2: {},
+		3: {},
+	}, 60, false))
+	collectReports()
+	plan := recoveryController.GetDryRunPlan()
+	re.NotNil(plan)
+
+	// the region splits after the dry run
+	reports[1].PeerReports[0].RegionState.Region.RegionEpoch.Version = 2
+	reports[1].PeerReports[0].RegionState.Region.EndKey = []byte("b")
+	re.NoError(recoveryController.ApproveDryRunPlan(plan.ID))
+	collectReports()
+	// the plan is not dispatched and the failed stores are kept
+	re.Equal(failed, recoveryController.GetStage())
+	re.False(cluster.GetStore(2).IsRemoved())
+	re.False(cluster.GetStore(3).IsRemoved())
+}
+
 func TestFailed(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
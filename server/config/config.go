@@ -27,9 +27,12 @@ import (
 
 	"github.com/docker/go-units"
 	"github.com/spf13/pflag"
+	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	rm "github.com/tikv/pd/pkg/mcs/resource_manager/server"
+	"github.com/tikv/pd/pkg/schedule/maintenance"
 	"github.com/tikv/pd/pkg/utils/configutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/metricutil"
@@ -90,6 +93,17 @@ type Config struct {
 	// be automatically clamped to the range.
 	TSOUpdatePhysicalInterval typeutil.Duration `toml:"tso-update-physical-interval" json:"tso-update-physical-interval"`
 
+	// TSOTimeSource is the source of the TSO physical time, which is "local" for the local wall clock
+	// by default, or "ntp://host[:port]" to follow the time of an NTP server with the uncertainty bound
+	// estimated from the round trip and the root dispersion of the server.
+	TSOTimeSource string `toml:"tso-time-source" json:"tso-time-source"`
+
+	// TSOMaxClockUncertainty is the max uncertainty bound of the TSO physical time source.
+	// Once the uncertainty exceeds it, the TSO leader stops advancing the physical time, which slows
+	// down and finally rejects the TSO allocation. The latest uncertainty is also reported in the header of
+	// every TSO response once it is set. Zero means no limit.
+	TSOMaxClockUncertainty typeutil.Duration `toml:"tso-max-clock-uncertainty" json:"tso-max-clock-uncertainty"`
+
 	// EnableLocalTSO is used to enable the Local TSO Allocator feature,
 	// which allows the PD server to generate Local TSO for certain DC-level transactions.
 	// To make this feature meaningful, user has to set the "zone" label for the PD server
@@ -160,6 +174,8 @@ type Config struct {
 	Keyspace KeyspaceConfig `toml:"keyspace" json:"keyspace"`
 
 	RequestUnit rm.RequestUnitConfig `toml:"request-unit" json:"request-unit"`
+
+	Notification NotificationConfig `toml:"notification" json:"notification"`
 }
 
 // NewConfig creates a new config.
@@ -197,6 +213,7 @@ const (
 	defaultHeartbeatStreamRebindInterval = time.Minute
 
 	defaultLeaderPriorityCheckInterval = time.Minute
+	defaultGCSafePointStallThreshold   = 24 * time.Hour
 
 	defaultUseRegionStorage  = true
 	defaultTraceRegionFlow   = true
@@ -504,7 +521,7 @@ func (c *Config) Adjust(meta *toml.MetaData, reloading bool) error {
 
 	c.RequestUnit.Adjust()
 
-	return nil
+	return c.Notification.adjust()
 }
 
 func (c *Config) adjustLog(meta *configutil.ConfigMetaData) {
@@ -541,6 +558,15 @@ type ScheduleConfig struct {
 	MaxMergeRegionKeys uint64 `toml:"max-merge-region-keys" json:"max-merge-region-keys"`
 	// SplitMergeInterval is the minimum interval time to permit merge after split.
 	SplitMergeInterval typeutil.Duration `toml:"split-merge-interval" json:"split-merge-interval"`
+	// MergeHotRegionCoolDown is the duration that a region is not allowed to be merged after it was hot
+	// or had flow above the hot thresholds, to avoid the region being split by load again right after merge.
+	// The region which is hot now is never merged, 0 means only checking the current state, which is the default.
+	MergeHotRegionCoolDown typeutil.Duration `toml:"merge-hot-region-cool-down" json:"merge-hot-region-cool-down"`
+	// ColdRegionMergeDuration is the duration that a region has no read and write flow to be considered as cold.
+	// The cold regions are merged more aggressively. 0 means disabling the cold region merge.
+	ColdRegionMergeDuration typeutil.Duration `toml:"cold-region-merge-duration" json:"cold-region-merge-duration"`
+	// ColdRegionMergeRateLimit is the max number of the cold region merge operators created per minute.
+	ColdRegionMergeRateLimit uint64 `toml:"cold-region-merge-rate-limit" json:"cold-region-merge-rate-limit"`
 	// SwitchWitnessInterval is the minimum interval that allows a peer to become a witness again after it is promoted to non-witness.
 	SwitchWitnessInterval typeutil.Duration `toml:"switch-witness-interval" json:"swtich-witness-interval"`
 	// EnableOneWayMerge is the option to enable one way merge. This means a Region can only be merged into the next region of it.
@@ -669,6 +695,27 @@ type ScheduleConfig struct {
 	// EnableWitness is the option to enable using witness
 	EnableWitness bool `toml:"enable-witness" json:"enable-witness,string"`
 
+	// EnableOperatorPreemption is the option to allow the operators of a higher priority class
+	// to preempt the running operators of the lower classes when the store limit is exceeded.
+	EnableOperatorPreemption bool `toml:"enable-operator-preemption" json:"enable-operator-preemption,string"`
+	// OperatorClassQuota is the max number of the running operators of each priority class.
+	// The classes are repair, rule-fix, hot, balance and merge, and the class without quota is unlimited.
+	OperatorClassQuota map[string]uint64 `toml:"operator-class-quota" json:"operator-class-quota"`
+
+	// ClusterMovementLimit is the max size of the data moved across stores per minute in the whole cluster.
+	// The size is the region size sent to the stores by snapshots. The operators to repair the replicas are
+	// not limited. 0 means no limit, otherwise it should be at least 1MiB.
+	ClusterMovementLimit typeutil.ByteSize `toml:"cluster-movement-limit" json:"cluster-movement-limit"`
+	// ZoneMovementLimit is the max size of the data moved into the stores of each zone per minute.
+	// The zone of a store is specified by its "zone" label. 0 means no limit, otherwise it should be at least 1MiB.
+	ZoneMovementLimit typeutil.ByteSize `toml:"zone-movement-limit" json:"zone-movement-limit"`
+
+	// MaintenanceWindows are the recurring daily windows in UTC, the schedulers and checkers
+	// restricted by the windows only run within them.
+	MaintenanceWindows []maintenance.Window `toml:"maintenance-windows" json:"maintenance-windows"`
+	// BlackoutPeriods are the one-off periods, the schedulers and checkers are paused within them.
+	BlackoutPeriods []maintenance.Blackout `toml:"blackout-periods" json:"blackout-periods"`
+
 	// SlowStoreEvictingAffectedStoreRatioThreshold is the affected ratio threshold when judging a store is slow
 	// A store's slowness must affected more than `store-count * SlowStoreEvictingAffectedStoreRatioThreshold` to trigger evicting.
 	SlowStoreEvictingAffectedStoreRatioThreshold float64 `toml:"slow-store-evicting-affected-store-ratio-threshold" json:"slow-store-evicting-affected-store-ratio-threshold,omitempty"`
@@ -684,8 +731,18 @@ func (c *ScheduleConfig) Clone() *ScheduleConfig {
 			storeLimit[k] = v
 		}
 	}
+	var classQuota map[string]uint64
+	if c.OperatorClassQuota != nil {
+		classQuota = make(map[string]uint64, len(c.OperatorClassQuota))
+		for k, v := range c.OperatorClassQuota {
+			classQuota[k] = v
+		}
+	}
 	cfg := *c
 	cfg.StoreLimit = storeLimit
+	cfg.OperatorClassQuota = classQuota
+	cfg.MaintenanceWindows = append(c.MaintenanceWindows[:0:0], c.MaintenanceWindows...)
+	cfg.BlackoutPeriods = append(c.BlackoutPeriods[:0:0], c.BlackoutPeriods...)
 	cfg.Schedulers = schedulers
 	cfg.SchedulersPayload = nil
 	return &cfg
@@ -697,6 +754,7 @@ const (
 	defaultMaxPendingPeerCount       = 64
 	defaultMaxMergeRegionSize        = 20
 	defaultSplitMergeInterval        = time.Hour
+	defaultColdRegionMergeRateLimit  = 10
 	defaultSwitchWitnessInterval     = time.Hour
 	defaultEnableDiagnostic          = false
 	defaultPatrolRegionInterval      = 10 * time.Millisecond
@@ -739,6 +797,9 @@ func (c *ScheduleConfig) adjust(meta *configutil.ConfigMetaData, reloading bool)
 		configutil.AdjustUint64(&c.MaxMergeRegionSize, defaultMaxMergeRegionSize)
 	}
 	configutil.AdjustDuration(&c.SplitMergeInterval, defaultSplitMergeInterval)
+	if !meta.IsDefined("cold-region-merge-rate-limit") {
+		configutil.AdjustUint64(&c.ColdRegionMergeRateLimit, defaultColdRegionMergeRateLimit)
+	}
 	configutil.AdjustDuration(&c.SwitchWitnessInterval, defaultSwitchWitnessInterval)
 	configutil.AdjustDuration(&c.PatrolRegionInterval, defaultPatrolRegionInterval)
 	configutil.AdjustDuration(&c.MaxStoreDownTime, defaultMaxStoreDownTime)
@@ -903,6 +964,27 @@ func (c *ScheduleConfig) Validate() error {
 	if c.SlowStoreEvictingAffectedStoreRatioThreshold == 0 {
 		return errors.Errorf("slow-store-evicting-affected-store-ratio-threshold is not set")
 	}
+	for class := range c.OperatorClassQuota {
+		if _, ok := constant.StringToPriorityClass(class); !ok {
+			return errors.Errorf("operator class %v in operator-class-quota is invalid", class)
+		}
+	}
+	if c.ClusterMovementLimit != 0 && c.ClusterMovementLimit < units.MiB {
+		return errors.Errorf("cluster-movement-limit %v should be 0 or at least 1MiB", c.ClusterMovementLimit)
+	}
+	if c.ZoneMovementLimit != 0 && c.ZoneMovementLimit < units.MiB {
+		return errors.Errorf("zone-movement-limit %v should be 0 or at least 1MiB", c.ZoneMovementLimit)
+	}
+	for i := range c.MaintenanceWindows {
+		if err := c.MaintenanceWindows[i].Validate(); err != nil {
+			return err
+		}
+	}
+	for i := range c.BlackoutPeriods {
+		if err := c.BlackoutPeriods[i].Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1225,6 +1307,16 @@ func (c *Config) GetTSOUpdatePhysicalInterval() time.Duration {
 	return c.TSOUpdatePhysicalInterval.Duration
 }
 
+// GetTSOTimeSource returns the source of the TSO physical time.
+func (c *Config) GetTSOTimeSource() string {
+	return c.TSOTimeSource
+}
+
+// GetTSOMaxClockUncertainty returns the max uncertainty bound of the TSO physical time source.
+func (c *Config) GetTSOMaxClockUncertainty() time.Duration {
+	return c.TSOMaxClockUncertainty.Duration
+}
+
 // GetTSOSaveInterval returns TSO save interval.
 func (c *Config) GetTSOSaveInterval() time.Duration {
 	return c.TSOSaveInterval.Duration
@@ -1379,6 +1471,23 @@ func (c *DRAutoSyncReplicationConfig) adjust(meta *configutil.ConfigMetaData) {
 	}
 }
 
+// NotificationConfig is the configuration for the webhook notifications of the critical conditions.
+type NotificationConfig struct {
+	Webhooks []event.WebhookConfig `toml:"webhooks" json:"webhooks"`
+	// GCSafePointStallThreshold is the max lag of a service GC safe point before it's regarded as stalled.
+	GCSafePointStallThreshold typeutil.Duration `toml:"gc-safepoint-stall-threshold" json:"gc-safepoint-stall-threshold"`
+}
+
+func (c *NotificationConfig) adjust() error {
+	configutil.AdjustDuration(&c.GCSafePointStallThreshold, defaultGCSafePointStallThreshold)
+	for i := range c.Webhooks {
+		if err := c.Webhooks[i].Adjust(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // KeyspaceConfig is the configuration for keyspace management.
 type KeyspaceConfig struct {
 	// PreAlloc contains the keyspace to be allocated during keyspace manager initialization.
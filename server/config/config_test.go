@@ -24,8 +24,10 @@ import (
 	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/docker/go-units"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/utils/configutil"
 )
@@ -136,6 +138,14 @@ func TestValidation(t *testing.T) {
 	re.NoError(cfg.Schedule.Validate())
 	cfg.Schedule.TolerantSizeRatio = -0.6
 	re.Error(cfg.Schedule.Validate())
+	cfg.Schedule.TolerantSizeRatio = 0
+	// check movement limit
+	cfg.Schedule.ZoneMovementLimit = 512 * units.KiB
+	re.Error(cfg.Schedule.Validate())
+	cfg.Schedule.ZoneMovementLimit = units.MiB
+	re.NoError(cfg.Schedule.Validate())
+	cfg.Schedule.ClusterMovementLimit = 1
+	re.Error(cfg.Schedule.Validate())
 	// check quota
 	re.Equal(defaultQuotaBackendBytes, cfg.QuotaBackendBytes)
 	// check request bytes
@@ -445,6 +455,37 @@ hot-regions-write-interval= "30m"
 	re.Equal(uint64(7), cfg.Schedule.HotRegionsReservedDays)
 }
 
+func TestMaintenanceWindowsConfig(t *testing.T) {
+	re := require.New(t)
+	sc.RegisterScheduler("balance-region")
+	sc.RegisterScheduler("balance-leader")
+	cfgData := `
+[schedule]
+[[schedule.maintenance-windows]]
+targets = ["balance-region", "merge"]
+start = "00:00"
+end = "06:00"
+[[schedule.blackout-periods]]
+targets = ["balance-leader"]
+start = 2023-05-01T00:00:00Z
+end = 2023-05-02T00:00:00Z
+`
+	cfg := NewConfig()
+	meta, err := toml.Decode(cfgData, &cfg)
+	re.NoError(err)
+	re.NoError(cfg.Adjust(&meta, false))
+	re.Len(cfg.Schedule.MaintenanceWindows, 1)
+	re.Equal("06:00", cfg.Schedule.MaintenanceWindows[0].End)
+	re.Len(cfg.Schedule.BlackoutPeriods, 1)
+	re.Equal(24*time.Hour, cfg.Schedule.BlackoutPeriods[0].End.Sub(cfg.Schedule.BlackoutPeriods[0].Start))
+	re.NoError(cfg.Schedule.Validate())
+
+	cfg.Schedule.MaintenanceWindows[0].Targets = []string{"rule"}
+	re.Error(cfg.Schedule.Validate())
+	cfg.Schedule.MaintenanceWindows[0].Targets = []string{"balance-hot-region"}
+	re.Error(cfg.Schedule.Validate())
+}
+
 func TestConfigClone(t *testing.T) {
 	re := require.New(t)
 	cfg := &Config{}
@@ -25,6 +25,7 @@ import (
 	"unsafe"
 
 	"github.com/coreos/go-semver/semver"
+	"github.com/docker/go-units"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/metapb"
@@ -32,6 +33,7 @@ import (
 	"github.com/tikv/pd/pkg/cache"
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/schedule/maintenance"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
@@ -281,6 +283,21 @@ func (o *PersistOptions) SetSplitMergeInterval(splitMergeInterval time.Duration)
 	o.SetScheduleConfig(v)
 }
 
+// GetMergeHotRegionCoolDown returns the duration that a region is not allowed to be merged after it was hot.
+func (o *PersistOptions) GetMergeHotRegionCoolDown() time.Duration {
+	return o.GetScheduleConfig().MergeHotRegionCoolDown.Duration
+}
+
+// GetColdRegionMergeDuration returns the duration that a region has no flow to be considered as cold for merge.
+func (o *PersistOptions) GetColdRegionMergeDuration() time.Duration {
+	return o.GetScheduleConfig().ColdRegionMergeDuration.Duration
+}
+
+// GetColdRegionMergeRateLimit returns the max number of the cold region merge operators created per minute.
+func (o *PersistOptions) GetColdRegionMergeRateLimit() uint64 {
+	return o.GetScheduleConfig().ColdRegionMergeRateLimit
+}
+
 // GetSwitchWitnessInterval returns the interval between promote to non-witness and starting to switch to witness.
 func (o *PersistOptions) GetSwitchWitnessInterval() time.Duration {
 	return o.GetScheduleConfig().SwitchWitnessInterval.Duration
@@ -303,6 +320,42 @@ func (o *PersistOptions) IsWitnessAllowed() bool {
 	return o.GetScheduleConfig().EnableWitness
 }
 
+// IsOperatorPreemptionEnabled returns whether the operators can be preempted by the ones of higher priority classes.
+func (o *PersistOptions) IsOperatorPreemptionEnabled() bool {
+	return o.GetScheduleConfig().EnableOperatorPreemption
+}
+
+// GetOperatorClassQuota returns the max number of the running operators of the priority class, 0 means unlimited.
+func (o *PersistOptions) GetOperatorClassQuota(class constant.PriorityClass) uint64 {
+	return o.GetScheduleConfig().OperatorClassQuota[class.String()]
+}
+
+// GetClusterMovementLimit returns the max size (MB) of the data moved per minute in the cluster, 0 means no limit.
+func (o *PersistOptions) GetClusterMovementLimit() uint64 {
+	return uint64((o.GetScheduleConfig().ClusterMovementLimit + units.MiB - 1) / units.MiB)
+}
+
+// GetZoneMovementLimit returns the max size (MB) of the data moved per minute into each zone, 0 means no limit.
+func (o *PersistOptions) GetZoneMovementLimit() uint64 {
+	return uint64((o.GetScheduleConfig().ZoneMovementLimit + units.MiB - 1) / units.MiB)
+}
+
+// GetMaintenanceWindows returns the maintenance windows of the schedulers and checkers.
+func (o *PersistOptions) GetMaintenanceWindows() []maintenance.Window {
+	return o.GetScheduleConfig().MaintenanceWindows
+}
+
+// GetBlackoutPeriods returns the blackout periods of the schedulers and checkers.
+func (o *PersistOptions) GetBlackoutPeriods() []maintenance.Blackout {
+	return o.GetScheduleConfig().BlackoutPeriods
+}
+
+// IsInMaintenanceWindow returns whether the scheduler or checker is allowed to run now.
+func (o *PersistOptions) IsInMaintenanceWindow(target string) bool {
+	cfg := o.GetScheduleConfig()
+	return maintenance.IsAllowed(cfg.MaintenanceWindows, cfg.BlackoutPeriods, target, time.Now())
+}
+
 // SetEnableWitness to set the option for witness. It's only used to test.
 func (o *PersistOptions) SetEnableWitness(enable bool) {
 	v := o.GetScheduleConfig().Clone()
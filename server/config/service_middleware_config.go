@@ -14,31 +14,49 @@
 
 package config
 
-import "github.com/tikv/pd/pkg/ratelimit"
+import (
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/audit"
+	"github.com/tikv/pd/pkg/ratelimit"
+)
 
 const (
 	defaultEnableAuditMiddleware     = true
+	defaultAuditStorageRetentionDays = 30
 	defaultEnableRateLimitMiddleware = false
+	defaultEnableGRPCRateLimit       = false
+	defaultEnableFairQueuing         = false
+	defaultEnableRBACMiddleware      = false
 )
 
 // ServiceMiddlewareConfig is the configuration for PD Service middleware.
 type ServiceMiddlewareConfig struct {
 	AuditConfig     `json:"audit"`
 	RateLimitConfig `json:"rate-limit"`
+	RBACConfig      `json:"rbac"`
 }
 
 // NewServiceMiddlewareConfig returns a new service middleware config
 func NewServiceMiddlewareConfig() *ServiceMiddlewareConfig {
 	audit := AuditConfig{
-		EnableAudit: defaultEnableAuditMiddleware,
+		EnableAudit:          defaultEnableAuditMiddleware,
+		StorageRetentionDays: defaultAuditStorageRetentionDays,
 	}
 	ratelimit := RateLimitConfig{
-		EnableRateLimit: defaultEnableRateLimitMiddleware,
-		LimiterConfig:   make(map[string]ratelimit.DimensionConfig),
+		EnableRateLimit:     defaultEnableRateLimitMiddleware,
+		LimiterConfig:       make(map[string]ratelimit.DimensionConfig),
+		EnableGRPCRateLimit: defaultEnableGRPCRateLimit,
+		GRPCLimiterConfig:   make(map[string]ratelimit.DimensionConfig),
+		EnableFairQueuing:   defaultEnableFairQueuing,
+		FairQueuing:         ratelimit.NewFairQueuingConfig(),
+	}
+	rbac := RBACConfig{
+		EnableRBAC: defaultEnableRBACMiddleware,
 	}
 	cfg := &ServiceMiddlewareConfig{
 		AuditConfig:     audit,
 		RateLimitConfig: ratelimit,
+		RBACConfig:      rbac,
 	}
 	return cfg
 }
@@ -53,24 +71,79 @@ func (c *ServiceMiddlewareConfig) Clone() *ServiceMiddlewareConfig {
 type AuditConfig struct {
 	// EnableAudit controls the switch of the audit middleware
 	EnableAudit bool `json:"enable-audit,string"`
+	// StorageRetentionDays is the days to keep the records of the durable audit storage, 0 means keeping forever.
+	StorageRetentionDays uint64 `json:"storage-retention-days"`
+	// GRPCBackendLabels overrides the audit backend labels of the gRPC methods, the key is the gRPC method
+	// like "pdpb.PD/PutStore" and an empty list disables the audit of the method.
+	GRPCBackendLabels map[string][]string `json:"grpc-backend-labels,omitempty"`
 }
 
 // Clone returns a cloned audit config.
 func (c *AuditConfig) Clone() *AuditConfig {
 	cfg := *c
+	if c.GRPCBackendLabels != nil {
+		cfg.GRPCBackendLabels = make(map[string][]string, len(c.GRPCBackendLabels))
+		for method, labels := range c.GRPCBackendLabels {
+			cfg.GRPCBackendLabels[method] = append([]string(nil), labels...)
+		}
+	}
 	return &cfg
 }
 
+// Validate checks the audit backend labels of the gRPC methods.
+func (c *AuditConfig) Validate() error {
+	for method, labels := range c.GRPCBackendLabels {
+		for _, label := range labels {
+			switch label {
+			case audit.PrometheusHistogram, audit.LocalLogLabel, audit.DurableStorageLabel:
+			default:
+				return errors.Errorf("unknown audit backend label %s of %s", label, method)
+			}
+		}
+	}
+	return nil
+}
+
 // RateLimitConfig is the configuration for rate limit
 type RateLimitConfig struct {
 	// EnableRateLimit controls the switch of the rate limit middleware
 	EnableRateLimit bool `json:"enable-rate-limit,string"`
 	// RateLimitConfig is the config of rate limit middleware
 	LimiterConfig map[string]ratelimit.DimensionConfig `json:"limiter-config"`
+	// EnableGRPCRateLimit controls the switch of the rate limit for gRPC methods
+	EnableGRPCRateLimit bool `json:"enable-grpc-rate-limit,string"`
+	// GRPCLimiterConfig is the config of the rate limit for gRPC methods, the key is the gRPC method like "pdpb.PD/GetRegion"
+	GRPCLimiterConfig map[string]ratelimit.DimensionConfig `json:"grpc-limiter-config"`
+	// GRPCAllowComponents is the allow list of the components whose gRPC requests won't be limited.
+	// NOTE: the component name is asserted by the client in the metadata, which can be spoofed by
+	// any client, use GRPCAllowSubjects instead if the clients are not trusted.
+	GRPCAllowComponents []string `json:"grpc-allow-components"`
+	// GRPCAllowSubjects is the allow list of the client subjects like "cn:tikv" whose gRPC requests won't be
+	// limited. The subjects are from the client certificate or the bearer token, which can't be spoofed by
+	// the client like the component name in the metadata.
+	GRPCAllowSubjects []string `json:"grpc-allow-subjects"`
+	// EnableFairQueuing controls the switch of the adaptive concurrency limit shared by the callers fairly,
+	// the caller is identified by the certificate CN or SAN, the client IP or the component name in order.
+	EnableFairQueuing bool `json:"enable-fair-queuing,string"`
+	// FairQueuing is the config of the fair queuing
+	FairQueuing ratelimit.FairQueuingConfig `json:"fair-queuing"`
 }
 
 // Clone returns a cloned rate limit config.
 func (c *RateLimitConfig) Clone() *RateLimitConfig {
+	cfg := *c
+	cfg.FairQueuing = c.FairQueuing.Clone()
+	return &cfg
+}
+
+// RBACConfig is the configuration for RBAC
+type RBACConfig struct {
+	// EnableRBAC controls the switch of the RBAC middleware
+	EnableRBAC bool `json:"enable-rbac,string"`
+}
+
+// Clone returns a cloned RBAC config.
+func (c *RBACConfig) Clone() *RBACConfig {
 	cfg := *c
 	return &cfg
 }
@@ -19,6 +19,7 @@ import (
 	"sync/atomic"
 
 	"github.com/pingcap/failpoint"
+	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 )
 
@@ -27,6 +28,7 @@ import (
 type ServiceMiddlewarePersistOptions struct {
 	audit     atomic.Value
 	rateLimit atomic.Value
+	rbac      atomic.Value
 }
 
 // NewServiceMiddlewarePersistOptions creates a new ServiceMiddlewarePersistOptions instance.
@@ -34,6 +36,7 @@ func NewServiceMiddlewarePersistOptions(cfg *ServiceMiddlewareConfig) *ServiceMi
 	o := &ServiceMiddlewarePersistOptions{}
 	o.audit.Store(&cfg.AuditConfig)
 	o.rateLimit.Store(&cfg.RateLimitConfig)
+	o.rbac.Store(&cfg.RBACConfig)
 	return o
 }
 
@@ -67,11 +70,46 @@ func (o *ServiceMiddlewarePersistOptions) IsRateLimitEnabled() bool {
 	return o.GetRateLimitConfig().EnableRateLimit
 }
 
+// IsGRPCRateLimitEnabled returns whether rate limit for gRPC methods is enabled
+func (o *ServiceMiddlewarePersistOptions) IsGRPCRateLimitEnabled() bool {
+	return o.GetRateLimitConfig().EnableGRPCRateLimit
+}
+
+// IsInGRPCRateLimitAllowList returns whether the gRPC requests of the client with the component or the subjects won't be limited
+func (o *ServiceMiddlewarePersistOptions) IsInGRPCRateLimitAllowList(component string, subjects []string) bool {
+	cfg := o.GetRateLimitConfig()
+	if slice.Contains(cfg.GRPCAllowComponents, component) {
+		return true
+	}
+	return slice.AnyOf(subjects, func(i int) bool { return slice.Contains(cfg.GRPCAllowSubjects, subjects[i]) })
+}
+
+// IsFairQueuingEnabled returns whether the fair queuing for the callers is enabled
+func (o *ServiceMiddlewarePersistOptions) IsFairQueuingEnabled() bool {
+	return o.GetRateLimitConfig().EnableFairQueuing
+}
+
+// GetRBACConfig returns pd service middleware configurations.
+func (o *ServiceMiddlewarePersistOptions) GetRBACConfig() *RBACConfig {
+	return o.rbac.Load().(*RBACConfig)
+}
+
+// SetRBACConfig sets the PD service middleware configuration.
+func (o *ServiceMiddlewarePersistOptions) SetRBACConfig(cfg *RBACConfig) {
+	o.rbac.Store(cfg)
+}
+
+// IsRBACEnabled returns whether RBAC middleware is enabled
+func (o *ServiceMiddlewarePersistOptions) IsRBACEnabled() bool {
+	return o.GetRBACConfig().EnableRBAC
+}
+
 // Persist saves the configuration to the storage.
 func (o *ServiceMiddlewarePersistOptions) Persist(storage endpoint.ServiceMiddlewareStorage) error {
 	cfg := &ServiceMiddlewareConfig{
 		AuditConfig:     *o.GetAuditConfig(),
 		RateLimitConfig: *o.GetRateLimitConfig(),
+		RBACConfig:      *o.GetRBACConfig(),
 	}
 	err := storage.SaveServiceMiddlewareConfig(cfg)
 	failpoint.Inject("persistServiceMiddlewareFail", func() {
@@ -91,6 +129,7 @@ func (o *ServiceMiddlewarePersistOptions) Reload(storage endpoint.ServiceMiddlew
 	if isExist {
 		o.audit.Store(&cfg.AuditConfig)
 		o.rateLimit.Store(&cfg.RateLimitConfig)
+		o.rbac.Store(&cfg.RBACConfig)
 	}
 	return nil
 }
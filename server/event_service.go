@@ -0,0 +1,174 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
+	"github.com/tikv/pd/pkg/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// EventsSubscribeMethod is the full name of the gRPC method to subscribe the events.
+const EventsSubscribeMethod = "/pdlocal.Events/Subscribe"
+
+// SubscribeEventsRequest is the request to subscribe the events by gRPC, the empty field matches all.
+type SubscribeEventsRequest struct {
+	ClusterID uint64       `json:"cluster_id"`
+	Types     []event.Type `json:"types,omitempty"`
+	Actions   []string     `json:"actions,omitempty"`
+	Subject   string       `json:"subject,omitempty"`
+	// ResumeToken is the ID of the last received event, the subscription is resumed after it.
+	ResumeToken string `json:"resume_token,omitempty"`
+}
+
+// EventServer serves the events of the cluster as a gRPC stream, which subscribes the same
+// event.Hub as the server-sent events of the HTTP API. The PD service in kvproto doesn't define
+// the RPC, so it's registered as a PD-local service whose messages are the JSON encoded
+// SubscribeEventsRequest and event.Event carried by the well-known BytesValue, which can be
+// decoded by the default codec without the generated code.
+type EventServer struct {
+	*GrpcServer
+}
+
+var eventsServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pdlocal.Events",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       subscribeEventsHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// RegisterEventServer registers the event service to the gRPC server.
+func RegisterEventServer(gs *grpc.Server, s *EventServer) {
+	gs.RegisterService(&eventsServiceDesc, s)
+}
+
+func subscribeEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	msg := &types.BytesValue{}
+	if err := stream.RecvMsg(msg); err != nil {
+		return err
+	}
+	req := &SubscribeEventsRequest{}
+	if err := json.Unmarshal(msg.GetValue(), req); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	return srv.(*EventServer).Subscribe(req, stream)
+}
+
+// Subscribe sends the events matching the request to the stream until the client cancels it. The
+// stream is aborted if the client is too slow to receive the events, and it can be resumed with
+// the ID of the last received event.
+func (s *EventServer) Subscribe(req *SubscribeEventsRequest, stream grpc.ServerStream) error {
+	if err := s.checkPermission(stream.Context()); err != nil {
+		return err
+	}
+	if err := s.validateRequest(&pdpb.RequestHeader{ClusterId: req.ClusterID}); err != nil {
+		return err
+	}
+	for _, typ := range req.Types {
+		if !slice.Contains(event.Types, typ) {
+			return status.Errorf(codes.InvalidArgument, "unknown event type %s", typ)
+		}
+	}
+	hub := s.GetEventHub()
+	sub, err := hub.Subscribe(event.Filter{Types: req.Types, Actions: req.Actions, Subject: req.Subject}, req.ResumeToken)
+	if err != nil {
+		if errs.ErrEventResumeToken.Equal(err) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		return status.Error(codes.Unknown, err.Error())
+	}
+	defer hub.Unsubscribe(sub)
+	// Tell the client that the subscription is established.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.Context().Done():
+			return ErrNotStarted
+		case e, ok := <-sub.Events():
+			if !ok {
+				return status.Errorf(codes.Aborted, "the subscriber is too slow")
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.SendMsg(&types.BytesValue{Value: data}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// EventStream receives the events from the gRPC event service.
+type EventStream struct {
+	grpc.ClientStream
+}
+
+// Recv receives the next event.
+func (s *EventStream) Recv() (*event.Event, error) {
+	msg := &types.BytesValue{}
+	if err := s.RecvMsg(msg); err != nil {
+		return nil, err
+	}
+	e := &event.Event{}
+	if err := json.Unmarshal(msg.GetValue(), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// SubscribeEvents subscribes the events from the gRPC event service of the PD leader. It returns
+// after the subscription is established, so the events published later are all received. The
+// error of the subscription may be returned by either it or the first Recv.
+func SubscribeEvents(ctx context.Context, cc *grpc.ClientConn, req *SubscribeEventsRequest) (*EventStream, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := cc.NewStream(ctx, &eventsServiceDesc.Streams[0], EventsSubscribeMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(&types.BytesValue{Value: data}); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	// The header is sent after the subscription is established.
+	if _, err := stream.Header(); err != nil {
+		return nil, err
+	}
+	return &EventStream{ClientStream: stream}, nil
+}
@@ -18,8 +18,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"path"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -28,13 +31,18 @@ import (
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
 	"github.com/pingcap/log"
+	"github.com/tikv/pd/client/tsoheader"
+	"github.com/tikv/pd/pkg/audit"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/storage/kv"
 	"github.com/tikv/pd/pkg/tso"
+	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/grpcutil"
 	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/requestutil"
 	"github.com/tikv/pd/pkg/utils/tsoutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 	"github.com/tikv/pd/server/cluster"
@@ -42,6 +50,8 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -77,6 +87,9 @@ func (s *GrpcServer) unaryMiddleware(ctx context.Context, req request, fn forwar
 	failpoint.Inject("customTimeout", func() {
 		time.Sleep(5 * time.Second)
 	})
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	forwardedHost := grpcutil.GetForwardedHost(ctx)
 	if !s.isLocalRequest(forwardedHost) {
 		client, err := s.getDelegateClient(ctx, forwardedHost)
@@ -92,6 +105,194 @@ func (s *GrpcServer) unaryMiddleware(ctx context.Context, req request, fn forwar
 	return nil, nil
 }
 
+// defaultGRPCAuditBackendLabels are the default audit backend labels of the gRPC methods which change
+// the cluster state, the key is the service label of the gRPC request like "pdpb.PD/PutStore". They can
+// be overridden by the audit config.
+var defaultGRPCAuditBackendLabels = map[string][]string{
+	"pdpb.PD/PutStore":                 {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/PutClusterConfig":         {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/ScatterRegion":            {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/SplitRegions":             {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/SplitAndScatterRegions":   {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/UpdateGCSafePoint":        {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/UpdateServiceGCSafePoint": {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/StoreGlobalConfig":        {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	"pdpb.PD/SetExternalTimestamp":     {audit.LocalLogLabel, audit.DurableStorageLabel, audit.PrometheusHistogram},
+	// ReportMinResolvedTS is reported by every TiKV periodically, so only the metrics are recorded.
+	"pdpb.PD/ReportMinResolvedTS": {audit.PrometheusHistogram},
+}
+
+// getGRPCAuditBackendLabels returns the audit backend labels of the gRPC method. The labels in the
+// audit config take precedence over the default ones, and an empty list disables the audit of the method.
+func (s *GrpcServer) getGRPCAuditBackendLabels(method string) *audit.BackendLabels {
+	if labels, ok := s.GetServiceMiddlewarePersistOptions().GetAuditConfig().GRPCBackendLabels[method]; ok {
+		if len(labels) == 0 {
+			return nil
+		}
+		return &audit.BackendLabels{Labels: labels}
+	}
+	return s.GetServiceAuditBackendLabels(method)
+}
+
+// auditMiddleware processes the audit backends matching the labels of the gRPC method and returns
+// the function which should be called with the returned response and error after the request is handled.
+// Like checkPermission, it's called at the beginning of the methods since there is no interceptor.
+// The request forwarded to the leader is audited by the leader.
+func (s *GrpcServer) auditMiddleware(ctx context.Context, req fmt.Stringer) func(resp interface{}, err error) {
+	if !s.GetServiceMiddlewarePersistOptions().IsAuditEnabled() ||
+		!s.isLocalRequest(grpcutil.GetForwardedHost(ctx)) {
+		return func(interface{}, error) {}
+	}
+	requestInfo := requestutil.GetGRPCRequestInfo(ctx, req)
+	labels := s.getGRPCAuditBackendLabels(requestInfo.ServiceLabel)
+	if labels == nil {
+		return func(interface{}, error) {}
+	}
+	ctx = requestutil.WithRequestInfo(ctx, requestInfo)
+	afterBackends := make([]audit.Backend, 0)
+	for _, backend := range s.GetAuditBackend() {
+		if !backend.Match(labels) {
+			continue
+		}
+		if backend.ProcessBeforeHandler() {
+			backend.ProcessGRPCRequest(ctx)
+		} else {
+			afterBackends = append(afterBackends, backend)
+		}
+	}
+	start := time.Now()
+	return func(resp interface{}, err error) {
+		ctx := requestutil.WithEndTime(ctx, time.Now().Unix())
+		ctx = requestutil.WithResponseInfo(ctx, requestutil.ResponseInfo{
+			StatusCode: int(auditStatusCode(resp, err)),
+			Latency:    time.Since(start),
+		})
+		for _, backend := range afterBackends {
+			backend.ProcessGRPCRequest(ctx)
+		}
+	}
+}
+
+// auditStatusCode returns the gRPC code of the audited request. Most of the methods report the
+// failure in the response header instead of the returned error, so the error in the response is
+// converted to the closest gRPC code.
+func auditStatusCode(resp interface{}, err error) codes.Code {
+	if err != nil {
+		return status.Code(err)
+	}
+	var respErr *pdpb.Error
+	switch r := resp.(type) {
+	case interface{ GetHeader() *pdpb.ResponseHeader }:
+		respErr = r.GetHeader().GetError()
+	case interface{ GetError() *pdpb.Error }:
+		// StoreGlobalConfigResponse has no header.
+		respErr = r.GetError()
+	}
+	if respErr == nil {
+		return codes.OK
+	}
+	switch respErr.GetType() {
+	case pdpb.ErrorType_OK:
+		return codes.OK
+	case pdpb.ErrorType_NOT_BOOTSTRAPPED, pdpb.ErrorType_STORE_TOMBSTONE, pdpb.ErrorType_INCOMPATIBLE_VERSION:
+		return codes.FailedPrecondition
+	case pdpb.ErrorType_ALREADY_BOOTSTRAPPED, pdpb.ErrorType_DUPLICATED_ENTRY:
+		return codes.AlreadyExists
+	case pdpb.ErrorType_REGION_NOT_FOUND, pdpb.ErrorType_GLOBAL_CONFIG_NOT_FOUND, pdpb.ErrorType_ENTRY_NOT_FOUND:
+		return codes.NotFound
+	case pdpb.ErrorType_INVALID_VALUE:
+		return codes.InvalidArgument
+	case pdpb.ErrorType_DATA_COMPACTED:
+		return codes.OutOfRange
+	default:
+		return codes.Unknown
+	}
+}
+
+// grpcRateLimitMethods are the gRPC methods which can be limited by the rate limiter. The methods
+// on the critical path like the heartbeats and TSO aren't limited to avoid affecting the cluster.
+var grpcRateLimitMethods = map[string]struct{}{
+	"pdpb.PD/GetStore":                 {},
+	"pdpb.PD/PutStore":                 {},
+	"pdpb.PD/GetAllStores":             {},
+	"pdpb.PD/GetRegion":                {},
+	"pdpb.PD/GetPrevRegion":            {},
+	"pdpb.PD/GetRegionByID":            {},
+	"pdpb.PD/ScanRegions":              {},
+	"pdpb.PD/GetClusterConfig":         {},
+	"pdpb.PD/PutClusterConfig":         {},
+	"pdpb.PD/ScatterRegion":            {},
+	"pdpb.PD/GetGCSafePoint":           {},
+	"pdpb.PD/UpdateGCSafePoint":        {},
+	"pdpb.PD/UpdateServiceGCSafePoint": {},
+	"pdpb.PD/GetOperator":              {},
+	"pdpb.PD/SplitRegions":             {},
+	"pdpb.PD/SplitAndScatterRegions":   {},
+	"pdpb.PD/LoadGlobalConfig":         {},
+	"pdpb.PD/StoreGlobalConfig":        {},
+	"pdpb.PD/SetExternalTimestamp":     {},
+	"pdpb.PD/GetExternalTimestamp":     {},
+}
+
+// IsGRPCRateLimitMethod returns whether the gRPC method can be limited by the rate limiter.
+func IsGRPCRateLimitMethod(method string) bool {
+	_, ok := grpcRateLimitMethods[method]
+	return ok
+}
+
+// rateLimitMiddleware limits the QPS and concurrency of the gRPC method if the gRPC rate limit is enabled
+// and the client isn't in the allow list. The request forwarded by another member has been limited by
+// the member, so it isn't limited again. The returned function should be called to release the limiter
+// after the request is handled.
+func (s *GrpcServer) rateLimitMiddleware(ctx context.Context) (func(), error) {
+	opts := s.GetServiceMiddlewarePersistOptions()
+	if !opts.IsGRPCRateLimitEnabled() || opts.IsInGRPCRateLimitAllowList(apiutil.GetComponentNameOnGRPC(ctx), rbac.SubjectsFromGRPC(ctx)) ||
+		s.isForwardedByMember(ctx) {
+		return func() {}, nil
+	}
+	method, ok := grpc.Method(ctx)
+	if !ok {
+		return func() {}, nil
+	}
+	method = strings.TrimPrefix(method, "/")
+	limiter := s.GetGRPCRateLimiter()
+	if !limiter.Allow(method) {
+		return nil, status.Error(codes.ResourceExhausted, errs.ErrGRPCRateLimit.FastGenByArgs(method).Error())
+	}
+	return func() { limiter.Release(method) }, nil
+}
+
+// isForwardedByMember returns whether the request is forwarded by another PD member. The member resets
+// the forwarded host in the metadata when forwarding, and the peer address must be one of the members,
+// so the clients can't bypass the limit by setting the metadata.
+func (s *GrpcServer) isForwardedByMember(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(grpcutil.ForwardMetadataKey)) == 0 || md.Get(grpcutil.ForwardMetadataKey)[0] != "" {
+		return false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+	ip, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+	for _, endpoint := range s.GetEndpoints() {
+		if u, err := url.Parse(endpoint); err == nil && u.Hostname() == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPermission checks whether the client is allowed to call the gRPC method if RBAC is enabled.
+// The gRPC server is created by etcd without the interceptor options, so it's called by
+// unaryMiddleware and at the beginning of the methods which don't use unaryMiddleware.
+func (s *GrpcServer) checkPermission(ctx context.Context) error {
+	return s.CheckGRPCPermission(ctx)
+}
+
 func (s *GrpcServer) wrapErrorToHeader(errorType pdpb.ErrorType, message string) *pdpb.ResponseHeader {
 	return s.errorHeader(&pdpb.Error{
 		Type:    errorType,
@@ -156,6 +357,9 @@ func (s *GrpcServer) GetMembers(context.Context, *pdpb.GetMembersRequest) (*pdpb
 
 // Tso implements gRPC PDServer.
 func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
+	if err := s.checkPermission(stream.Context()); err != nil {
+		return err
+	}
 	var (
 		doneCh chan struct{}
 		errCh  chan error
@@ -210,7 +414,7 @@ func (s *GrpcServer) Tso(stream pdpb.PD_TsoServer) error {
 		}
 		tsoHandleDuration.Observe(time.Since(start).Seconds())
 		response := &pdpb.TsoResponse{
-			Header:    s.header(),
+			Header:    s.tsoHeader(s.tsoAllocatorManager.GetClockUncertainty()),
 			Timestamp: &ts,
 			Count:     count,
 		}
@@ -339,7 +543,8 @@ func (s *GrpcServer) processTSORequests(forwardStream pdpb.PD_TsoClient, request
 	// This is different from the logic of client batch, for example, if we have a largest ts whose logical part is 10,
 	// count is 5, then the splitting results should be 5 and 10.
 	firstLogical := addLogical(logical, -int64(count), suffixBits)
-	return s.finishTSORequest(requests, physical, firstLogical, suffixBits)
+	uncertainty, ok := tsoheader.ParseClockUncertainty(resp.GetHeader().XXX_unrecognized)
+	return s.finishTSORequest(requests, physical, firstLogical, suffixBits, uncertainty, ok)
 }
 
 // Because of the suffix, we need to shift the count before we add it to the logical part.
@@ -347,13 +552,14 @@ func addLogical(logical, count int64, suffixBits uint32) int64 {
 	return logical + count<<suffixBits
 }
 
-func (s *GrpcServer) finishTSORequest(requests []*tsoRequest, physical, firstLogical int64, suffixBits uint32) error {
+func (s *GrpcServer) finishTSORequest(requests []*tsoRequest, physical, firstLogical int64, suffixBits uint32,
+	uncertainty time.Duration, reportUncertainty bool) error {
 	countSum := int64(0)
 	for i := 0; i < len(requests); i++ {
 		count := requests[i].request.GetCount()
 		countSum += int64(count)
 		response := &pdpb.TsoResponse{
-			Header: s.header(),
+			Header: s.tsoHeader(uncertainty, reportUncertainty),
 			Count:  count,
 			Timestamp: &pdpb.Timestamp{
 				Physical:   physical,
@@ -474,6 +680,9 @@ func (s *GrpcServer) AllocID(ctx context.Context, request *pdpb.AllocIDRequest)
 
 // IsSnapshotRecovering implements gRPC PDServer.
 func (s *GrpcServer) IsSnapshotRecovering(ctx context.Context, request *pdpb.IsSnapshotRecoveringRequest) (*pdpb.IsSnapshotRecoveringResponse, error) {
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	// recovering mark is stored in etcd directly, there's no need to forward.
 	marked, err := s.Server.IsSnapshotRecovering(ctx)
 	if err != nil {
@@ -489,6 +698,11 @@ func (s *GrpcServer) IsSnapshotRecovering(ctx context.Context, request *pdpb.IsS
 
 // GetStore implements gRPC PDServer.
 func (s *GrpcServer) GetStore(ctx context.Context, request *pdpb.GetStoreRequest) (*pdpb.GetStoreResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetStore(ctx, request)
 	}
@@ -534,7 +748,14 @@ func checkStore(rc *cluster.RaftCluster, storeID uint64) *pdpb.Error {
 }
 
 // PutStore implements gRPC PDServer.
-func (s *GrpcServer) PutStore(ctx context.Context, request *pdpb.PutStoreRequest) (*pdpb.PutStoreResponse, error) {
+func (s *GrpcServer) PutStore(ctx context.Context, request *pdpb.PutStoreRequest) (resp *pdpb.PutStoreResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).PutStore(ctx, request)
 	}
@@ -581,6 +802,11 @@ func (s *GrpcServer) PutStore(ctx context.Context, request *pdpb.PutStoreRequest
 
 // GetAllStores implements gRPC PDServer.
 func (s *GrpcServer) GetAllStores(ctx context.Context, request *pdpb.GetAllStoresRequest) (*pdpb.GetAllStoresResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetAllStores(ctx, request)
 	}
@@ -755,6 +981,9 @@ func (s *heartbeatServer) Recv() (*pdpb.RegionHeartbeatRequest, error) {
 
 // ReportBuckets implements gRPC PDServer
 func (s *GrpcServer) ReportBuckets(stream pdpb.PD_ReportBucketsServer) error {
+	if err := s.checkPermission(stream.Context()); err != nil {
+		return err
+	}
 	var (
 		server            = &bucketHeartbeatServer{stream: stream}
 		forwardStream     pdpb.PD_ReportBucketsClient
@@ -849,6 +1078,9 @@ func (s *GrpcServer) ReportBuckets(stream pdpb.PD_ReportBucketsServer) error {
 
 // RegionHeartbeat implements gRPC PDServer.
 func (s *GrpcServer) RegionHeartbeat(stream pdpb.PD_RegionHeartbeatServer) error {
+	if err := s.checkPermission(stream.Context()); err != nil {
+		return err
+	}
 	var (
 		server            = &heartbeatServer{stream: stream}
 		flowRoundOption   = core.WithFlowRoundByDigit(s.persistOptions.GetPDServerConfig().FlowRoundByDigit)
@@ -977,6 +1209,11 @@ func (s *GrpcServer) RegionHeartbeat(stream pdpb.PD_RegionHeartbeatServer) error
 
 // GetRegion implements gRPC PDServer.
 func (s *GrpcServer) GetRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetRegion(ctx, request)
 	}
@@ -1010,6 +1247,11 @@ func (s *GrpcServer) GetRegion(ctx context.Context, request *pdpb.GetRegionReque
 
 // GetPrevRegion implements gRPC PDServer
 func (s *GrpcServer) GetPrevRegion(ctx context.Context, request *pdpb.GetRegionRequest) (*pdpb.GetRegionResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetPrevRegion(ctx, request)
 	}
@@ -1044,6 +1286,11 @@ func (s *GrpcServer) GetPrevRegion(ctx context.Context, request *pdpb.GetRegionR
 
 // GetRegionByID implements gRPC PDServer.
 func (s *GrpcServer) GetRegionByID(ctx context.Context, request *pdpb.GetRegionByIDRequest) (*pdpb.GetRegionResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetRegionByID(ctx, request)
 	}
@@ -1077,6 +1324,11 @@ func (s *GrpcServer) GetRegionByID(ctx context.Context, request *pdpb.GetRegionB
 
 // ScanRegions implements gRPC PDServer.
 func (s *GrpcServer) ScanRegions(ctx context.Context, request *pdpb.ScanRegionsRequest) (*pdpb.ScanRegionsResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).ScanRegions(ctx, request)
 	}
@@ -1248,6 +1500,11 @@ func (s *GrpcServer) ReportBatchSplit(ctx context.Context, request *pdpb.ReportB
 
 // GetClusterConfig implements gRPC PDServer.
 func (s *GrpcServer) GetClusterConfig(ctx context.Context, request *pdpb.GetClusterConfigRequest) (*pdpb.GetClusterConfigResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetClusterConfig(ctx, request)
 	}
@@ -1268,7 +1525,14 @@ func (s *GrpcServer) GetClusterConfig(ctx context.Context, request *pdpb.GetClus
 }
 
 // PutClusterConfig implements gRPC PDServer.
-func (s *GrpcServer) PutClusterConfig(ctx context.Context, request *pdpb.PutClusterConfigRequest) (*pdpb.PutClusterConfigResponse, error) {
+func (s *GrpcServer) PutClusterConfig(ctx context.Context, request *pdpb.PutClusterConfigRequest) (resp *pdpb.PutClusterConfigResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).PutClusterConfig(ctx, request)
 	}
@@ -1298,7 +1562,14 @@ func (s *GrpcServer) PutClusterConfig(ctx context.Context, request *pdpb.PutClus
 }
 
 // ScatterRegion implements gRPC PDServer.
-func (s *GrpcServer) ScatterRegion(ctx context.Context, request *pdpb.ScatterRegionRequest) (*pdpb.ScatterRegionResponse, error) {
+func (s *GrpcServer) ScatterRegion(ctx context.Context, request *pdpb.ScatterRegionRequest) (resp *pdpb.ScatterRegionResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).ScatterRegion(ctx, request)
 	}
@@ -1353,6 +1624,11 @@ func (s *GrpcServer) ScatterRegion(ctx context.Context, request *pdpb.ScatterReg
 
 // GetGCSafePoint implements gRPC PDServer.
 func (s *GrpcServer) GetGCSafePoint(ctx context.Context, request *pdpb.GetGCSafePointRequest) (*pdpb.GetGCSafePointResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetGCSafePoint(ctx, request)
 	}
@@ -1380,6 +1656,9 @@ func (s *GrpcServer) GetGCSafePoint(ctx context.Context, request *pdpb.GetGCSafe
 
 // SyncRegions syncs the regions.
 func (s *GrpcServer) SyncRegions(stream pdpb.PD_SyncRegionsServer) error {
+	if err := s.checkPermission(stream.Context()); err != nil {
+		return err
+	}
 	if s.IsClosed() || s.cluster == nil {
 		return ErrNotStarted
 	}
@@ -1391,7 +1670,14 @@ func (s *GrpcServer) SyncRegions(stream pdpb.PD_SyncRegionsServer) error {
 }
 
 // UpdateGCSafePoint implements gRPC PDServer.
-func (s *GrpcServer) UpdateGCSafePoint(ctx context.Context, request *pdpb.UpdateGCSafePointRequest) (*pdpb.UpdateGCSafePointResponse, error) {
+func (s *GrpcServer) UpdateGCSafePoint(ctx context.Context, request *pdpb.UpdateGCSafePointRequest) (resp *pdpb.UpdateGCSafePointResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).UpdateGCSafePoint(ctx, request)
 	}
@@ -1429,7 +1715,14 @@ func (s *GrpcServer) UpdateGCSafePoint(ctx context.Context, request *pdpb.Update
 }
 
 // UpdateServiceGCSafePoint update the safepoint for specific service
-func (s *GrpcServer) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb.UpdateServiceGCSafePointRequest) (*pdpb.UpdateServiceGCSafePointResponse, error) {
+func (s *GrpcServer) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb.UpdateServiceGCSafePointRequest) (resp *pdpb.UpdateServiceGCSafePointResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).UpdateServiceGCSafePoint(ctx, request)
 	}
@@ -1476,6 +1769,11 @@ func (s *GrpcServer) UpdateServiceGCSafePoint(ctx context.Context, request *pdpb
 
 // GetOperator gets information about the operator belonging to the specify region.
 func (s *GrpcServer) GetOperator(ctx context.Context, request *pdpb.GetOperatorRequest) (*pdpb.GetOperatorResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetOperator(ctx, request)
 	}
@@ -1529,6 +1827,16 @@ func (s *GrpcServer) header() *pdpb.ResponseHeader {
 	return &pdpb.ResponseHeader{ClusterId: s.clusterID}
 }
 
+// tsoHeader returns the header of the TSO response, which also carries the clock uncertainty
+// bound of the TSO physical time if it's reported.
+func (s *GrpcServer) tsoHeader(uncertainty time.Duration, report bool) *pdpb.ResponseHeader {
+	header := s.header()
+	if report {
+		header.XXX_unrecognized = tsoheader.AppendClockUncertainty(header.XXX_unrecognized, uncertainty)
+	}
+	return header
+}
+
 func (s *GrpcServer) errorHeader(err *pdpb.Error) *pdpb.ResponseHeader {
 	return &pdpb.ResponseHeader{
 		ClusterId: s.clusterID,
@@ -1563,7 +1871,10 @@ var mockLocalAllocatorLeaderChangeFlag = false
 
 // SyncMaxTS will check whether MaxTS is the biggest one among all Local TSOs this PD is holding when skipCheck is set,
 // and write it into all Local TSO Allocators then if it's indeed the biggest one.
-func (s *GrpcServer) SyncMaxTS(_ context.Context, request *pdpb.SyncMaxTSRequest) (*pdpb.SyncMaxTSResponse, error) {
+func (s *GrpcServer) SyncMaxTS(ctx context.Context, request *pdpb.SyncMaxTSRequest) (*pdpb.SyncMaxTSResponse, error) {
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	if err := s.validateInternalRequest(request.GetHeader(), true); err != nil {
 		return nil, err
 	}
@@ -1660,7 +1971,14 @@ func (s *GrpcServer) SyncMaxTS(_ context.Context, request *pdpb.SyncMaxTSRequest
 }
 
 // SplitRegions split regions by the given split keys
-func (s *GrpcServer) SplitRegions(ctx context.Context, request *pdpb.SplitRegionsRequest) (*pdpb.SplitRegionsResponse, error) {
+func (s *GrpcServer) SplitRegions(ctx context.Context, request *pdpb.SplitRegionsRequest) (resp *pdpb.SplitRegionsResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).SplitRegions(ctx, request)
 	}
@@ -1685,7 +2003,14 @@ func (s *GrpcServer) SplitRegions(ctx context.Context, request *pdpb.SplitRegion
 // SplitAndScatterRegions split regions by the given split keys, and scatter regions.
 // Only regions which splited successfully will be scattered.
 // scatterFinishedPercentage indicates the percentage of successfully splited regions that are scattered.
-func (s *GrpcServer) SplitAndScatterRegions(ctx context.Context, request *pdpb.SplitAndScatterRegionsRequest) (*pdpb.SplitAndScatterRegionsResponse, error) {
+func (s *GrpcServer) SplitAndScatterRegions(ctx context.Context, request *pdpb.SplitAndScatterRegionsRequest) (resp *pdpb.SplitAndScatterRegionsResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).SplitAndScatterRegions(ctx, request)
 	}
@@ -1730,6 +2055,9 @@ func scatterRegions(cluster *cluster.RaftCluster, regionsID []uint64, group stri
 
 // GetDCLocationInfo gets the dc-location info of the given dc-location from PD leader's TSO allocator manager.
 func (s *GrpcServer) GetDCLocationInfo(ctx context.Context, request *pdpb.GetDCLocationInfoRequest) (*pdpb.GetDCLocationInfoResponse, error) {
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
 	var err error
 	if err = s.validateInternalRequest(request.GetHeader(), false); err != nil {
 		return nil, err
@@ -1890,7 +2218,17 @@ const globalConfigPath = "/global/config/"
 // StoreGlobalConfig store global config into etcd by transaction
 // Since item value needs to support marshal of different struct types,
 // it should be set to `Payload bytes` instead of `Value string`
-func (s *GrpcServer) StoreGlobalConfig(_ context.Context, request *pdpb.StoreGlobalConfigRequest) (*pdpb.StoreGlobalConfigResponse, error) {
+func (s *GrpcServer) StoreGlobalConfig(ctx context.Context, request *pdpb.StoreGlobalConfigRequest) (resp *pdpb.StoreGlobalConfigResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	configPath := request.GetConfigPath()
 	if configPath == "" {
 		configPath = globalConfigPath
@@ -1925,6 +2263,14 @@ func (s *GrpcServer) StoreGlobalConfig(_ context.Context, request *pdpb.StoreGlo
 // - `Names` iteratively get value from `ConfigPath/Name` but not care about revision
 // - `ConfigPath` if `Names` is nil can get all values and revision of current path
 func (s *GrpcServer) LoadGlobalConfig(ctx context.Context, request *pdpb.LoadGlobalConfigRequest) (*pdpb.LoadGlobalConfigResponse, error) {
+	if err := s.checkPermission(ctx); err != nil {
+		return nil, err
+	}
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	configPath := request.GetConfigPath()
 	if configPath == "" {
 		configPath = globalConfigPath
@@ -1961,6 +2307,9 @@ func (s *GrpcServer) LoadGlobalConfig(ctx context.Context, request *pdpb.LoadGlo
 // by Etcd.Watch() as long as the context has not been canceled or timed out.
 // Watch on revision which greater than or equal to the required revision.
 func (s *GrpcServer) WatchGlobalConfig(req *pdpb.WatchGlobalConfigRequest, server pdpb.PD_WatchGlobalConfigServer) error {
+	if err := s.checkPermission(server.Context()); err != nil {
+		return err
+	}
 	ctx, cancel := context.WithCancel(s.Context())
 	defer cancel()
 	configPath := req.GetConfigPath()
@@ -2045,7 +2394,9 @@ func (s *GrpcServer) handleDamagedStore(stats *pdpb.StoreStats) {
 }
 
 // ReportMinResolvedTS implements gRPC PDServer.
-func (s *GrpcServer) ReportMinResolvedTS(ctx context.Context, request *pdpb.ReportMinResolvedTsRequest) (*pdpb.ReportMinResolvedTsResponse, error) {
+func (s *GrpcServer) ReportMinResolvedTS(ctx context.Context, request *pdpb.ReportMinResolvedTsRequest) (resp *pdpb.ReportMinResolvedTsResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).ReportMinResolvedTS(ctx, request)
 	}
@@ -2074,7 +2425,14 @@ func (s *GrpcServer) ReportMinResolvedTS(ctx context.Context, request *pdpb.Repo
 }
 
 // SetExternalTimestamp implements gRPC PDServer.
-func (s *GrpcServer) SetExternalTimestamp(ctx context.Context, request *pdpb.SetExternalTimestampRequest) (*pdpb.SetExternalTimestampResponse, error) {
+func (s *GrpcServer) SetExternalTimestamp(ctx context.Context, request *pdpb.SetExternalTimestampRequest) (resp *pdpb.SetExternalTimestampResponse, err error) {
+	done := s.auditMiddleware(ctx, request)
+	defer func() { done(resp, err) }()
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).SetExternalTimestamp(ctx, request)
 	}
@@ -2097,6 +2455,11 @@ func (s *GrpcServer) SetExternalTimestamp(ctx context.Context, request *pdpb.Set
 
 // GetExternalTimestamp implements gRPC PDServer.
 func (s *GrpcServer) GetExternalTimestamp(ctx context.Context, request *pdpb.GetExternalTimestampRequest) (*pdpb.GetExternalTimestampResponse, error) {
+	release, err := s.rateLimitMiddleware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	fn := func(ctx context.Context, client *grpc.ClientConn) (interface{}, error) {
 		return pdpb.NewPDClient(client).GetExternalTimestamp(ctx, request)
 	}
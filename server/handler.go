@@ -221,7 +221,7 @@ func (h *Handler) AddScheduler(name string, args ...string) error {
 		return err
 	}
 
-	s, err := schedule.CreateScheduler(name, c.GetOperatorController(), h.s.storage, schedule.ConfigSliceDecoder(name, args))
+	s, err := schedule.CreateScheduler(name, c.GetOperatorController(), c.GetRegionScatter(), h.s.storage, schedule.ConfigSliceDecoder(name, args))
 	if err != nil {
 		return err
 	}
@@ -312,6 +312,11 @@ func (h *Handler) AddBalanceRegionScheduler() error {
 	return h.AddScheduler(schedulers.BalanceRegionType)
 }
 
+// AddBalanceTableScheduler adds a balance-table-scheduler.
+func (h *Handler) AddBalanceTableScheduler(args ...string) error {
+	return h.AddScheduler(schedulers.BalanceTableType, args...)
+}
+
 // AddBalanceHotRegionScheduler adds a balance-hot-region-scheduler.
 func (h *Handler) AddBalanceHotRegionScheduler() error {
 	return h.AddScheduler(schedulers.HotRegionType)
@@ -322,6 +327,11 @@ func (h *Handler) AddEvictSlowTrendScheduler() error {
 	return h.AddScheduler(schedulers.EvictSlowTrendType)
 }
 
+// AddStoreHealthScheduler adds a store-health-scheduler.
+func (h *Handler) AddStoreHealthScheduler() error {
+	return h.AddScheduler(schedulers.StoreHealthType)
+}
+
 // AddLabelScheduler adds a label-scheduler.
 func (h *Handler) AddLabelScheduler() error {
 	return h.AddScheduler(schedulers.LabelType)
@@ -362,6 +372,16 @@ func (h *Handler) AddEvictSlowStoreScheduler() error {
 	return h.AddScheduler(schedulers.EvictSlowStoreType)
 }
 
+// AddLoadSplitScheduler adds a load-split-scheduler.
+func (h *Handler) AddLoadSplitScheduler() error {
+	return h.AddScheduler(schedulers.LoadSplitType)
+}
+
+// AddScatterGroupScheduler adds a scatter-group-scheduler.
+func (h *Handler) AddScatterGroupScheduler() error {
+	return h.AddScheduler(schedulers.ScatterGroupType)
+}
+
 // AddSplitBucketScheduler adds a split-bucket-scheduler.
 func (h *Handler) AddSplitBucketScheduler() error {
 	return h.AddScheduler(schedulers.SplitBucketType)
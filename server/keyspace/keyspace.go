@@ -16,12 +16,15 @@ package keyspace
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 	"github.com/pingcap/log"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/id"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
@@ -63,6 +66,8 @@ type Manager struct {
 	ctx context.Context
 	// config is the configurations of the manager.
 	config config.KeyspaceConfig
+	// eventHub is used to publish the state changes of the keyspaces.
+	eventHub *event.Hub
 }
 
 // CreateKeyspaceRequest represents necessary arguments to create a keyspace.
@@ -91,6 +96,11 @@ func NewKeyspaceManager(store endpoint.KeyspaceStorage,
 	}
 }
 
+// SetEventHub sets the hub to publish the state changes of the keyspaces.
+func (manager *Manager) SetEventHub(hub *event.Hub) {
+	manager.eventHub = hub
+}
+
 // Bootstrap saves default keyspace info.
 func (manager *Manager) Bootstrap() error {
 	// Split Keyspace Region for default keyspace.
@@ -165,9 +175,17 @@ func (manager *Manager) CreateKeyspace(request *CreateKeyspaceRequest) (*keyspac
 		zap.Uint32("ID", keyspace.GetId()),
 		zap.String("name", keyspace.GetName()),
 	)
+	manager.publishEvent(keyspace, "create")
 	return keyspace, nil
 }
 
+func (manager *Manager) publishEvent(meta *keyspacepb.KeyspaceMeta, action string) {
+	manager.eventHub.Publish(event.TypeKeyspace, action, strconv.FormatUint(uint64(meta.GetId()), 10), map[string]interface{}{
+		"name":  meta.GetName(),
+		"state": meta.GetState().String(),
+	})
+}
+
 func (manager *Manager) saveNewKeyspace(keyspace *keyspacepb.KeyspaceMeta) error {
 	manager.metaLock.Lock(keyspace.Id)
 	defer manager.metaLock.Unlock(keyspace.Id)
@@ -360,7 +378,10 @@ func (manager *Manager) UpdateKeyspaceState(name string, newState keyspacepb.Key
 		)
 		return nil, errModifyDefault
 	}
-	var meta *keyspacepb.KeyspaceMeta
+	var (
+		meta     *keyspacepb.KeyspaceMeta
+		oldState keyspacepb.KeyspaceState
+	)
 	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
 		// First get KeyspaceID from Name.
 		loaded, id, err := manager.store.LoadKeyspaceID(txn, name)
@@ -380,6 +401,7 @@ func (manager *Manager) UpdateKeyspaceState(name string, newState keyspacepb.Key
 		if meta == nil {
 			return ErrKeyspaceNotFound
 		}
+		oldState = meta.GetState()
 		// Update keyspace meta.
 		if err = updateKeyspaceState(meta, newState, now); err != nil {
 			return err
@@ -399,6 +421,9 @@ func (manager *Manager) UpdateKeyspaceState(name string, newState keyspacepb.Key
 		zap.String("name", meta.GetName()),
 		zap.String("new state", newState.String()),
 	)
+	if oldState != newState {
+		manager.publishEvent(meta, strings.ToLower(newState.String()))
+	}
 	return meta, nil
 }
 
@@ -412,8 +437,11 @@ func (manager *Manager) UpdateKeyspaceStateByID(id uint32, newState keyspacepb.K
 		)
 		return nil, errModifyDefault
 	}
-	var meta *keyspacepb.KeyspaceMeta
-	var err error
+	var (
+		meta     *keyspacepb.KeyspaceMeta
+		oldState keyspacepb.KeyspaceState
+		err      error
+	)
 	err = manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
 		manager.metaLock.Lock(id)
 		defer manager.metaLock.Unlock(id)
@@ -425,6 +453,7 @@ func (manager *Manager) UpdateKeyspaceStateByID(id uint32, newState keyspacepb.K
 		if meta == nil {
 			return ErrKeyspaceNotFound
 		}
+		oldState = meta.GetState()
 		// Update keyspace meta.
 		if err = updateKeyspaceState(meta, newState, now); err != nil {
 			return err
@@ -444,6 +473,9 @@ func (manager *Manager) UpdateKeyspaceStateByID(id uint32, newState keyspacepb.K
 		zap.String("name", meta.GetName()),
 		zap.String("new state", newState.String()),
 	)
+	if oldState != newState {
+		manager.publishEvent(meta, strings.ToLower(newState.String()))
+	}
 	return meta, nil
 }
 
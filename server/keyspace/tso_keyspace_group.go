@@ -0,0 +1,216 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/tikv/pd/pkg/mcs/utils"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+var (
+	// ErrKeyspaceGroupExists indicates target keyspace group already exists.
+	ErrKeyspaceGroupExists = errors.New("keyspace group already exists")
+	// ErrKeyspaceGroupNotFound is used to indicate target keyspace group does not exist.
+	ErrKeyspaceGroupNotFound = errors.New("keyspace group does not exist")
+	errModifyDefaultGroup    = errors.New("cannot modify default keyspace group")
+)
+
+// GroupManager is the manager of keyspace group related data.
+// Each keyspace group is served by an independent TSO allocator in the TSO service,
+// which allocates timestamps for the keyspaces belonging to it.
+type GroupManager struct {
+	mu syncutil.RWMutex
+	// store is the storage for keyspace group related information.
+	store groupStorage
+	// ctx is the context of the manager, to be used in transaction.
+	ctx context.Context
+}
+
+// groupStorage is the storage used by the GroupManager. The keyspace storage is
+// used to check the existence of the keyspaces assigned to the keyspace groups.
+type groupStorage interface {
+	endpoint.KeyspaceGroupStorage
+	endpoint.KeyspaceStorage
+}
+
+// NewKeyspaceGroupManager creates a Manager of keyspace group related data.
+func NewKeyspaceGroupManager(store groupStorage) *GroupManager {
+	return &GroupManager{
+		store: store,
+		ctx:   context.TODO(),
+	}
+}
+
+// Bootstrap saves the default keyspace group info.
+func (m *GroupManager) Bootstrap() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.store.RunInTxn(m.ctx, func(txn kv.Txn) error {
+		kg, err := m.store.LoadKeyspaceGroup(txn, utils.DefaultKeyspaceGroupID)
+		if err != nil {
+			return err
+		}
+		// It's possible that the default keyspace group already exists in the storage (e.g. PD restart/recover).
+		if kg != nil {
+			return nil
+		}
+		return m.store.SaveKeyspaceGroup(txn, &endpoint.KeyspaceGroup{
+			ID:        utils.DefaultKeyspaceGroupID,
+			Keyspaces: []uint32{DefaultKeyspaceID},
+		})
+	})
+}
+
+// CreateKeyspaceGroups creates keyspace groups.
+// A keyspace can only belong to one keyspace group, and keyspaces which are not
+// assigned to any keyspace group will be served by the default keyspace group.
+// The assigned keyspaces must exist, and they are recorded as transfers from the
+// default keyspace group, so that the TSO service won't serve them by the new keyspace
+// groups until the timestamps allocated by the default keyspace group are fenced.
+func (m *GroupManager) CreateKeyspaceGroups(keyspaceGroups []*endpoint.KeyspaceGroup) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, err := m.store.LoadKeyspaceGroups()
+	if err != nil {
+		return err
+	}
+	assigned := make(map[uint32]uint32)
+	groupIDs := make(map[uint32]struct{})
+	for _, kg := range existing {
+		groupIDs[kg.ID] = struct{}{}
+		if kg.ID == utils.DefaultKeyspaceGroupID {
+			continue
+		}
+		for _, keyspaceID := range kg.Keyspaces {
+			assigned[keyspaceID] = kg.ID
+		}
+	}
+	for _, kg := range keyspaceGroups {
+		if kg.ID >= utils.MaxKeyspaceGroupCount {
+			return errors.Errorf("illegal keyspace group id %d, should be less than %d", kg.ID, utils.MaxKeyspaceGroupCount)
+		}
+		if _, ok := groupIDs[kg.ID]; ok {
+			return ErrKeyspaceGroupExists
+		}
+		groupIDs[kg.ID] = struct{}{}
+		for _, keyspaceID := range kg.Keyspaces {
+			if keyspaceID == DefaultKeyspaceID {
+				return errors.Errorf("default keyspace can only belong to the default keyspace group")
+			}
+			if groupID, ok := assigned[keyspaceID]; ok {
+				return errors.Errorf("keyspace %d already belongs to keyspace group %d", keyspaceID, groupID)
+			}
+			assigned[keyspaceID] = kg.ID
+		}
+	}
+	return m.store.RunInTxn(m.ctx, func(txn kv.Txn) error {
+		for _, kg := range keyspaceGroups {
+			for _, keyspaceID := range kg.Keyspaces {
+				meta, err := m.store.LoadKeyspaceMeta(txn, keyspaceID)
+				if err != nil {
+					return err
+				}
+				if meta == nil {
+					return errors.Wrapf(ErrKeyspaceNotFound, "keyspace %d", keyspaceID)
+				}
+			}
+			kg.Transfers = nil
+			if len(kg.Keyspaces) > 0 {
+				kg.Transfers = []*endpoint.KeyspaceTransfer{{
+					Source:    utils.DefaultKeyspaceGroupID,
+					Keyspaces: kg.Keyspaces,
+				}}
+			}
+			if err := m.store.SaveKeyspaceGroup(txn, kg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetKeyspaceGroups returns all keyspace groups.
+func (m *GroupManager) GetKeyspaceGroups() ([]*endpoint.KeyspaceGroup, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.store.LoadKeyspaceGroups()
+}
+
+// GetKeyspaceGroupByID returns the keyspace group by id.
+func (m *GroupManager) GetKeyspaceGroupByID(id uint32) (*endpoint.KeyspaceGroup, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var kg *endpoint.KeyspaceGroup
+	if err := m.store.RunInTxn(m.ctx, func(txn kv.Txn) (err error) {
+		kg, err = m.store.LoadKeyspaceGroup(txn, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if kg == nil {
+		return nil, ErrKeyspaceGroupNotFound
+	}
+	return kg, nil
+}
+
+// DeleteKeyspaceGroupByID deletes the keyspace group by id.
+// The keyspaces of the deleted keyspace group will fall back to the default keyspace group,
+// which is recorded as a transfer of the default keyspace group in the same transaction.
+func (m *GroupManager) DeleteKeyspaceGroupByID(id uint32) error {
+	if id == utils.DefaultKeyspaceGroupID {
+		return errModifyDefaultGroup
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.store.RunInTxn(m.ctx, func(txn kv.Txn) error {
+		kg, err := m.store.LoadKeyspaceGroup(txn, id)
+		if err != nil {
+			return err
+		}
+		if kg == nil {
+			return ErrKeyspaceGroupNotFound
+		}
+		if len(kg.Keyspaces) > 0 {
+			defaultGroup, err := m.store.LoadKeyspaceGroup(txn, utils.DefaultKeyspaceGroupID)
+			if err != nil {
+				return err
+			}
+			if defaultGroup == nil {
+				return ErrKeyspaceGroupNotFound
+			}
+			defaultGroup.Transfers = addTransfer(defaultGroup.Transfers, id, kg.Keyspaces)
+			if err := m.store.SaveKeyspaceGroup(txn, defaultGroup); err != nil {
+				return err
+			}
+		}
+		return m.store.DeleteKeyspaceGroup(txn, id)
+	})
+}
+
+// addTransfer merges the keyspaces moved from the source keyspace group into the transfers.
+func addTransfer(transfers []*endpoint.KeyspaceTransfer, source uint32, keyspaces []uint32) []*endpoint.KeyspaceTransfer {
+	for _, transfer := range transfers {
+		if transfer.Source == source {
+			transfer.Keyspaces = append(transfer.Keyspaces, keyspaces...)
+			return transfers
+		}
+	}
+	return append(transfers, &endpoint.KeyspaceTransfer{Source: source, Keyspaces: keyspaces})
+}
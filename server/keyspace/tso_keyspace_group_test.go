@@ -0,0 +1,102 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/keyspacepb"
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/mcs/utils"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+type keyspaceGroupTestSuite struct {
+	suite.Suite
+	kgm *GroupManager
+}
+
+func TestKeyspaceGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(keyspaceGroupTestSuite))
+}
+
+func (suite *keyspaceGroupTestSuite) SetupTest() {
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	suite.NoError(store.RunInTxn(context.TODO(), func(txn kv.Txn) error {
+		for id := uint32(1); id <= 4; id++ {
+			if err := store.SaveKeyspaceMeta(txn, &keyspacepb.KeyspaceMeta{Id: id}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	suite.kgm = NewKeyspaceGroupManager(store)
+	suite.NoError(suite.kgm.Bootstrap())
+}
+
+func (suite *keyspaceGroupTestSuite) TestKeyspaceGroupOperations() {
+	re := suite.Require()
+
+	// The default keyspace group is created by bootstrap.
+	kg, err := suite.kgm.GetKeyspaceGroupByID(utils.DefaultKeyspaceGroupID)
+	re.NoError(err)
+	re.Equal([]uint32{DefaultKeyspaceID}, kg.Keyspaces)
+	// Bootstrap again should be idempotent.
+	re.NoError(suite.kgm.Bootstrap())
+
+	keyspaceGroups := []*endpoint.KeyspaceGroup{
+		{ID: 1, Keyspaces: []uint32{1, 2}},
+		{ID: 2, Keyspaces: []uint32{3}},
+		{ID: 3},
+	}
+	re.NoError(suite.kgm.CreateKeyspaceGroups(keyspaceGroups))
+	kgs, err := suite.kgm.GetKeyspaceGroups()
+	re.NoError(err)
+	re.Len(kgs, 4)
+	kg, err = suite.kgm.GetKeyspaceGroupByID(1)
+	re.NoError(err)
+	re.Equal(keyspaceGroups[0], kg)
+	// The keyspaces are moved from the default keyspace group.
+	re.Equal([]*endpoint.KeyspaceTransfer{{Source: utils.DefaultKeyspaceGroupID, Keyspaces: []uint32{1, 2}}}, kg.Transfers)
+
+	// Create an existing keyspace group.
+	re.ErrorIs(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 3}}), ErrKeyspaceGroupExists)
+	// A keyspace can only belong to one keyspace group.
+	re.Error(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 4, Keyspaces: []uint32{2}}}))
+	re.Error(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 4, Keyspaces: []uint32{4}}, {ID: 5, Keyspaces: []uint32{4}}}))
+	re.Error(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 4, Keyspaces: []uint32{DefaultKeyspaceID}}}))
+	// The keyspace does not exist.
+	re.ErrorIs(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 4, Keyspaces: []uint32{5}}}), ErrKeyspaceNotFound)
+	// The keyspace group ID is out of range.
+	re.Error(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: utils.MaxKeyspaceGroupCount}}))
+	// Nothing is created by the failed requests.
+	_, err = suite.kgm.GetKeyspaceGroupByID(4)
+	re.ErrorIs(err, ErrKeyspaceGroupNotFound)
+
+	// Delete the keyspace group.
+	re.NoError(suite.kgm.DeleteKeyspaceGroupByID(1))
+	_, err = suite.kgm.GetKeyspaceGroupByID(1)
+	re.ErrorIs(err, ErrKeyspaceGroupNotFound)
+	re.ErrorIs(suite.kgm.DeleteKeyspaceGroupByID(1), ErrKeyspaceGroupNotFound)
+	// The keyspaces of the deleted keyspace group are moved back to the default keyspace group.
+	kg, err = suite.kgm.GetKeyspaceGroupByID(utils.DefaultKeyspaceGroupID)
+	re.NoError(err)
+	re.Equal([]*endpoint.KeyspaceTransfer{{Source: 1, Keyspaces: []uint32{1, 2}}}, kg.Transfers)
+	re.Error(suite.kgm.DeleteKeyspaceGroupByID(utils.DefaultKeyspaceGroupID))
+	// The keyspaces of the deleted keyspace group could be assigned again.
+	re.NoError(suite.kgm.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 4, Keyspaces: []uint32{1}}}))
+}
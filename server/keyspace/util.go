@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 )
@@ -107,22 +108,31 @@ func keyspaceIDHash(id uint32) uint32 {
 // These repeated bound will not cause any problem, as repetitive bound will be ignored during rangeListBuild,
 // but provides guard against hole in keyspace allocations should it occur.
 func makeKeyRanges(id uint32) []interface{} {
+	ranges := GetKeyspaceKeyRanges(id)
+	keyRanges := make([]interface{}, 0, len(ranges))
+	for _, r := range ranges {
+		keyRanges = append(keyRanges, map[string]interface{}{
+			"start_key": hex.EncodeToString(r.StartKey),
+			"end_key":   hex.EncodeToString(r.EndKey),
+		})
+	}
+	return keyRanges
+}
+
+// GetKeyspaceKeyRanges returns the encoded raw mode and txn mode key ranges of the target keyspace.
+func GetKeyspaceKeyRanges(id uint32) []core.KeyRange {
 	keyspaceIDBytes := make([]byte, 4)
 	nextKeyspaceIDBytes := make([]byte, 4)
 	binary.BigEndian.PutUint32(keyspaceIDBytes, id)
 	binary.BigEndian.PutUint32(nextKeyspaceIDBytes, id+1)
-	rawLeftBound := hex.EncodeToString(codec.EncodeBytes(append([]byte{'r'}, keyspaceIDBytes[1:]...)))
-	rawRightBound := hex.EncodeToString(codec.EncodeBytes(append([]byte{'r'}, nextKeyspaceIDBytes[1:]...)))
-	txnLeftBound := hex.EncodeToString(codec.EncodeBytes(append([]byte{'x'}, keyspaceIDBytes[1:]...)))
-	txnRightBound := hex.EncodeToString(codec.EncodeBytes(append([]byte{'x'}, nextKeyspaceIDBytes[1:]...)))
-	return []interface{}{
-		map[string]interface{}{
-			"start_key": rawLeftBound,
-			"end_key":   rawRightBound,
+	return []core.KeyRange{
+		{
+			StartKey: codec.EncodeBytes(append([]byte{'r'}, keyspaceIDBytes[1:]...)),
+			EndKey:   codec.EncodeBytes(append([]byte{'r'}, nextKeyspaceIDBytes[1:]...)),
 		},
-		map[string]interface{}{
-			"start_key": txnLeftBound,
-			"end_key":   txnRightBound,
+		{
+			StartKey: codec.EncodeBytes(append([]byte{'x'}, keyspaceIDBytes[1:]...)),
+			EndKey:   codec.EncodeBytes(append([]byte{'x'}, nextKeyspaceIDBytes[1:]...)),
 		},
 	}
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/schedule"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
@@ -88,6 +89,8 @@ type ModeManager struct {
 	drTotalRegion        int // number of all regions
 
 	drStoreStatus sync.Map
+
+	eventHub *event.Hub
 }
 
 // NewReplicationModeManager creates the replicate mode manager.
@@ -109,6 +112,13 @@ func NewReplicationModeManager(config config.ReplicationModeConfig, storage endp
 	return m, nil
 }
 
+// SetEventHub sets the hub to alert the state changes of DR auto-sync.
+func (m *ModeManager) SetEventHub(hub *event.Hub) {
+	m.Lock()
+	defer m.Unlock()
+	m.eventHub = hub
+}
+
 // UpdateConfig updates configuration online and updates internal state.
 func (m *ModeManager) UpdateConfig(config config.ReplicationModeConfig) error {
 	m.Lock()
@@ -245,7 +255,7 @@ func (m *ModeManager) drSwitchToAsyncWait(availableStores []uint64) error {
 		log.Warn("failed to switch to async state", zap.String("replicate-mode", modeDRAutoSync), errs.ZapError(err))
 		return err
 	}
-	m.drAutoSync = dr
+	m.drUpdateStatusWithLock(dr)
 	log.Info("switched to async_wait state", zap.String("replicate-mode", modeDRAutoSync))
 	return nil
 }
@@ -268,7 +278,7 @@ func (m *ModeManager) drSwitchToAsyncWithLock(availableStores []uint64) error {
 		log.Warn("failed to switch to async state", zap.String("replicate-mode", modeDRAutoSync), errs.ZapError(err))
 		return err
 	}
-	m.drAutoSync = dr
+	m.drUpdateStatusWithLock(dr)
 	log.Info("switched to async state", zap.String("replicate-mode", modeDRAutoSync))
 	return nil
 }
@@ -292,7 +302,7 @@ func (m *ModeManager) drSwitchToSyncRecoverWithLock() error {
 		log.Warn("failed to switch to sync_recover state", zap.String("replicate-mode", modeDRAutoSync), errs.ZapError(err))
 		return err
 	}
-	m.drAutoSync = dr
+	m.drUpdateStatusWithLock(dr)
 	m.drRecoverKey, m.drRecoverCount = nil, 0
 	log.Info("switched to sync_recover state", zap.String("replicate-mode", modeDRAutoSync))
 	return nil
@@ -312,11 +322,22 @@ func (m *ModeManager) drSwitchToSync() error {
 		log.Warn("failed to switch to sync state", zap.String("replicate-mode", modeDRAutoSync), errs.ZapError(err))
 		return err
 	}
-	m.drAutoSync = dr
+	m.drUpdateStatusWithLock(dr)
 	log.Info("switched to sync state", zap.String("replicate-mode", modeDRAutoSync))
 	return nil
 }
 
+// drUpdateStatusWithLock updates the status in memory and alerts if the state is changed.
+func (m *ModeManager) drUpdateStatusWithLock(status drAutoSyncStatus) {
+	if m.drAutoSync.State != status.State {
+		m.eventHub.Publish(event.TypeAlert, event.AlertDRAutoSyncStateChange, status.State, map[string]interface{}{
+			"from":     m.drAutoSync.State,
+			"state-id": status.StateID,
+		})
+	}
+	m.drAutoSync = status
+}
+
 func (m *ModeManager) drPersistStatusWithLock(status drAutoSyncStatus) {
 	ctx, cancel := context.WithTimeout(context.Background(), persistFileTimeout)
 	defer cancel()
@@ -45,6 +45,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/encryption"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/event"
 	"github.com/tikv/pd/pkg/id"
 	ms_server "github.com/tikv/pd/pkg/mcs/meta_storage/server"
 	"github.com/tikv/pd/pkg/mcs/registry"
@@ -53,6 +54,7 @@ import (
 	_ "github.com/tikv/pd/pkg/mcs/tso/server/apis/v1"              // init tso API group
 	"github.com/tikv/pd/pkg/member"
 	"github.com/tikv/pd/pkg/ratelimit"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/schedule"
 	"github.com/tikv/pd/pkg/schedule/hbstream"
 	"github.com/tikv/pd/pkg/schedule/placement"
@@ -79,11 +81,16 @@ import (
 	"go.etcd.io/etcd/pkg/types"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	serverMetricsInterval = time.Minute
-	leaderTickInterval    = 50 * time.Millisecond
+	// rbacRewatchInterval is the interval to watch the RBAC config and roles again on the
+	// followers after the watch is canceled.
+	rbacRewatchInterval = time.Second
+	leaderTickInterval  = 50 * time.Millisecond
 	// pdRootPath for all pd servers.
 	pdRootPath      = "/pd"
 	pdAPIPrefix     = "/pd/"
@@ -156,6 +163,10 @@ type Server struct {
 	gcSafePointManager *gc.SafePointManager
 	// keyspace manager
 	keyspaceManager *keyspace.Manager
+	// keyspace group manager
+	keyspaceGroupManager *keyspace.GroupManager
+	// rbac manager
+	rbacManager *rbac.Manager
 	// for basicCluster operation.
 	basicCluster *core.BasicCluster
 	// for tso.
@@ -187,10 +198,21 @@ type Server struct {
 	serviceRateLimiter *ratelimit.Limiter
 	serviceLabels      map[string][]apiutil.AccessPath
 	apiServiceLabelMap map[apiutil.AccessPath]string
+	// grpcServiceRateLimiter is used to limit the gRPC methods, the label is the gRPC method.
+	grpcServiceRateLimiter *ratelimit.Limiter
+	// fairLimiter is used to share the concurrency of the HTTP services between the callers fairly.
+	fairLimiter *ratelimit.FairLimiter
 
 	serviceAuditBackendLabels map[string]*audit.BackendLabels
+	// streamingServiceLabels are the service labels of the long-lived HTTP APIs like the event stream.
+	streamingServiceLabels map[string]struct{}
 
 	auditBackends []audit.Backend
+	// durableAuditBackend records the audit records into the local storage.
+	durableAuditBackend *audit.DurableStorageBackend
+
+	// eventHub publishes the state changes of the cluster to the subscribers.
+	eventHub *event.Hub
 
 	registry *registry.ServiceRegistry
 	mode     string
@@ -225,12 +247,26 @@ func CreateServer(ctx context.Context, cfg *config.Config, services []string, le
 	s.handler = newHandler(s)
 
 	// create audit backend
+	s.durableAuditBackend = audit.NewDurableStorageBackend(func(r *http.Request) []string {
+		if s.rbacManager == nil {
+			return rbac.SubjectsFromHTTP(r)
+		}
+		return s.rbacManager.ClientSubjectsFromHTTP(r)
+	})
 	s.auditBackends = []audit.Backend{
 		audit.NewLocalLogBackend(true),
 		audit.NewPrometheusHistogramBackend(serviceAuditHistogram, false),
+		s.durableAuditBackend,
 	}
 	s.serviceRateLimiter = ratelimit.NewLimiter()
+	s.grpcServiceRateLimiter = ratelimit.NewLimiter()
+	s.fairLimiter = ratelimit.NewFairLimiter(serviceMiddlewareCfg.FairQueuing)
+	s.eventHub = event.NewHub(event.DefaultBacklogSize)
 	s.serviceAuditBackendLabels = make(map[string]*audit.BackendLabels)
+	s.streamingServiceLabels = make(map[string]struct{})
+	for method, labels := range defaultGRPCAuditBackendLabels {
+		s.SetServiceAuditBackendLabels(method, labels)
+	}
 	s.serviceLabels = make(map[string][]apiutil.AccessPath)
 	s.apiServiceLabelMap = make(map[apiutil.AccessPath]string)
 
@@ -260,6 +296,7 @@ func CreateServer(ctx context.Context, cfg *config.Config, services []string, le
 		grpcServer := &GrpcServer{Server: s}
 		pdpb.RegisterPDServer(gs, grpcServer)
 		keyspacepb.RegisterKeyspaceServer(gs, &KeyspaceServer{GrpcServer: grpcServer})
+		RegisterEventServer(gs, &EventServer{GrpcServer: grpcServer})
 		diagnosticspb.RegisterDiagnosticsServer(gs, s)
 		// Register the micro services GRPC service.
 		s.registry.InstallAllGRPCServices(s, gs)
@@ -376,9 +413,14 @@ func (s *Server) startServer(ctx context.Context) error {
 	defaultStorage := storage.NewStorageWithEtcdBackend(s.client, s.rootPath)
 	s.storage = storage.NewCoreStorage(defaultStorage, regionStorage)
 
+	timeSource, err := tso.NewTimeSource(ctx, s.cfg.GetTSOTimeSource())
+	if err != nil {
+		return err
+	}
 	s.tsoAllocatorManager = tso.NewAllocatorManager(
 		s.member, s.rootPath, s.storage, s.cfg.IsLocalTSOEnabled(), s.cfg.GetTSOSaveInterval(), s.cfg.GetTSOUpdatePhysicalInterval(), s.cfg.GetTLSConfig(),
-		func() time.Duration { return s.persistOptions.GetMaxResetTSGap() })
+		func() time.Duration { return s.persistOptions.GetMaxResetTSGap() }, timeSource, s.cfg.GetTSOMaxClockUncertainty())
+	s.tsoAllocatorManager.SetEventHub(s.eventHub)
 	// Set up the Global TSO Allocator here, it will be initialized once the PD campaigns leader successfully.
 	s.tsoAllocatorManager.SetUpAllocator(ctx, tso.GlobalDCLocation, s.member.GetLeadership())
 	// When disabled the Local TSO, we should clean up the Local TSO Allocator's meta info written in etcd if it exists.
@@ -398,6 +440,10 @@ func (s *Server) startServer(ctx context.Context) error {
 	}
 
 	s.gcSafePointManager = gc.NewSafePointManager(s.storage)
+	s.rbacManager = rbac.NewManager(s.storage, s.serviceMiddlewarePersistOptions.IsRBACEnabled)
+	if err = s.rbacManager.Load(); err != nil {
+		return err
+	}
 	s.basicCluster = core.NewBasicCluster()
 	s.cluster = cluster.NewRaftCluster(ctx, s.clusterID, syncer.NewRegionSyncer(s), s.client, s.httpClient)
 	keyspaceIDAllocator := id.NewAllocator(&id.AllocatorParams{
@@ -409,6 +455,8 @@ func (s *Server) startServer(ctx context.Context) error {
 		Step:      keyspace.AllocStep,
 	})
 	s.keyspaceManager = keyspace.NewKeyspaceManager(s.storage, s.cluster, keyspaceIDAllocator, s.cfg.Keyspace)
+	s.keyspaceManager.SetEventHub(s.eventHub)
+	s.keyspaceGroupManager = keyspace.NewKeyspaceGroupManager(s.storage)
 	s.hbStreams = hbstream.NewHeartbeatStreams(ctx, s.clusterID, s.cluster)
 	// initial hot_region_storage in here.
 	s.hotRegionStorage, err = storage.NewHotRegionsStorage(
@@ -416,6 +464,11 @@ func (s *Server) startServer(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err = s.durableAuditBackend.Open(ctx, filepath.Join(s.cfg.DataDir, "audit"), func() uint64 {
+		return s.serviceMiddlewarePersistOptions.GetAuditConfig().StorageRetentionDays
+	}); err != nil {
+		return err
+	}
 	// Run callbacks
 	log.Info("triggering the start callback functions")
 	for _, cb := range s.startCallbacks {
@@ -469,6 +522,10 @@ func (s *Server) Close() {
 		log.Error("close hot region storage meet error", errs.ZapError(err))
 	}
 
+	if err := s.durableAuditBackend.Close(); err != nil {
+		log.Error("close audit storage meet error", errs.ZapError(err))
+	}
+
 	// Run callbacks
 	log.Info("triggering the close callback functions")
 	for _, cb := range s.closeCallbacks {
@@ -523,12 +580,14 @@ func (s *Server) LoopContext() context.Context {
 
 func (s *Server) startServerLoop(ctx context.Context) {
 	s.serverLoopCtx, s.serverLoopCancel = context.WithCancel(ctx)
-	s.serverLoopWg.Add(5)
+	s.serverLoopWg.Add(7)
 	go s.leaderLoop()
 	go s.etcdLeaderLoop()
 	go s.serverMetricsLoop()
 	go s.tsoAllocatorLoop()
 	go s.encryptionKeyManagerLoop()
+	go s.rbacLoop()
+	go s.notificationLoop()
 }
 
 func (s *Server) stopServerLoop() {
@@ -553,6 +612,76 @@ func (s *Server) serverMetricsLoop() {
 	}
 }
 
+// notificationLoop sends the alerts to the webhooks. The alerts are mostly raised by the leader,
+// but it runs on all the members to send the ones raised before the leadership is lost.
+func (s *Server) notificationLoop() {
+	defer logutil.LogPanic()
+	defer s.serverLoopWg.Done()
+
+	notifier := event.NewNotifier(s.eventHub, s.cfg.Notification.Webhooks, filepath.Join(s.cfg.DataDir, "notification", "dead-letter.log"))
+	notifier.Run(s.serverLoopCtx)
+}
+
+// rbacLoop is used to reload the service middleware config and the RBAC roles and bindings on the
+// followers, since the followers also check the permissions and limit the rate of the requests
+// they serve or forward. They are only updated by the leader, so the followers watch the keys and
+// reload them once they are changed, and the revoked bindings take effect immediately.
+func (s *Server) rbacLoop() {
+	defer logutil.LogPanic()
+	defer s.serverLoopWg.Done()
+
+	ctx, cancel := context.WithCancel(s.serverLoopCtx)
+	defer cancel()
+	for {
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		configChan := s.client.Watch(watchCtx, path.Join(s.rootPath, endpoint.ServiceMiddlewarePath()))
+		rbacChan := s.client.Watch(watchCtx, path.Join(s.rootPath, endpoint.RBACPrefix()), clientv3.WithPrefix())
+		// Reload after watching to not miss the changes before the watch starts.
+		s.reloadRBAC()
+	watchLoop:
+		for {
+			var (
+				resp clientv3.WatchResponse
+				ok   bool
+			)
+			select {
+			case resp, ok = <-configChan:
+			case resp, ok = <-rbacChan:
+			case <-ctx.Done():
+				watchCancel()
+				log.Info("server is closed, exit rbac loop")
+				return
+			}
+			if !ok || resp.Err() != nil {
+				log.Warn("rbac watcher is canceled, the watcher will watch again", errs.ZapError(resp.Err()))
+				break watchLoop
+			}
+			s.reloadRBAC()
+		}
+		watchCancel()
+		select {
+		case <-time.After(rbacRewatchInterval):
+		case <-ctx.Done():
+			log.Info("server is closed, exit rbac loop")
+			return
+		}
+	}
+}
+
+func (s *Server) reloadRBAC() {
+	if s.IsClosed() || s.member.IsLeader() {
+		return
+	}
+	if err := s.serviceMiddlewarePersistOptions.Reload(s.storage); err != nil {
+		log.Warn("failed to reload service middleware config", errs.ZapError(err))
+	} else {
+		s.loadRateLimitConfig()
+	}
+	if err := s.rbacManager.Load(); err != nil {
+		log.Warn("failed to reload rbac roles and bindings", errs.ZapError(err))
+	}
+}
+
 // tsoAllocatorLoop is used to run the TSO Allocator updating daemon.
 func (s *Server) tsoAllocatorLoop() {
 	defer logutil.LogPanic()
@@ -662,6 +791,9 @@ func (s *Server) bootstrapCluster(req *pdpb.BootstrapRequest) (*pdpb.BootstrapRe
 	if err = s.GetKeyspaceManager().Bootstrap(); err != nil {
 		log.Warn("bootstrap keyspace manager failed", errs.ZapError(err))
 	}
+	if err = s.GetKeyspaceGroupManager().Bootstrap(); err != nil {
+		log.Warn("bootstrap keyspace group manager failed", errs.ZapError(err))
+	}
 
 	return &pdpb.BootstrapResponse{
 		ReplicationStatus: s.cluster.GetReplicationMode().GetReplicationStatus(),
@@ -750,6 +882,11 @@ func (s *Server) GetHistoryHotRegionStorage() *storage.HotRegionStorage {
 	return s.hotRegionStorage
 }
 
+// GetDurableAuditBackend returns the audit backend which records into the local storage.
+func (s *Server) GetDurableAuditBackend() *audit.DurableStorageBackend {
+	return s.durableAuditBackend
+}
+
 // SetStorage changes the storage only for test purpose.
 // When we use it, we should prevent calling GetStorage, otherwise, it may cause a data race problem.
 func (s *Server) SetStorage(storage storage.Storage) {
@@ -791,6 +928,16 @@ func (s *Server) GetKeyspaceManager() *keyspace.Manager {
 	return s.keyspaceManager
 }
 
+// GetKeyspaceGroupManager returns the keyspace group manager of server.
+func (s *Server) GetKeyspaceGroupManager() *keyspace.GroupManager {
+	return s.keyspaceGroupManager
+}
+
+// GetRBACManager returns the RBAC manager of server.
+func (s *Server) GetRBACManager() *rbac.Manager {
+	return s.rbacManager
+}
+
 // Name returns the unique etcd Name for this server in etcd cluster.
 func (s *Server) Name() string {
 	return s.cfg.Name
@@ -819,6 +966,7 @@ func (s *Server) GetServiceMiddlewareConfig() *config.ServiceMiddlewareConfig {
 	cfg := s.serviceMiddlewareCfg.Clone()
 	cfg.AuditConfig = *s.serviceMiddlewarePersistOptions.GetAuditConfig().Clone()
 	cfg.RateLimitConfig = *s.serviceMiddlewarePersistOptions.GetRateLimitConfig().Clone()
+	cfg.RBACConfig = *s.serviceMiddlewarePersistOptions.GetRBACConfig().Clone()
 	return cfg
 }
 
@@ -981,6 +1129,9 @@ func (s *Server) GetAuditConfig() *config.AuditConfig {
 
 // SetAuditConfig sets the audit config.
 func (s *Server) SetAuditConfig(cfg config.AuditConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
 	old := s.serviceMiddlewarePersistOptions.GetAuditConfig()
 	s.serviceMiddlewarePersistOptions.SetAuditConfig(&cfg)
 	if err := s.serviceMiddlewarePersistOptions.Persist(s.storage); err != nil {
@@ -995,6 +1146,68 @@ func (s *Server) SetAuditConfig(cfg config.AuditConfig) error {
 	return nil
 }
 
+// GetRBACConfig gets the RBAC config information.
+func (s *Server) GetRBACConfig() *config.RBACConfig {
+	return s.serviceMiddlewarePersistOptions.GetRBACConfig().Clone()
+}
+
+// SetRBACConfig sets the RBAC config. RBAC can't be enabled unless a subject is bound to the
+// admin role, otherwise nobody is able to manage the cluster any more.
+func (s *Server) SetRBACConfig(cfg config.RBACConfig) error {
+	if cfg.EnableRBAC && !s.rbacManager.HasAdmin() {
+		return errors.Errorf("no subject is bound to the %s role", rbac.RoleAdmin)
+	}
+	old := s.serviceMiddlewarePersistOptions.GetRBACConfig()
+	s.serviceMiddlewarePersistOptions.SetRBACConfig(&cfg)
+	if err := s.serviceMiddlewarePersistOptions.Persist(s.storage); err != nil {
+		s.serviceMiddlewarePersistOptions.SetRBACConfig(old)
+		log.Error("failed to update RBAC config",
+			zap.Reflect("new", cfg),
+			zap.Reflect("old", old),
+			errs.ZapError(err))
+		return err
+	}
+	log.Info("rbac config is updated", zap.Reflect("new", cfg), zap.Reflect("old", old))
+	return nil
+}
+
+// IsRBACAllowed returns whether the subjects are allowed to do the action on the service.
+// It always returns true if RBAC is disabled.
+func (s *Server) IsRBACAllowed(subjects []string, service, action string) bool {
+	if !s.serviceMiddlewarePersistOptions.IsRBACEnabled() {
+		return true
+	}
+	return s.rbacManager.IsAllowed(subjects, service, action)
+}
+
+// CheckHTTPPermission is used to implement basicserver.AccessController.
+func (s *Server) CheckHTTPPermission(r *http.Request, service string) error {
+	if !s.serviceMiddlewarePersistOptions.IsRBACEnabled() {
+		return nil
+	}
+	if !s.rbacManager.IsAllowed(s.rbacManager.ClientSubjectsFromHTTP(r), service, rbac.HTTPAction(r.Method)) {
+		return errs.ErrPermissionDenied.FastGenByArgs(service)
+	}
+	return nil
+}
+
+// CheckGRPCPermission is used to implement basicserver.AccessController. The request forwarded by
+// a PD follower is checked by the follower, and the leader only checks the certificate of the follower.
+func (s *Server) CheckGRPCPermission(ctx context.Context) error {
+	if !s.serviceMiddlewarePersistOptions.IsRBACEnabled() {
+		return nil
+	}
+	method, ok := grpc.Method(ctx)
+	if !ok {
+		return nil
+	}
+	method = strings.TrimPrefix(method, "/")
+	if !s.rbacManager.IsAllowed(rbac.SubjectsFromGRPC(ctx), method, rbac.GRPCAction(method)) {
+		return status.Error(codes.PermissionDenied, errs.ErrPermissionDenied.FastGenByArgs(method).Error())
+	}
+	return nil
+}
+
 // UpdateRateLimitConfig is used to update rate-limit config which will reserve old limiter-config
 func (s *Server) UpdateRateLimitConfig(key, label string, value ratelimit.DimensionConfig) error {
 	cfg := s.GetServiceMiddlewareConfig()
@@ -1023,6 +1236,18 @@ func (s *Server) UpdateRateLimit(cfg *config.RateLimitConfig, key string, value
 	return err
 }
 
+// UpdateGRPCRateLimitConfig is used to update the rate limit config of the gRPC method which will reserve
+// the config of other methods.
+func (s *Server) UpdateGRPCRateLimitConfig(method string, value ratelimit.DimensionConfig) error {
+	cfg := s.GetServiceMiddlewareConfig()
+	rateLimitCfg := make(map[string]ratelimit.DimensionConfig)
+	for label, item := range cfg.GRPCLimiterConfig {
+		rateLimitCfg[label] = item
+	}
+	rateLimitCfg[method] = value
+	return s.UpdateRateLimit(&cfg.RateLimitConfig, "grpc-limiter-config", &rateLimitCfg)
+}
+
 // GetRateLimitConfig gets the rate limit config information.
 func (s *Server) GetRateLimitConfig() *config.RateLimitConfig {
 	return s.serviceMiddlewarePersistOptions.GetRateLimitConfig().Clone()
@@ -1040,6 +1265,7 @@ func (s *Server) SetRateLimitConfig(cfg config.RateLimitConfig) error {
 			errs.ZapError(err))
 		return err
 	}
+	s.fairLimiter.UpdateConfig(cfg.FairQueuing)
 	log.Info("rate limit config is updated", zap.Reflect("new", cfg), zap.Reflect("old", old))
 	return nil
 }
@@ -1266,6 +1492,17 @@ func (s *Server) SetServiceAuditBackendLabels(serviceLabel string, labels []stri
 	s.serviceAuditBackendLabels[serviceLabel] = &audit.BackendLabels{Labels: labels}
 }
 
+// AddStreamingServiceLabel marks the service as a streaming one, which holds the connection for a long time.
+func (s *Server) AddStreamingServiceLabel(serviceLabel string) {
+	s.streamingServiceLabels[serviceLabel] = struct{}{}
+}
+
+// IsStreamingService returns whether the service is a streaming one.
+func (s *Server) IsStreamingService(serviceLabel string) bool {
+	_, ok := s.streamingServiceLabels[serviceLabel]
+	return ok
+}
+
 // GetServiceRateLimiter is used to get rate limiter
 func (s *Server) GetServiceRateLimiter() *ratelimit.Limiter {
 	return s.serviceRateLimiter
@@ -1281,6 +1518,26 @@ func (s *Server) UpdateServiceRateLimiter(serviceLabel string, opts ...ratelimit
 	return s.serviceRateLimiter.Update(serviceLabel, opts...)
 }
 
+// GetGRPCRateLimiter is used to get the rate limiter of gRPC methods
+func (s *Server) GetGRPCRateLimiter() *ratelimit.Limiter {
+	return s.grpcServiceRateLimiter
+}
+
+// GetEventHub returns the hub of the cluster events.
+func (s *Server) GetEventHub() *event.Hub {
+	return s.eventHub
+}
+
+// GetFairLimiter is used to get the fair limiter of the callers
+func (s *Server) GetFairLimiter() *ratelimit.FairLimiter {
+	return s.fairLimiter
+}
+
+// UpdateGRPCServiceRateLimiter is used to update the rate limiter of the gRPC method
+func (s *Server) UpdateGRPCServiceRateLimiter(method string, opts ...ratelimit.Option) ratelimit.UpdateStatus {
+	return s.grpcServiceRateLimiter.Update(method, opts...)
+}
+
 // GetClusterStatus gets cluster status.
 func (s *Server) GetClusterStatus() (*cluster.Status, error) {
 	s.cluster.Lock()
@@ -1488,6 +1745,15 @@ func (s *Server) campaignLeader() {
 		log.Error("failed to sync id from etcd", errs.ZapError(err))
 		return
 	}
+	// The resume tokens of the events are based on the leader term, which is identified by the create
+	// revision of the leader key, so the tokens issued by the previous leaders are never resumed from
+	// the events of this one.
+	resp, err := etcdutil.EtcdKVGet(s.client, s.member.GetLeaderPath())
+	if err != nil || len(resp.Kvs) == 0 {
+		log.Error("failed to get the leader term", errs.ZapError(err))
+		return
+	}
+	s.eventHub.RenewEpoch(uint64(resp.Kvs[0].CreateRevision))
 	// EnableLeader to accept the remaining service, such as GetStore, GetRegion.
 	s.member.EnableLeader()
 	// Check the cluster dc-location after the PD leader is elected.
@@ -1501,6 +1767,8 @@ func (s *Server) campaignLeader() {
 
 	CheckPDVersion(s.persistOptions)
 	log.Info(fmt.Sprintf("%s leader is ready to serve", s.mode), zap.String("leader-name", s.Name()))
+	s.eventHub.Publish(event.TypeLeader, "elected", s.Name(), map[string]interface{}{"member-id": s.member.ID()})
+	defer s.eventHub.Publish(event.TypeLeader, "resigned", s.Name(), map[string]interface{}{"member-id": s.member.ID()})
 
 	leaderTicker := time.NewTicker(leaderTickInterval)
 	defer leaderTicker.Stop()
@@ -1552,6 +1820,9 @@ func (s *Server) reloadConfigFromKV() error {
 		return err
 	}
 	s.loadRateLimitConfig()
+	if err = s.rbacManager.Load(); err != nil {
+		return err
+	}
 	useRegionStorage := s.persistOptions.IsUseRegionStorage()
 	regionStorage := storage.TrySwitchRegionStorage(s.storage, useRegionStorage)
 	if regionStorage != nil {
@@ -1570,6 +1841,12 @@ func (s *Server) loadRateLimitConfig() {
 		value := cfg[key]
 		s.serviceRateLimiter.Update(key, ratelimit.UpdateDimensionConfig(&value))
 	}
+	s.fairLimiter.UpdateConfig(s.serviceMiddlewarePersistOptions.GetRateLimitConfig().FairQueuing)
+	grpcCfg := s.serviceMiddlewarePersistOptions.GetRateLimitConfig().GRPCLimiterConfig
+	for key := range grpcCfg {
+		value := grpcCfg[key]
+		s.grpcServiceRateLimiter.Update(key, ratelimit.UpdateDimensionConfig(&value))
+	}
 }
 
 // ReplicateFileToMember is used to synchronize state to a member.
@@ -248,6 +248,33 @@ func TestUpdateAfterResetTSO(t *testing.T) {
 	re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/tso/delaySyncTimestamp"))
 }
 
+func TestTSOClockUncertainty(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1, func(conf *config.Config, serverName string) {
+		conf.TSOMaxClockUncertainty = typeutil.NewDuration(time.Second)
+	})
+	re.NoError(err)
+	defer cluster.Destroy()
+
+	endpoints := runServer(re, cluster)
+	cli := setupCli(re, ctx, endpoints)
+	defer cli.Close()
+
+	// No TSO response has been received yet.
+	_, ok := cli.GetTSOClockUncertainty(tso.GlobalDCLocation)
+	re.False(ok)
+	testutil.Eventually(re, func() bool {
+		_, _, err := cli.GetTS(context.TODO())
+		return err == nil
+	})
+	// The local wall clock is used by default, which has no uncertainty.
+	uncertainty, ok := cli.GetTSOClockUncertainty(tso.GlobalDCLocation)
+	re.True(ok)
+	re.Zero(uncertainty)
+}
+
 func TestTSOAllocatorLeader(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -541,6 +568,59 @@ func TestCustomTimeout(t *testing.T) {
 	re.Less(time.Since(start), 2*time.Second)
 }
 
+func TestGetSafeReadTS(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1)
+	re.NoError(err)
+	defer cluster.Destroy()
+
+	endpoints := runServer(re, cluster)
+	cli := setupCli(re, ctx, endpoints)
+	defer cli.Close()
+
+	rc := cluster.GetServer(cluster.GetLeader()).GetRaftCluster()
+	// Split the bootstrapped region into [, b) and [b, ), with the leaders on store 1 and 2,
+	// and a learner of [b, ) on store 3.
+	for _, storeID := range []uint64{2, 3} {
+		re.NoError(rc.PutStore(&metapb.Store{Id: storeID, Address: fmt.Sprintf("mock://%d", storeID), LastHeartbeat: time.Now().UnixNano()}))
+	}
+	learner := &metapb.Peer{Id: 6, StoreId: 3, Role: metapb.PeerRole_Learner}
+	for i, leader := range []*metapb.Peer{{Id: 3, StoreId: 1}, {Id: 5, StoreId: 2}} {
+		meta := &metapb.Region{
+			Id:          uint64(2 + 2*i),
+			Peers:       []*metapb.Peer{leader},
+			RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 2},
+		}
+		if i == 0 {
+			meta.EndKey = []byte("b")
+		} else {
+			meta.StartKey = []byte("b")
+			meta.Peers = append(meta.Peers, learner)
+		}
+		re.NoError(rc.HandleRegionHeartbeat(core.NewRegionInfo(meta, leader)))
+	}
+	re.NoError(rc.SetMinResolvedTS(1, 100))
+	re.NoError(rc.SetMinResolvedTS(2, 200))
+	re.NoError(rc.SetMinResolvedTS(3, 150))
+
+	ts, err := cli.GetSafeReadTS(ctx, []byte("a"), []byte("b"))
+	re.NoError(err)
+	re.Equal(uint64(100), ts)
+	// The stale read may be served by the learner, so its store is counted as well.
+	ts, err = cli.GetSafeReadTS(ctx, []byte("b"), []byte("c"))
+	re.NoError(err)
+	re.Equal(uint64(150), ts)
+	ts, err = cli.GetSafeReadTS(ctx, []byte("a"), []byte("c"))
+	re.NoError(err)
+	re.Equal(uint64(100), ts)
+	// The encoded keyspace ranges start with 'r' and 'x', which are all in [b, ).
+	ts, err = cli.GetKeyspaceSafeReadTS(ctx, 1)
+	re.NoError(err)
+	re.Equal(uint64(150), ts)
+}
+
 func TestGetRegionFromFollowerClient(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,118 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tso
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/tso"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/pkg/utils/tsoutil"
+	"github.com/tikv/pd/server/keyspace"
+	"github.com/tikv/pd/tests"
+)
+
+func TestTSOKeyspaceGroups(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1)
+	re.NoError(err)
+	defer cluster.Destroy()
+	re.NoError(cluster.RunInitialServers())
+	pdLeader := cluster.GetServer(cluster.WaitLeader())
+	re.NoError(pdLeader.BootstrapCluster())
+	backendEndpoints := pdLeader.GetAddr()
+
+	// The keyspaces assigned to the keyspace groups must exist.
+	var keyspaceIDs []uint32
+	for _, name := range []string{"ks1", "ks2"} {
+		meta, err := pdLeader.GetServer().GetKeyspaceManager().CreateKeyspace(&keyspace.CreateKeyspaceRequest{
+			Name: name,
+			Now:  time.Now().Unix(),
+		})
+		re.NoError(err)
+		keyspaceIDs = append(keyspaceIDs, meta.GetId())
+	}
+	groupManager := pdLeader.GetServer().GetKeyspaceGroupManager()
+	re.Error(groupManager.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 1, Keyspaces: []uint32{12345}}}))
+	// The created keyspaces are served by the keyspace group 1, and the others by the default keyspace group.
+	re.NoError(groupManager.CreateKeyspaceGroups([]*endpoint.KeyspaceGroup{{ID: 1, Keyspaces: keyspaceIDs}}))
+
+	s, cleanup, err := startSingleTSOTestServer(ctx, re, backendEndpoints)
+	re.NoError(err)
+	defer cleanup()
+	kgm := s.GetKeyspaceGroupManager()
+	re.Equal(uint32(1), kgm.GetKeyspaceGroupID(keyspaceIDs[1]))
+	re.Equal(uint32(0), kgm.GetKeyspaceGroupID(12345))
+	// The keyspaces moved from the default keyspace group are taken over after it's fenced.
+	waitKeyspacesTakenOver(re, groupManager, 1)
+
+	for _, keyspaceID := range []uint32{0, keyspaceIDs[0], keyspaceIDs[1], 12345} {
+		checkTSOMonotonic(ctx, re, backendEndpoints, keyspaceID, 0)
+	}
+
+	// Make the keyspace group 1 ahead of the default keyspace group, then move its keyspaces back.
+	am, err := kgm.GetAllocatorManager(1)
+	re.NoError(err)
+	allocator, err := am.GetAllocator(tso.GlobalDCLocation)
+	re.NoError(err)
+	ahead := tsoutil.GenerateTS(tsoutil.GenerateTimestamp(time.Now().Add(time.Hour), 0))
+	re.NoError(allocator.SetTSO(ahead, false, true))
+	re.NoError(groupManager.DeleteKeyspaceGroupByID(1))
+	testutil.Eventually(re, func() bool {
+		return kgm.GetKeyspaceGroupID(keyspaceIDs[0]) == 0
+	})
+	waitKeyspacesTakenOver(re, groupManager, 0)
+	// The default keyspace group has been advanced past the keyspace group 1.
+	for _, keyspaceID := range keyspaceIDs {
+		checkTSOMonotonic(ctx, re, backendEndpoints, keyspaceID, ahead)
+	}
+
+	// The keyspace group 1 persists its timestamp in its own path.
+	client := pdLeader.GetEtcdClient()
+	resp, err := etcdutil.EtcdKVGet(client, path.Join(endpoint.KeyspaceGroupTSOPath(pdLeader.GetClusterID(), 1), "timestamp"))
+	re.NoError(err)
+	re.Len(resp.Kvs, 1)
+}
+
+func waitKeyspacesTakenOver(re *require.Assertions, groupManager *keyspace.GroupManager, id uint32) {
+	testutil.Eventually(re, func() bool {
+		kg, err := groupManager.GetKeyspaceGroupByID(id)
+		re.NoError(err)
+		return len(kg.Transfers) == 0
+	})
+}
+
+// checkTSOMonotonic checks the timestamps of the keyspace are increasing and greater than the given one.
+func checkTSOMonotonic(ctx context.Context, re *require.Assertions, backendEndpoints string, keyspaceID uint32, lastTS uint64) {
+	cli, err := pd.NewTSOClientWithContext(ctx, keyspaceID, []string{backendEndpoints}, pd.SecurityOption{})
+	re.NoError(err)
+	defer cli.Close()
+	for i := 0; i < tsoRequestRound; i++ {
+		physical, logical, err := cli.GetTS(ctx)
+		re.NoError(err)
+		ts := tsoutil.ComposeTS(physical, logical)
+		re.Less(lastTS, ts)
+		lastTS = ts
+	}
+}
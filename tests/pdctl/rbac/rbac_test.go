@@ -0,0 +1,117 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/pd/pkg/rbac"
+	"github.com/tikv/pd/tests"
+	"github.com/tikv/pd/tests/pdctl"
+	pdctlCmd "github.com/tikv/pd/tools/pd-ctl/pdctl"
+)
+
+func TestRBAC(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1)
+	re.NoError(err)
+	defer cluster.Destroy()
+	re.NoError(cluster.RunInitialServers())
+	cluster.WaitLeader()
+	leaderServer := cluster.GetServer(cluster.GetLeader())
+	re.NoError(leaderServer.BootstrapCluster())
+	pdAddr := leaderServer.GetAddr()
+	cmd := pdctlCmd.GetRootCmd()
+
+	// role set and show
+	args := []string{"-u", pdAddr, "rbac", "role", "set", "store-reader", "--read", "GetStore,GetStores"}
+	output, err := pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+	args = []string{"-u", pdAddr, "rbac", "role", "show", "store-reader"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	role := &rbac.Role{}
+	re.NoError(json.Unmarshal(output, role))
+	re.Equal([]string{"GetStore", "GetStores"}, role.Permissions[0].Services)
+	args = []string{"-u", pdAddr, "rbac", "role", "show"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	var roles []*rbac.Role
+	re.NoError(json.Unmarshal(output, &roles))
+	re.Len(roles, 4)
+
+	// enabling requires an admin
+	args = []string{"-u", pdAddr, "rbac", "enable"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Failed")
+
+	// binding set and show
+	args = []string{"-u", pdAddr, "rbac", "binding", "set", "token", "admin-token", "admin"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+	args = []string{"-u", pdAddr, "rbac", "binding", "set", "token", "reader-token", "store-reader"}
+	_, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	args = []string{"-u", pdAddr, "rbac", "binding", "show"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	var bindings []*rbac.Binding
+	re.NoError(json.Unmarshal(output, &bindings))
+	re.Len(bindings, 2)
+
+	args = []string{"-u", pdAddr, "rbac", "enable"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+
+	// the token is required once enabled
+	args = []string{"-u", pdAddr, "rbac", "binding", "show"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "[403]")
+	args = []string{"-u", pdAddr, "--token", "reader-token", "store"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.NotContains(string(output), "[403]")
+	args = []string{"-u", pdAddr, "--token", "reader-token", "rbac", "binding", "delete", "token", "reader-token"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "[403]")
+	args = []string{"-u", pdAddr, "--token", "admin-token", "rbac", "binding", "delete", "token", "reader-token"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+	args = []string{"-u", pdAddr, "--token", "admin-token", "rbac", "role", "delete", "store-reader"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+
+	args = []string{"-u", pdAddr, "--token", "admin-token", "rbac", "disable"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+	args = []string{"-u", pdAddr, "rbac", "binding", "show"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.NotContains(string(output), "[403]")
+}
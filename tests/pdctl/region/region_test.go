@@ -162,6 +162,15 @@ func TestRegion(t *testing.T) {
 		{[]string{"region", "keys", "--format=hex", "63", "65"}, []*core.RegionInfo{r3, r4}},
 		// region keys --format=hex <start_key> <end_key> <limit> command
 		{[]string{"region", "keys", "--format=hex", "63", "65", "1"}, []*core.RegionInfo{r3}},
+		// region store <store_id> --limit <limit> command
+		{[]string{"region", "store", "1", "--limit", "2"}, []*core.RegionInfo{r1, r2}},
+		// region --store <store_id> command
+		{[]string{"region", "--store", "2"}, []*core.RegionInfo{r1}},
+		// region check miss-peer --max-size <size> command
+		{[]string{"region", "check", "miss-peer", "--max-size", "10"}, []*core.RegionInfo{r4}},
+		// region query <query> command
+		{[]string{"region", "query", "size >= 10MiB and pending_peers = 0 order by size desc limit 2"}, []*core.RegionInfo{r2, r4}},
+		{[]string{"region", "query", "store", "=", "2", "or", "down_peers", ">", "0"}, []*core.RegionInfo{r1, r3}},
 	}
 
 	for _, testCase := range testRegionsCases {
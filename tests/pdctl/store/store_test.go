@@ -26,6 +26,7 @@ import (
 	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/server/api"
+	pdcluster "github.com/tikv/pd/server/cluster"
 	"github.com/tikv/pd/tests"
 	"github.com/tikv/pd/tests/pdctl"
 	ctl "github.com/tikv/pd/tools/pd-ctl/pdctl"
@@ -377,6 +378,35 @@ func TestStore(t *testing.T) {
 	limit = leaderServer.GetRaftCluster().GetStoreLimitByType(3, storelimit.RemovePeer)
 	re.Equal(25.0, limit)
 
+	// store drain <store_id> command
+	args = []string{"-u", pdAddr, "store", "drain", "1", "--target-stores", "3", "--target-labels", "zone=z1", "--rate", "10"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Equal("Success!\n", string(output))
+	args = []string{"-u", pdAddr, "store", "drain", "1", "--target-labels", "zone"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "invalid label")
+	args = []string{"-u", pdAddr, "store", "drain", "show", "1"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	progress := &pdcluster.StoreDrainProgress{}
+	re.NoError(json.Unmarshal(output, progress))
+	re.Equal([]uint64{3}, progress.Options.TargetStores)
+	re.Equal(map[string]string{"zone": "z1"}, progress.Options.TargetLabels)
+	re.Equal(10.0, progress.Options.RateLimit)
+	args = []string{"-u", pdAddr, "store", "drain", "show"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	var progresses []*pdcluster.StoreDrainProgress
+	re.NoError(json.Unmarshal(output, &progresses))
+	re.Len(progresses, 1)
+	args = []string{"-u", pdAddr, "store", "drain", "cancel", "1"}
+	output, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Equal("Success!\n", string(output))
+	re.True(leaderServer.GetRaftCluster().GetStore(1).IsUp())
+
 	// store remove-tombstone
 	args = []string{"-u", pdAddr, "store", "check", "Tombstone"}
 	output, err = pdctl.ExecuteCommand(cmd, args...)
@@ -54,4 +54,14 @@ func TestRemoveFailedStores(t *testing.T) {
 	args = []string{"-u", pdAddr, "unsafe", "remove-failed-stores", "history"}
 	_, err = pdctl.ExecuteCommand(cmd, args...)
 	re.NoError(err)
+	args = []string{"-u", pdAddr, "unsafe", "remove-failed-stores", "1,2,3", "--dry-run"}
+	_, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	args = []string{"-u", pdAddr, "unsafe", "remove-failed-stores", "plan"}
+	_, err = pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	args = []string{"-u", pdAddr, "unsafe", "remove-failed-stores", "approve", "abc"}
+	output, err := pdctl.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "no dry run plan to approve")
 }
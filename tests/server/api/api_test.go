@@ -33,8 +33,12 @@ import (
 	"github.com/pingcap/log"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/apiclient"
+	"github.com/tikv/pd/pkg/audit"
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/utils/apiutil/serverapi"
+	"github.com/tikv/pd/pkg/utils/requestutil"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server"
@@ -475,6 +479,40 @@ func (suite *middlewareTestSuite) TestAuditLocalLogBackend() {
 	os.Remove(tempStdoutFile.Name())
 }
 
+func (suite *middlewareTestSuite) TestAuditDurableStorageBackend() {
+	re := suite.Require()
+	start := time.Now().Add(-time.Second).Unix()
+	// Record a request on the follower, which isn't redirected to the leader.
+	follower := suite.cluster.GetServer(suite.cluster.GetFollower())
+	req, err := http.NewRequest(http.MethodPost, follower.GetAddr()+"/pd/api/v1/admin/log", http.NoBody)
+	re.NoError(err)
+	req = req.WithContext(requestutil.WithRequestInfo(req.Context(), requestutil.RequestInfo{
+		ServiceLabel:   "SetLogLevel",
+		Component:      "audit-member-test",
+		StartTimeStamp: time.Now().Unix(),
+	}))
+	re.True(follower.GetServer().GetDurableAuditBackend().ProcessHTTPRequest(req))
+
+	// The leader collects the records of all the members.
+	var records []*audit.Record
+	url := fmt.Sprintf("%s/pd/api/v1/admin/audit?start_time=%d&component=audit-member-test", follower.GetAddr(), start)
+	re.NoError(testutil.ReadGetJSON(re, dialClient, url, &records))
+	re.Len(records, 1)
+	re.Equal("SetLogLevel", records[0].Service)
+	// Only the records of the server itself are returned if it's required.
+	leader := suite.cluster.GetServer(suite.cluster.GetLeader())
+	url = fmt.Sprintf("%s/pd/api/v1/admin/audit?start_time=%d&component=audit-member-test", leader.GetAddr(), start)
+	req, err = http.NewRequest(http.MethodGet, url, http.NoBody)
+	re.NoError(err)
+	req.Header.Set(serverapi.PDAllowFollowerHandle, "true")
+	resp, err := dialClient.Do(req)
+	re.NoError(err)
+	defer resp.Body.Close()
+	re.Equal(http.StatusOK, resp.StatusCode)
+	re.NoError(json.NewDecoder(resp.Body).Decode(&records))
+	re.Empty(records)
+}
+
 func BenchmarkDoRequestWithLocalLogAudit(b *testing.B) {
 	b.StopTimer()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -656,6 +694,38 @@ func (suite *redirectorTestSuite) TestNotLeader() {
 	suite.NoError(err)
 }
 
+func (suite *redirectorTestSuite) TestRBACWatch() {
+	re := suite.Require()
+	leader := suite.cluster.GetServer(suite.cluster.GetLeader()).GetServer()
+	var follower *server.Server
+	for _, svr := range suite.cluster.GetServers() {
+		if svr.GetServer() != leader {
+			follower = svr.GetServer()
+			break
+		}
+	}
+
+	// the followers reload the bindings and the config once they are changed by the leader.
+	re.NoError(leader.GetRBACManager().SetBinding(&rbac.Binding{Kind: rbac.KindToken, Value: "admin-token", Roles: []string{rbac.RoleAdmin}}))
+	re.NoError(leader.GetRBACManager().SetBinding(&rbac.Binding{Kind: rbac.KindToken, Value: "viewer-token", Roles: []string{rbac.RoleViewer}}))
+	re.NoError(leader.SetRBACConfig(config.RBACConfig{EnableRBAC: true}))
+	testutil.Eventually(re, func() bool {
+		return follower.GetServiceMiddlewareConfig().EnableRBAC &&
+			follower.GetRBACManager().HasRole([]string{"token:" + rbac.HashToken("viewer-token")}, rbac.RoleViewer)
+	}, testutil.WithWaitFor(3*time.Second))
+	// the revoked binding takes effect on the followers immediately.
+	re.NoError(leader.GetRBACManager().DeleteBinding(rbac.KindToken, "viewer-token"))
+	testutil.Eventually(re, func() bool {
+		return !follower.GetRBACManager().HasRole([]string{"token:" + rbac.HashToken("viewer-token")}, rbac.RoleViewer)
+	}, testutil.WithWaitFor(3*time.Second))
+
+	re.NoError(leader.SetRBACConfig(config.RBACConfig{EnableRBAC: false}))
+	re.NoError(leader.GetRBACManager().DeleteBinding(rbac.KindToken, "admin-token"))
+	testutil.Eventually(re, func() bool {
+		return !follower.GetServiceMiddlewareConfig().EnableRBAC && !follower.GetRBACManager().HasAdmin()
+	}, testutil.WithWaitFor(3*time.Second))
+}
+
 func mustRequestSuccess(re *require.Assertions, s *server.Server) http.Header {
 	resp, err := dialClient.Get(s.GetAddr() + "/pd/api/v1/version")
 	re.NoError(err)
@@ -907,6 +977,56 @@ func TestPreparingProgress(t *testing.T) {
 	re.NoError(failpoint.Disable("github.com/tikv/pd/server/cluster/highFrequencyClusterJobs"))
 }
 
+func TestGetSafeReadTS(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster, err := tests.NewTestCluster(ctx, 1)
+	re.NoError(err)
+	defer cluster.Destroy()
+	re.NoError(cluster.RunInitialServers())
+	cluster.WaitLeader()
+	leader := cluster.GetServer(cluster.GetLeader())
+	re.NoError(leader.BootstrapCluster())
+
+	rc := leader.GetRaftCluster()
+	// Split the bootstrapped region into [, b) and [b, ), with the leaders on store 1 and 2.
+	re.NoError(rc.PutStore(&metapb.Store{Id: 2, Address: "mock://2", LastHeartbeat: time.Now().UnixNano()}))
+	for i, peer := range []*metapb.Peer{{Id: 3, StoreId: 1}, {Id: 5, StoreId: 2}} {
+		meta := &metapb.Region{
+			Id:          uint64(2 + 2*i),
+			Peers:       []*metapb.Peer{peer},
+			RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 2},
+		}
+		if i == 0 {
+			meta.EndKey = []byte("b")
+		} else {
+			meta.StartKey = []byte("b")
+		}
+		re.NoError(rc.HandleRegionHeartbeat(core.NewRegionInfo(meta, peer)))
+	}
+	re.NoError(rc.SetMinResolvedTS(1, 100))
+	re.NoError(rc.SetMinResolvedTS(2, 200))
+
+	cli, err := apiclient.NewClient([]string{leader.GetAddr()})
+	re.NoError(err)
+	testCases := []struct {
+		opts   *apiclient.GetSafeReadTSOptions
+		expect uint64
+	}{
+		{&apiclient.GetSafeReadTSOptions{StartKey: "a", EndKey: "b"}, 100},
+		{&apiclient.GetSafeReadTSOptions{StartKey: "b", EndKey: "c"}, 200},
+		{&apiclient.GetSafeReadTSOptions{StartKey: "a", EndKey: "c"}, 100},
+		// The encoded keyspace ranges start with 'r' and 'x', which are all in [b, ).
+		{&apiclient.GetSafeReadTSOptions{KeyspaceID: 1}, 200},
+	}
+	for _, testCase := range testCases {
+		resp, err := cli.GetSafeReadTS(ctx, testCase.opts)
+		re.NoError(err)
+		re.Equal(testCase.expect, resp.SafeReadTS)
+	}
+}
+
 func sendRequest(re *require.Assertions, url string, method string, statusCode int) []byte {
 	req, _ := http.NewRequest(method, url, nil)
 	resp, err := dialClient.Do(req)
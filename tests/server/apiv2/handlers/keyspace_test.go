@@ -26,8 +26,10 @@ import (
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/rbac"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server/apiv2/handlers"
+	"github.com/tikv/pd/server/config"
 	"github.com/tikv/pd/server/keyspace"
 	"github.com/tikv/pd/tests"
 	"go.uber.org/goleak"
@@ -86,6 +88,37 @@ func (suite *keyspaceTestSuite) TestCreateLoadKeyspace() {
 	re.Equal(keyspacepb.KeyspaceState_ENABLED, defaultKeyspace.State)
 }
 
+func (suite *keyspaceTestSuite) TestRBAC() {
+	re := suite.Require()
+	svr := suite.server.GetServer()
+	for token, role := range map[string]string{"admin-token": rbac.RoleAdmin, "viewer-token": rbac.RoleViewer} {
+		re.NoError(svr.GetRBACManager().SetBinding(&rbac.Binding{Kind: rbac.KindToken, Value: token, Roles: []string{role}}))
+	}
+	re.NoError(svr.SetRBACConfig(config.RBACConfig{EnableRBAC: true}))
+	do := func(method, path, token string) int {
+		httpReq, err := http.NewRequest(method, suite.server.GetAddr()+path, bytes.NewBufferString("{}"))
+		re.NoError(err)
+		if token != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := dialClient.Do(httpReq)
+		re.NoError(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+	// the keyspace API of v2.
+	re.Equal(http.StatusForbidden, do(http.MethodGet, keyspacesPrefix, ""))
+	re.Equal(http.StatusOK, do(http.MethodGet, keyspacesPrefix, "viewer-token"))
+	re.Equal(http.StatusForbidden, do(http.MethodPost, keyspacesPrefix, "viewer-token"))
+	// the API of the resource manager.
+	groupsPath := "/resource-manager/api/v1/config/groups"
+	re.Equal(http.StatusForbidden, do(http.MethodGet, groupsPath, ""))
+	re.Equal(http.StatusOK, do(http.MethodGet, groupsPath, "viewer-token"))
+	re.Equal(http.StatusForbidden, do(http.MethodPost, "/resource-manager/api/v1/config/group", "viewer-token"))
+	re.NoError(svr.SetRBACConfig(config.RBACConfig{EnableRBAC: false}))
+	re.Equal(http.StatusOK, do(http.MethodGet, keyspacesPrefix, ""))
+}
+
 func (suite *keyspaceTestSuite) TestUpdateKeyspaceConfig() {
 	re := suite.Require()
 	keyspaces := mustMakeTestKeyspaces(re, suite.server, 10)
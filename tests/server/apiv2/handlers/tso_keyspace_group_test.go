@@ -0,0 +1,140 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/server/apiv2/handlers"
+	"github.com/tikv/pd/tests"
+)
+
+const keyspaceGroupsPrefix = "/pd/api/v2/tso/keyspace-groups"
+
+type keyspaceGroupTestSuite struct {
+	suite.Suite
+	cleanup func()
+	cluster *tests.TestCluster
+	server  *tests.TestServer
+}
+
+func TestKeyspaceGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(keyspaceGroupTestSuite))
+}
+
+func (suite *keyspaceGroupTestSuite) SetupTest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.cleanup = cancel
+	cluster, err := tests.NewTestCluster(ctx, 1)
+	suite.cluster = cluster
+	suite.NoError(err)
+	suite.NoError(cluster.RunInitialServers())
+	suite.NotEmpty(cluster.WaitLeader())
+	suite.server = cluster.GetServer(cluster.GetLeader())
+	suite.NoError(suite.server.BootstrapCluster())
+}
+
+func (suite *keyspaceGroupTestSuite) TearDownTest() {
+	suite.cleanup()
+	suite.cluster.Destroy()
+}
+
+func (suite *keyspaceGroupTestSuite) TestCreateLoadDeleteKeyspaceGroups() {
+	re := suite.Require()
+	keyspaces := mustMakeTestKeyspaces(re, suite.server, 3)
+	kgs := &handlers.CreateKeyspaceGroupParams{KeyspaceGroups: []*endpoint.KeyspaceGroup{
+		{ID: 1, Keyspaces: []uint32{keyspaces[0].GetId(), keyspaces[1].GetId()}},
+		{ID: 2, Keyspaces: []uint32{keyspaces[2].GetId()}},
+	}}
+	re.Equal(http.StatusOK, sendCreateKeyspaceGroupsRequest(re, suite.server, kgs))
+	// The keyspace group already exists.
+	re.Equal(http.StatusInternalServerError, sendCreateKeyspaceGroupsRequest(re, suite.server, kgs))
+	// The keyspace does not exist.
+	re.Equal(http.StatusBadRequest, sendCreateKeyspaceGroupsRequest(re, suite.server, &handlers.CreateKeyspaceGroupParams{
+		KeyspaceGroups: []*endpoint.KeyspaceGroup{{ID: 3, Keyspaces: []uint32{12345}}},
+	}))
+
+	// The default keyspace group is created when bootstrapping.
+	loaded := mustLoadKeyspaceGroups(re, suite.server)
+	re.Len(loaded, 3)
+	for i, kg := range kgs.KeyspaceGroups {
+		re.Equal(kg.ID, loaded[i+1].ID)
+		re.Equal(kg.Keyspaces, loaded[i+1].Keyspaces)
+		// The keyspaces are moved from the default keyspace group.
+		re.Equal([]*endpoint.KeyspaceTransfer{{Source: 0, Keyspaces: kg.Keyspaces}}, loaded[i+1].Transfers)
+	}
+	re.Equal(loaded[2], mustLoadKeyspaceGroupByID(re, suite.server, "2"))
+
+	re.Equal(http.StatusOK, sendDeleteKeyspaceGroupRequest(re, suite.server, "2"))
+	re.Equal(http.StatusNotFound, sendDeleteKeyspaceGroupRequest(re, suite.server, "2"))
+	re.Equal(http.StatusInternalServerError, sendDeleteKeyspaceGroupRequest(re, suite.server, "0"))
+	re.Equal(http.StatusBadRequest, sendDeleteKeyspaceGroupRequest(re, suite.server, "invalid"))
+	loaded = mustLoadKeyspaceGroups(re, suite.server)
+	re.Len(loaded, 2)
+	// The keyspaces of the deleted keyspace group are moved back to the default keyspace group.
+	re.Equal([]*endpoint.KeyspaceTransfer{{Source: 2, Keyspaces: kgs.KeyspaceGroups[1].Keyspaces}}, loaded[0].Transfers)
+}
+
+func sendCreateKeyspaceGroupsRequest(re *require.Assertions, server *tests.TestServer, request *handlers.CreateKeyspaceGroupParams) int {
+	data, err := json.Marshal(request)
+	re.NoError(err)
+	httpReq, err := http.NewRequest(http.MethodPost, server.GetAddr()+keyspaceGroupsPrefix, bytes.NewBuffer(data))
+	re.NoError(err)
+	resp, err := dialClient.Do(httpReq)
+	re.NoError(err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func sendDeleteKeyspaceGroupRequest(re *require.Assertions, server *tests.TestServer, id string) int {
+	httpReq, err := http.NewRequest(http.MethodDelete, server.GetAddr()+keyspaceGroupsPrefix+"/"+id, nil)
+	re.NoError(err)
+	resp, err := dialClient.Do(httpReq)
+	re.NoError(err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func mustLoadKeyspaceGroups(re *require.Assertions, server *tests.TestServer) []*endpoint.KeyspaceGroup {
+	resp, err := dialClient.Get(server.GetAddr() + keyspaceGroupsPrefix)
+	re.NoError(err)
+	defer resp.Body.Close()
+	re.Equal(http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	re.NoError(err)
+	var kgs []*endpoint.KeyspaceGroup
+	re.NoError(json.Unmarshal(data, &kgs))
+	return kgs
+}
+
+func mustLoadKeyspaceGroupByID(re *require.Assertions, server *tests.TestServer, id string) *endpoint.KeyspaceGroup {
+	resp, err := dialClient.Get(server.GetAddr() + keyspaceGroupsPrefix + "/" + id)
+	re.NoError(err)
+	defer resp.Body.Close()
+	re.Equal(http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	re.NoError(err)
+	kg := &endpoint.KeyspaceGroup{}
+	re.NoError(json.Unmarshal(data, kg))
+	return kg
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tikv/pd/pkg/openapi"
+)
+
+var (
+	packages      = flag.String("packages", "./server/api,./pkg/tso", "comma separated packages to parse the annotations")
+	specPath      = flag.String("spec", "docs/openapi/v1.json", "path of the generated OpenAPI document")
+	clientPath    = flag.String("client", "pkg/apiclient/client_gen.go", "path of the generated client, skip if it's empty")
+	clientPackage = flag.String("client-package", "apiclient", "package name of the generated client")
+)
+
+func checkErr(err error) {
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+// The command must be run in the root of the module.
+func main() {
+	flag.Parse()
+	doc, err := openapi.Generate(openapi.Options{Dir: ".", Packages: strings.Split(*packages, ",")})
+	checkErr(err)
+	spec, err := openapi.Marshal(doc)
+	checkErr(err)
+	checkErr(os.WriteFile(*specPath, spec, 0o644))
+	if *clientPath == "" {
+		return
+	}
+	client, err := openapi.GenerateClient(doc, *clientPackage)
+	checkErr(err)
+	checkErr(os.WriteFile(*clientPath, client, 0o644))
+}
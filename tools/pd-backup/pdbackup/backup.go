@@ -17,6 +17,7 @@ package pdbackup
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,7 @@ import (
 	"path"
 	"strconv"
 
+	"github.com/tikv/pd/pkg/apiclient"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server/config"
@@ -110,7 +112,12 @@ func OutputToFile(backInfo *BackupInfo, f *os.File) error {
 }
 
 func getConfig(pdAddr string) (*config.Config, error) {
-	resp, err := http.Get(pdAddr + pdConfigAPIPath)
+	cli, err := apiclient.NewClient([]string{pdAddr})
+	if err != nil {
+		return nil, err
+	}
+	// The config is decoded as the server config to keep all the fields in the backup.
+	resp, err := cli.Do(context.Background(), http.MethodGet, pdConfigAPIPath, nil, nil)
 	if err != nil {
 		return nil, err
 	}
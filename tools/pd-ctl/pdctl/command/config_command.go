@@ -15,10 +15,9 @@
 package command
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
-	"net/url"
 	"os"
 	"path"
 	"reflect"
@@ -26,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/tikv/pd/pkg/apiclient"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/reflectutil"
 	"github.com/tikv/pd/server/config"
@@ -323,7 +323,7 @@ func showServerCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println(r)
 }
 
-func postConfigDataWithPath(cmd *cobra.Command, key, value, path string) error {
+func postConfigData(cmd *cobra.Command, key, value string) error {
 	var val interface{}
 	data := make(map[string]interface{})
 	val, err := strconv.ParseFloat(value, 64)
@@ -331,16 +331,8 @@ func postConfigDataWithPath(cmd *cobra.Command, key, value, path string) error {
 		val = value
 	}
 	data[key] = val
-	reqData, err := json.Marshal(data)
-	if err != nil {
-		return err
-	}
-	_, err = doRequest(cmd, path, http.MethodPost,
-		http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(reqData)))
-	if err != nil {
-		return err
-	}
-	return nil
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).SetConfig(context.Background(), data, nil)
+	return err
 }
 
 func setConfigCommandFunc(cmd *cobra.Command, args []string) {
@@ -349,7 +341,7 @@ func setConfigCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 	opt, val := args[0], args[1]
-	err := postConfigDataWithPath(cmd, opt, val, configPrefix)
+	err := postConfigData(cmd, opt, val)
 	if err != nil {
 		cmd.Printf("Failed to set config: %s\n", err)
 		return
@@ -376,8 +368,8 @@ func postLabelProperty(cmd *cobra.Command, action string, args []string) {
 		"label-key":   args[1],
 		"label-value": args[2],
 	}
-	prefix := path.Join(labelPropertyPrefix)
-	postJSON(cmd, prefix, input)
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetLabelPropertyConfig(context.Background(), input)
+	printPostResult(cmd, err)
 }
 
 func setClusterVersionCommandFunc(cmd *cobra.Command, args []string) {
@@ -388,12 +380,13 @@ func setClusterVersionCommandFunc(cmd *cobra.Command, args []string) {
 	input := map[string]interface{}{
 		"cluster-version": args[0],
 	}
-	postJSON(cmd, clusterVersionPrefix, input)
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetClusterVersion(context.Background(), input)
+	printPostResult(cmd, err)
 }
 
 func setReplicationModeCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) == 1 {
-		postJSON(cmd, replicationModePrefix, map[string]interface{}{"replication-mode": args[0]})
+		setReplicationModeConfig(cmd, map[string]interface{}{"replication-mode": args[0]})
 	} else if len(args) == 3 {
 		t := reflectutil.FindFieldByJSONTag(reflect.TypeOf(config.ReplicationModeConfig{}), []string{args[0], args[1]})
 		if t != nil && t.Kind() == reflect.Int {
@@ -403,15 +396,20 @@ func setReplicationModeCommandFunc(cmd *cobra.Command, args []string) {
 				cmd.Printf("value %v cannot covert to number: %v", args[2], err)
 				return
 			}
-			postJSON(cmd, replicationModePrefix, map[string]interface{}{args[0]: map[string]interface{}{args[1]: arg2}})
+			setReplicationModeConfig(cmd, map[string]interface{}{args[0]: map[string]interface{}{args[1]: arg2}})
 			return
 		}
-		postJSON(cmd, replicationModePrefix, map[string]interface{}{args[0]: map[string]string{args[1]: args[2]}})
+		setReplicationModeConfig(cmd, map[string]interface{}{args[0]: map[string]string{args[1]: args[2]}})
 	} else {
 		cmd.Println(cmd.UsageString())
 	}
 }
 
+func setReplicationModeConfig(cmd *cobra.Command, input map[string]interface{}) {
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetReplicationModeConfig(context.Background(), input)
+	printPostResult(cmd, err)
+}
+
 // NewPlacementRulesCommand placement rules subcommand
 func NewPlacementRulesCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -513,7 +511,7 @@ func NewPlacementRulesCommand() *cobra.Command {
 }
 
 func enablePlacementRulesFunc(cmd *cobra.Command, args []string) {
-	err := postConfigDataWithPath(cmd, "enable-placement-rules", "true", configPrefix)
+	err := postConfigData(cmd, "enable-placement-rules", "true")
 	if err != nil {
 		cmd.Printf("Failed to set config: %s\n", err)
 		return
@@ -522,7 +520,7 @@ func enablePlacementRulesFunc(cmd *cobra.Command, args []string) {
 }
 
 func disablePlacementRulesFunc(cmd *cobra.Command, args []string) {
-	err := postConfigDataWithPath(cmd, "enable-placement-rules", "false", configPrefix)
+	err := postConfigData(cmd, "enable-placement-rules", "false")
 	if err != nil {
 		cmd.Printf("Failed to set config: %s\n", err)
 		return
@@ -608,9 +606,9 @@ func putPlacementRulesFunc(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	b, _ := json.Marshal(validOpts)
-	_, err = doRequest(cmd, rulesBatchPrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(b)))
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).BatchRules(context.Background(), validOpts)
 	if err != nil {
+		b, _ := json.Marshal(validOpts)
 		cmd.Printf("failed to save rules %s: %s\n", b, err)
 		return
 	}
@@ -656,11 +654,12 @@ func updateRuleGroupFunc(cmd *cobra.Command, args []string) {
 		cmd.Printf("override %s should be a boolean\n", args[2])
 		return
 	}
-	postJSON(cmd, ruleGroupPrefix, map[string]interface{}{
-		"id":       args[0],
-		"index":    index,
-		"override": override,
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).SetGroupConfig(context.Background(), &placement.RuleGroup{
+		ID:       args[0],
+		Index:    int(index),
+		Override: override,
 	})
+	printPostResult(cmd, err)
 }
 
 func deleteRuleGroupFunc(cmd *cobra.Command, args []string) {
@@ -668,7 +667,7 @@ func deleteRuleGroupFunc(cmd *cobra.Command, args []string) {
 		cmd.Println(cmd.UsageString())
 		return
 	}
-	_, err := doRequest(cmd, path.Join(ruleGroupPrefix, args[0]), http.MethodDelete, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteGroupConfig(context.Background(), args[0])
 	if err != nil {
 		cmd.Printf("Failed to remove rule group config: %s \n", err)
 		return
@@ -718,17 +717,13 @@ func setRuleBundle(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	id := struct {
-		GroupID string `json:"group_id"`
-	}{}
-	if err = json.Unmarshal(content, &id); err != nil {
+	bundle := &placement.GroupBundle{}
+	if err = json.Unmarshal(content, bundle); err != nil {
 		cmd.Println(err)
 		return
 	}
 
-	reqPath := path.Join(ruleBundlePrefix, id.GroupID)
-
-	res, err := doRequest(cmd, reqPath, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewReader(content)))
+	res, err := newAPIClient(cmd, getEndpoints(cmd)).SetPlacementRuleByGroup(context.Background(), bundle.ID, bundle)
 	if err != nil {
 		cmd.Printf("failed to save rule bundle %s: %s\n", content, err)
 		return
@@ -743,13 +738,9 @@ func delRuleBundle(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	reqPath := path.Join(ruleBundlePrefix, url.PathEscape(args[0]))
-
-	if ok, _ := cmd.Flags().GetBool("regexp"); ok {
-		reqPath += "?regexp"
-	}
-
-	res, err := doRequest(cmd, reqPath, http.MethodDelete, http.Header{})
+	regexp, _ := cmd.Flags().GetBool("regexp")
+	res, err := newAPIClient(cmd, getEndpoints(cmd)).DeletePlacementRuleByGroup(context.Background(), args[0],
+		&apiclient.DeletePlacementRuleByGroupOptions{Regexp: regexp})
 	if err != nil {
 		cmd.Println(err)
 		return
@@ -793,12 +784,15 @@ func saveRuleBundle(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	path := ruleBundlePrefix
-	if ok, _ := cmd.Flags().GetBool("partial"); ok {
-		path += "?partial=true"
+	var bundles []*placement.GroupBundle
+	if err = json.Unmarshal(content, &bundles); err != nil {
+		cmd.Println(err)
+		return
 	}
 
-	res, err := doRequest(cmd, path, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewReader(content)))
+	partial, _ := cmd.Flags().GetBool("partial")
+	res, err := newAPIClient(cmd, getEndpoints(cmd)).SetPlacementRules(context.Background(), bundles,
+		&apiclient.SetPlacementRulesOptions{Partial: partial})
 	if err != nil {
 		cmd.Printf("failed to save rule bundles %s: %s\n", content, err)
 		return
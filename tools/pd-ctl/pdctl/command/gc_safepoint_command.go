@@ -15,6 +15,7 @@
 package command
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/spf13/cobra"
@@ -61,8 +62,7 @@ func deleteSSP(cmd *cobra.Command, args []string) {
 		return
 	}
 	serviceID := args[0]
-	deleteURL := serviceGCSafepointPrefix + "/" + serviceID
-	r, err := doRequest(cmd, deleteURL, http.MethodDelete, http.Header{})
+	r, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteGCSafePoint(context.Background(), serviceID)
 	if err != nil {
 		cmd.Printf("Failed to delete service GC safepoint: %s\n", err)
 		return
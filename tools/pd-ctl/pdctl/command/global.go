@@ -15,8 +15,7 @@
 package command
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"io"
 	"net/http"
 	"net/url"
@@ -25,6 +24,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
+	"github.com/tikv/pd/pkg/apiclient"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"go.etcd.io/etcd/pkg/transport"
 )
@@ -34,9 +34,15 @@ var (
 	dialClient                = &http.Client{
 		Transport: apiutil.NewComponentSignatureRoundTripper(http.DefaultTransport, pdControllerComponentName),
 	}
-	pingPrefix = "pd/api/v1/ping"
+	// bearerToken is sent in the Authorization header if it's set.
+	bearerToken string
 )
 
+// SetBearerToken sets the bearer token which is used to access the PD with RBAC enabled.
+func SetBearerToken(token string) {
+	bearerToken = token
+}
+
 // InitHTTPSClient creates https client with ca file
 func InitHTTPSClient(caPath, certPath, keyPath string) error {
 	tlsInfo := transport.TLSInfo{
@@ -73,48 +79,29 @@ func WithBody(body io.Reader) BodyOption {
 
 func doRequest(cmd *cobra.Command, prefix string, method string, customHeader http.Header,
 	opts ...BodyOption) (string, error) {
-	b := &bodyOption{}
-	for _, o := range opts {
-		o(b)
-	}
-	var resp string
-
-	endpoints := getEndpoints(cmd)
-	err := tryURLs(cmd, endpoints, func(endpoint string) error {
-		return do(endpoint, prefix, method, &resp, customHeader, b)
-	})
-	return resp, err
+	return request(newAPIClient(cmd, getEndpoints(cmd)), prefix, method, customHeader, opts...)
 }
 
 func doRequestSingleEndpoint(cmd *cobra.Command, endpoint, prefix, method string, customHeader http.Header,
 	opts ...BodyOption) (string, error) {
+	return request(newAPIClient(cmd, []string{endpoint}), prefix, method, customHeader, opts...)
+}
+
+// request sends a request to the server and returns the response. Default is Get. It's used by
+// the commands which print the response as it is, the others use the typed methods of the client.
+func request(cli *apiclient.Client, prefix, method string, customHeader http.Header, opts ...BodyOption) (string, error) {
 	b := &bodyOption{}
 	for _, o := range opts {
 		o(b)
 	}
-	var resp string
-
-	err := requestURL(cmd, endpoint, func(endpoint string) error {
-		return do(endpoint, prefix, method, &resp, customHeader, b)
-	})
-	return resp, err
-}
-
-func dial(req *http.Request) (string, error) {
-	resp, err := dialClient.Do(req)
+	if method == "" {
+		method = http.MethodGet
+	}
+	resp, err := cli.Do(context.Background(), method, "/"+prefix, b.body, customHeader)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		var msg []byte
-		msg, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return "", err
-		}
-		return "", errors.Errorf("[%d] %s", resp.StatusCode, msg)
-	}
-
 	content, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", err
@@ -122,38 +109,24 @@ func dial(req *http.Request) (string, error) {
 	return string(content), nil
 }
 
-// DoFunc receives an endpoint which you can issue request to
-type DoFunc func(endpoint string) error
-
-// tryURLs issues requests to each URL and tries next one if there
-// is an error
-func tryURLs(cmd *cobra.Command, endpoints []string, f DoFunc) error {
-	var err error
+// newAPIClient creates the client of the PD HTTP API, which tries the endpoints in order.
+func newAPIClient(cmd *cobra.Command, endpoints []string, opts ...apiclient.ClientOption) *apiclient.Client {
+	urls := make([]string, 0, len(endpoints))
 	for _, endpoint := range endpoints {
-		endpoint, err = checkURL(endpoint)
+		u, err := checkURL(endpoint)
 		if err != nil {
 			cmd.Println(err.Error())
 			os.Exit(1)
 		}
-		err = f(endpoint)
-		if err != nil {
-			continue
-		}
-		break
-	}
-	if len(endpoints) > 1 && err != nil {
-		err = errors.Errorf("after trying all endpoints, no endpoint is available, the last error we met: %s", err)
+		urls = append(urls, u)
 	}
-	return err
-}
-
-func requestURL(cmd *cobra.Command, endpoint string, f DoFunc) error {
-	endpoint, err := checkURL(endpoint)
+	opts = append([]apiclient.ClientOption{apiclient.WithHTTPClient(dialClient), apiclient.WithBearerToken(bearerToken)}, opts...)
+	cli, err := apiclient.NewClient(urls, opts...)
 	if err != nil {
 		cmd.Println(err.Error())
 		os.Exit(1)
 	}
-	return f(endpoint)
+	return cli
 }
 
 func getEndpoints(cmd *cobra.Command) []string {
@@ -165,32 +138,8 @@ func getEndpoints(cmd *cobra.Command) []string {
 	return strings.Split(addrs, ",")
 }
 
-func postJSON(cmd *cobra.Command, prefix string, input map[string]interface{}) {
-	data, err := json.Marshal(input)
-	if err != nil {
-		cmd.Println(err)
-		return
-	}
-
-	endpoints := getEndpoints(cmd)
-	err = tryURLs(cmd, endpoints, func(endpoint string) error {
-		var msg []byte
-		var r *http.Response
-		url := endpoint + "/" + prefix
-		r, err = dialClient.Post(url, "application/json", bytes.NewBuffer(data))
-		if err != nil {
-			return err
-		}
-		defer r.Body.Close()
-		if r.StatusCode != http.StatusOK {
-			msg, err = io.ReadAll(r.Body)
-			if err != nil {
-				return err
-			}
-			return errors.Errorf("[%d] %s", r.StatusCode, msg)
-		}
-		return nil
-	})
+// printPostResult prints the result of the request which changes the server.
+func printPostResult(cmd *cobra.Command, err error) {
 	if err != nil {
 		cmd.Printf("Failed! %s", err)
 		return
@@ -198,33 +147,6 @@ func postJSON(cmd *cobra.Command, prefix string, input map[string]interface{}) {
 	cmd.Println("Success!")
 }
 
-// do send a request to server. Default is Get.
-func do(endpoint, prefix, method string, resp *string, customHeader http.Header, b *bodyOption) error {
-	var err error
-	url := endpoint + "/" + prefix
-	if method == "" {
-		method = http.MethodGet
-	}
-	var req *http.Request
-
-	req, err = http.NewRequest(method, url, b.body)
-	if err != nil {
-		return err
-	}
-
-	for key, values := range customHeader {
-		for _, v := range values {
-			req.Header.Add(key, v)
-		}
-	}
-	// the resp would be returned by the outer function
-	*resp, err = dial(req)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func checkURL(endpoint string) (string, error) {
 	if j := strings.Index(endpoint, "//"); j == -1 {
 		endpoint = "//" + endpoint
@@ -15,15 +15,11 @@
 package command
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"net/http"
 
 	"github.com/spf13/cobra"
-)
-
-var (
-	logPrefix = "pd/api/v1/admin/log"
+	"github.com/tikv/pd/pkg/apiclient"
 )
 
 // NewLogCommand New a log subcommand of the rootCmd
@@ -43,27 +39,20 @@ func logCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	data, err := json.Marshal(args[0])
-	if err != nil {
-		cmd.Printf("Failed to set log level: %s\n", err)
-		return
-	}
-
 	if len(args) == 2 {
 		url, err := checkURL(args[1])
 		if err != nil {
 			cmd.Printf("Failed to parse address %v: %s\n", args[1], err)
 			return
 		}
-		_, err = doRequestSingleEndpoint(cmd, url, logPrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}, "PD-Allow-follower-handle": {"true"}},
-			WithBody(bytes.NewBuffer(data)))
+		cli := newAPIClient(cmd, []string{url}, apiclient.WithHeader(http.Header{"PD-Allow-follower-handle": {"true"}}))
+		_, err = cli.SetLogLevel(context.Background(), args[0])
 		if err != nil {
 			cmd.Printf("Failed to set %v log level: %s\n", args[1], err)
 			return
 		}
 	} else {
-		_, err = doRequest(cmd, logPrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}},
-			WithBody(bytes.NewBuffer(data)))
+		_, err = newAPIClient(cmd, getEndpoints(cmd)).SetLogLevel(context.Background(), args[0])
 		if err != nil {
 			cmd.Printf("Failed to set log level: %s\n", err)
 			return
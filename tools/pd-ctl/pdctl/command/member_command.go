@@ -15,8 +15,7 @@
 package command
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"net/http"
 	"strconv"
 
@@ -103,8 +102,7 @@ func deleteMemberByNameCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Println("Usage: member delete <member_name>")
 		return
 	}
-	prefix := membersPrefix + "/name/" + args[0]
-	_, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteMemberByName(context.Background(), args[0])
 	if err != nil {
 		cmd.Printf("Failed to delete member %s: %s\n", args[0], err)
 		return
@@ -117,8 +115,10 @@ func deleteMemberByIDCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Println("Usage: member delete id <member_id>")
 		return
 	}
-	prefix := membersPrefix + "/id/" + args[0]
-	_, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{})
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err == nil {
+		_, err = newAPIClient(cmd, getEndpoints(cmd)).DeleteMemberByID(context.Background(), id)
+	}
 	if err != nil {
 		cmd.Printf("Failed to delete member %s: %s\n", args[0], err)
 		return
@@ -136,8 +136,7 @@ func getLeaderMemberCommandFunc(cmd *cobra.Command, args []string) {
 }
 
 func resignLeaderCommandFunc(cmd *cobra.Command, args []string) {
-	prefix := leaderMemberPrefix + "/resign"
-	_, err := doRequest(cmd, prefix, http.MethodPost, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).ResignLeader(context.Background())
 	if err != nil {
 		cmd.Printf("Failed to resign: %s\n", err)
 		return
@@ -150,8 +149,7 @@ func transferPDLeaderCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Println("Usage: leader transfer <member_name>")
 		return
 	}
-	prefix := leaderMemberPrefix + "/transfer/" + args[0]
-	_, err := doRequest(cmd, prefix, http.MethodPost, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).TransferLeader(context.Background(), args[0])
 	if err != nil {
 		cmd.Printf("Failed to transfer leadership: %s\n", err)
 		return
@@ -164,15 +162,13 @@ func setLeaderPriorityFunc(cmd *cobra.Command, args []string) {
 		cmd.Println("Usage: leader_priority <member_name> <priority>")
 		return
 	}
-	prefix := membersPrefix + "/name/" + args[0]
 	priority, err := strconv.ParseFloat(args[1], 64)
 	if err != nil {
 		cmd.Printf("failed to parse priority: %v\n", err)
 		return
 	}
 	data := map[string]interface{}{"leader-priority": priority}
-	reqData, _ := json.Marshal(data)
-	_, err = doRequest(cmd, prefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(reqData)))
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).SetMemberPropertyByName(context.Background(), args[0], data)
 	if err != nil {
 		cmd.Printf("failed to set leader priority: %v\n", err)
 		return
@@ -15,6 +15,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -162,7 +163,7 @@ func transferLeaderCommandFunc(cmd *cobra.Command, args []string) {
 	input["name"] = cmd.Name()
 	input["region_id"] = ids[0]
 	input["to_store_id"] = ids[1]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewTransferRegionCommand returns a command to transfer region.
@@ -199,7 +200,7 @@ func transferRegionCommandFunc(cmd *cobra.Command, args []string) {
 	if len(roles) > 0 {
 		input["peer_roles"] = roles
 	}
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewTransferPeerCommand returns a command to transfer region.
@@ -229,7 +230,7 @@ func transferPeerCommandFunc(cmd *cobra.Command, args []string) {
 	input["region_id"] = ids[0]
 	input["from_store_id"] = ids[1]
 	input["to_store_id"] = ids[2]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewAddPeerCommand returns a command to add region peer.
@@ -258,7 +259,7 @@ func addPeerCommandFunc(cmd *cobra.Command, args []string) {
 	input["name"] = cmd.Name()
 	input["region_id"] = ids[0]
 	input["store_id"] = ids[1]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewAddLearnerCommand returns a command to add region learner.
@@ -287,7 +288,7 @@ func addLearnerCommandFunc(cmd *cobra.Command, args []string) {
 	input["name"] = cmd.Name()
 	input["region_id"] = ids[0]
 	input["store_id"] = ids[1]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewMergeRegionCommand returns a command to merge two regions.
@@ -316,7 +317,7 @@ func mergeRegionCommandFunc(cmd *cobra.Command, args []string) {
 	input["name"] = cmd.Name()
 	input["source_region_id"] = ids[0]
 	input["target_region_id"] = ids[1]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewRemovePeerCommand returns a command to add region peer.
@@ -345,7 +346,7 @@ func removePeerCommandFunc(cmd *cobra.Command, args []string) {
 	input["name"] = cmd.Name()
 	input["region_id"] = ids[0]
 	input["store_id"] = ids[1]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewSplitRegionCommand returns a command to split a region.
@@ -384,7 +385,7 @@ func splitRegionCommandFunc(cmd *cobra.Command, args []string) {
 	input["name"] = cmd.Name()
 	input["region_id"] = ids[0]
 	input["policy"] = policy
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewScatterRegionCommand returns a command to scatter a region.
@@ -413,7 +414,7 @@ func scatterRegionCommandFunc(cmd *cobra.Command, args []string) {
 	input := make(map[string]interface{})
 	input["name"] = cmd.Name()
 	input["region_id"] = ids[0]
-	postJSON(cmd, operatorsPrefix, input)
+	createOperator(cmd, input)
 }
 
 // NewRemoveOperatorCommand returns a command to remove operators.
@@ -432,8 +433,10 @@ func removeOperatorCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	path := operatorsPrefix + "/" + args[0]
-	_, err := doRequest(cmd, path, http.MethodDelete, http.Header{})
+	regionID, err := strconv.ParseUint(args[0], 10, 64)
+	if err == nil {
+		_, err = newAPIClient(cmd, getEndpoints(cmd)).DeleteOperatorByRegion(context.Background(), regionID)
+	}
 	if err != nil {
 		cmd.Println(err)
 		return
@@ -441,6 +444,11 @@ func removeOperatorCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println("Success!")
 }
 
+func createOperator(cmd *cobra.Command, input map[string]interface{}) {
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).CreateOperator(context.Background(), input)
+	printPostResult(cmd, err)
+}
+
 // NewHistoryOperatorCommand returns a command to history finished operators.
 func NewHistoryOperatorCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -15,7 +15,7 @@
 package command
 
 import (
-	"net/http"
+	"context"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -33,7 +33,7 @@ func NewPingCommand() *cobra.Command {
 
 func showPingCommandFunc(cmd *cobra.Command, args []string) {
 	start := time.Now()
-	_, err := doRequest(cmd, pingPrefix, http.MethodGet, http.Header{})
+	err := newAPIClient(cmd, getEndpoints(cmd)).Ping(context.Background())
 	if err != nil {
 		cmd.Println(err)
 		return
@@ -15,18 +15,12 @@
 package command
 
 import (
-	"bytes"
-	"encoding/json"
-	"net/http"
+	"context"
 
 	"github.com/spf13/cobra"
 	"github.com/tikv/pd/server/cluster"
 )
 
-var (
-	pluginPrefix = "pd/api/v1/plugin"
-)
-
 // NewPluginCommand a set subcommand of plugin command
 func NewPluginCommand() *cobra.Command {
 	r := &cobra.Command{
@@ -74,16 +68,13 @@ func sendPluginCommand(cmd *cobra.Command, action string, args []string) {
 	data := map[string]interface{}{
 		"plugin-path": args[0],
 	}
-	reqData, err := json.Marshal(data)
-	if err != nil {
-		cmd.Println(err)
-		return
-	}
+	cli := newAPIClient(cmd, getEndpoints(cmd))
+	var err error
 	switch action {
 	case cluster.PluginLoad:
-		_, err = doRequest(cmd, pluginPrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(reqData)))
+		_, err = cli.LoadPlugin(context.Background(), data)
 	case cluster.PluginUnload:
-		_, err = doRequest(cmd, pluginPrefix, http.MethodDelete, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(reqData)))
+		_, err = cli.UnloadPlugin(context.Background(), data)
 	default:
 		cmd.Printf("Unknown action %s\n", action)
 		return
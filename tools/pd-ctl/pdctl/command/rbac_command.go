@@ -0,0 +1,219 @@
+// Copyright 2023 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tikv/pd/pkg/rbac"
+)
+
+var (
+	rbacRolesPrefix    = "pd/api/v1/rbac/roles"
+	rbacBindingsPrefix = "pd/api/v1/rbac/bindings"
+)
+
+// NewRBACCommand returns a rbac subcommand of rootCmd
+func NewRBACCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "rbac <subcommand>",
+		Short: "role-based access control commands",
+	}
+	r.AddCommand(newRBACRoleCommand())
+	r.AddCommand(newRBACBindingCommand())
+	r.AddCommand(&cobra.Command{
+		Use:   "enable",
+		Short: "enable the access control, a subject must be bound to the admin role first",
+		Run:   enableRBACCommandFunc,
+	})
+	r.AddCommand(&cobra.Command{
+		Use:   "disable",
+		Short: "disable the access control",
+		Run:   disableRBACCommandFunc,
+	})
+	return r
+}
+
+func newRBACRoleCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "role <subcommand>",
+		Short: "show or update the roles",
+	}
+	r.AddCommand(&cobra.Command{
+		Use:   "show [name]",
+		Short: "show all the roles or the role with the name",
+		Run:   showRBACRoleCommandFunc,
+	})
+	set := &cobra.Command{
+		Use:   "set <name> [--read <services>] [--write <services>] [--exclude <services>]",
+		Short: "create or update a custom role, the services are comma separated HTTP service labels or gRPC methods, which support the glob pattern",
+		Run:   setRBACRoleCommandFunc,
+	}
+	set.Flags().String("read", "", "the services allowed to read")
+	set.Flags().String("write", "", "the services allowed to read and write")
+	set.Flags().String("exclude", "", "the services excluded from the read and write services")
+	r.AddCommand(set)
+	r.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "delete a custom role",
+		Run:   deleteRBACRoleCommandFunc,
+	})
+	return r
+}
+
+func newRBACBindingCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "binding <subcommand>",
+		Short: "show or update the role bindings",
+	}
+	r.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show all the role bindings",
+		Run:   showRBACBindingCommandFunc,
+	})
+	r.AddCommand(&cobra.Command{
+		Use:   "set <cn|san|token> <value> <role>...",
+		Short: "bind the roles to a certificate CN, a certificate SAN or a bearer token",
+		Run:   setRBACBindingCommandFunc,
+	})
+	r.AddCommand(&cobra.Command{
+		Use:   "delete <cn|san|token> <value>",
+		Short: "delete the role binding, the value of the token binding can be the token or its hash",
+		Run:   deleteRBACBindingCommandFunc,
+	})
+	return r
+}
+
+func showRBACRoleCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Usage()
+		return
+	}
+	prefix := rbacRolesPrefix
+	if len(args) == 1 {
+		prefix += "/" + url.PathEscape(args[0])
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get roles: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func setRBACRoleCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	read, _ := cmd.Flags().GetString("read")
+	write, _ := cmd.Flags().GetString("write")
+	exclude, _ := cmd.Flags().GetString("exclude")
+	role := &rbac.Role{Name: args[0]}
+	if read != "" {
+		role.Permissions = append(role.Permissions, &rbac.Permission{
+			Actions:          []string{rbac.ActionRead},
+			Services:         strings.Split(read, ","),
+			ExcludedServices: splitServices(exclude),
+		})
+	}
+	if write != "" {
+		role.Permissions = append(role.Permissions, &rbac.Permission{
+			Actions:          []string{rbac.ActionRead, rbac.ActionWrite},
+			Services:         strings.Split(write, ","),
+			ExcludedServices: splitServices(exclude),
+		})
+	}
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetRBACRole(context.Background(), role)
+	printPostResult(cmd, err)
+}
+
+func splitServices(services string) []string {
+	if services == "" {
+		return nil
+	}
+	return strings.Split(services, ",")
+}
+
+func deleteRBACRoleCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteRBACRole(context.Background(), args[0])
+	if err != nil {
+		cmd.Printf("Failed to delete role: %s\n", err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
+func showRBACBindingCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Usage()
+		return
+	}
+	r, err := doRequest(cmd, rbacBindingsPrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get role bindings: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func setRBACBindingCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 3 {
+		cmd.Usage()
+		return
+	}
+	binding := &rbac.Binding{Kind: args[0], Value: args[1], Roles: args[2:]}
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetRBACBinding(context.Background(), binding)
+	printPostResult(cmd, err)
+}
+
+func deleteRBACBindingCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteRBACBinding(context.Background(), args[0], args[1])
+	if err != nil {
+		cmd.Printf("Failed to delete role binding: %s\n", err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
+func enableRBACCommandFunc(cmd *cobra.Command, args []string) {
+	setRBACEnabled(cmd, "true")
+}
+
+func disableRBACCommandFunc(cmd *cobra.Command, args []string) {
+	setRBACEnabled(cmd, "false")
+}
+
+func setRBACEnabled(cmd *cobra.Command, enabled string) {
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetServiceMiddlewareConfig(context.Background(),
+		map[string]interface{}{"rbac.enable-rbac": enabled})
+	if err != nil {
+		cmd.Printf("Failed to update rbac config: %s\n", err)
+		return
+	}
+	cmd.Println("Success!")
+}
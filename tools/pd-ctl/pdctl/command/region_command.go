@@ -43,6 +43,7 @@ var (
 	regionTopKeysPrefix     = "pd/api/v1/regions/keys"
 	regionTopCPUPrefix      = "pd/api/v1/regions/cpu"
 	regionsKeyPrefix        = "pd/api/v1/regions/key"
+	regionsQueryPrefix      = "pd/api/v1/regions/query"
 	regionsSiblingPrefix    = "pd/api/v1/regions/sibling"
 	regionsRangeHolesPrefix = "pd/api/v1/regions/range-holes"
 	regionIDPrefix          = "pd/api/v1/region/id"
@@ -60,6 +61,7 @@ func NewRegionCommand() *cobra.Command {
 	r.AddCommand(NewRegionWithCheckCommand())
 	r.AddCommand(NewRegionWithSiblingCommand())
 	r.AddCommand(NewRegionWithStoreCommand())
+	r.AddCommand(NewRegionWithQueryCommand())
 	r.AddCommand(NewRegionsByKeysCommand())
 	r.AddCommand(NewRangesWithRangeHolesCommand())
 
@@ -128,10 +130,72 @@ func NewRegionCommand() *cobra.Command {
 	r.AddCommand(scanRegion)
 
 	r.Flags().String("jq", "", "jq query")
+	addRegionListFlags(r)
 
 	return r
 }
 
+// regionListQueries maps the flags to list regions to the query parameters.
+var regionListQueries = map[string]string{
+	"start-key":       "start_key",
+	"start-id":        "start_id",
+	"limit":           "limit",
+	"fields":          "fields",
+	"store":           "store_id",
+	"label":           "label",
+	"peer-state":      "peer_state",
+	"min-size":        "min_size",
+	"max-size":        "max_size",
+	"min-keys":        "min_keys",
+	"max-keys":        "max_keys",
+	"min-write-bytes": "min_write_bytes",
+	"max-write-bytes": "max_write_bytes",
+	"min-read-bytes":  "min_read_bytes",
+	"max-read-bytes":  "max_read_bytes",
+}
+
+// addRegionListFlags adds the flags to paginate, filter and select the fields of the listed regions.
+func addRegionListFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.String("start-key", "", "list the regions from the hex encoded key in the order of the start key, it's the next_key of the last page")
+	flags.Uint64("start-id", 0, "list the regions from the region ID in the order of the ID, it's the next_id of the last page")
+	flags.Int("limit", 0, "max count of the regions in a page")
+	flags.StringSlice("fields", nil, "fields of the regions to show, e.g. id,approximate_size")
+	flags.StringSlice("store", nil, "only show the regions which have a peer on any of the stores")
+	flags.StringArray("label", nil, "only show the regions which have all the region labels in the form of key=value")
+	flags.StringSlice("peer-state", nil, "only show the regions which have a peer in any of the states: pending, down, learner, witness")
+	flags.Uint64("min-size", 0, "min approximate size in MiB")
+	flags.Uint64("max-size", 0, "max approximate size in MiB")
+	flags.Uint64("min-keys", 0, "min approximate keys")
+	flags.Uint64("max-keys", 0, "max approximate keys")
+	flags.Uint64("min-write-bytes", 0, "min written bytes")
+	flags.Uint64("max-write-bytes", 0, "max written bytes")
+	flags.Uint64("min-read-bytes", 0, "min read bytes")
+	flags.Uint64("max-read-bytes", 0, "max read bytes")
+}
+
+// regionListQuery returns the query to list regions from the flags which are set.
+func regionListQuery(cmd *cobra.Command) string {
+	query := url.Values{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		name, ok := regionListQueries[f.Name]
+		if !ok {
+			return
+		}
+		if values, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range values.GetSlice() {
+				query.Add(name, v)
+			}
+			return
+		}
+		query.Set(name, f.Value.String())
+	})
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
 func showRegionCommandFunc(cmd *cobra.Command, args []string) {
 	prefix := regionsPrefix
 	if len(args) == 1 {
@@ -140,6 +204,8 @@ func showRegionCommandFunc(cmd *cobra.Command, args []string) {
 			return
 		}
 		prefix = regionIDPrefix + "/" + args[0]
+	} else {
+		prefix += regionListQuery(cmd)
 	}
 	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
 	if err != nil {
@@ -369,6 +435,7 @@ func NewRegionWithCheckCommand() *cobra.Command {
 	}
 
 	r.Flags().String("jq", "", "jq query")
+	addRegionListFlags(r)
 	return r
 }
 
@@ -399,6 +466,8 @@ func showRegionWithCheckCommandFunc(cmd *cobra.Command, args []string) {
 		} else {
 			prefix += "?bound=10000"
 		}
+	} else {
+		prefix += regionListQuery(cmd)
 	}
 	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
 	if err != nil {
@@ -445,6 +514,7 @@ func NewRegionWithStoreCommand() *cobra.Command {
 		Short: "show the regions of a specific store",
 		Run:   showRegionWithStoreCommandFunc,
 	}
+	addRegionListFlags(r)
 	return r
 }
 
@@ -454,7 +524,7 @@ func showRegionWithStoreCommandFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 	storeID := args[0]
-	prefix := regionsStorePrefix + "/" + storeID
+	prefix := regionsStorePrefix + "/" + storeID + regionListQuery(cmd)
 	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
 	if err != nil {
 		cmd.Printf("Failed to get regions with the given storeID: %s\n", err)
@@ -463,6 +533,55 @@ func showRegionWithStoreCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println(r)
 }
 
+const regionQueryExample = `
+  region query "size > 96MiB and store in (1, 4) and leader.store.label.zone = 'z1' order by size desc limit 10"
+  region query "pending_peers > 0 or down_peers > 0" --fields=id,pending_peers,down_peers
+  region query "label.env = 'prod' and start_key >= '7480000000000000ff'"`
+
+// NewRegionWithQueryCommand returns regions with query subcommand of regionCmd
+func NewRegionWithQueryCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   `query "<query>" [--fields=<fields>] [--jq="<query string>"]`,
+		Short: "show the regions which match the query",
+		Long: `show the regions which match the query. A query consists of the comparisons of the fields like size > 96MiB,
+combined by and, or, not and the parentheses, and may be followed by order by and limit.
+The fields are id, size, keys, written_bytes, written_keys, read_bytes, read_keys, cpu_usage, conf_ver, version,
+peers, voters, learners, witnesses, pending_peers, down_peers, start_key, end_key (hex encoded), store, leader.store,
+store.label.<key>, leader.store.label.<key> and label.<key>.`,
+		Example: regionQueryExample,
+		Run:     showRegionWithQueryCommandFunc,
+	}
+	r.Flags().StringSlice("fields", nil, "fields of the regions to show, e.g. id,approximate_size")
+	r.Flags().String("jq", "", "jq query")
+	return r
+}
+
+func showRegionWithQueryCommandFunc(cmd *cobra.Command, args []string) {
+	query := url.Values{}
+	// The query may be split into several arguments by the shell if it's not quoted.
+	if q := strings.Join(args, " "); q != "" {
+		query.Set("q", q)
+	}
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	for _, field := range fields {
+		query.Add("fields", field)
+	}
+	prefix := regionsQueryPrefix
+	if len(query) > 0 {
+		prefix += "?" + query.Encode()
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to query regions: %s\n", err)
+		return
+	}
+	if flag := cmd.Flag("jq"); flag != nil && flag.Value.String() != "" {
+		printWithJQFilter(r, flag.Value.String())
+		return
+	}
+	cmd.Println(r)
+}
+
 const (
 	rangeHolesLongDesc = `There are some cases that the region range is not continuous, for example, the region doesn't send the heartbeat to PD after a splitting.
 This command will output all empty ranges without any region info.`
@@ -15,8 +15,7 @@
 package command
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -73,9 +72,9 @@ func pauseSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Usage()
 		return
 	}
-	path := schedulersPrefix + "/" + args[0]
 	input := map[string]interface{}{"delay": delay}
-	postJSON(cmd, path, input)
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).PauseOrResumeScheduler(context.Background(), args[0], input)
+	printPostResult(cmd, err)
 }
 
 // NewResumeSchedulerCommand returns a command to resume a scheduler.
@@ -93,9 +92,9 @@ func resumeSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Usage()
 		return
 	}
-	path := schedulersPrefix + "/" + args[0]
 	input := map[string]interface{}{"delay": 0}
-	postJSON(cmd, path, input)
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).PauseOrResumeScheduler(context.Background(), args[0], input)
+	printPostResult(cmd, err)
 }
 
 // NewShowSchedulerCommand returns a command to show schedulers.
@@ -105,7 +104,7 @@ func NewShowSchedulerCommand() *cobra.Command {
 		Short: "show schedulers",
 		Run:   showSchedulerCommandFunc,
 	}
-	c.Flags().String("status", "", "the scheduler status value can be [paused | disabled]")
+	c.Flags().String("status", "", "the scheduler status value can be [paused | disabled | windowed]")
 	c.Flags().BoolP("timestamp", "t", false, "fetch the paused and resume timestamp for paused scheduler(s)")
 	return c
 }
@@ -145,13 +144,17 @@ func NewAddSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewScatterRangeSchedulerCommand())
 	c.AddCommand(NewBalanceLeaderSchedulerCommand())
 	c.AddCommand(NewBalanceRegionSchedulerCommand())
+	c.AddCommand(NewBalanceTableSchedulerCommand())
 	c.AddCommand(NewBalanceHotRegionSchedulerCommand())
 	c.AddCommand(NewRandomMergeSchedulerCommand())
 	c.AddCommand(NewLabelSchedulerCommand())
 	c.AddCommand(NewEvictSlowStoreSchedulerCommand())
 	c.AddCommand(NewGrantHotRegionSchedulerCommand())
 	c.AddCommand(NewSplitBucketSchedulerCommand())
+	c.AddCommand(NewLoadSplitSchedulerCommand())
+	c.AddCommand(NewScatterGroupSchedulerCommand())
 	c.AddCommand(NewSlowTrendEvictLeaderSchedulerCommand())
+	c.AddCommand(NewStoreHealthSchedulerCommand())
 	c.AddCommand(NewBalanceWitnessSchedulerCommand())
 	c.AddCommand(NewTransferWitnessLeaderSchedulerCommand())
 	return c
@@ -178,13 +181,11 @@ func NewEvictLeaderSchedulerCommand() *cobra.Command {
 }
 
 func checkSchedulerExist(cmd *cobra.Command, schedulerName string) (bool, error) {
-	r, err := doRequest(cmd, schedulersPrefix, http.MethodGet, http.Header{})
+	schedulerList, err := newAPIClient(cmd, getEndpoints(cmd)).GetSchedulers(context.Background())
 	if err != nil {
 		cmd.Println(err)
 		return false, err
 	}
-	var schedulerList []string
-	json.Unmarshal([]byte(r), &schedulerList)
 	for idx := range schedulerList {
 		if strings.Contains(schedulerList[idx], schedulerName) {
 			return true, nil
@@ -193,6 +194,12 @@ func checkSchedulerExist(cmd *cobra.Command, schedulerName string) (bool, error)
 	return false, nil
 }
 
+// createScheduler creates a scheduler with the input, the name of the scheduler is in the input.
+func createScheduler(cmd *cobra.Command, input map[string]interface{}) {
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).CreateScheduler(context.Background(), input)
+	printPostResult(cmd, err)
+}
+
 func addSchedulerForStoreCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
 		cmd.Println(cmd.UsageString())
@@ -221,7 +228,7 @@ func addSchedulerForStoreCommandFunc(cmd *cobra.Command, args []string) {
 		input := make(map[string]interface{})
 		input["name"] = cmd.Name()
 		input["store_id"] = storeID
-		postJSON(cmd, schedulersPrefix, input)
+		createScheduler(cmd, input)
 	}
 }
 
@@ -272,7 +279,7 @@ func addSchedulerForShuffleHotRegionCommandFunc(cmd *cobra.Command, args []strin
 	input := make(map[string]interface{})
 	input["name"] = cmd.Name()
 	input["limit"] = limit
-	postJSON(cmd, schedulersPrefix, input)
+	createScheduler(cmd, input)
 }
 
 // NewBalanceLeaderSchedulerCommand returns a command to add a balance-leader-scheduler.
@@ -305,6 +312,29 @@ func NewBalanceRegionSchedulerCommand() *cobra.Command {
 	return c
 }
 
+// NewBalanceTableSchedulerCommand returns a command to add a balance-table-scheduler.
+func NewBalanceTableSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-table-scheduler [table|keyspace]",
+		Short: "add a scheduler to balance regions of each table or keyspace between stores",
+		Run:   addSchedulerForBalanceTableCommandFunc,
+	}
+	return c
+}
+
+func addSchedulerForBalanceTableCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	input := make(map[string]interface{})
+	input["name"] = cmd.Name()
+	if len(args) == 1 {
+		input["granularity"] = args[0]
+	}
+	createScheduler(cmd, input)
+}
+
 // NewBalanceHotRegionSchedulerCommand returns a command to add a balance-hot-region-scheduler.
 func NewBalanceHotRegionSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -345,6 +375,26 @@ func NewSplitBucketSchedulerCommand() *cobra.Command {
 	return cmd
 }
 
+// NewLoadSplitSchedulerCommand returns a command to add a load-split-scheduler.
+func NewLoadSplitSchedulerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load-split-scheduler",
+		Short: "add a scheduler to split hot regions by load and scatter them",
+		Run:   addSchedulerForSplitBucketCommandFunc,
+	}
+	return cmd
+}
+
+// NewScatterGroupSchedulerCommand returns a command to add a scatter-group-scheduler.
+func NewScatterGroupSchedulerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scatter-group-scheduler",
+		Short: "add a scheduler to keep the scatter groups evenly distributed",
+		Run:   addSchedulerForSplitBucketCommandFunc,
+	}
+	return cmd
+}
+
 // NewGrantHotRegionSchedulerCommand returns a command to add a grant-hot-region-scheduler.
 func NewGrantHotRegionSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -385,10 +435,20 @@ func NewSlowTrendEvictLeaderSchedulerCommand() *cobra.Command {
 	return c
 }
 
+// NewStoreHealthSchedulerCommand returns a command to add a store-health-scheduler.
+func NewStoreHealthSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "store-health-scheduler",
+		Short: "add a scheduler to detect unhealthy stores by multiple signals and quarantine them",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
 func addSchedulerForSplitBucketCommandFunc(cmd *cobra.Command, args []string) {
 	input := make(map[string]interface{})
 	input["name"] = cmd.Name()
-	postJSON(cmd, schedulersPrefix, input)
+	createScheduler(cmd, input)
 }
 
 func addSchedulerForGrantHotRegionCommandFunc(cmd *cobra.Command, args []string) {
@@ -400,7 +460,7 @@ func addSchedulerForGrantHotRegionCommandFunc(cmd *cobra.Command, args []string)
 	input["name"] = cmd.Name()
 	input["store-leader-id"] = args[0]
 	input["store-id"] = args[1]
-	postJSON(cmd, schedulersPrefix, input)
+	createScheduler(cmd, input)
 }
 
 func addSchedulerCommandFunc(cmd *cobra.Command, args []string) {
@@ -410,7 +470,7 @@ func addSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	input := make(map[string]interface{})
 	input["name"] = cmd.Name()
-	postJSON(cmd, schedulersPrefix, input)
+	createScheduler(cmd, input)
 }
 
 // NewScatterRangeSchedulerCommand returns a command to add a scatter-range-scheduler.
@@ -445,7 +505,7 @@ func addSchedulerForScatterRangeCommandFunc(cmd *cobra.Command, args []string) {
 	input["start_key"] = url.QueryEscape(startKey)
 	input["end_key"] = url.QueryEscape(endKey)
 	input["range_name"] = args[2]
-	postJSON(cmd, schedulersPrefix, input)
+	createScheduler(cmd, input)
 }
 
 // NewRemoveSchedulerCommand returns a command to remove scheduler.
@@ -476,8 +536,7 @@ func removeSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 	case strings.HasPrefix(args[0], grantLeaderSchedulerName) && args[0] != grantLeaderSchedulerName:
 		redirectRemoveSchedulerToDeleteConfig(cmd, grantLeaderSchedulerName, args)
 	default:
-		path := schedulersPrefix + "/" + args[0]
-		_, err := doRequest(cmd, path, http.MethodDelete, http.Header{})
+		_, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteScheduler(context.Background(), args[0])
 		if err != nil {
 			cmd.Println(err)
 			return
@@ -500,6 +559,8 @@ func NewConfigSchedulerCommand() *cobra.Command {
 		newConfigGrantHotRegionCommand(),
 		newConfigBalanceLeaderCommand(),
 		newSplitBucketCommand(),
+		newLoadSplitCommand(),
+		newConfigBalanceTableCommand(),
 	)
 	return c
 }
@@ -544,6 +605,46 @@ func newSplitBucketCommand() *cobra.Command {
 	return c
 }
 
+func newLoadSplitCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "load-split-scheduler",
+		Short: "load-split-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "list the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
+func newConfigBalanceTableCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-table-scheduler",
+		Short: "balance-table-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "list the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
 func newConfigHotRegionCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "balance-hot-region-scheduler",
@@ -637,7 +738,13 @@ func addStoreToSchedulerConfig(cmd *cobra.Command, schedulerName string, args []
 	input["name"] = schedulerName
 	input["store_id"] = storeID
 
-	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
+	setSchedulerConfig(cmd, schedulerName, input)
+}
+
+// setSchedulerConfig updates the config items of the scheduler.
+func setSchedulerConfig(cmd *cobra.Command, schedulerName string, input map[string]interface{}) {
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetSchedulerConfig(context.Background(), schedulerName, input)
+	printPostResult(cmd, err)
 }
 
 func listSchedulerConfigCommandFunc(cmd *cobra.Command, args []string) {
@@ -698,7 +805,7 @@ func setGrantHotRegionCommandFunc(cmd *cobra.Command, schedulerName string, args
 	input := make(map[string]interface{})
 	input["store-leader-id"] = args[0]
 	input["store-id"] = args[1]
-	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
+	setSchedulerConfig(cmd, schedulerName, input)
 }
 
 func postSchedulerConfigCommandFunc(cmd *cobra.Command, schedulerName string, args []string) {
@@ -743,7 +850,7 @@ func postSchedulerConfigCommandFunc(cmd *cobra.Command, schedulerName string, ar
 	} else {
 		input[key] = val
 	}
-	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
+	setSchedulerConfig(cmd, schedulerName, input)
 }
 
 func deleteStoreFromSchedulerConfig(cmd *cobra.Command, schedulerName string, args []string) {
@@ -751,8 +858,10 @@ func deleteStoreFromSchedulerConfig(cmd *cobra.Command, schedulerName string, ar
 		cmd.Println(cmd.Usage())
 		return
 	}
-	path := path.Join(schedulerConfigPrefix, "/", schedulerName, "delete", args[0])
-	_, err := doRequest(cmd, path, http.MethodDelete, http.Header{})
+	storeID, err := strconv.ParseUint(args[0], 10, 64)
+	if err == nil {
+		_, err = newAPIClient(cmd, getEndpoints(cmd)).DeleteSchedulerConfigStore(context.Background(), schedulerName, storeID)
+	}
 	if err != nil {
 		cmd.Println(err)
 		return
@@ -790,10 +899,7 @@ func setShuffleRegionSchedulerRolesCommandFunc(cmd *cobra.Command, args []string
 			roles = append(roles, f)
 		}
 	}
-	b, _ := json.Marshal(roles)
-	path := path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "roles")
-	_, err := doRequest(cmd, path, http.MethodPost, http.Header{"Content-Type": {"application/json"}},
-		WithBody(bytes.NewBuffer(b)))
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetSchedulerRoles(context.Background(), cmd.Parent().Name(), roles)
 	if err != nil {
 		cmd.Println(err)
 		return
@@ -15,7 +15,7 @@
 package command
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -25,17 +25,17 @@ import (
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/spf13/cobra"
+	"github.com/tikv/pd/pkg/apiclient"
 	"github.com/tikv/pd/server/api"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
 var (
-	storesPrefix       = "pd/api/v1/stores"
-	storesLimitPrefix  = "pd/api/v1/stores/limit"
-	storePrefix        = "pd/api/v1/store/%v"
-	storeUpStatePrefix = "pd/api/v1/store/%v/state?state=Up"
-	maxStoreLimit      = float64(200)
+	storesPrefix      = "pd/api/v1/stores"
+	storesLimitPrefix = "pd/api/v1/stores/limit"
+	storePrefix       = "pd/api/v1/store/%v"
+	maxStoreLimit     = float64(200)
 )
 
 // NewStoreCommand return a stores subcommand of rootCmd
@@ -53,6 +53,7 @@ func NewStoreCommand() *cobra.Command {
 	s.AddCommand(NewRemoveTombStoneCommand())
 	s.AddCommand(NewStoreLimitSceneCommand())
 	s.AddCommand(NewStoreCheckCommand())
+	s.AddCommand(NewDrainStoreCommand())
 	s.Flags().String("jq", "", "jq query")
 	s.Flags().StringSlice("state", nil, "state filter")
 	return s
@@ -140,6 +141,29 @@ func NewStoreLimitCommand() *cobra.Command {
 	return c
 }
 
+// NewDrainStoreCommand returns a drain subcommand of storeCmd.
+func NewDrainStoreCommand() *cobra.Command {
+	d := &cobra.Command{
+		Use:   "drain <store_id> [--target-stores=<store_id>,...] [--target-labels=<key>=<value>,...] [--rate=<regions_per_minute>]",
+		Short: "drain the store gracefully, the leaders are transferred out first, then the peers are moved out",
+		Run:   drainStoreCommandFunc,
+	}
+	d.Flags().StringSlice("target-stores", nil, "the stores which the peers are moved to")
+	d.Flags().StringSlice("target-labels", nil, "the labels which the target stores must match")
+	d.Flags().Float64("rate", 0, "the max number of the regions moved out per minute, 0 means no limit")
+	d.AddCommand(&cobra.Command{
+		Use:   "show [<store_id>]",
+		Short: "show the progress of the store drains",
+		Run:   showStoreDrainCommandFunc,
+	})
+	d.AddCommand(&cobra.Command{
+		Use:   "cancel <store_id>",
+		Short: "cancel draining the store",
+		Run:   cancelStoreDrainCommandFunc,
+	})
+	return d
+}
+
 // NewStoreCheckCommand return a check subcommand of storeCmd
 func NewStoreCheckCommand() *cobra.Command {
 	d := &cobra.Command{
@@ -272,10 +296,12 @@ func storeLimitSceneCommandFunc(cmd *cobra.Command, args []string) {
 			cmd.Println(err)
 			return
 		}
+		opts := &apiclient.SetStoreLimitSceneOptions{}
 		if len(args) == 3 {
-			prefix = path.Join(prefix, fmt.Sprintf("?type=%s", args[2]))
+			opts.Type = args[2]
 		}
-		postJSON(cmd, prefix, map[string]interface{}{scene: rate})
+		_, err = newAPIClient(cmd, getEndpoints(cmd)).SetStoreLimitScene(context.Background(), map[string]interface{}{scene: rate}, opts)
+		printPostResult(cmd, err)
 	}
 }
 
@@ -365,12 +391,12 @@ func deleteStoreCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Usage()
 		return
 	}
-	if _, err := strconv.Atoi(args[0]); err != nil {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
 		cmd.Println("store_id should be a number")
 		return
 	}
-	prefix := fmt.Sprintf(storePrefix, args[0])
-	_, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{})
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).DeleteStore(context.Background(), id, nil)
 	if err != nil {
 		cmd.Printf("Failed to delete store %s: %s\n", args[0], err)
 		return
@@ -384,8 +410,7 @@ func deleteStoreCommandByAddrFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 	// delete store by its ID
-	prefix := fmt.Sprintf(storePrefix, id)
-	_, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteStore(context.Background(), uint64(id), nil)
 	if err != nil {
 		cmd.Printf("Failed to delete store %s: %s\n", args[0], err)
 		return
@@ -398,35 +423,25 @@ func cancelDeleteStoreCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Usage()
 		return
 	}
-	if _, err := strconv.Atoi(args[0]); err != nil {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
 		cmd.Println("store_id should be a number")
 		return
 	}
 
-	prefix := fmt.Sprintf(storePrefix, args[0])
-	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	cli := newAPIClient(cmd, getEndpoints(cmd))
+	storeInfo, err := cli.GetStore(context.Background(), id)
 	if err != nil {
 		cmd.Printf("Failed to get store: %s\n", err)
 		return
 	}
 
-	storeInfo := struct {
-		Store struct {
-			State metapb.StoreState `json:"state"`
-		} `json:"store"`
-	}{}
-	if err = json.Unmarshal([]byte(r), &storeInfo); err != nil {
-		cmd.Printf("Failed to parse store info: %s\n", err)
-		return
-	}
-
-	if storeInfo.Store.State != metapb.StoreState_Offline {
+	if storeInfo.Store == nil || storeInfo.Store.State != int32(metapb.StoreState_Offline) {
 		cmd.Printf("store %v is not offline\n", args[0])
 		return
 	}
 
-	prefix = fmt.Sprintf(storeUpStatePrefix, args[0])
-	_, err = doRequest(cmd, prefix, http.MethodPost, http.Header{})
+	_, err = cli.SetStoreState(context.Background(), id, metapb.StoreState_Up.String())
 	if err != nil {
 		cmd.Printf("Failed to cancel delete store %s: %s\n", args[0], err)
 		return
@@ -440,8 +455,7 @@ func cancelDeleteStoreCommandByAddrFunc(cmd *cobra.Command, args []string) {
 		return
 	}
 	// cancel delete store by its ID
-	prefix := fmt.Sprintf(storeUpStatePrefix, id)
-	_, err := doRequest(cmd, prefix, http.MethodPost, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).SetStoreState(context.Background(), uint64(id), metapb.StoreState_Up.String())
 	if err != nil {
 		cmd.Printf("Failed to cancel delete store %s: %s\n", args[0], err)
 		return
@@ -458,34 +472,20 @@ func getStoreID(cmd *cobra.Command, args []string, isCancel bool) (id int) {
 	addr := args[0]
 
 	// fetch all the stores
-	r, err := doRequest(cmd, storesPrefix, http.MethodGet, http.Header{})
+	storesInfo, err := newAPIClient(cmd, getEndpoints(cmd)).GetStores(context.Background(), nil)
 	if err != nil {
 		cmd.Printf("Failed to get store: %s\n", err)
 		return
 	}
 
-	storeInfo := struct {
-		Stores []struct {
-			Store struct {
-				ID      int               `json:"id"`
-				Address string            `json:"address"`
-				State   metapb.StoreState `json:"state"`
-			} `json:"store"`
-		} `json:"stores"`
-	}{}
-	if err = json.Unmarshal([]byte(r), &storeInfo); err != nil {
-		cmd.Printf("Failed to parse store info: %s\n", err)
-		return
-	}
-
 	// filter by the addr
-	for _, store := range storeInfo.Stores {
-		if store.Store.Address == addr {
-			if isCancel && store.Store.State != metapb.StoreState_Offline {
+	for _, store := range storesInfo.Stores {
+		if store.Store != nil && store.Store.Address == addr {
+			if isCancel && store.Store.State != int32(metapb.StoreState_Offline) {
 				cmd.Printf("store is not offline: %s\n", addr)
 				return
 			}
-			id = store.Store.ID
+			id = int(store.Store.ID)
 			break
 		}
 	}
@@ -509,20 +509,17 @@ func labelStoreCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Usage()
 		return
 	}
-	if _, err := strconv.Atoi(args[0]); err != nil {
+	storeID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
 		cmd.Println("store_id should be a number")
 		return
 	}
-	prefix := fmt.Sprintf(path.Join(storePrefix, "label"), args[0])
-	var toDeletedLabel string
 	if isDelete {
 		if len(args) != 2 {
 			cmd.PrintErrln("Failed: not allow to delete multiple labels at a time")
 			return
 		}
-		toDeletedLabel = args[1]
-		b, _ := json.Marshal(toDeletedLabel)
-		res, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(b)))
+		res, err := newAPIClient(cmd, getEndpoints(cmd)).DeleteStoreLabel(context.Background(), storeID, args[1])
 		if err != nil {
 			cmd.Printf("Failed! %s\n", err)
 			return
@@ -560,13 +557,14 @@ func labelStoreCommandFunc(cmd *cobra.Command, args []string) {
 			i += 2
 		}
 	}
+	opts := &apiclient.SetStoreLabelOptions{}
 	if force, _ := cmd.Flags().GetBool("force"); force {
-		prefix += "?force=true"
+		opts.Force = "true"
 	} else if rewrite, _ := cmd.Flags().GetBool("rewrite"); rewrite {
-		prefix += "?force=true"
+		opts.Force = "true"
 	}
-	cmd.Println(prefix)
-	postJSON(cmd, prefix, labels)
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).SetStoreLabel(context.Background(), storeID, labels, opts)
+	printPostResult(cmd, err)
 }
 
 func setStoreWeightCommandFunc(cmd *cobra.Command, args []string) {
@@ -584,11 +582,16 @@ func setStoreWeightCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Println("region_weight should be a number that >= 0")
 		return
 	}
-	prefix := fmt.Sprintf(path.Join(storePrefix, "weight"), args[0])
-	postJSON(cmd, prefix, map[string]interface{}{
+	storeID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		cmd.Println("store_id should be a number")
+		return
+	}
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).SetStoreWeight(context.Background(), storeID, map[string]interface{}{
 		"leader": leader,
 		"region": region,
 	})
+	printPostResult(cmd, err)
 }
 
 func storeLimitCommandFunc(cmd *cobra.Command, args []string) {
@@ -610,30 +613,34 @@ func storeLimitCommandFunc(cmd *cobra.Command, args []string) {
 			cmd.Println("rate should be a number that > 0.")
 			return
 		}
+		postInput := map[string]interface{}{
+			"rate": rate,
+		}
+		if argsCount == 3 {
+			postInput["type"] = args[2]
+		}
 		// if the store id is "all", set limits for all stores
-		var prefix string
 		if args[0] == "all" {
-			prefix = storesLimitPrefix
 			if rate > maxStoreLimit {
 				cmd.Printf("rate should less than %f for all\n", maxStoreLimit)
 				return
 			}
+			_, err = newAPIClient(cmd, getEndpoints(cmd)).SetAllStoresLimit(context.Background(), postInput, nil)
 		} else {
-			prefix = fmt.Sprintf(path.Join(storePrefix, "limit"), args[0])
-		}
-		postInput := map[string]interface{}{
-			"rate": rate,
-		}
-		if argsCount == 3 {
-			postInput["type"] = args[2]
+			var storeID uint64
+			storeID, err = strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				cmd.Println("store_id should be a number")
+				return
+			}
+			_, err = newAPIClient(cmd, getEndpoints(cmd)).SetStoreLimit(context.Background(), storeID, postInput, nil)
 		}
-		postJSON(cmd, prefix, postInput)
+		printPostResult(cmd, err)
 	} else {
 		if args[0] != "all" {
 			cmd.Println("Labels are an option of set all stores limit.")
 		} else {
 			postInput := map[string]interface{}{}
-			prefix := storesLimitPrefix
 			ratePos := argsCount - 1
 			if argsCount%2 == 1 {
 				postInput["type"] = args[argsCount-1]
@@ -654,7 +661,8 @@ func storeLimitCommandFunc(cmd *cobra.Command, args []string) {
 				labels[args[i]] = args[i+1]
 			}
 			postInput["labels"] = labels
-			postJSON(cmd, prefix, postInput)
+			_, err = newAPIClient(cmd, getEndpoints(cmd)).SetAllStoresLimit(context.Background(), postInput, nil)
+			printPostResult(cmd, err)
 		}
 	}
 }
@@ -714,8 +722,7 @@ func showAllStoresLimitCommandFunc(cmd *cobra.Command, args []string) {
 }
 
 func removeTombStoneCommandFunc(cmd *cobra.Command, args []string) {
-	prefix := path.Join(storesPrefix, "remove-tombstone")
-	_, err := doRequest(cmd, prefix, http.MethodDelete, http.Header{})
+	_, err := newAPIClient(cmd, getEndpoints(cmd)).RemoveTombStone(context.Background())
 	if err != nil {
 		cmd.Printf("Failed to remove tombstone store %s \n", err)
 		return
@@ -734,12 +741,94 @@ func setAllLimitCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Println("rate should be a number that > 0.")
 		return
 	}
-	prefix := storesLimitPrefix
 	input := map[string]interface{}{
 		"rate": rate,
 	}
 	if len(args) == 2 {
 		input["type"] = args[1]
 	}
-	postJSON(cmd, prefix, input)
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).SetAllStoresLimit(context.Background(), input, nil)
+	printPostResult(cmd, err)
+}
+
+func drainStoreCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	storeID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		cmd.Println("store_id should be a number")
+		return
+	}
+	input := &apiclient.StoreDrainOptions{}
+	targetStores, _ := cmd.Flags().GetStringSlice("target-stores")
+	for _, s := range targetStores {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			cmd.Println("target-stores should be numbers")
+			return
+		}
+		input.TargetStores = append(input.TargetStores, id)
+	}
+	targetLabels, _ := cmd.Flags().GetStringSlice("target-labels")
+	if len(targetLabels) > 0 {
+		labels := make(map[string]string, len(targetLabels))
+		for _, l := range targetLabels {
+			kv := strings.SplitN(l, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				cmd.Printf("invalid label %s, should be <key>=<value>\n", l)
+				return
+			}
+			labels[kv[0]] = kv[1]
+		}
+		input.TargetLabels = labels
+	}
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	if rate < 0 {
+		cmd.Println("rate should be a number that >= 0")
+		return
+	}
+	input.RateLimit = rate
+	_, err = newAPIClient(cmd, getEndpoints(cmd)).DrainStore(context.Background(), storeID, input)
+	printPostResult(cmd, err)
+}
+
+func showStoreDrainCommandFunc(cmd *cobra.Command, args []string) {
+	prefix := path.Join(storesPrefix, "drain")
+	switch len(args) {
+	case 0:
+	case 1:
+		if _, err := strconv.ParseUint(args[0], 10, 64); err != nil {
+			cmd.Println("store_id should be a number")
+			return
+		}
+		prefix = fmt.Sprintf(path.Join(storePrefix, "drain"), args[0])
+	default:
+		cmd.Usage()
+		return
+	}
+	r, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get the store drain: %s\n", err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func cancelStoreDrainCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	storeID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		cmd.Println("store_id should be a number")
+		return
+	}
+	if _, err := newAPIClient(cmd, getEndpoints(cmd)).CancelStoreDrain(context.Background(), storeID); err != nil {
+		cmd.Printf("Failed to cancel the store drain %s: %s\n", args[0], err)
+		return
+	}
+	cmd.Println("Success!")
 }
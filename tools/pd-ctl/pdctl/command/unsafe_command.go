@@ -15,6 +15,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -46,7 +47,10 @@ func NewRemoveFailedStoresCommand() *cobra.Command {
 	cmd.PersistentFlags().Bool("auto-detect", false, `detect failed stores automatically without needing to pass failed store ids, and all stores not in PD stores list are regarded as failed; 
 Note: DO NOT RECOMMEND to use this flag for general use, it's used only for case that PD doesn't have the store information of failed stores after pd-recover;
 Note: Do it with caution to make sure all live stores's heartbeats has been reported PD already, otherwise it may regarded some stores as failed mistakenly.`)
+	cmd.PersistentFlags().Bool("dry-run", false, "only generate the recovery plan without executing it, use `plan` to review it and `approve` to execute it")
 	cmd.AddCommand(NewRemoveFailedStoresShowCommand())
+	cmd.AddCommand(NewRemoveFailedStoresPlanCommand())
+	cmd.AddCommand(NewRemoveFailedStoresApproveCommand())
 	return cmd
 }
 
@@ -59,9 +63,26 @@ func NewRemoveFailedStoresShowCommand() *cobra.Command {
 	}
 }
 
+// NewRemoveFailedStoresPlanCommand returns the unsafe remove failed stores plan command.
+func NewRemoveFailedStoresPlanCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan",
+		Short: "Show the recovery plan generated by the dry run of failed stores removal",
+		Run:   removeFailedStoresPlanCommandFunc,
+	}
+}
+
+// NewRemoveFailedStoresApproveCommand returns the unsafe remove failed stores approve command.
+func NewRemoveFailedStoresApproveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "approve <plan_id>",
+		Short: "Approve the recovery plan generated by the dry run to remove the failed stores, the plan ID is shown by `plan`",
+		Run:   removeFailedStoresApproveCommandFunc,
+	}
+}
+
 func removeFailedStoresCommandFunc(cmd *cobra.Command, args []string) {
-	prefix := fmt.Sprintf("%s/remove-failed-stores", unsafePrefix)
-	postInput := make(map[string]interface{}, 3)
+	postInput := make(map[string]interface{}, 4)
 
 	autoDetect, err := cmd.Flags().GetBool("auto-detect")
 	if err != nil {
@@ -95,6 +116,15 @@ func removeFailedStoresCommandFunc(cmd *cobra.Command, args []string) {
 		postInput["stores"] = stores
 	}
 
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	if dryRun {
+		postInput["dry-run"] = dryRun
+	}
+
 	timeout, err := cmd.Flags().GetFloat64("timeout")
 	if err != nil {
 		cmd.Println(err)
@@ -103,7 +133,7 @@ func removeFailedStoresCommandFunc(cmd *cobra.Command, args []string) {
 		postInput["timeout"] = timeout
 	}
 
-	postJSON(cmd, prefix, postInput)
+	printPostResult(cmd, newAPIClient(cmd, getEndpoints(cmd)).RemoveFailedStores(context.Background(), postInput))
 }
 
 func removeFailedStoresShowCommandFunc(cmd *cobra.Command, args []string) {
@@ -117,3 +147,22 @@ func removeFailedStoresShowCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	cmd.Println(resp)
 }
+
+func removeFailedStoresPlanCommandFunc(cmd *cobra.Command, args []string) {
+	prefix := fmt.Sprintf("%s/remove-failed-stores/plan", unsafePrefix)
+	resp, err := doRequest(cmd, prefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(resp)
+}
+
+func removeFailedStoresApproveCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	err := newAPIClient(cmd, getEndpoints(cmd)).ApproveFailedStoresRemovalPlan(context.Background(), map[string]interface{}{"id": args[0]})
+	printPostResult(cmd, err)
+}
@@ -50,6 +50,8 @@ var (
 	keyPath                = flag.String("key", "", "path of file that contains X509 key in PEM format")
 	maxBatchWaitInterval   = flag.Duration("batch-interval", 0, "the max batch wait interval")
 	enableTSOFollowerProxy = flag.Bool("enable-tso-follower-proxy", false, "whether enable the TSO Follower Proxy")
+	enableAdaptiveBatching = flag.Bool("enable-adaptive-batching", false, "whether enable the TSO adaptive batching, the batch-interval will be ignored if enabled")
+	compareBatching        = flag.Bool("compare-batching", false, "run each benchmark with the fixed batching and the adaptive batching respectively, and compare the results")
 	wg                     sync.WaitGroup
 )
 
@@ -79,13 +81,55 @@ func main() {
 	}()
 
 	for i := 0; i < *count; i++ {
-		fmt.Printf("\nStart benchmark #%d, duration: %+vs\n", i, duration.Seconds())
-		bench(ctx)
+		if !*compareBatching {
+			fmt.Printf("\nStart benchmark #%d, duration: %+vs\n", i, duration.Seconds())
+			bench(ctx, *enableAdaptiveBatching)
+			continue
+		}
+		results := make([]*benchResult, 0, 2)
+		for _, adaptive := range []bool{false, true} {
+			fmt.Printf("\nStart benchmark #%d with %s batching, duration: %+vs\n", i, batchingMode(adaptive), duration.Seconds())
+			results = append(results, bench(ctx, adaptive))
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		showComparison(results)
+	}
+}
+
+func batchingMode(adaptive bool) string {
+	if adaptive {
+		return "adaptive"
 	}
+	return fmt.Sprintf("fixed(batch-interval=%v)", *maxBatchWaitInterval)
+}
+
+// benchResult is the result of one benchmark, which is used to compare the different batching modes.
+type benchResult struct {
+	mode                     string
+	total                    *stats
+	p50, p80, p90, p99, p999 float64
+}
+
+func showComparison(results []*benchResult) {
+	fmt.Println("Batching comparison:")
+	for _, r := range results {
+		if r.total == nil || r.total.count == 0 {
+			continue
+		}
+		fmt.Printf("%s: count: %d, avg: %.4fms, max: %.4fms, P0.5: %.4fms, P0.8: %.4fms, P0.9: %.4fms, P0.99: %.4fms, P0.999: %.4fms\n",
+			r.mode, r.total.count, float64(r.total.totalDur.Nanoseconds())/float64(r.total.count)/float64(time.Millisecond),
+			float64(r.total.maxDur.Nanoseconds())/float64(time.Millisecond), r.p50, r.p80, r.p90, r.p99, r.p999)
+	}
+	fmt.Println()
 }
 
-func bench(mainCtx context.Context) {
+func bench(mainCtx context.Context, adaptive bool) *benchResult {
 	promServer = httptest.NewServer(promhttp.Handler())
+	defer promServer.Close()
+	latencyTDigest = tdigest.New()
+	result := &benchResult{mode: batchingMode(adaptive)}
 
 	// Initialize all clients
 	fmt.Printf("Create %d client(s) for benchmark\n", *clientNumber)
@@ -112,6 +156,7 @@ func bench(mainCtx context.Context) {
 
 		pdCli.UpdateOption(pd.MaxTSOBatchWaitInterval, *maxBatchWaitInterval)
 		pdCli.UpdateOption(pd.EnableTSOFollowerProxy, *enableTSOFollowerProxy)
+		pdCli.UpdateOption(pd.EnableTSOAdaptiveBatching, adaptive)
 		if err != nil {
 			log.Fatal(fmt.Sprintf("create pd client #%d failed: %v", idx, err))
 		}
@@ -137,7 +182,7 @@ func bench(mainCtx context.Context) {
 	}
 
 	wg.Add(1)
-	go showStats(ctx, durCh)
+	go showStats(ctx, durCh, result)
 
 	timer := time.NewTimer(*duration)
 	defer timer.Stop()
@@ -153,11 +198,12 @@ func bench(mainCtx context.Context) {
 	for _, pdCli := range pdClients {
 		pdCli.Close()
 	}
+	return result
 }
 
 var latencyTDigest *tdigest.TDigest = tdigest.New()
 
-func showStats(ctx context.Context, durCh chan time.Duration) {
+func showStats(ctx context.Context, durCh chan time.Duration, result *benchResult) {
 	defer wg.Done()
 
 	statCtx, cancel := context.WithCancel(ctx)
@@ -187,6 +233,9 @@ func showStats(ctx context.Context, durCh chan time.Duration) {
 			fmt.Println(total.Percentage())
 			// Calculate the percentiles by using the tDigest algorithm.
 			fmt.Printf("P0.5: %.4fms, P0.8: %.4fms, P0.9: %.4fms, P0.99: %.4fms\n\n", latencyTDigest.Quantile(0.5), latencyTDigest.Quantile(0.8), latencyTDigest.Quantile(0.9), latencyTDigest.Quantile(0.99))
+			result.total = total
+			result.p50, result.p80, result.p90 = latencyTDigest.Quantile(0.5), latencyTDigest.Quantile(0.8), latencyTDigest.Quantile(0.9)
+			result.p99, result.p999 = latencyTDigest.Quantile(0.99), latencyTDigest.Quantile(0.999)
 			if *verbose {
 				fmt.Println(collectMetrics(promServer))
 			}